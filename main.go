@@ -2,31 +2,127 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
+	"go-rerankers/pkg/eval"
 	"go-rerankers/pkg/reranker"
+	"go-rerankers/pkg/server"
 	"go-rerankers/pkg/utils"
 )
 
+var (
+	// envConfig holds the RERANKERS_* environment configuration, applied
+	// before flag overrides
+	envConfig reranker.Config
+	// warmupModels makes every reranker built by the CLI warm up before use
+	warmupModels bool
+	// resultWriter prints ranked results; nil prints plain text under a model header
+	resultWriter utils.ResultWriter
+	// queryInstruction is the instruction of the test case being run, passed
+	// to instruction-following models as Options["instruction"]
+	queryInstruction string
+	// asyncQueries is the number of concurrent queries of the --async benchmark
+	asyncQueries int
+	// explainResults prints the words contributing most to each ranked result
+	explainResults bool
+	// rankDeltaReport prints how reranking moved each result from the input order
+	rankDeltaReport bool
+	// scoreHistogram prints the distribution of all document scores after ranking
+	scoreHistogram bool
+	// benchmarkOutput is the JSON Lines file benchmark results are appended to; empty skips it
+	benchmarkOutput string
+	// compareModels holds the two models of --compare; nil when not comparing
+	compareModels []string
+	// compareThreshold is the rank difference at which compared models disagree
+	compareThreshold int
+)
+
 func main() {
+	// Sub-commands are dispatched before the top-level flags are parsed
+	if len(os.Args) > 1 && os.Args[1] == "models" {
+		if err := runModelsCommand(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Define CLI flags
 	var (
 		testFile   = flag.String("test-file", "", "Path to JSON test file")
+		fileFormat = flag.String("test-file-format", "json", "Test file format: json (one test case) or jsonl (one test case per line)")
 		testAll    = flag.Bool("test-all", false, "Test all JSON files in test_data directory")
 		query      = flag.String("query", "", "Query string (if not using test file)")
 		documents  = flag.String("documents", "", "Comma-separated document strings (if not using test file)")
+		docsFile   = flag.String("documents-file", "", "File with one document per line, or - to read documents from stdin (if not using test file)")
+		inputFmt   = flag.String("input-format", utils.InputFormatText, "Format of --documents-file: text (one document per line) or jsonl (one JSON string or {\"content\": ...} object per line)")
 		modelName  = flag.String("reranker", "", "Specific reranker to use (default: all)")
 		topK       = flag.Int("top-k", 3, "Number of top results to return")
 		benchmark  = flag.Bool("benchmark", false, "Run performance benchmark instead of normal ranking")
+		benchOut   = flag.String("benchmark-output", "", "Append benchmark results with a timestamp to this JSON Lines file")
 		listModels = flag.Bool("list-models", false, "List all available models")
+		serve      = flag.Bool("serve", false, "Start an HTTP reranking server")
+		port       = flag.Int("port", server.DefaultPort, "Port for the HTTP server (with --serve)")
+		warmup     = flag.Bool("warmup", false, "Warm up models before ranking or benchmarking")
+		output     = flag.String("output-format", utils.OutputPlain, "Result output format: plain, json, csv or xml")
+		evalFile   = flag.String("eval", "", "Path to JSON relevance file mapping document IDs (doc_1, doc_2, ...) to grades; prints NDCG, MAP, MRR and precision")
+		compare    = flag.String("compare", "", "Two comma-separated models whose rankings are compared side by side (model1,model2)")
+		compareMin = flag.Int("compare-threshold", utils.DefaultCompareThreshold, "Rank difference at which --compare reports a disagreement")
+		abTest     = flag.String("ab-test", "", "Two comma-separated models compared by NDCG@top-k on --relevance-file with a paired t-test (model-a,model-b)")
+		relFile    = flag.String("relevance-file", "", "Path to a JSON array of labeled queries ({\"query\", \"documents\", \"relevance\"}) for --ab-test")
+		alpha      = flag.Float64("alpha", 0.05, "Significance level of --ab-test")
+		async      = flag.Int("async", 0, "Benchmark this many concurrent RankAsync calls of the query (requires --reranker)")
+		explain    = flag.Bool("explain", false, "Show the top-5 positive and negative contributing words of each result (GGUF models)")
+		rankDelta  = flag.Bool("rank-delta", false, "Show how far each result moved from the input order, with Kendall tau and Spearman rho")
+		histogram  = flag.Bool("histogram", false, "Show an ASCII histogram of the scores of all documents after ranking")
+		generate   = flag.Bool("generate-test", false, "Write a synthetic JSON test file for --query to stdout")
+		relevant   = flag.Int("relevant", 3, "Number of relevant documents generated by --generate-test")
+		distractor = flag.Int("distractors", 7, "Number of distractor documents generated by --generate-test")
+		seed       = flag.Int64("seed", 1, "Random seed for --generate-test")
 	)
 	flag.Parse()
+	warmupModels = *warmup
+	explainResults = *explain
+	rankDeltaReport = *rankDelta
+	scoreHistogram = *histogram
+	benchmarkOutput = *benchOut
+	asyncQueries = *async
+	compareThreshold = *compareMin
+	if *compare != "" {
+		compareModels = strings.Split(*compare, ",")
+		for i := range compareModels {
+			compareModels[i] = strings.TrimSpace(compareModels[i])
+		}
+		if len(compareModels) != 2 || compareModels[0] == "" || compareModels[1] == "" {
+			log.Fatalf("--compare expects two comma-separated models, got %q", *compare)
+		}
+	}
+	if *output != utils.OutputPlain {
+		writer, err := utils.NewResultWriter(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resultWriter = writer
+	}
+
+	// Models added with "models add" are available to every command
+	loadUserRegistry()
+
+	// Environment configuration is the baseline; flags override it
+	envConfig = reranker.ConfigFromEnv()
+	if *modelName == "" {
+		*modelName = envConfig.Model
+	}
 
 	// List models if requested
 	if *listModels {
@@ -34,23 +130,84 @@ func main() {
 		return
 	}
 
+	// Generate a synthetic test file if requested
+	if *generate {
+		if *query == "" {
+			log.Fatal("--generate-test requires --query")
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(utils.GenerateTestData(*query, *relevant, *distractor, *seed)); err != nil {
+			log.Fatalf("Error writing test data: %v", err)
+		}
+		return
+	}
+
+	// Serve over HTTP if requested
+	if *serve {
+		runServer(*port, *modelName)
+		return
+	}
+
+	// Run an A/B test on labeled queries if requested
+	if *abTest != "" {
+		models := strings.Split(*abTest, ",")
+		for i := range models {
+			models[i] = strings.TrimSpace(models[i])
+		}
+		if len(models) != 2 || models[0] == "" || models[1] == "" {
+			log.Fatalf("--ab-test expects two comma-separated models, got %q", *abTest)
+		}
+		if *relFile == "" {
+			log.Fatal("--ab-test requires --relevance-file")
+		}
+		runABTest(models[0], models[1], *relFile, *topK, *alpha)
+		return
+	}
+
+	// Load relevance judgments for evaluation
+	var relevance map[string]int
+	if *evalFile != "" {
+		var err error
+		relevance, err = eval.LoadRelevance(*evalFile)
+		if err != nil {
+			log.Fatalf("Error loading relevance file: %v", err)
+		}
+	}
+
 	// Test all JSON files if requested
 	if *testAll {
 		testAllJSONFiles(*modelName, *topK, *benchmark)
 		return
 	}
 
+	// Run every test case of a test file
+	if *testFile != "" {
+		testCases, err := loadTestCases(*testFile, *fileFormat)
+		if err != nil {
+			log.Fatalf("Error loading test file: %v", err)
+		}
+		for i, testData := range testCases {
+			if len(testCases) > 1 {
+				statusf("\n%s\nTest case %d/%d\n%s\n", strings.Repeat("=", 50), i+1, len(testCases), strings.Repeat("=", 50))
+			}
+			queryInstruction = testData.Instruction
+			runQuery(testData.Query, testData.Documents, *modelName, *topK, *benchmark, relevance)
+		}
+		return
+	}
+
 	// Get query and documents
 	var queryStr string
 	var docs []string
 
-	if *testFile != "" {
-		testData, err := utils.LoadTestData(*testFile)
+	if *query != "" && *docsFile != "" {
+		queryStr = *query
+		var err error
+		docs, err = utils.LoadDocuments(*docsFile, *inputFmt)
 		if err != nil {
-			log.Fatalf("Error loading test file: %v", err)
+			log.Fatalf("Error loading documents: %v", err)
 		}
-		queryStr = testData.Query
-		docs = testData.Documents
 	} else if *query != "" && *documents != "" {
 		queryStr = *query
 		docs = strings.Split(*documents, ",")
@@ -59,31 +216,229 @@ func main() {
 			docs[i] = strings.TrimSpace(docs[i])
 		}
 	} else {
-		fmt.Println("Error: Either --test-file, --test-all, or both --query and --documents must be provided")
+		fmt.Println("Error: Either --test-file, --test-all, or --query with --documents or --documents-file must be provided")
 		fmt.Println("\nUsage examples:")
 		fmt.Println("  go run main.go --test-file test_data/test_ml.json --top-k 3")
 		fmt.Println("  go run main.go --test-all --reranker mxbai-v2 --top-k 3")
+		fmt.Println("  go run main.go --test-file cases.jsonl --test-file-format jsonl --reranker mxbai-v2")
 		fmt.Println("  go run main.go --query \"What is AI?\" --documents \"AI is...,Cooking...\" --reranker mxbai-v2")
+		fmt.Println("  cat docs.txt | go run main.go --query \"What is AI?\" --documents-file - --reranker mxbai-v2")
 		fmt.Println("  go run main.go --benchmark --reranker all")
+		fmt.Println("  go run main.go --test-file test_data/test_ml.json --eval qrels.json --top-k 3")
+		fmt.Println("  go run main.go --test-file test_data/test_ml.json --compare qwen-0.6b,bge-v2-m3")
+		fmt.Println("  go run main.go --ab-test qwen-0.6b,bge-v2-m3 --relevance-file rel.json --alpha 0.05")
 		fmt.Println("  go run main.go --list-models")
+		fmt.Println("  go run main.go models add --name mymodel --model-id /path/to/model.gguf --type gguf-local")
+		fmt.Println("  go run main.go --generate-test --query \"What is AI?\" --relevant 3 --distractors 7 --seed 42 > test_data/generated.json")
+		fmt.Println("  go run main.go --serve --port 8080 --reranker mxbai-v2")
 		os.Exit(1)
 	}
 
-	fmt.Printf("Query: %s\n", queryStr)
-	fmt.Printf("Number of documents: %d\n", len(docs))
+	runQuery(queryStr, docs, *modelName, *topK, *benchmark, relevance)
+}
+
+// loadTestCases reads the test cases of a JSON or JSONL test file
+func loadTestCases(path, format string) ([]*utils.TestData, error) {
+	switch format {
+	case "json":
+		testData, err := utils.LoadTestData(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*utils.TestData{testData}, nil
+	case "jsonl":
+		return utils.LoadTestDataJSONL(path)
+	}
+	return nil, fmt.Errorf("unsupported test file format %q (expected json or jsonl)", format)
+}
+
+// statusf prints a progress or error line; it goes to stderr when stdout
+// carries results in a machine-readable --output-format
+func statusf(format string, args ...interface{}) {
+	out := os.Stdout
+	if resultWriter != nil {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, format, args...)
+}
+
+// runQuery ranks, benchmarks or, when relevance judgments are given, evaluates
+// a single query against its documents
+func runQuery(queryStr string, docs []string, modelName string, topK int, benchmark bool, relevance map[string]int) {
+	statusf("Query: %s\n", queryStr)
+	statusf("Number of documents: %d\n", len(docs))
 
-	// Convert strings to documents
-	documentList := utils.StringsToDocuments(docs)
+	// Convert strings to documents; judged runs use positional IDs (doc_1, doc_2, ...)
+	converter := utils.NewDocumentConverter(envConfig)
+	if relevance != nil {
+		converter.IDGenerator = utils.SequentialIDGenerator("doc")
+	}
+	documentList := converter.Convert(docs)
 
 	// Get device info
 	device := utils.GetDevice()
-	fmt.Printf("Using device: %s\n", device)
+	statusf("Using device: %s\n", device)
+
+	if relevance != nil {
+		runEvaluation(queryStr, documentList, modelName, topK, relevance)
+	} else if asyncQueries > 0 {
+		runAsyncBenchmark(queryStr, documentList, modelName, asyncQueries)
+	} else if benchmark {
+		runBenchmark(queryStr, documentList, modelName)
+	} else if compareModels != nil {
+		runComparison(queryStr, documentList, compareModels[0], compareModels[1])
+	} else {
+		runReranking(queryStr, documentList, modelName, topK)
+	}
+}
+
+// runEvaluation ranks all documents with each model and prints IR metrics at topK
+func runEvaluation(query string, documents []reranker.Document, modelName string, topK int, relevance map[string]int) {
+	modelIDs := []string{modelName}
+	if modelName == "" || modelName == "all" {
+		modelIDs = nil
+		for _, model := range reranker.GetSupportedModels() {
+			modelIDs = append(modelIDs, model.ModelID)
+		}
+	}
+
+	statusf("\n%-45s %8s %8s %8s %8s\n", "Model", fmt.Sprintf("NDCG@%d", topK), "MAP", "MRR", fmt.Sprintf("P@%d", topK))
+	for _, modelID := range modelIDs {
+		r, err := reranker.NewReranker(newModelConfig(modelID))
+		if err != nil {
+			statusf("%-45s ERROR - %v\n", modelID, err)
+			continue
+		}
+
+		results, err := r.Rank(context.Background(), query, documents, 0)
+		if err != nil {
+			statusf("%-45s ERROR - %v\n", r.GetModelName(), err)
+			continue
+		}
+
+		fmt.Printf("%-45s %8.4f %8.4f %8.4f %8.4f\n", r.GetModelName(),
+			eval.NDCG(results, relevance, topK),
+			eval.MAP(results, relevance),
+			eval.MRR(results, relevance),
+			eval.PrecisionAtK(results, relevance, topK))
+	}
+}
 
-	if *benchmark {
-		runBenchmark(queryStr, documentList, *modelName)
+// runABTest ranks every labeled query with both models and reports whether
+// their NDCG@topK differs significantly at level alpha
+func runABTest(modelA, modelB, relevancePath string, topK int, alpha float64) {
+	queries, err := eval.LoadLabeledQueries(relevancePath)
+	if err != nil {
+		log.Fatalf("Error loading relevance file: %v", err)
+	}
+
+	var variants [2]reranker.Reranker
+	for i, modelName := range []string{modelA, modelB} {
+		variants[i], err = reranker.NewReranker(newModelConfig(modelName))
+		if err != nil {
+			log.Fatalf("Error initializing reranker %s: %v", modelName, err)
+		}
+	}
+
+	test, err := eval.NewABTest(variants[0], variants[1], topK)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := test.Evaluate(context.Background(), queries); err != nil {
+		log.Fatalf("Error running A/B test: %v", err)
+	}
+
+	report := test.Report()
+	fmt.Printf("\n=== A/B test: %s vs %s (%d queries) ===\n", modelA, modelB, report.Queries)
+	fmt.Printf("%-45s %8s\n", "Model", fmt.Sprintf("NDCG@%d", report.K))
+	fmt.Printf("%-45s %8.4f\n", modelA, report.MeanA)
+	fmt.Printf("%-45s %8.4f\n", modelB, report.MeanB)
+	fmt.Printf("\nDifference: %+.4f, t = %.3f, p = %.4f\n", report.MeanDifference, report.TStatistic, report.PValue)
+	if report.IsSignificant(alpha) {
+		fmt.Printf("Significant at alpha = %g\n", alpha)
 	} else {
-		runReranking(queryStr, documentList, *modelName, *topK)
+		fmt.Printf("Not significant at alpha = %g\n", alpha)
+	}
+}
+
+// runComparison ranks all documents with both models and prints where their
+// rankings diverge, color-coded when stdout is a terminal
+func runComparison(query string, documents []reranker.Document, modelA, modelB string) {
+	var rankings [2][]reranker.RerankResult
+	for i, modelName := range []string{modelA, modelB} {
+		r, err := reranker.NewReranker(newModelConfig(modelName))
+		if err != nil {
+			statusf("Error initializing reranker %s: %v\n", modelName, err)
+			return
+		}
+		rankings[i], err = r.Rank(context.Background(), query, documents, 0)
+		if err != nil {
+			statusf("Error ranking documents with %s: %v\n", modelName, err)
+			return
+		}
+	}
+
+	statusf("\n=== %s vs %s ===\n", modelA, modelB)
+	report := utils.CompareRankingsWithThreshold(rankings[0], rankings[1], compareThreshold)
+	if err := utils.WriteComparison(os.Stdout, report, modelA, modelB, utils.IsTerminal(os.Stdout)); err != nil {
+		statusf("Error writing comparison: %v\n", err)
+	}
+}
+
+// runAsyncBenchmark fires queries concurrent RankAsync calls of the query and
+// waits on all futures, reporting the wall-clock time of the whole batch
+func runAsyncBenchmark(query string, documents []reranker.Document, modelName string, queries int) {
+	if modelName == "" || modelName == "all" {
+		fmt.Println("Error: --async requires a specific --reranker")
+		return
+	}
+	r, err := reranker.NewReranker(newModelConfig(modelName))
+	if err != nil {
+		fmt.Printf("Error initializing reranker: %v\n", err)
+		return
+	}
+	async, ok := reranker.Unwrap(r).(reranker.AsyncReranker)
+	if !ok {
+		fmt.Printf("Error: %s does not support asynchronous ranking\n", r.GetModelName())
+		return
+	}
+
+	fmt.Printf("\n=== Async benchmark: %s, %d concurrent queries ===\n", r.GetModelName(), queries)
+	start := time.Now()
+	futures := make([]*reranker.RankFuture, queries)
+	for i := range futures {
+		futures[i] = async.RankAsync(context.Background(), query, documents, 0)
+	}
+	fmt.Printf("All queries started in %v\n", time.Since(start))
+
+	failed := 0
+	for i, future := range futures {
+		if _, err := future.Wait(); err != nil {
+			fmt.Printf("Query %d failed: %v\n", i+1, err)
+			failed++
+		}
+	}
+	duration := time.Since(start)
+	fmt.Printf("Completed %d/%d queries in %v (%.2f queries/sec)\n",
+		queries-failed, queries, duration, float64(queries)/duration.Seconds())
+}
+
+// runServer serves the HTTP API until SIGINT or SIGTERM
+func runServer(port int, defaultModel string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var opts []server.Option
+	if defaultModel != "" && defaultModel != "all" {
+		opts = append(opts, server.WithDefaultModel(defaultModel))
+	}
+
+	addr := fmt.Sprintf(":%d", port)
+	fmt.Printf("Serving rerank API on %s (POST /rerank, GET /health, GET /models)\n", addr)
+	if err := server.New(opts...).ListenAndServe(ctx, addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Server error: %v", err)
 	}
+	fmt.Println("Server stopped")
 }
 
 func printAvailableModels() {
@@ -100,8 +455,88 @@ func printAvailableModels() {
 		if len(model.Strengths) > 0 {
 			fmt.Printf("  Strengths: %s\n", strings.Join(model.Strengths, ", "))
 		}
+		// Models whose file is present show its header metadata
+		if strings.HasSuffix(model.ModelID, ".gguf") {
+			if metadata, err := reranker.ReadGGUFMetadata(model.ModelID); err == nil {
+				fmt.Printf("  GGUF: architecture %s, quantization %s, context %d, embedding dim %d\n",
+					metadata.Architecture, metadata.QuantizationType, metadata.ContextLength, metadata.EmbeddingDim)
+			}
+		}
+	}
+}
+
+// loadUserRegistry loads the registry managed by the models sub-command,
+// unless RERANKERS_MODEL_REGISTRY names another registry
+func loadUserRegistry() {
+	if os.Getenv(reranker.ModelRegistryEnv) != "" {
+		return
+	}
+	path, err := reranker.UserRegistryPath()
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	if err := reranker.LoadModelRegistry(path); err != nil {
+		log.Printf("WARNING: ignoring user model registry: %v", err)
+	}
+}
+
+// runModelsCommand implements "models add", "models remove" and "models list"
+// on the user registry (~/.go-rerankers/models.json)
+func runModelsCommand(args []string) error {
+	const usage = "usage: models add --name NAME --model-id ID [--type TYPE] | models remove --name NAME | models list"
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	path, err := reranker.UserRegistryPath()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "add":
+		flags := flag.NewFlagSet("models add", flag.ExitOnError)
+		name := flags.String("name", "", "Name used to select the model with --reranker")
+		modelID := flags.String("model-id", "", "Model passed to the backend, e.g. a GGUF path (default: --name)")
+		modelType := flags.String("type", string(reranker.TypeGGUFLocal), "Backend type, e.g. gguf-local, http or llama-server")
+		displayName := flags.String("display-name", "", "Human-readable model name (default: --name)")
+		provider := flags.String("provider", "User", "Model provider")
+		flags.Parse(args[1:])
+
+		model := reranker.ModelInfo{
+			Name:        *name,
+			DisplayName: *displayName,
+			Provider:    *provider,
+			ModelID:     *modelID,
+			Type:        *modelType,
+		}
+		if model.DisplayName == "" {
+			model.DisplayName = model.Name
+		}
+		if err := reranker.AddRegistryModel(path, model); err != nil {
+			return err
+		}
+		fmt.Printf("Added %s to %s\n", model.Name, path)
+	case "remove":
+		flags := flag.NewFlagSet("models remove", flag.ExitOnError)
+		name := flags.String("name", "", "Name of the model to remove")
+		flags.Parse(args[1:])
+
+		if err := reranker.RemoveRegistryModel(path, *name); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %s from %s\n", *name, path)
+	case "list":
+		loadUserRegistry()
+		printAvailableModels()
+	default:
+		return fmt.Errorf("unknown models command %q; %s", args[0], usage)
 	}
+	return nil
 }
+
 func runReranking(query string, documents []reranker.Document, modelName string, topK int) {
 	if modelName == "" || modelName == "all" {
 		// Test all models
@@ -113,9 +548,9 @@ func runReranking(query string, documents []reranker.Document, modelName string,
 }
 
 func runBenchmark(query string, documents []reranker.Document, modelName string) {
-	fmt.Println("\n" + strings.Repeat("=", 50))
-	fmt.Println("RUNNING BENCHMARKS")
-	fmt.Println(strings.Repeat("=", 50))
+	statusf("\n%s\n", strings.Repeat("=", 50))
+	statusf("RUNNING BENCHMARKS\n")
+	statusf("%s\n", strings.Repeat("=", 50))
 
 	var results []*utils.BenchmarkResult
 
@@ -161,6 +596,14 @@ func runBenchmark(query string, documents []reranker.Document, modelName string)
 			}
 		}
 	}
+
+	if benchmarkOutput != "" && len(results) > 0 {
+		if err := utils.WriteBenchmarkReport(results, benchmarkOutput); err != nil {
+			statusf("Error writing benchmark report: %v\n", err)
+		} else {
+			statusf("\nBenchmark results appended to %s\n", benchmarkOutput)
+		}
+	}
 }
 
 func testAllModels(query string, documents []reranker.Document, topK int) {
@@ -168,58 +611,133 @@ func testAllModels(query string, documents []reranker.Document, topK int) {
 	successCount := 0
 	
 	for _, model := range models {
-		fmt.Printf("\n%s\n", strings.Repeat("=", 60))
-		fmt.Printf("Testing: %s (%s)\n", model.DisplayName, model.Name)
-		fmt.Printf("%s\n", strings.Repeat("=", 60))
+		statusf("\n%s\n", strings.Repeat("=", 60))
+		statusf("Testing: %s (%s)\n", model.DisplayName, model.Name)
+		statusf("%s\n", strings.Repeat("=", 60))
 
 		if testSingleModel(query, documents, model.ModelID, topK) {
 			successCount++
 		}
 	}
 
-	fmt.Printf("\n%s\n", strings.Repeat("=", 60))
-	fmt.Printf("SUMMARY: %d/%d models tested successfully\n", successCount, len(models))
-	fmt.Printf("%s\n", strings.Repeat("=", 60))
+	statusf("\n%s\n", strings.Repeat("=", 60))
+	statusf("SUMMARY: %d/%d models tested successfully\n", successCount, len(models))
+	statusf("%s\n", strings.Repeat("=", 60))
 }
 
-func testSingleModel(query string, documents []reranker.Document, modelName string, topK int) bool {
-	config := reranker.Config{
-		Model:     modelName,
+// newModelConfig builds the CLI configuration for a model: CLI defaults,
+// overridden by the environment, overridden by flags
+func newModelConfig(modelName string) reranker.Config {
+	defaults := reranker.Config{
 		MaxDocs:   100,
 		Threshold: -10.0, // Show all documents including low-scoring ones
 		Device:    utils.GetDevice(),
 	}
+	flags := reranker.Config{Model: modelName, Options: map[string]interface{}{}}
+	if warmupModels {
+		flags.Options["warmup"] = true
+	}
+	if queryInstruction != "" {
+		flags.Options["instruction"] = queryInstruction
+	}
+	return reranker.MergeConfig(reranker.MergeConfig(defaults, envConfig), flags)
+}
+
+func testSingleModel(query string, documents []reranker.Document, modelName string, topK int) bool {
+	config := newModelConfig(modelName)
 
 	r, err := reranker.NewReranker(config)
 	if err != nil {
-		fmt.Printf("Error initializing reranker: %v\n", err)
+		statusf("Error initializing reranker: %v\n", err)
 		return false
 	}
 
 	ctx := context.Background()
 	start := time.Now()
 	
-	results, err := r.Rank(ctx, query, documents, topK)
+	// The histogram covers every document, not only the top-k
+	rankN := topK
+	if scoreHistogram {
+		rankN = 0
+	}
+	allResults, err := r.Rank(ctx, query, documents, rankN)
 	if err != nil {
-		fmt.Printf("Error ranking documents: %v\n", err)
+		statusf("Error ranking documents: %v\n", err)
 		return false
 	}
+	results := allResults
+	if topK > 0 && len(results) > topK {
+		results = results[:topK]
+	}
 
 	duration := time.Since(start)
-	fmt.Printf("Ranking completed in %v\n", duration)
+	statusf("Ranking completed in %v\n", duration)
 
-	utils.PrintResults(r.GetModelName(), results, topK)
+	if resultWriter == nil {
+		utils.PrintResults(r.GetModelName(), results, topK)
+		if explainResults {
+			printExplanations(ctx, r, query, results, topK)
+		}
+		if rankDeltaReport {
+			printRankDelta(documents, results)
+		}
+		if scoreHistogram {
+			utils.PrintHistogram(utils.ScoreHistogram(allResults, utils.DefaultHistogramBins))
+		}
+		return true
+	}
+	if err := utils.WriteResults(os.Stdout, resultWriter, results, topK); err != nil {
+		statusf("Error writing results: %v\n", err)
+		return false
+	}
 	return true
 }
 
-func benchmarkModel(query string, documents []reranker.Document, modelName string) *utils.BenchmarkResult {
-	config := reranker.Config{
-		Model:     modelName,
-		MaxDocs:   100,
-		Threshold: -10.0,
-		Device:    utils.GetDevice(),
+// printExplanations prints the top contributing words of the first topK results
+func printExplanations(ctx context.Context, r reranker.Reranker, query string, results []reranker.RerankResult, topK int) {
+	attributor, ok := reranker.Unwrap(r).(reranker.AttributableReranker)
+	if !ok {
+		fmt.Printf("\n%s does not support score explanations\n", r.GetModelName())
+		return
+	}
+	if topK > 0 && topK < len(results) {
+		results = results[:topK]
 	}
 
+	fmt.Println("\nContributing words:")
+	for _, result := range results {
+		contributions, err := attributor.AttributeScores(ctx, query, result.Document)
+		if err != nil {
+			fmt.Printf("  #%d %s: error - %v\n", result.Rank, result.Document.ID, err)
+			continue
+		}
+		utils.PrintAttributions(result, contributions, 5)
+	}
+}
+
+// printRankDelta prints how reranking moved each result from the input order
+// and the rank correlation of the input and reranked orders
+func printRankDelta(documents []reranker.Document, results []reranker.RerankResult) {
+	fmt.Println("\nRank changes (input -> reranked):")
+	for _, delta := range eval.ComputeRankDelta(documents, results) {
+		fmt.Printf("  %-12s %3d -> %-3d (%+d) score %+.4f\n",
+			delta.DocumentID, delta.OriginalRank, delta.NewRank, delta.RankDelta, delta.ScoreDelta)
+	}
+
+	original := make([]string, len(documents))
+	for i, doc := range documents {
+		original[i] = doc.ID
+	}
+	reranked := make([]string, len(results))
+	for i, result := range results {
+		reranked[i] = result.Document.ID
+	}
+	fmt.Printf("Kendall tau: %.4f\nSpearman rho: %.4f\n", eval.KendallTau(original, reranked), eval.SpearmanRho(original, reranked))
+}
+
+func benchmarkModel(query string, documents []reranker.Document, modelName string) *utils.BenchmarkResult {
+	config := newModelConfig(modelName)
+
 	r, err := reranker.NewReranker(config)
 	if err != nil {
 		return &utils.BenchmarkResult{
@@ -228,10 +746,10 @@ func benchmarkModel(query string, documents []reranker.Document, modelName strin
 		}
 	}
 
-	fmt.Printf("Benchmarking: %s...\n", r.GetModelName())
+	statusf("Benchmarking: %s...\n", r.GetModelName())
 	
 	// Run benchmark with 3 iterations for more accurate timing
-	result := utils.BenchmarkReranker(r, query, documents, 3)
+	result := utils.BenchmarkReranker(r, query, documents, 3, config)
 	
 	utils.PrintBenchmark(result)
 	return result
@@ -247,64 +765,65 @@ func testAllJSONFiles(modelName string, topK int, benchmark bool) {
 	}
 	
 	if len(files) == 0 {
-		fmt.Println("No JSON files found in test_data directory")
+		statusf("No JSON files found in test_data directory\n")
 		return
 	}
 	
-	fmt.Printf("Found %d JSON test files in %s directory\n", len(files), testDataDir)
-	fmt.Printf("%s\n", strings.Repeat("=", 80))
+	statusf("Found %d JSON test files in %s directory\n", len(files), testDataDir)
+	statusf("%s\n", strings.Repeat("=", 80))
 	
 	successCount := 0
 	totalFiles := len(files)
 	
 	for i, file := range files {
-		fmt.Printf("\n[%d/%d] Testing file: %s\n", i+1, totalFiles, filepath.Base(file))
-		fmt.Printf("%s\n", strings.Repeat("-", 60))
+		statusf("\n[%d/%d] Testing file: %s\n", i+1, totalFiles, filepath.Base(file))
+		statusf("%s\n", strings.Repeat("-", 60))
 		
 		// Load test data
 		testData, err := utils.LoadTestData(file)
 		if err != nil {
-			fmt.Printf("❌ Error loading test file %s: %v\n", filepath.Base(file), err)
+			statusf("❌ Error loading test file %s: %v\n", filepath.Base(file), err)
 			continue
 		}
 		
-		fmt.Printf("Query: %s\n", testData.Query)
-		fmt.Printf("Documents: %d\n", len(testData.Documents))
+		statusf("Query: %s\n", testData.Query)
+		statusf("Documents: %d\n", len(testData.Documents))
+		queryInstruction = testData.Instruction
 		
 		// Convert strings to documents
-		documentList := utils.StringsToDocuments(testData.Documents)
+		documentList := utils.StringsToDocuments(testData.Documents, envConfig)
 		
 		if benchmark {
 			// Run benchmark for this file
 			if modelName == "" || modelName == "all" {
-				fmt.Println("\nRunning benchmarks for all models...")
+				statusf("\nRunning benchmarks for all models...\n")
 				runBenchmark(testData.Query, documentList, modelName)
 			} else {
-				fmt.Printf("\nRunning benchmark for model: %s...\n", modelName)
+				statusf("\nRunning benchmark for model: %s...\n", modelName)
 				runBenchmark(testData.Query, documentList, modelName)
 			}
 			successCount++
 		} else {
 			// Run normal reranking for this file
 			if modelName == "" || modelName == "all" {
-				fmt.Println("\nTesting with all models...")
+				statusf("\nTesting with all models...\n")
 				testAllModels(testData.Query, documentList, topK)
 			} else {
-				fmt.Printf("\nTesting with model: %s...\n", modelName)
+				statusf("\nTesting with model: %s...\n", modelName)
 				if testSingleModel(testData.Query, documentList, modelName, topK) {
 					successCount++
 				}
 			}
 		}
 		
-		fmt.Printf("✅ Completed testing file: %s\n", filepath.Base(file))
+		statusf("✅ Completed testing file: %s\n", filepath.Base(file))
 	}
 	
-	fmt.Printf("\n%s\n", strings.Repeat("=", 80))
+	statusf("\n%s\n", strings.Repeat("=", 80))
 	if benchmark {
-		fmt.Printf("SUMMARY: Completed benchmarking %d test files\n", totalFiles)
+		statusf("SUMMARY: Completed benchmarking %d test files\n", totalFiles)
 	} else {
-		fmt.Printf("SUMMARY: %d/%d test files processed successfully\n", successCount, totalFiles)
+		statusf("SUMMARY: %d/%d test files processed successfully\n", successCount, totalFiles)
 	}
-	fmt.Printf("%s\n", strings.Repeat("=", 80))
+	statusf("%s\n", strings.Repeat("=", 80))
 }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+	"go-rerankers/pkg/utils"
+)
+
+// captureStdout returns what fn writes to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		done <- string(data)
+	}()
+	fn()
+	writer.Close()
+	return <-done
+}
+
+func TestRunQuery_OutputFormatsAreParseable(t *testing.T) {
+	docs := []string{"machine learning models", "cooking pasta", "deep learning and machine vision"}
+	defer func() { resultWriter = nil }()
+
+	for _, format := range []string{utils.OutputJSON, utils.OutputCSV, utils.OutputXML} {
+		writer, err := utils.NewResultWriter(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resultWriter = writer
+		out := captureStdout(t, func() {
+			runQuery("machine learning", docs, "bm25", 3, false, nil)
+		})
+
+		var rows int
+		switch format {
+		case utils.OutputJSON:
+			var results []reranker.RerankResult
+			err = json.Unmarshal([]byte(out), &results)
+			rows = len(results)
+		case utils.OutputCSV:
+			var records [][]string
+			records, err = csv.NewReader(strings.NewReader(out)).ReadAll()
+			rows = len(records) - 1
+		case utils.OutputXML:
+			var document utils.XMLResults
+			err = xml.Unmarshal([]byte(out), &document)
+			rows = len(document.Results)
+		}
+		if err != nil {
+			t.Errorf("%s: stdout does not parse (%v):\n%s", format, err, out)
+		} else if rows != 3 {
+			t.Errorf("%s: expected 3 results, got %d:\n%s", format, rows, out)
+		}
+	}
+}
+
+func TestTestAllJSONFiles_JSONOutputIsParseable(t *testing.T) {
+	writer, err := utils.NewResultWriter(utils.OutputJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resultWriter = writer
+	defer func() { resultWriter = nil }()
+
+	out := captureStdout(t, func() {
+		testAllJSONFiles("bm25", 2, false)
+	})
+
+	// Every file contributes one JSON array; status lines go to stderr
+	decoder := json.NewDecoder(strings.NewReader(out))
+	var arrays int
+	for {
+		var results []reranker.RerankResult
+		if err := decoder.Decode(&results); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("stdout does not parse as JSON (%v):\n%s", err, out)
+		}
+		arrays++
+	}
+	if arrays == 0 {
+		t.Errorf("Expected ranked results on stdout, got:\n%s", out)
+	}
+}
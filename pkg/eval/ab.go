@@ -0,0 +1,279 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// DefaultABTestK is the NDCG cutoff of an A/B test when k is not positive
+const DefaultABTestK = 10
+
+// Variants of an ABTest
+const (
+	VariantA = "A"
+	VariantB = "B"
+)
+
+// LabeledQuery is a query with its documents and relevance judgments.
+// Documents are given positional IDs (doc_1, doc_2, ...), which Relevance
+// refers to.
+type LabeledQuery struct {
+	Query     string         `json:"query"`
+	Documents []string       `json:"documents"`
+	Relevance map[string]int `json:"relevance"`
+}
+
+// documents returns the query's documents with their positional IDs
+func (q LabeledQuery) documents() []reranker.Document {
+	documents := make([]reranker.Document, len(q.Documents))
+	for i, content := range q.Documents {
+		documents[i] = reranker.Document{ID: fmt.Sprintf("doc_%d", i+1), Content: content}
+	}
+	return documents
+}
+
+// LoadLabeledQueries reads a JSON array of labeled queries
+func LoadLabeledQueries(filePath string) ([]LabeledQuery, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read relevance file: %w", err)
+	}
+
+	var queries []LabeledQuery
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("failed to parse relevance file: %w", err)
+	}
+	for i, query := range queries {
+		if query.Query == "" || len(query.Documents) == 0 {
+			return nil, fmt.Errorf("relevance file entry %d needs a query and documents", i+1)
+		}
+	}
+	return queries, nil
+}
+
+// StatisticalReport compares the per-query NDCG@K of two variants with a
+// two-sided paired t-test
+type StatisticalReport struct {
+	K       int `json:"k"`
+	Queries int `json:"queries"`
+	// MeanA and MeanB are the variants' mean NDCG@K
+	MeanA float64 `json:"mean_a"`
+	MeanB float64 `json:"mean_b"`
+	// MeanDifference is the mean of B's NDCG minus A's
+	MeanDifference float64 `json:"mean_difference"`
+	TStatistic     float64 `json:"t_statistic"`
+	PValue         float64 `json:"p_value"`
+}
+
+// IsSignificant reports whether the difference is significant at level alpha
+func (r StatisticalReport) IsSignificant(alpha float64) bool {
+	return r.PValue < alpha
+}
+
+// ABTest splits traffic between two rerankers and compares their ranking
+// quality on labeled queries. It is safe for concurrent use.
+type ABTest struct {
+	variants [2]reranker.Reranker
+	k        int
+
+	mutex    sync.Mutex
+	requests [2]int64
+	ndcg     [2][]float64
+}
+
+// NewABTest compares variant a with variant b by NDCG@k
+func NewABTest(a, b reranker.Reranker, k int) (*ABTest, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("%w: A/B test requires two rerankers", reranker.ErrInvalidInput)
+	}
+	if k <= 0 {
+		k = DefaultABTestK
+	}
+	return &ABTest{variants: [2]reranker.Reranker{a, b}, k: k}, nil
+}
+
+// Rank serves a request with variant A or B in turn, returning the variant used
+func (t *ABTest) Rank(ctx context.Context, query string, documents []reranker.Document, topN int) ([]reranker.RerankResult, string, error) {
+	t.mutex.Lock()
+	variant := 0
+	if t.requests[0] > t.requests[1] {
+		variant = 1
+	}
+	t.requests[variant]++
+	t.mutex.Unlock()
+
+	results, err := t.variants[variant].Rank(ctx, query, documents, topN)
+	return results, variantName(variant), err
+}
+
+// Requests returns the number of requests served by variants A and B
+func (t *ABTest) Requests() (int64, int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.requests[0], t.requests[1]
+}
+
+// Evaluate ranks each labeled query with both variants, alternating which
+// runs first, and records their NDCG@K
+func (t *ABTest) Evaluate(ctx context.Context, queries []LabeledQuery) error {
+	for i, query := range queries {
+		var ndcg [2]float64
+		for j := 0; j < 2; j++ {
+			variant := (i + j) % 2
+			results, err := t.variants[variant].Rank(ctx, query.Query, query.documents(), 0)
+			if err != nil {
+				return fmt.Errorf("variant %s failed on query %d: %w", variantName(variant), i+1, err)
+			}
+			ndcg[variant] = NDCG(results, query.Relevance, t.k)
+		}
+
+		t.mutex.Lock()
+		t.ndcg[0] = append(t.ndcg[0], ndcg[0])
+		t.ndcg[1] = append(t.ndcg[1], ndcg[1])
+		t.mutex.Unlock()
+	}
+	return nil
+}
+
+// Report compares the NDCG@K recorded by Evaluate
+func (t *ABTest) Report() StatisticalReport {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	n := len(t.ndcg[0])
+	report := StatisticalReport{K: t.k, Queries: n, PValue: 1}
+	if n == 0 {
+		return report
+	}
+
+	differences := make([]float64, n)
+	for i := range differences {
+		report.MeanA += t.ndcg[0][i]
+		report.MeanB += t.ndcg[1][i]
+		differences[i] = t.ndcg[1][i] - t.ndcg[0][i]
+	}
+	report.MeanA /= float64(n)
+	report.MeanB /= float64(n)
+	report.TStatistic, report.PValue = pairedTTest(differences)
+	report.MeanDifference = report.MeanB - report.MeanA
+	return report
+}
+
+// variantName returns "A" or "B"
+func variantName(variant int) string {
+	if variant == 0 {
+		return VariantA
+	}
+	return VariantB
+}
+
+// pairedTTest returns the t statistic and two-sided p-value of the paired
+// differences having mean 0. Fewer than two differences, or differences that
+// are all 0, give p = 1; identical non-zero differences give p = 0.
+func pairedTTest(differences []float64) (float64, float64) {
+	n := float64(len(differences))
+	if n < 2 {
+		return 0, 1
+	}
+
+	var mean float64
+	for _, d := range differences {
+		mean += d
+	}
+	mean /= n
+
+	var variance float64
+	for _, d := range differences {
+		variance += (d - mean) * (d - mean)
+	}
+	variance /= n - 1
+
+	const epsilon = 1e-12
+	if variance < epsilon {
+		if math.Abs(mean) < epsilon {
+			return 0, 1
+		}
+		return math.Copysign(math.Inf(1), mean), 0
+	}
+
+	t := mean / math.Sqrt(variance/n)
+	df := n - 1
+	return t, regularizedIncompleteBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// regularizedIncompleteBeta evaluates I_x(a, b) with the continued fraction
+// of Numerical Recipes (betacf)
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	lgammaAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges quickly below the mean of the distribution
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaContinuedFraction(1-x, b, a)/b
+	}
+	return front * betaContinuedFraction(x, a, b) / a
+}
+
+// betaContinuedFraction evaluates the continued fraction of the incomplete
+// beta function with the modified Lentz method
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m <= maxIterations; m++ {
+		// Even step
+		numerator := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step
+		numerator = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
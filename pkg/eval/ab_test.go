@@ -0,0 +1,137 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// labeledQueries returns n queries whose one relevant document, the only one
+// mentioning "relevant", moves through the input positions
+func labeledQueries(n int) []LabeledQuery {
+	queries := make([]LabeledQuery, n)
+	for i := range queries {
+		documents := []string{"filler text one", "filler text two", "filler text three", "filler text four"}
+		position := i % len(documents)
+		documents[position] = fmt.Sprintf("the relevant answer to question %d", i)
+		queries[i] = LabeledQuery{
+			Query:     fmt.Sprintf("question %d", i),
+			Documents: documents,
+			Relevance: map[string]int{fmt.Sprintf("doc_%d", position+1): Relevant},
+		}
+	}
+	return queries
+}
+
+func TestABTest_IdenticalModelsNotSignificant(t *testing.T) {
+	test, err := NewABTest(reranker.NewSimpleReranker(reranker.Config{}), reranker.NewSimpleReranker(reranker.Config{}), 3)
+	if err != nil {
+		t.Fatalf("NewABTest failed: %v", err)
+	}
+	if err := test.Evaluate(context.Background(), labeledQueries(8)); err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+
+	report := test.Report()
+	if report.Queries != 8 || report.MeanA != report.MeanB {
+		t.Errorf("Expected equal means over 8 queries, got %+v", report)
+	}
+	if report.PValue < 0.5 || report.IsSignificant(0.05) {
+		t.Errorf("Expected a high p-value for identical models, got %+v", report)
+	}
+}
+
+func TestABTest_BetterModelSignificant(t *testing.T) {
+	inputOrder := reranker.NewMockReranker(nil)
+	keyword := reranker.NewMockReranker(func(query string, doc reranker.Document) float64 {
+		if strings.Contains(doc.Content, "relevant") {
+			return 1
+		}
+		return 0
+	})
+	test, err := NewABTest(inputOrder, keyword, 3)
+	if err != nil {
+		t.Fatalf("NewABTest failed: %v", err)
+	}
+	if err := test.Evaluate(context.Background(), labeledQueries(12)); err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+
+	report := test.Report()
+	assertClose(t, "mean B", report.MeanB, 1)
+	// A finds the relevant document at ranks 1, 2, 3 and beyond the cutoff in turn
+	assertClose(t, "mean A", report.MeanA, (1+1/math.Log2(3)+0.5+0)/4)
+	if report.TStatistic <= 0 || !report.IsSignificant(0.05) {
+		t.Errorf("Expected B to be significantly better, got %+v", report)
+	}
+}
+
+func TestPairedTTest(t *testing.T) {
+	// Mean 1, sample standard deviation 1 over 11 pairs: t = sqrt(11), df = 10
+	differences := []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	differences[0], differences[1] = 1-math.Sqrt(5), 1+math.Sqrt(5)
+	tStat, p := pairedTTest(differences)
+	assertClose(t, "t", tStat, math.Sqrt(11))
+	// Two-sided p-value of t = 3.3166 with 10 degrees of freedom
+	if math.Abs(p-0.00778) > 1e-4 {
+		t.Errorf("Expected p ~0.00778, got %v", p)
+	}
+
+	if _, p := pairedTTest([]float64{0.2}); p != 1 {
+		t.Errorf("Expected p = 1 for a single pair, got %v", p)
+	}
+	if _, p := pairedTTest([]float64{0.2, 0.2, 0.2}); p != 0 {
+		t.Errorf("Expected p = 0 for identical non-zero differences, got %v", p)
+	}
+}
+
+func TestABTest_RankAlternates(t *testing.T) {
+	test, err := NewABTest(reranker.NewSimpleReranker(reranker.Config{}), reranker.NewSimpleReranker(reranker.Config{}), 0)
+	if err != nil {
+		t.Fatalf("NewABTest failed: %v", err)
+	}
+
+	documents := []reranker.Document{{ID: "1", Content: "machine learning"}}
+	var variants []string
+	for i := 0; i < 5; i++ {
+		_, variant, err := test.Rank(context.Background(), "learning", documents, 1)
+		if err != nil {
+			t.Fatalf("Rank failed: %v", err)
+		}
+		variants = append(variants, variant)
+	}
+	if got := strings.Join(variants, ""); got != "ABABA" {
+		t.Errorf("Expected alternating variants, got %s", got)
+	}
+	if a, b := test.Requests(); a != 3 || b != 2 {
+		t.Errorf("Expected 3 and 2 requests, got %d and %d", a, b)
+	}
+
+	if _, err := NewABTest(nil, reranker.NewSimpleReranker(reranker.Config{}), 0); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without variant A, got %v", err)
+	}
+}
+
+func TestLoadLabeledQueries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rel.json")
+	os.WriteFile(path, []byte(`[{"query": "q", "documents": ["a", "b"], "relevance": {"doc_2": 2}}]`), 0644)
+	queries, err := LoadLabeledQueries(path)
+	if err != nil {
+		t.Fatalf("LoadLabeledQueries failed: %v", err)
+	}
+	if len(queries) != 1 || queries[0].Relevance["doc_2"] != 2 || queries[0].documents()[1].ID != "doc_2" {
+		t.Errorf("Unexpected labeled queries %+v", queries)
+	}
+
+	os.WriteFile(path, []byte(`[{"query": "q"}]`), 0644)
+	if _, err := LoadLabeledQueries(path); err == nil {
+		t.Error("Expected an error for a query without documents")
+	}
+}
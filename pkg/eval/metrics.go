@@ -0,0 +1,263 @@
+// Package eval computes standard information-retrieval metrics for ranked results.
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// Relevance grades used in relevance judgments
+const (
+	Irrelevant     = 0
+	Relevant       = 1
+	HighlyRelevant = 2
+)
+
+// NDCG returns the normalized discounted cumulative gain of the first k results,
+// using linear gains (the trec_eval ndcg_cut convention). relevance maps
+// document IDs to graded relevance; unjudged documents count as irrelevant.
+// The ideal ranking is built from every judged document. k <= 0 uses all results.
+func NDCG(results []reranker.RerankResult, relevance map[string]int, k int) float64 {
+	k = cutoff(k, len(results))
+
+	var dcg float64
+	for i, result := range results[:k] {
+		dcg += gain(relevance[result.Document.ID]) / math.Log2(float64(i+2))
+	}
+
+	grades := make([]int, 0, len(relevance))
+	for _, grade := range relevance {
+		if grade > 0 {
+			grades = append(grades, grade)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(grades)))
+
+	var idcg float64
+	for i, grade := range grades {
+		if i >= k {
+			break
+		}
+		idcg += gain(grade) / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+	return dcg / idcg
+}
+
+// MAP returns the average precision of the results: the mean of the precision
+// at each relevant result, over all relevant judged documents. Averaging it
+// across queries gives mean average precision.
+func MAP(results []reranker.RerankResult, relevance map[string]int) float64 {
+	totalRelevant := 0
+	for _, grade := range relevance {
+		if grade > 0 {
+			totalRelevant++
+		}
+	}
+	if totalRelevant == 0 {
+		return 0
+	}
+
+	var sum float64
+	found := 0
+	for i, result := range results {
+		if relevance[result.Document.ID] > 0 {
+			found++
+			sum += float64(found) / float64(i+1)
+		}
+	}
+	return sum / float64(totalRelevant)
+}
+
+// MRR returns the reciprocal rank of the first relevant result, or 0 when
+// none is relevant. Averaging it across queries gives mean reciprocal rank.
+func MRR(results []reranker.RerankResult, relevance map[string]int) float64 {
+	for i, result := range results {
+		if relevance[result.Document.ID] > 0 {
+			return 1 / float64(i+1)
+		}
+	}
+	return 0
+}
+
+// PrecisionAtK returns the fraction of the top k results that are relevant.
+// Missing results below k count as irrelevant. k <= 0 uses all results.
+func PrecisionAtK(results []reranker.RerankResult, relevance map[string]int, k int) float64 {
+	if k <= 0 {
+		k = len(results)
+	}
+	if k == 0 {
+		return 0
+	}
+
+	relevant := 0
+	for _, result := range results[:cutoff(k, len(results))] {
+		if relevance[result.Document.ID] > 0 {
+			relevant++
+		}
+	}
+	return float64(relevant) / float64(k)
+}
+
+// RankDelta describes how reranking moved one document
+type RankDelta struct {
+	DocumentID string `json:"document_id"`
+	// OriginalRank is the 1-based position in the retrieval order
+	OriginalRank int `json:"original_rank"`
+	// NewRank is the 1-based rank after reranking
+	NewRank int `json:"new_rank"`
+	// ScoreDelta is the reranked score minus the retrieval score
+	ScoreDelta float64 `json:"score_delta"`
+	// RankDelta is OriginalRank - NewRank; positive values moved up
+	RankDelta int `json:"rank_delta"`
+}
+
+// ComputeRankDelta compares each result with its position and score in
+// originalDocs, the retrieval order, returning one delta per result in result
+// order. Results are matched to originalDocs by Index, falling back to the
+// document ID when the index is out of range; unmatched results get an
+// OriginalRank of 0.
+func ComputeRankDelta(originalDocs []reranker.Document, results []reranker.RerankResult) []RankDelta {
+	positions := make(map[string]int, len(originalDocs))
+	for i, doc := range originalDocs {
+		if _, exists := positions[doc.ID]; !exists {
+			positions[doc.ID] = i
+		}
+	}
+
+	deltas := make([]RankDelta, len(results))
+	for i, result := range results {
+		newRank := result.Rank
+		if newRank == 0 {
+			newRank = i + 1
+		}
+		delta := RankDelta{DocumentID: result.Document.ID, NewRank: newRank, ScoreDelta: result.Score}
+
+		position, found := result.Index, result.Index >= 0 && result.Index < len(originalDocs)
+		if !found {
+			position, found = positions[result.Document.ID]
+		}
+		if found {
+			delta.OriginalRank = position + 1
+			delta.ScoreDelta = result.Score - originalDocs[position].Score
+			delta.RankDelta = delta.OriginalRank - newRank
+		}
+		deltas[i] = delta
+	}
+	return deltas
+}
+
+// KendallTau returns the Kendall rank correlation (tau-a) of two orderings of
+// document IDs, from 1 when identical to -1 when reversed. Only IDs present
+// in both are compared; fewer than two are trivially in agreement.
+func KendallTau(a, b []string) float64 {
+	ranksA, ranksB := sharedRanks(a, b)
+	n := len(ranksA)
+	if n < 2 {
+		return 1
+	}
+
+	balance := 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if (ranksA[i] < ranksA[j]) == (ranksB[i] < ranksB[j]) {
+				balance++
+			} else {
+				balance--
+			}
+		}
+	}
+	return float64(balance) / float64(n*(n-1)/2)
+}
+
+// SpearmanRho returns the Spearman rank correlation of two orderings of
+// document IDs, from 1 when identical to -1 when reversed. Only IDs present
+// in both are compared, re-ranked within that set; fewer than two are
+// trivially in agreement.
+func SpearmanRho(a, b []string) float64 {
+	ranksA, ranksB := sharedRanks(a, b)
+	n := len(ranksA)
+	if n < 2 {
+		return 1
+	}
+
+	var sumSquares float64
+	for i := range ranksA {
+		d := float64(ranksA[i] - ranksB[i])
+		sumSquares += d * d
+	}
+	return 1 - 6*sumSquares/float64(n*(n*n-1))
+}
+
+// sharedRanks returns, for each ID present in both a and b in a's order, its
+// 0-based rank among the shared IDs of a and of b. Repeated IDs keep their
+// first position.
+func sharedRanks(a, b []string) ([]int, []int) {
+	inB := make(map[string]bool, len(b))
+	for _, id := range b {
+		inB[id] = true
+	}
+
+	rankInA := make(map[string]int)
+	var shared []string
+	for _, id := range a {
+		if _, seen := rankInA[id]; !seen && inB[id] {
+			rankInA[id] = len(shared)
+			shared = append(shared, id)
+		}
+	}
+
+	rankInB := make(map[string]int, len(shared))
+	for _, id := range b {
+		if _, inA := rankInA[id]; inA {
+			if _, seen := rankInB[id]; !seen {
+				rankInB[id] = len(rankInB)
+			}
+		}
+	}
+
+	ranksA := make([]int, len(shared))
+	ranksB := make([]int, len(shared))
+	for i, id := range shared {
+		ranksA[i] = i
+		ranksB[i] = rankInB[id]
+	}
+	return ranksA, ranksB
+}
+
+// LoadRelevance reads a JSON object mapping document IDs to relevance grades
+func LoadRelevance(filePath string) (map[string]int, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read relevance file: %w", err)
+	}
+
+	var relevance map[string]int
+	if err := json.Unmarshal(data, &relevance); err != nil {
+		return nil, fmt.Errorf("failed to parse relevance file: %w", err)
+	}
+	return relevance, nil
+}
+
+// gain is the linear gain of a relevance grade; negative grades count as 0
+func gain(grade int) float64 {
+	if grade < 0 {
+		return 0
+	}
+	return float64(grade)
+}
+
+// cutoff clamps k to the number of results, treating k <= 0 as all of them
+func cutoff(k, n int) int {
+	if k <= 0 || k > n {
+		return n
+	}
+	return k
+}
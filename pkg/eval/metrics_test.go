@@ -0,0 +1,147 @@
+package eval
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// msmarcoRelevance holds graded judgments (TREC DL scale 0-3) for MS MARCO passages
+var msmarcoRelevance = map[string]int{
+	"7067032": 3,
+	"7067035": 2,
+	"7067036": 0,
+	"3198474": 1,
+	"7067038": 2,
+	"4815151": 0,
+}
+
+// msmarcoRanking is a reranker run over the judged passages plus one unjudged passage
+var msmarcoRanking = []string{"7067036", "7067032", "3198474", "7067038", "4815151", "7067035", "9999999"}
+
+func rankedResults(ids []string) []reranker.RerankResult {
+	results := make([]reranker.RerankResult, len(ids))
+	for i, id := range ids {
+		results[i] = reranker.RerankResult{Document: reranker.Document{ID: id}, Index: i, Rank: i + 1}
+	}
+	return results
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s: expected %.10f, got %.10f", name, want, got)
+	}
+}
+
+func TestNDCG_MSMARCO(t *testing.T) {
+	results := rankedResults(msmarcoRanking)
+
+	assertClose(t, "NDCG@3", NDCG(results, msmarcoRelevance, 3), 0.4547421415311807)
+	assertClose(t, "NDCG@5", NDCG(results, msmarcoRelevance, 5), 0.5716507264214418)
+	assertClose(t, "NDCG@10", NDCG(results, msmarcoRelevance, 10), 0.6967995820552574)
+	assertClose(t, "NDCG@all", NDCG(results, msmarcoRelevance, 0), 0.6967995820552574)
+}
+
+func TestNDCG_Ideal(t *testing.T) {
+	results := rankedResults([]string{"7067032", "7067035", "7067038", "3198474", "7067036"})
+	assertClose(t, "ideal NDCG@5", NDCG(results, msmarcoRelevance, 5), 1)
+	assertClose(t, "no judgments", NDCG(results, map[string]int{}, 5), 0)
+	assertClose(t, "no results", NDCG(nil, msmarcoRelevance, 5), 0)
+}
+
+func TestMAP(t *testing.T) {
+	results := rankedResults(msmarcoRanking)
+	// Relevant at ranks 2, 3, 4 and 6 out of 4 relevant: (1/2 + 2/3 + 3/4 + 4/6) / 4
+	assertClose(t, "MAP", MAP(results, msmarcoRelevance), 0.6458333333333333)
+	assertClose(t, "MAP without relevant", MAP(results, map[string]int{"7067036": 0}), 0)
+}
+
+func TestMRR(t *testing.T) {
+	results := rankedResults(msmarcoRanking)
+	assertClose(t, "MRR", MRR(results, msmarcoRelevance), 0.5)
+	assertClose(t, "MRR without relevant", MRR(results, map[string]int{}), 0)
+}
+
+func TestPrecisionAtK(t *testing.T) {
+	results := rankedResults(msmarcoRanking)
+	assertClose(t, "P@1", PrecisionAtK(results, msmarcoRelevance, 1), 0)
+	assertClose(t, "P@4", PrecisionAtK(results, msmarcoRelevance, 4), 0.75)
+	assertClose(t, "P@10", PrecisionAtK(results, msmarcoRelevance, 10), 0.4)
+	assertClose(t, "P@all", PrecisionAtK(results, msmarcoRelevance, 0), 4.0/7.0)
+	assertClose(t, "P@all without results", PrecisionAtK(nil, msmarcoRelevance, 0), 0)
+}
+
+func TestLoadRelevance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "qrels.json")
+	if err := os.WriteFile(path, []byte(`{"doc_1": 2, "doc_3": 1}`), 0o644); err != nil {
+		t.Fatalf("Failed to write relevance file: %v", err)
+	}
+
+	relevance, err := LoadRelevance(path)
+	if err != nil {
+		t.Fatalf("LoadRelevance failed: %v", err)
+	}
+	if relevance["doc_1"] != HighlyRelevant || relevance["doc_3"] != Relevant || len(relevance) != 2 {
+		t.Errorf("Unexpected relevance: %v", relevance)
+	}
+
+	if err := os.WriteFile(path, []byte(`not json`), 0o644); err != nil {
+		t.Fatalf("Failed to write relevance file: %v", err)
+	}
+	if _, err := LoadRelevance(path); err == nil {
+		t.Error("Expected error for invalid relevance file")
+	}
+}
+
+func TestKendallTauAndSpearmanRho(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e"}
+	reversed := []string{"e", "d", "c", "b", "a"}
+	assertClose(t, "tau identical", KendallTau(ids, ids), 1)
+	assertClose(t, "tau reversed", KendallTau(ids, reversed), -1)
+	assertClose(t, "rho identical", SpearmanRho(ids, ids), 1)
+	assertClose(t, "rho reversed", SpearmanRho(ids, reversed), -1)
+
+	// One adjacent swap: 5 concordant and 1 discordant pair; d = (0, 1, -1, 0)
+	swapped := []string{"a", "c", "b", "d"}
+	assertClose(t, "tau one swap", KendallTau(ids[:4], swapped), 4.0/6.0)
+	assertClose(t, "rho one swap", SpearmanRho(ids[:4], swapped), 0.8)
+
+	// IDs missing from either ordering are ignored
+	assertClose(t, "tau shared only", KendallTau([]string{"a", "x", "b", "c"}, []string{"a", "b", "y", "c"}), 1)
+	assertClose(t, "tau single shared", KendallTau([]string{"a"}, []string{"a", "b"}), 1)
+}
+
+func TestComputeRankDelta(t *testing.T) {
+	original := []reranker.Document{
+		{ID: "a", Score: 0.9},
+		{ID: "b", Score: 0.8},
+		{ID: "c", Score: 0.7},
+	}
+	results := []reranker.RerankResult{
+		{Document: reranker.Document{ID: "c"}, Score: 2.5, Index: 2, Rank: 1},
+		{Document: reranker.Document{ID: "a"}, Score: 1.0, Index: 0, Rank: 2},
+		{Document: reranker.Document{ID: "b"}, Score: 0.5, Index: -1, Rank: 3},
+	}
+
+	want := []RankDelta{
+		{DocumentID: "c", OriginalRank: 3, NewRank: 1, RankDelta: 2},
+		{DocumentID: "a", OriginalRank: 1, NewRank: 2, RankDelta: -1},
+		{DocumentID: "b", OriginalRank: 2, NewRank: 3, RankDelta: -1},
+	}
+	wantScoreDeltas := []float64{1.8, 0.1, -0.3}
+	deltas := ComputeRankDelta(original, results)
+	if len(deltas) != len(want) {
+		t.Fatalf("Expected %d deltas, got %d", len(want), len(deltas))
+	}
+	for i, delta := range deltas {
+		assertClose(t, "score delta "+delta.DocumentID, delta.ScoreDelta, wantScoreDeltas[i])
+		delta.ScoreDelta = 0
+		if delta != want[i] {
+			t.Errorf("Delta %d: expected %+v, got %+v", i, want[i], delta)
+		}
+	}
+}
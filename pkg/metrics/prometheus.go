@@ -0,0 +1,243 @@
+// Package metrics instruments rerankers with Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace prefixes every metric name, e.g. go_rerankers_calls_total
+const Namespace = "go_rerankers"
+
+// Method label values
+const (
+	MethodRank         = "rank"
+	MethodRerank       = "rerank"
+	MethodComputeScore = "compute_score"
+)
+
+// CacheSizer is implemented by rerankers that hold a score cache
+type CacheSizer interface {
+	CacheLen() int
+}
+
+// Option configures a MetricsCollector
+type Option func(*collectorOptions)
+
+// collectorOptions holds the settings applied by Option
+type collectorOptions struct {
+	registerer prometheus.Registerer
+}
+
+// WithMetrics registers the collector's metrics with reg instead of
+// prometheus.DefaultRegisterer
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(o *collectorOptions) {
+		o.registerer = reg
+	}
+}
+
+// MetricsCollector decorates a Reranker, recording call counts, latency,
+// errors by type, processed documents and, for cacheable implementations,
+// the current cache size. It implements reranker.Reranker itself.
+type MetricsCollector struct {
+	inner     reranker.Reranker
+	calls     *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	documents *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	cacheSize *prometheus.GaugeVec
+}
+
+// NewMetricsCollector wraps inner and registers its metrics. Collectors sharing
+// a registry share the underlying metric vectors, distinguished by the model label.
+func NewMetricsCollector(inner reranker.Reranker, opts ...Option) (*MetricsCollector, error) {
+	if inner == nil {
+		return nil, errors.New("metrics: inner reranker is required")
+	}
+
+	options := collectorOptions{registerer: prometheus.DefaultRegisterer}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	labels := []string{"model", "method"}
+	c := &MetricsCollector{inner: inner}
+	var err error
+	if c.calls, err = registerCounterVec(options.registerer, prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "calls_total",
+		Help:      "Total number of reranker calls.",
+	}, labels); err != nil {
+		return nil, err
+	}
+	if c.errors, err = registerCounterVec(options.registerer, prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "errors_total",
+		Help:      "Total number of failed reranker calls by error type.",
+	}, append(labels, "type")); err != nil {
+		return nil, err
+	}
+	if c.documents, err = registerCounterVec(options.registerer, prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "documents_total",
+		Help:      "Total number of documents submitted to the reranker.",
+	}, labels); err != nil {
+		return nil, err
+	}
+	if c.latency, err = registerHistogramVec(options.registerer, prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "duration_seconds",
+		Help:      "Reranker call latency in seconds.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, labels); err != nil {
+		return nil, err
+	}
+	if c.cacheSize, err = registerGaugeVec(options.registerer, prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "cache_entries",
+		Help:      "Current number of cached scores.",
+	}, []string{"model"}); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// registerCounterVec registers a counter vector, reusing an identical one already registered
+func registerCounterVec(reg prometheus.Registerer, opts prometheus.CounterOpts, labels []string) (*prometheus.CounterVec, error) {
+	vec := prometheus.NewCounterVec(opts, labels)
+	existing, err := register(reg, vec)
+	if err != nil {
+		return nil, err
+	}
+	return existing.(*prometheus.CounterVec), nil
+}
+
+// registerHistogramVec registers a histogram vector, reusing an identical one already registered
+func registerHistogramVec(reg prometheus.Registerer, opts prometheus.HistogramOpts, labels []string) (*prometheus.HistogramVec, error) {
+	vec := prometheus.NewHistogramVec(opts, labels)
+	existing, err := register(reg, vec)
+	if err != nil {
+		return nil, err
+	}
+	return existing.(*prometheus.HistogramVec), nil
+}
+
+// registerGaugeVec registers a gauge vector, reusing an identical one already registered
+func registerGaugeVec(reg prometheus.Registerer, opts prometheus.GaugeOpts, labels []string) (*prometheus.GaugeVec, error) {
+	vec := prometheus.NewGaugeVec(opts, labels)
+	existing, err := register(reg, vec)
+	if err != nil {
+		return nil, err
+	}
+	return existing.(*prometheus.GaugeVec), nil
+}
+
+// register adds collector to reg, returning the previously registered
+// collector when an identical one already exists
+func register(reg prometheus.Registerer, collector prometheus.Collector) (prometheus.Collector, error) {
+	if err := reg.Register(collector); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			return already.ExistingCollector, nil
+		}
+		return nil, err
+	}
+	return collector, nil
+}
+
+// observe records the outcome of a single call
+func (c *MetricsCollector) observe(method string, numDocs int, start time.Time, err error) {
+	model := c.inner.GetModelName()
+	c.calls.WithLabelValues(model, method).Inc()
+	c.documents.WithLabelValues(model, method).Add(float64(numDocs))
+	c.latency.WithLabelValues(model, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(model, method, ErrorType(err)).Inc()
+	}
+	if sizer, ok := c.inner.(CacheSizer); ok {
+		c.cacheSize.WithLabelValues(model).Set(float64(sizer.CacheLen()))
+	}
+}
+
+// ErrorType maps an error to a low-cardinality label value
+func ErrorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, reranker.ErrInvalidInput):
+		return "invalid_input"
+	case errors.Is(err, reranker.ErrInference):
+		return "inference"
+	case errors.Is(err, reranker.ErrInitialization):
+		return "initialization"
+	case errors.Is(err, reranker.ErrModelNotFound):
+		return "model_not_found"
+	case errors.Is(err, reranker.ErrUnsupportedModel):
+		return "unsupported_model"
+	}
+	return "other"
+}
+
+// Rerank delegates to the wrapped reranker and records metrics
+func (c *MetricsCollector) Rerank(ctx context.Context, query string, documents []reranker.Document) ([]reranker.Document, error) {
+	start := time.Now()
+	reranked, err := c.inner.Rerank(ctx, query, documents)
+	c.observe(MethodRerank, len(documents), start, err)
+	return reranked, err
+}
+
+// ComputeScore delegates to the wrapped reranker and records metrics
+func (c *MetricsCollector) ComputeScore(ctx context.Context, query string, documents []reranker.Document) ([]float64, error) {
+	start := time.Now()
+	scores, err := c.inner.ComputeScore(ctx, query, documents)
+	c.observe(MethodComputeScore, len(documents), start, err)
+	return scores, err
+}
+
+// Rank delegates to the wrapped reranker and records metrics
+func (c *MetricsCollector) Rank(ctx context.Context, query string, documents []reranker.Document, topN int) ([]reranker.RerankResult, error) {
+	start := time.Now()
+	results, err := c.inner.Rank(ctx, query, documents, topN)
+	c.observe(MethodRank, len(documents), start, err)
+	return results, err
+}
+
+// Configure delegates to the wrapped reranker
+func (c *MetricsCollector) Configure(config reranker.Config) error {
+	return c.inner.Configure(config)
+}
+
+// GetModelName returns the wrapped model name
+func (c *MetricsCollector) GetModelName() string {
+	return c.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (c *MetricsCollector) Version() string {
+	return c.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (c *MetricsCollector) HealthCheck(ctx context.Context) error {
+	return c.inner.HealthCheck(ctx)
+}
+
+// Close releases resources held by the wrapped reranker
+func (c *MetricsCollector) Close() error {
+	switch closer := c.inner.(type) {
+	case interface{ Close() error }:
+		return closer.Close()
+	case interface{ Close() }:
+		closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// findMetric returns the metric in family name whose labels include want
+func findMetric(t *testing.T, reg *prometheus.Registry, name string, want map[string]string) *dto.Metric {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			matched := true
+			for key, value := range want {
+				if labels[key] != value {
+					matched = false
+				}
+			}
+			if matched {
+				return metric
+			}
+		}
+	}
+	t.Fatalf("Metric %s%v not found", name, want)
+	return nil
+}
+
+func TestMetricsCollector_Rank(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	inner := reranker.NewSimpleReranker(reranker.Config{Model: "simple"})
+	c, err := NewMetricsCollector(inner, WithMetrics(reg))
+	if err != nil {
+		t.Fatalf("NewMetricsCollector failed: %v", err)
+	}
+
+	documents := []reranker.Document{{ID: "1", Content: "machine learning"}, {ID: "2", Content: "cooking"}}
+	if _, err := c.Rank(context.Background(), "machine learning", documents, 1); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	labels := map[string]string{"model": "simple", "method": MethodRank}
+	if got := findMetric(t, reg, "go_rerankers_calls_total", labels).GetCounter().GetValue(); got != 1 {
+		t.Errorf("Expected 1 call, got %v", got)
+	}
+	if got := findMetric(t, reg, "go_rerankers_documents_total", labels).GetCounter().GetValue(); got != 2 {
+		t.Errorf("Expected 2 documents, got %v", got)
+	}
+	histogram := findMetric(t, reg, "go_rerankers_duration_seconds", labels).GetHistogram()
+	if histogram.GetSampleCount() != 1 || histogram.GetSampleSum() <= 0 {
+		t.Errorf("Expected one non-zero latency observation, got count=%d sum=%v",
+			histogram.GetSampleCount(), histogram.GetSampleSum())
+	}
+}
+
+// failingReranker always fails with the configured error
+type failingReranker struct {
+	reranker.Reranker
+	err error
+}
+
+func (r *failingReranker) ComputeScore(ctx context.Context, query string, documents []reranker.Document) ([]float64, error) {
+	return nil, r.err
+}
+
+func (r *failingReranker) GetModelName() string { return "failing" }
+
+func (r *failingReranker) Version() string { return "failing@test" }
+
+func (r *failingReranker) HealthCheck(ctx context.Context) error { return nil }
+
+func (r *failingReranker) CacheLen() int { return 3 }
+
+func TestMetricsCollector_ErrorsAndCache(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	inner := &failingReranker{err: fmt.Errorf("%w: backend down", reranker.ErrInference)}
+	c, err := NewMetricsCollector(inner, WithMetrics(reg))
+	if err != nil {
+		t.Fatalf("NewMetricsCollector failed: %v", err)
+	}
+
+	if _, err := c.ComputeScore(context.Background(), "q", []reranker.Document{{Content: "d"}}); err == nil {
+		t.Fatal("Expected ComputeScore to fail")
+	}
+
+	errorLabels := map[string]string{"model": "failing", "method": MethodComputeScore, "type": "inference"}
+	if got := findMetric(t, reg, "go_rerankers_errors_total", errorLabels).GetCounter().GetValue(); got != 1 {
+		t.Errorf("Expected 1 inference error, got %v", got)
+	}
+	if got := findMetric(t, reg, "go_rerankers_cache_entries", map[string]string{"model": "failing"}).GetGauge().GetValue(); got != 3 {
+		t.Errorf("Expected cache gauge 3, got %v", got)
+	}
+
+	// A second collector on the same registry reuses the registered vectors
+	if _, err := NewMetricsCollector(inner, WithMetrics(reg)); err != nil {
+		t.Errorf("Expected re-registration to succeed, got %v", err)
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	if got := ErrorType(context.Canceled); got != "canceled" {
+		t.Errorf("Expected canceled, got %s", got)
+	}
+	if got := ErrorType(errors.New("boom")); got != "other" {
+		t.Errorf("Expected other, got %s", got)
+	}
+}
@@ -0,0 +1,61 @@
+package reranker
+
+import "context"
+
+var _ AsyncReranker = (*GGUFLocalReranker)(nil)
+
+// RankFuture is the pending result of a RankAsync call
+type RankFuture struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	results []RerankResult
+	err     error
+}
+
+// rankAsync starts r.Rank in a new goroutine under a cancellable child of ctx
+func rankAsync(ctx context.Context, r Reranker, query string, documents []Document, topN int) *RankFuture {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+
+	future := &RankFuture{done: make(chan struct{}), cancel: cancel}
+	go func() {
+		defer close(future.done)
+		defer cancel()
+		future.results, future.err = r.Rank(ctx, query, documents, topN)
+	}()
+	return future
+}
+
+// Wait blocks until ranking finishes and returns its results
+func (f *RankFuture) Wait() ([]RerankResult, error) {
+	<-f.done
+	return f.results, f.err
+}
+
+// Done returns a channel that is closed once ranking finishes
+func (f *RankFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel cancels the ranking context; Wait then returns once the running
+// inference subprocesses have stopped. Cancelling a finished future is a no-op.
+func (f *RankFuture) Cancel() {
+	f.cancel()
+}
+
+// IsReady reports whether ranking has finished without blocking
+func (f *RankFuture) IsReady() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// RankAsync starts ranking in the background and returns immediately
+func (r *GGUFLocalReranker) RankAsync(ctx context.Context, query string, documents []Document, topN int) *RankFuture {
+	return rankAsync(ctx, r, query, documents, topN)
+}
@@ -0,0 +1,70 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// assertNoLeakedGoroutines fails unless the goroutine count drops back to
+// baseline within a second, in the manner of goleak.VerifyNone
+func assertNoLeakedGoroutines(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("Expected %d goroutines, got %d:\n%s", baseline, runtime.NumGoroutine(), buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestGGUFLocalReranker_RankAsync(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 2)
+	future := reranker.RankAsync(context.Background(), "query", []Document{{ID: "a", Content: "first"}, {ID: "b", Content: "second"}}, 1)
+
+	select {
+	case <-future.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected ranking to finish")
+	}
+	if !future.IsReady() {
+		t.Error("Expected the future to be ready once Done is closed")
+	}
+	results, err := future.Wait()
+	if err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Rank != 1 {
+		t.Errorf("Expected a single rank-1 result, got %+v", results)
+	}
+
+	// Cancelling a finished future changes nothing
+	future.Cancel()
+	if again, err := future.Wait(); err != nil || len(again) != 1 {
+		t.Errorf("Expected the same results after Cancel, got %+v, %v", again, err)
+	}
+}
+
+func TestGGUFLocalReranker_RankAsyncCancel(t *testing.T) {
+	reranker, pidFile := newHangingGGUFReranker(t, "exec sleep 30")
+	reranker.config.Options["inference_timeout_seconds"] = 30
+	baseline := runtime.NumGoroutine()
+
+	future := reranker.RankAsync(context.Background(), "query", []Document{{Content: "document"}}, 0)
+	time.Sleep(50 * time.Millisecond)
+	if future.IsReady() {
+		t.Fatal("Expected ranking to still be running")
+	}
+
+	future.Cancel()
+	_, err := future.Wait()
+	if !errors.Is(err, ErrInference) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected ErrInference wrapping context.Canceled, got %v", err)
+	}
+	assertNoLeakedGoroutines(t, baseline)
+	assertProcessGone(t, pidFile)
+}
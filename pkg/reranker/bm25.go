@@ -0,0 +1,202 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// Default BM25 parameters
+const (
+	DefaultBM25K1 = 1.5
+	DefaultBM25B  = 0.75
+)
+
+// bm25Index holds the corpus statistics BM25 scores against
+type bm25Index struct {
+	documentFrequency map[string]int
+	documents         int
+	averageLength     float64
+}
+
+// newBM25Index counts the documents containing each term and the average
+// document length in tokens
+func newBM25Index(documents []Document) *bm25Index {
+	index := &bm25Index{documentFrequency: make(map[string]int), documents: len(documents)}
+	var totalLength int
+	for _, doc := range documents {
+		tokens := tokenize(doc.Content)
+		totalLength += len(tokens)
+		seen := make(map[string]bool, len(tokens))
+		for _, token := range tokens {
+			if !seen[token] {
+				seen[token] = true
+				index.documentFrequency[token]++
+			}
+		}
+	}
+	if len(documents) > 0 {
+		index.averageLength = float64(totalLength) / float64(len(documents))
+	}
+	return index
+}
+
+// idf is the non-negative BM25 inverse document frequency
+// ln(1 + (N - df + 0.5) / (df + 0.5))
+func (index *bm25Index) idf(term string) float64 {
+	df := float64(index.documentFrequency[term])
+	return math.Log(1 + (float64(index.documents)-df+0.5)/(df+0.5))
+}
+
+// BM25Reranker is a fast lexical reranker scoring documents with Okapi BM25.
+// IDF and the average document length come from the corpus given to
+// NewBM25Reranker, or from each call's documents when there is none.
+// Scores are 0 for documents sharing no term with the query and typically
+// stay below 20.
+//
+// Recognized options:
+//   - "k1": term frequency saturation (default 1.5)
+//   - "b": document length normalization, from 0 to 1 (default 0.75)
+type BM25Reranker struct {
+	config Config
+	k1     float64
+	b      float64
+	index  *bm25Index
+}
+
+// NewBM25Reranker creates a BM25 reranker indexing corpus; a nil corpus
+// indexes the documents of each call instead
+func NewBM25Reranker(config Config, corpus []Document) (*BM25Reranker, error) {
+	r := &BM25Reranker{}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	if len(corpus) > 0 {
+		r.index = newBM25Index(corpus)
+	}
+	return r, nil
+}
+
+// Rerank reorders documents by their BM25 score
+func (r *BM25Reranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns the BM25 score of each document in document order
+func (r *BM25Reranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *BM25Reranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	index := r.index
+	if index == nil {
+		index = newBM25Index(documents)
+	}
+
+	// Repeated query terms count once
+	terms := make(map[string]float64)
+	for _, token := range tokenize(query) {
+		terms[token] = index.idf(token)
+	}
+
+	scores := make([]float64, len(documents))
+	for i, doc := range documents {
+		tokens := tokenize(doc.Content)
+		frequencies := make(map[string]float64, len(tokens))
+		for _, token := range tokens {
+			frequencies[token]++
+		}
+
+		lengthNorm := 1.0
+		if index.averageLength > 0 {
+			lengthNorm = 1 - r.b + r.b*float64(len(tokens))/index.averageLength
+		}
+		for term, idf := range terms {
+			if tf := frequencies[term]; tf > 0 {
+				scores[i] += idf * tf * (r.k1 + 1) / (tf + r.k1*lengthNorm)
+			}
+		}
+	}
+	return r.config.transformScores(scores)
+}
+
+// Rank returns top-N documents by BM25 score; Index is the input position
+func (r *BM25Reranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the model name
+func (r *BM25Reranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"
+func (r *BM25Reranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck is a no-op; scoring needs no external resources
+func (r *BM25Reranker) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// Configure updates the configuration and BM25 parameters; the corpus index
+// is left unchanged
+func (r *BM25Reranker) Configure(config Config) error {
+	if err := validateThreshold(config); err != nil {
+		return err
+	}
+	k1 := OptionFloat(config.Options, "k1", DefaultBM25K1)
+	if k1 < 0 {
+		return fmt.Errorf("%w: k1 must not be negative, got %v", ErrInvalidInput, k1)
+	}
+	b := OptionFloat(config.Options, "b", DefaultBM25B)
+	if b < 0 || b > 1 {
+		return fmt.Errorf("%w: b must be between 0 and 1, got %v", ErrInvalidInput, b)
+	}
+
+	r.config = config
+	if r.config.Model == "" {
+		r.config.Model = "bm25"
+	}
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.k1, r.b = k1, b
+	return nil
+}
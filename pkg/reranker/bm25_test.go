@@ -0,0 +1,77 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// bm25Corpus is a small corpus where "reranker" and "gguf" are rare terms
+var bm25Corpus = []Document{
+	{ID: "both", Content: "A GGUF reranker scores documents locally"},
+	{ID: "none", Content: "Cooking pasta takes about ten minutes"},
+	{ID: "one", Content: "The reranker reorders search results"},
+	{ID: "long", Content: "A reranker is a model; this document goes on and on about search engines, indexes, queries and ranking pipelines"},
+	{ID: "other", Content: "Gardening in spring needs water and sunlight"},
+}
+
+func TestBM25Reranker_RanksMatchingTermsFirst(t *testing.T) {
+	r, err := NewBM25Reranker(Config{}, bm25Corpus)
+	if err != nil {
+		t.Fatalf("NewBM25Reranker failed: %v", err)
+	}
+
+	results, err := r.Rank(context.Background(), "gguf reranker", bm25Corpus, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	ids := resultIDs(results)
+	if ids[0] != "both" || ids[1] != "one" || ids[2] != "long" {
+		t.Errorf("Expected all terms, then one term in a short and a long document, got %v", ids)
+	}
+	for _, result := range results {
+		if result.Score < 0 || result.Score > 20 {
+			t.Errorf("Expected scores in [0, 20], got %v for %s", result.Score, result.Document.ID)
+		}
+		if (result.Document.ID == "none" || result.Document.ID == "other") && result.Score != 0 {
+			t.Errorf("Expected 0 for %s without query terms, got %v", result.Document.ID, result.Score)
+		}
+	}
+}
+
+func TestBM25Reranker_Formula(t *testing.T) {
+	documents := []Document{{ID: "a", Content: "apple apple banana"}, {ID: "b", Content: "cherry"}}
+	r, err := NewBM25Reranker(Config{Options: map[string]interface{}{"k1": 1.2, "b": 0.5}}, nil)
+	if err != nil {
+		t.Fatalf("NewBM25Reranker failed: %v", err)
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "apple apple", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+
+	// Indexed on the call's documents: N = 2, df(apple) = 1, average length 2
+	idf := math.Log(1 + 1.5/1.5)
+	want := idf * 2 * 2.2 / (2 + 1.2*(1-0.5+0.5*3.0/2))
+	if math.Abs(scores[0]-want) > 1e-9 || scores[1] != 0 {
+		t.Errorf("Expected scores [%v 0], got %v", want, scores)
+	}
+}
+
+func TestBM25Reranker_Factory(t *testing.T) {
+	r, err := NewReranker(Config{Model: "bm25"})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*BM25Reranker); !ok || r.GetModelName() != "bm25" {
+		t.Errorf("Expected a BM25Reranker named bm25, got %T %s", r, r.GetModelName())
+	}
+
+	for _, options := range []map[string]interface{}{{"k1": -1.0}, {"b": 1.5}} {
+		if _, err := NewBM25Reranker(Config{Options: options}, nil); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %v, got %v", options, err)
+		}
+	}
+}
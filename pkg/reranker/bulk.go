@@ -0,0 +1,64 @@
+package reranker
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+var _ BulkReranker = (*GGUFLocalReranker)(nil)
+
+// BulkRank ranks every request with a single worker pool bounded by the
+// "workers" option (default: number of CPUs divided by "threads"). All
+// query-document pairs share the pool and the score cache, so repeated pairs
+// across requests run llama-embedding only once. Results are returned in
+// request order.
+func (r *GGUFLocalReranker) BulkRank(ctx context.Context, requests []RankRequest) ([][]RerankResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Fan out every pair at once rather than one Rank call per request, so
+	// small requests do not leave workers idle
+	scores := make([][]float64, len(requests))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(r.workerCount())
+	for i, request := range requests {
+		scores[i] = make([]float64, len(request.Documents))
+		for j, doc := range request.Documents {
+			i, j, query, content := i, j, request.Query, doc.Content
+			group.Go(func() error {
+				if err := groupCtx.Err(); err != nil {
+					return err
+				}
+				score, err := r.computeRerankerScore(groupCtx, query, content)
+				if isContextError(err) {
+					return err
+				}
+				if err != nil {
+					// If scoring fails, assign a low score
+					score = -5.0
+				}
+				scores[i][j] = score
+				return nil
+			})
+		}
+	}
+	if err := group.Wait(); err != nil {
+		return nil, inferenceError(err)
+	}
+
+	results := make([][]RerankResult, len(requests))
+	for i, request := range requests {
+		if len(request.Documents) == 0 {
+			continue
+		}
+		normalized, err := r.config.transformScores(scores[i])
+		if err != nil {
+			return nil, err
+		}
+		ranked := rankByScores(request.Documents, normalized, r.config.scoreThreshold(normalized), request.TopN, r.config.tieBreak())
+		results[i] = assignRanks(ranked, r.config.NormalizeScores)
+	}
+	return results, nil
+}
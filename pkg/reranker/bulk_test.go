@@ -0,0 +1,84 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// newTopicGGUFReranker embeds "topic <n>" texts as [n, 1], so a query scores
+// highest against the document with the same topic number
+func newTopicGGUFReranker(t *testing.T, workers int) *GGUFLocalReranker {
+	t.Helper()
+
+	reranker := newFakeGGUFReranker(t, workers)
+	script := "#!/bin/sh\nn=0\nfor i in 0 1 2 3 4 5 6 7 8 9; do\n\tcase \"$*\" in *\"topic $i\"*) n=$i ;; esac\ndone\n" +
+		"echo \"{\\\"data\\\":[{\\\"index\\\":0,\\\"embedding\\\":[$n,1]}]}\"\n"
+	writeStubBinary(t, reranker.inferenceBinary, script)
+	return reranker
+}
+
+func TestGGUFLocalReranker_BulkRankPreservesOrder(t *testing.T) {
+	reranker := newTopicGGUFReranker(t, 4)
+	documents := make([]Document, 10)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("topic %d", (i*7)%10)}
+	}
+
+	requests := make([]RankRequest, 10)
+	for i := range requests {
+		requests[i] = RankRequest{Query: fmt.Sprintf("topic %d", i), Documents: documents, TopN: 3}
+	}
+
+	results, err := reranker.BulkRank(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("BulkRank failed: %v", err)
+	}
+	if len(results) != len(requests) {
+		t.Fatalf("Expected %d result sets, got %d", len(requests), len(results))
+	}
+
+	for i, ranked := range results {
+		if len(ranked) != 3 {
+			t.Fatalf("Request %d: expected 3 results, got %d", i, len(ranked))
+		}
+		if want := fmt.Sprintf("topic %d", i); ranked[0].Document.Content != want {
+			t.Errorf("Request %d: expected %q first, got %q", i, want, ranked[0].Document.Content)
+		}
+		if ranked[0].Rank != 1 || documents[ranked[0].Index].Content != ranked[0].Document.Content {
+			t.Errorf("Request %d: unexpected rank or index %+v", i, ranked[0])
+		}
+	}
+
+	// Bulk results match individual Rank calls
+	single, err := reranker.Rank(context.Background(), requests[4].Query, documents, 3)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	for i := range single {
+		if single[i].Index != results[4][i].Index || single[i].Score != results[4][i].Score {
+			t.Errorf("Position %d: Rank returned %+v, BulkRank returned %+v", i, single[i], results[4][i])
+		}
+	}
+}
+
+func TestGGUFLocalReranker_BulkRankEmptyRequests(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 2)
+	results, err := reranker.BulkRank(context.Background(), []RankRequest{{Query: "q"}, {Query: "q", Documents: []Document{{Content: "d"}}}})
+	if err != nil {
+		t.Fatalf("BulkRank failed: %v", err)
+	}
+	if len(results) != 2 || results[0] != nil || len(results[1]) != 1 {
+		t.Errorf("Expected no results for the empty request and one for the other, got %+v", results)
+	}
+}
+
+func TestGGUFLocalReranker_BulkRankCancelled(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := reranker.BulkRank(ctx, []RankRequest{{Query: "q", Documents: []Document{{Content: "d"}}}}); err == nil {
+		t.Error("Expected an error for a cancelled context")
+	}
+}
@@ -0,0 +1,328 @@
+package reranker
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the score cache capacity used when cache_size is not set
+const DefaultCacheSize = 1000
+
+// DefaultQueryCacheSize is the query cache capacity used when query_cache_size is not set
+const DefaultQueryCacheSize = 100
+
+// lruCache is a thread-safe LRU cache with an optional per-entry TTL
+type lruCache[V any] struct {
+	capacity int
+	ttl      time.Duration
+	mutex    sync.Mutex
+	order    *list.List // front = most recently used
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+// lruEntry is the value stored in each list element
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// newLRUCache creates a cache holding at most capacity entries; a zero ttl disables expiry
+func newLRUCache[V any](capacity int, ttl time.Duration) lruCache[V] {
+	return lruCache[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get returns the cached value for key, evicting it if it has expired
+func (c *lruCache[V]) Get(key string) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var zero V
+	element, exists := c.entries[key]
+	if !exists {
+		return zero, false
+	}
+
+	entry := element.Value.(*lruEntry[V])
+	if c.expired(entry) {
+		c.removeElement(element)
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set stores a value, evicting the least recently used entry when full
+func (c *lruCache[V]) Set(key string, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = c.now().Add(c.ttl)
+	}
+
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Len returns the number of entries currently held, including expired ones not yet accessed
+func (c *lruCache[V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+// Clear removes all entries
+func (c *lruCache[V]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
+
+// expired reports whether an entry has outlived the TTL; callers hold the mutex
+func (c *lruCache[V]) expired(entry *lruEntry[V]) bool {
+	return !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt)
+}
+
+// removeElement drops an element from both the list and the index; callers hold the mutex
+func (c *lruCache[V]) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry[V]).key)
+}
+
+// ScoreCache is a thread-safe LRU cache of scores with an optional per-entry TTL.
+// It can be embedded by any reranker that wants to memoize query-document scores.
+type ScoreCache struct {
+	lruCache[float64]
+}
+
+// NewScoreCache creates a cache holding at most capacity entries.
+// A non-positive capacity uses DefaultCacheSize; a zero ttl disables expiry.
+func NewScoreCache(capacity int, ttl time.Duration) *ScoreCache {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+	return &ScoreCache{newLRUCache[float64](capacity, ttl)}
+}
+
+// newScoreCacheFromOptions reads cache_size and cache_ttl_seconds from config options
+func newScoreCacheFromOptions(opts map[string]interface{}) *ScoreCache {
+	return NewScoreCache(
+		OptionInt(opts, "cache_size", DefaultCacheSize),
+		OptionDuration(opts, "cache_ttl_seconds", 0),
+	)
+}
+
+// QueryCache is a thread-safe LRU cache of full Rank results keyed by
+// QueryCacheKey, with an optional per-entry TTL
+type QueryCache struct {
+	lruCache[[]RerankResult]
+}
+
+// NewQueryCache creates a cache holding at most capacity result lists.
+// A non-positive capacity uses DefaultQueryCacheSize; a zero ttl disables expiry.
+func NewQueryCache(capacity int, ttl time.Duration) *QueryCache {
+	if capacity <= 0 {
+		capacity = DefaultQueryCacheSize
+	}
+	return &QueryCache{newLRUCache[[]RerankResult](capacity, ttl)}
+}
+
+// newQueryCacheFromOptions reads query_cache_size and query_cache_ttl_seconds from config options
+func newQueryCacheFromOptions(opts map[string]interface{}) *QueryCache {
+	return NewQueryCache(
+		OptionInt(opts, "query_cache_size", DefaultQueryCacheSize),
+		OptionDuration(opts, "query_cache_ttl_seconds", 0),
+	)
+}
+
+// QueryCacheKey returns the SHA-256 fingerprint of the query, the sorted
+// document IDs and topN. Documents are identified by ID only, so callers must
+// not reuse an ID for different content.
+func QueryCacheKey(query string, documents []Document, topN int) string {
+	ids := make([]string, len(documents))
+	for i, doc := range documents {
+		ids[i] = doc.ID
+	}
+	sort.Strings(ids)
+
+	hash := sha256.New()
+	// Length-prefix every field so distinct inputs cannot concatenate to the same bytes
+	writeField := func(field string) {
+		var size [8]byte
+		binary.BigEndian.PutUint64(size[:], uint64(len(field)))
+		hash.Write(size[:])
+		hash.Write([]byte(field))
+	}
+	writeField(query)
+	for _, id := range ids {
+		writeField(id)
+	}
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(int64(topN)))
+	hash.Write(n[:])
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// queryCacheEnabled reports whether the options request a query cache
+func queryCacheEnabled(opts map[string]interface{}) bool {
+	return OptionInt(opts, "query_cache_size", 0) > 0
+}
+
+// QueryCachingReranker memoizes Rank results of the wrapped reranker for
+// repeated (query, document IDs, topN) requests. Rerank and ComputeScore are
+// passed through unchanged. Requests containing documents with empty or
+// duplicate IDs bypass the cache.
+//
+// Recognized options:
+//   - "query_cache_size": maximum number of cached result lists (default 100)
+//   - "query_cache_ttl_seconds": lifetime of cached results (default: no expiry)
+type QueryCachingReranker struct {
+	config Config
+	inner  Reranker
+	cache  *QueryCache
+}
+
+// NewQueryCachingReranker wraps inner with a Rank result cache configured from config options
+func NewQueryCachingReranker(inner Reranker, config Config) (*QueryCachingReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: query caching requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &QueryCachingReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rerank delegates to the wrapped reranker without caching
+func (r *QueryCachingReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	return r.inner.Rerank(ctx, query, documents)
+}
+
+// ComputeScore delegates to the wrapped reranker without caching
+func (r *QueryCachingReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	return r.inner.ComputeScore(ctx, query, documents)
+}
+
+// Rank returns cached results for a repeated request, or ranks with the wrapped
+// reranker and caches the results. Result indices always refer to positions in
+// documents, even when a cached request listed the same documents in another order.
+func (r *QueryCachingReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	positions, ok := documentPositions(documents)
+	if !ok {
+		return r.inner.Rank(ctx, query, documents, topN)
+	}
+
+	key := QueryCacheKey(query, documents, topN)
+	if cached, hit := r.cache.Get(key); hit {
+		results := append([]RerankResult(nil), cached...)
+		for i := range results {
+			results[i].Index = positions[results[i].Document.ID]
+		}
+		return results, nil
+	}
+
+	results, err := r.inner.Rank(ctx, query, documents, topN)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Set(key, append([]RerankResult(nil), results...))
+	return results, nil
+}
+
+// documentPositions maps document IDs to their positions, reporting false
+// when an ID is empty or repeated
+func documentPositions(documents []Document) (map[string]int, bool) {
+	positions := make(map[string]int, len(documents))
+	for i, doc := range documents {
+		if _, seen := positions[doc.ID]; seen || doc.ID == "" {
+			return nil, false
+		}
+		positions[doc.ID] = i
+	}
+	return positions, true
+}
+
+// GetModelName returns the wrapped model name
+func (r *QueryCachingReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *QueryCachingReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *QueryCachingReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure replaces the cache with one sized from config options; the inner reranker is left unchanged
+func (r *QueryCachingReranker) Configure(config Config) error {
+	r.config = config
+	r.cache = newQueryCacheFromOptions(config.Options)
+	return nil
+}
+
+// CacheLen returns the number of cached result lists
+func (r *QueryCachingReranker) CacheLen() int {
+	return r.cache.Len()
+}
+
+// Close clears the cache and releases resources held by the wrapped reranker
+func (r *QueryCachingReranker) Close() error {
+	r.cache.Clear()
+	return closeReranker(r.inner)
+}
+
+// Unwrap returns the wrapped reranker
+func (r *QueryCachingReranker) Unwrap() Reranker {
+	return r.inner
+}
@@ -0,0 +1,208 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScoreCache_CapacityEviction(t *testing.T) {
+	cache := NewScoreCache(2, 0)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// Touch "a" so "b" becomes least recently used
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Expected a to be cached")
+	}
+	cache.Set("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if score, ok := cache.Get("a"); !ok || score != 1 {
+		t.Errorf("Expected a=1 to survive, got %f (%v)", score, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestScoreCache_TTLExpiration(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := NewScoreCache(10, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", 1)
+	now = now.Add(30 * time.Second)
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Expected a to be cached before TTL")
+	}
+
+	now = now.Add(31 * time.Second)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected a to expire after TTL")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected expired entry to be evicted on access, got %d entries", cache.Len())
+	}
+}
+
+func TestScoreCache_Options(t *testing.T) {
+	cache := newScoreCacheFromOptions(map[string]interface{}{"cache_size": 5, "cache_ttl_seconds": 2})
+	if cache.capacity != 5 || cache.ttl != 2*time.Second {
+		t.Errorf("Expected capacity 5 and ttl 2s, got %d and %v", cache.capacity, cache.ttl)
+	}
+
+	defaults := newScoreCacheFromOptions(nil)
+	if defaults.capacity != DefaultCacheSize || defaults.ttl != 0 {
+		t.Errorf("Expected defaults, got %d and %v", defaults.capacity, defaults.ttl)
+	}
+}
+
+func TestScoreCache_Concurrent(t *testing.T) {
+	cache := NewScoreCache(50, time.Hour)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("k%d", (worker*i)%100)
+				cache.Set(key, float64(i))
+				cache.Get(key)
+				if i%100 == 0 {
+					cache.Len()
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	if cache.Len() > 50 {
+		t.Errorf("Expected at most 50 entries, got %d", cache.Len())
+	}
+}
+
+// countingReranker counts Rank calls made to a SimpleReranker
+type countingReranker struct {
+	SimpleReranker
+	rankCalls int
+}
+
+func (r *countingReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	r.rankCalls++
+	return r.SimpleReranker.Rank(ctx, query, documents, topN)
+}
+
+func TestQueryCachingReranker_HitAndMiss(t *testing.T) {
+	inner := &countingReranker{SimpleReranker: *NewSimpleReranker(Config{})}
+	r, err := NewQueryCachingReranker(inner, Config{Options: map[string]interface{}{"query_cache_size": 10}})
+	if err != nil {
+		t.Fatalf("NewQueryCachingReranker failed: %v", err)
+	}
+
+	documents := []Document{
+		{ID: "a", Content: "machine learning models"},
+		{ID: "b", Content: "cooking pasta"},
+		{ID: "c", Content: "deep learning"},
+	}
+	first, err := r.Rank(context.Background(), "machine learning", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	second, err := r.Rank(context.Background(), "machine learning", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if inner.rankCalls != 1 {
+		t.Errorf("Expected the repeated request to hit the cache, got %d inner calls", inner.rankCalls)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected cached results %+v, got %+v", first, second)
+	}
+
+	// A different topN or query is a miss
+	if _, err := r.Rank(context.Background(), "machine learning", documents, 3); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if _, err := r.Rank(context.Background(), "pasta", documents, 2); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if inner.rankCalls != 3 {
+		t.Errorf("Expected misses to call through, got %d inner calls", inner.rankCalls)
+	}
+	if r.CacheLen() != 3 {
+		t.Errorf("Expected 3 cached result lists, got %d", r.CacheLen())
+	}
+}
+
+func TestQueryCachingReranker_ReorderedDocuments(t *testing.T) {
+	inner := &countingReranker{SimpleReranker: *NewSimpleReranker(Config{})}
+	r, err := NewQueryCachingReranker(inner, Config{})
+	if err != nil {
+		t.Fatalf("NewQueryCachingReranker failed: %v", err)
+	}
+
+	documents := []Document{{ID: "a", Content: "machine learning"}, {ID: "b", Content: "cooking"}}
+	if _, err := r.Rank(context.Background(), "machine learning", documents, 0); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	reordered := []Document{documents[1], documents[0]}
+	results, err := r.Rank(context.Background(), "machine learning", reordered, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if inner.rankCalls != 1 {
+		t.Errorf("Expected reordered documents to hit the cache, got %d inner calls", inner.rankCalls)
+	}
+	for _, result := range results {
+		if reordered[result.Index].ID != result.Document.ID {
+			t.Errorf("Expected index %d to point at %s", result.Index, result.Document.ID)
+		}
+	}
+}
+
+func TestQueryCachingReranker_BypassesAmbiguousIDs(t *testing.T) {
+	inner := &countingReranker{SimpleReranker: *NewSimpleReranker(Config{})}
+	r, err := NewQueryCachingReranker(inner, Config{})
+	if err != nil {
+		t.Fatalf("NewQueryCachingReranker failed: %v", err)
+	}
+
+	documents := []Document{{Content: "machine learning"}, {Content: "cooking"}}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Rank(context.Background(), "machine learning", documents, 0); err != nil {
+			t.Fatalf("Rank failed: %v", err)
+		}
+	}
+	if inner.rankCalls != 2 || r.CacheLen() != 0 {
+		t.Errorf("Expected documents without IDs to bypass the cache, got %d calls and %d entries", inner.rankCalls, r.CacheLen())
+	}
+}
+
+func TestQueryCacheKey(t *testing.T) {
+	documents := []Document{{ID: "a"}, {ID: "b"}}
+	reordered := []Document{{ID: "b"}, {ID: "a"}}
+	if QueryCacheKey("q", documents, 1) != QueryCacheKey("q", reordered, 1) {
+		t.Error("Expected key to ignore document order")
+	}
+	if QueryCacheKey("q", documents, 1) == QueryCacheKey("q", documents, 2) {
+		t.Error("Expected key to depend on topN")
+	}
+	if QueryCacheKey("q a", []Document{{ID: "b"}}, 1) == QueryCacheKey("q", []Document{{ID: "a b"}}, 1) {
+		t.Error("Expected key fields not to run together")
+	}
+}
+
+func TestQueryCache_Options(t *testing.T) {
+	cache := newQueryCacheFromOptions(map[string]interface{}{"query_cache_size": 5, "query_cache_ttl_seconds": 2})
+	if cache.capacity != 5 || cache.ttl != 2*time.Second {
+		t.Errorf("Expected capacity 5 and ttl 2s, got %d and %v", cache.capacity, cache.ttl)
+	}
+}
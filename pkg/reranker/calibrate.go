@@ -0,0 +1,163 @@
+package reranker
+
+import (
+	"fmt"
+	"math"
+)
+
+// CalibrationExample is a raw model score labelled with its ground-truth relevance
+type CalibrationExample struct {
+	Score    float64
+	Relevant bool
+}
+
+// PlattCalibrator maps raw scores to probabilities with a fitted sigmoid:
+// P = 1 / (1 + exp(-(A*score + B)))
+type PlattCalibrator struct {
+	A float64
+	B float64
+}
+
+// Fitting parameters for PlattCalibrator.Fit
+const (
+	plattMaxIterations = 100
+	plattMinStep       = 1e-10
+	plattSigma         = 1e-12
+	plattEpsilon       = 1e-5
+)
+
+// NewPlattCalibrator creates a calibrator with pre-fitted parameters
+func NewPlattCalibrator(a, b float64) *PlattCalibrator {
+	return &PlattCalibrator{A: a, B: b}
+}
+
+// Fit estimates A and B by maximum likelihood on the labelled examples, using
+// Platt's smoothed targets and a Newton method with backtracking line search
+// (Lin, Lin and Weng, 2007).
+func (c *PlattCalibrator) Fit(examples []CalibrationExample) error {
+	if len(examples) == 0 {
+		return fmt.Errorf("%w: calibration requires at least one example", ErrInvalidInput)
+	}
+
+	var positives, negatives float64
+	for _, example := range examples {
+		if math.IsNaN(example.Score) || math.IsInf(example.Score, 0) {
+			return fmt.Errorf("%w: calibration score must be finite", ErrInvalidInput)
+		}
+		if example.Relevant {
+			positives++
+		} else {
+			negatives++
+		}
+	}
+
+	// Smoothed targets avoid overfitting when the classes are separable
+	highTarget := (positives + 1) / (positives + 2)
+	lowTarget := 1 / (negatives + 2)
+	targets := make([]float64, len(examples))
+	for i, example := range examples {
+		if example.Relevant {
+			targets[i] = highTarget
+		} else {
+			targets[i] = lowTarget
+		}
+	}
+
+	// The optimisation follows Platt's convention P = 1/(1+exp(a*f+b)) and
+	// negates the parameters at the end
+	a, b := 0.0, math.Log((negatives+1)/(positives+1))
+	objective := func(a, b float64) float64 {
+		var value float64
+		for i, example := range examples {
+			fApB := example.Score*a + b
+			if fApB >= 0 {
+				value += targets[i]*fApB + math.Log1p(math.Exp(-fApB))
+			} else {
+				value += (targets[i]-1)*fApB + math.Log1p(math.Exp(fApB))
+			}
+		}
+		return value
+	}
+	value := objective(a, b)
+
+	for iteration := 0; iteration < plattMaxIterations; iteration++ {
+		// Gradient and Hessian of the negative log-likelihood
+		h11, h22, h21 := plattSigma, plattSigma, 0.0
+		g1, g2 := 0.0, 0.0
+		for i, example := range examples {
+			fApB := example.Score*a + b
+			var p, q float64
+			if fApB >= 0 {
+				p = math.Exp(-fApB) / (1 + math.Exp(-fApB))
+				q = 1 / (1 + math.Exp(-fApB))
+			} else {
+				p = 1 / (1 + math.Exp(fApB))
+				q = math.Exp(fApB) / (1 + math.Exp(fApB))
+			}
+			d2 := p * q
+			h11 += example.Score * example.Score * d2
+			h22 += d2
+			h21 += example.Score * d2
+			d1 := targets[i] - p
+			g1 += example.Score * d1
+			g2 += d1
+		}
+		if math.Abs(g1) < plattEpsilon && math.Abs(g2) < plattEpsilon {
+			break
+		}
+
+		// Newton direction
+		det := h11*h22 - h21*h21
+		dA := -(h22*g1 - h21*g2) / det
+		dB := -(-h21*g1 + h11*g2) / det
+		gd := g1*dA + g2*dB
+
+		step := 1.0
+		for step >= plattMinStep {
+			newA, newB := a+step*dA, b+step*dB
+			newValue := objective(newA, newB)
+			if newValue < value+0.0001*step*gd {
+				a, b, value = newA, newB, newValue
+				break
+			}
+			step /= 2
+		}
+		if step < plattMinStep {
+			break
+		}
+	}
+
+	c.A, c.B = -a, -b
+	return nil
+}
+
+// Transform maps a raw score to a calibrated probability in (0, 1)
+func (c *PlattCalibrator) Transform(score float64) float64 {
+	return 1.0 / (1.0 + math.Exp(-(c.A*score + c.B)))
+}
+
+// calibratorFromOptions returns the calibrator configured by the
+// "calibration_a" (default 1) and "calibration_b" (default 0) options, or nil
+// when neither is set
+func calibratorFromOptions(opts map[string]interface{}) *PlattCalibrator {
+	_, hasA := opts["calibration_a"]
+	_, hasB := opts["calibration_b"]
+	if !hasA && !hasB {
+		return nil
+	}
+	return NewPlattCalibrator(OptionFloat(opts, "calibration_a", 1), OptionFloat(opts, "calibration_b", 0))
+}
+
+// applyCalibration returns scores transformed by the configured calibrator,
+// or scores unchanged when none is configured
+func applyCalibration(scores []float64, opts map[string]interface{}) []float64 {
+	calibrator := calibratorFromOptions(opts)
+	if calibrator == nil {
+		return scores
+	}
+	calibrated := make([]float64, len(scores))
+	for i, score := range scores {
+		calibrated[i] = calibrator.Transform(score)
+	}
+	return calibrated
+}
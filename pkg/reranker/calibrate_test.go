@@ -0,0 +1,136 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// logisticExamples builds count examples per score, labelled relevant in
+// proportion to the sigmoid of a*score + b
+func logisticExamples(a, b float64, scores []float64, count int) []CalibrationExample {
+	var examples []CalibrationExample
+	for _, score := range scores {
+		relevant := int(math.Round(float64(count) / (1 + math.Exp(-(a*score + b)))))
+		for i := 0; i < count; i++ {
+			examples = append(examples, CalibrationExample{Score: score, Relevant: i < relevant})
+		}
+	}
+	return examples
+}
+
+func TestPlattCalibrator_FitRecoversParameters(t *testing.T) {
+	examples := logisticExamples(2, -1, []float64{-2, -1, -0.5, 0, 0.5, 1, 2}, 1000)
+
+	var calibrator PlattCalibrator
+	if err := calibrator.Fit(examples); err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if math.Abs(calibrator.B-(-1)) > 0.05 {
+		t.Errorf("Expected b close to -1, got %f", calibrator.B)
+	}
+	if math.Abs(calibrator.A-2) > 0.05 {
+		t.Errorf("Expected a close to 2, got %f", calibrator.A)
+	}
+}
+
+func TestPlattCalibrator_FitSymmetric(t *testing.T) {
+	var examples []CalibrationExample
+	for i := 0; i < 50; i++ {
+		examples = append(examples,
+			CalibrationExample{Score: -1, Relevant: false},
+			CalibrationExample{Score: 1, Relevant: true},
+		)
+	}
+
+	var calibrator PlattCalibrator
+	if err := calibrator.Fit(examples); err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if math.Abs(calibrator.B) > 1e-6 {
+		t.Errorf("Expected b close to 0 for a symmetric dataset, got %f", calibrator.B)
+	}
+	if calibrator.A <= 0 {
+		t.Errorf("Expected positive slope, got %f", calibrator.A)
+	}
+	if p := calibrator.Transform(1); p <= 0.9 || p >= 1 {
+		t.Errorf("Expected high probability for a relevant score, got %f", p)
+	}
+	if p := calibrator.Transform(0); math.Abs(p-0.5) > 1e-6 {
+		t.Errorf("Expected 0.5 at the decision boundary, got %f", p)
+	}
+}
+
+func TestPlattCalibrator_FitInvalid(t *testing.T) {
+	var calibrator PlattCalibrator
+	if err := calibrator.Fit(nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for no examples, got %v", err)
+	}
+	if err := calibrator.Fit([]CalibrationExample{{Score: math.NaN()}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for NaN score, got %v", err)
+	}
+}
+
+func TestCrossEncoderReranker_Calibration(t *testing.T) {
+	documents := []Document{
+		{ID: "1", Content: "machine learning models"},
+		{ID: "2", Content: "cooking recipes"},
+	}
+
+	raw, err := NewCrossEncoderReranker(Config{}).ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+
+	calibrated := NewCrossEncoderReranker(Config{Options: map[string]interface{}{
+		"calibration_a": 0.5,
+		"calibration_b": -1.0,
+	}})
+	scores, err := calibrated.ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+
+	calibrator := NewPlattCalibrator(0.5, -1)
+	for i := range scores {
+		if want := calibrator.Transform(raw[i]); math.Abs(scores[i]-want) > 1e-12 {
+			t.Errorf("Document %d: expected calibrated score %f, got %f", i, want, scores[i])
+		}
+	}
+}
+
+func TestNewReranker_Calibration(t *testing.T) {
+	documents := []Document{
+		{ID: "1", Content: "machine learning models"},
+		{ID: "2", Content: "cooking recipes"},
+	}
+
+	raw, err := NewReranker(Config{Model: "bm25"})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	rawScores, err := raw.ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+
+	calibrated, err := NewReranker(Config{Model: "bm25", Options: map[string]interface{}{
+		"calibration_a": 2.0,
+		"calibration_b": -1.0,
+	}})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	scores, err := calibrated.ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+
+	calibrator := NewPlattCalibrator(2, -1)
+	for i := range scores {
+		if want := calibrator.Transform(rawScores[i]); math.Abs(scores[i]-want) > 1e-12 {
+			t.Errorf("Document %d: expected calibrated score %f, got %f", i, want, scores[i])
+		}
+	}
+}
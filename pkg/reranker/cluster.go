@@ -0,0 +1,314 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MetaClusterID is the Meta key holding a document's cluster in ClusteredRank results
+const MetaClusterID = "cluster_id"
+
+// DefaultClusterCount is the number of clusters used when cluster_count is not set
+const DefaultClusterCount = 3
+
+// maxKMeansIterations bounds the assignment/update rounds of ClusterDocuments
+const maxKMeansIterations = 50
+
+// ClusterDocuments groups documents by topic with k-means over their TF-IDF
+// vectors, using cosine similarity. Initial centroids are chosen
+// deterministically: the first document, then repeatedly the document least
+// similar to every centroid so far, so the same input always gives the same
+// clusters. Clusters are returned in order of their first document and keep
+// input order within; empty clusters are dropped. A numClusters of 1 or less
+// returns a single cluster.
+func ClusterDocuments(docs []Document, numClusters int) [][]Document {
+	assignments, count := clusterAssignments(docs, numClusters)
+	clusters := make([][]Document, count)
+	for i, cluster := range assignments {
+		clusters[cluster] = append(clusters[cluster], docs[i])
+	}
+	return clusters
+}
+
+// clusterAssignments returns the cluster of each document, numbered by first
+// appearance, and the number of non-empty clusters
+func clusterAssignments(docs []Document, numClusters int) ([]int, int) {
+	if len(docs) == 0 {
+		return nil, 0
+	}
+	if numClusters > len(docs) {
+		numClusters = len(docs)
+	}
+	if numClusters <= 1 {
+		return make([]int, len(docs)), 1
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.Content
+	}
+	vectors := tfidfVectors(texts)
+	for _, vector := range vectors {
+		normalizeSparse(vector)
+	}
+
+	centroids := initialCentroids(vectors, numClusters)
+	assignments := make([]int, len(docs))
+	for iteration := 0; iteration < maxKMeansIterations; iteration++ {
+		changed := false
+		for i, vector := range vectors {
+			if best := nearestCentroid(vector, centroids); best != assignments[i] {
+				assignments[i] = best
+				changed = true
+			}
+		}
+		if iteration > 0 && !changed {
+			break
+		}
+		centroids = updateCentroids(vectors, assignments, centroids)
+	}
+
+	// Renumber clusters by first appearance, dropping empty ones
+	numbering := make(map[int]int, numClusters)
+	for i, cluster := range assignments {
+		if _, seen := numbering[cluster]; !seen {
+			numbering[cluster] = len(numbering)
+		}
+		assignments[i] = numbering[cluster]
+	}
+	return assignments, len(numbering)
+}
+
+// normalizeSparse scales a sparse vector to unit length in place
+func normalizeSparse(vector map[string]float64) {
+	var norm float64
+	for _, weight := range vector {
+		norm += weight * weight
+	}
+	if norm == 0 {
+		return
+	}
+	norm = math.Sqrt(norm)
+	for term := range vector {
+		vector[term] /= norm
+	}
+}
+
+// initialCentroids picks the first vector, then repeatedly the vector whose
+// best similarity to the chosen centroids is lowest (ties to the earliest)
+func initialCentroids(vectors []map[string]float64, k int) []map[string]float64 {
+	centroids := []map[string]float64{vectors[0]}
+	chosen := map[int]bool{0: true}
+	for len(centroids) < k {
+		farthest, lowest := -1, math.Inf(1)
+		for i, vector := range vectors {
+			if chosen[i] {
+				continue
+			}
+			best := math.Inf(-1)
+			for _, centroid := range centroids {
+				best = math.Max(best, sparseCosine(vector, centroid))
+			}
+			if best < lowest {
+				farthest, lowest = i, best
+			}
+		}
+		chosen[farthest] = true
+		centroids = append(centroids, vectors[farthest])
+	}
+	return centroids
+}
+
+// nearestCentroid returns the index of the most similar centroid, the lowest on ties
+func nearestCentroid(vector map[string]float64, centroids []map[string]float64) int {
+	best, bestSimilarity := 0, math.Inf(-1)
+	for i, centroid := range centroids {
+		if similarity := sparseCosine(vector, centroid); similarity > bestSimilarity {
+			best, bestSimilarity = i, similarity
+		}
+	}
+	return best
+}
+
+// updateCentroids averages the vectors of each cluster; a cluster left empty
+// keeps its previous centroid
+func updateCentroids(vectors []map[string]float64, assignments []int, previous []map[string]float64) []map[string]float64 {
+	sums := make([]map[string]float64, len(previous))
+	for i, vector := range vectors {
+		cluster := assignments[i]
+		if sums[cluster] == nil {
+			sums[cluster] = make(map[string]float64)
+		}
+		for term, weight := range vector {
+			sums[cluster][term] += weight
+		}
+	}
+	for cluster := range sums {
+		if sums[cluster] == nil {
+			sums[cluster] = previous[cluster]
+			continue
+		}
+		normalizeSparse(sums[cluster])
+	}
+	return sums
+}
+
+// ClusteredReranker clusters documents by topic (see ClusterDocuments), ranks
+// them within each cluster with the wrapped reranker and interleaves the
+// clusters round-robin, best cluster first, so the top results cover several
+// topics. Results record their cluster in Meta["cluster_id"].
+//
+// Recognized options:
+//   - "cluster_count": number of clusters, at least 1 (default 3)
+type ClusteredReranker struct {
+	config   Config
+	inner    Reranker
+	clusters int
+}
+
+// NewClusteredReranker wraps inner; the cluster count is read from config options
+func NewClusteredReranker(inner Reranker, config Config) (*ClusteredReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: clustered reranking requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &ClusteredReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ClusteredRank scores documents once with the wrapped reranker, sorts each
+// cluster by score and returns up to topN results taking one document from
+// each cluster in turn. Clusters are visited in order of their best score.
+// Index is the input position and Rank the interleaved position.
+func (r *ClusteredReranker) ClusteredRank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.inner.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) != len(documents) {
+		return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(documents), len(scores))
+	}
+
+	assignments, count := clusterAssignments(documents, r.clusters)
+	threshold := r.config.scoreThreshold(scores)
+	clusters := make([][]RerankResult, count)
+	for i, doc := range documents {
+		if scores[i] < threshold {
+			continue
+		}
+		doc.Meta = make(map[string]interface{}, len(documents[i].Meta)+1)
+		for key, value := range documents[i].Meta {
+			doc.Meta[key] = value
+		}
+		doc.Meta[MetaClusterID] = assignments[i]
+		clusters[assignments[i]] = append(clusters[assignments[i]], RerankResult{Document: doc, Score: scores[i], Index: i})
+	}
+
+	var ranked [][]RerankResult
+	for _, cluster := range clusters {
+		if len(cluster) > 0 {
+			sortResults(cluster, r.config.tieBreak())
+			ranked = append(ranked, cluster)
+		}
+	}
+	// Best cluster first, keeping cluster order on equal top scores
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i][0].Score > ranked[j][0].Score
+	})
+
+	var results []RerankResult
+	for round := 0; len(results) < len(documents); round++ {
+		added := false
+		for _, cluster := range ranked {
+			if round < len(cluster) {
+				results = append(results, cluster[round])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+	return assignRanks(results, r.config.NormalizeScores), nil
+}
+
+// Rerank returns up to MaxDocs documents in interleaved cluster order
+func (r *ClusteredReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	results, err := r.ClusteredRank(ctx, query, documents, r.config.MaxDocs)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := make([]Document, len(results))
+	for i, result := range results {
+		reranked[i] = result.Document
+		reranked[i].Score = result.Score
+	}
+	return reranked, nil
+}
+
+// ComputeScore returns the wrapped reranker's scores; clustering only affects order
+func (r *ClusteredReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	return r.inner.ComputeScore(ctx, query, documents)
+}
+
+// Rank is ClusteredRank
+func (r *ClusteredReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	return r.ClusteredRank(ctx, query, documents, topN)
+}
+
+// GetModelName returns the wrapped model name
+func (r *ClusteredReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *ClusteredReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *ClusteredReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the cluster count; the inner reranker is left unchanged
+func (r *ClusteredReranker) Configure(config Config) error {
+	clusters := OptionInt(config.Options, "cluster_count", DefaultClusterCount)
+	if clusters < 1 {
+		return fmt.Errorf("%w: cluster_count must be at least 1, got %d", ErrInvalidInput, clusters)
+	}
+
+	r.config = config
+	r.clusters = clusters
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *ClusteredReranker) Close() error {
+	return closeReranker(r.inner)
+}
@@ -0,0 +1,104 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// topicDocuments returns three cooking and three astronomy documents, interleaved
+func topicDocuments() []Document {
+	return []Document{
+		{ID: "cook1", Content: "simmer the tomato sauce with garlic and basil"},
+		{ID: "space1", Content: "the telescope observed a distant galaxy and its stars"},
+		{ID: "cook2", Content: "bake the bread dough with garlic butter"},
+		{ID: "space2", Content: "astronomers measured stars orbiting the galaxy core"},
+		{ID: "cook3", Content: "season the sauce and bake the garlic bread"},
+		{ID: "space3", Content: "a new telescope maps stars across the galaxy"},
+	}
+}
+
+func clusterIDs(cluster []Document) []string {
+	ids := make([]string, len(cluster))
+	for i, doc := range cluster {
+		ids[i] = doc.ID
+	}
+	return ids
+}
+
+func TestClusterDocuments_SeparatesTopics(t *testing.T) {
+	clusters := ClusterDocuments(topicDocuments(), 2)
+	if len(clusters) != 2 {
+		t.Fatalf("Expected 2 clusters, got %d", len(clusters))
+	}
+
+	want := [][]string{{"cook1", "cook2", "cook3"}, {"space1", "space2", "space3"}}
+	for i, cluster := range clusters {
+		if got := clusterIDs(cluster); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Cluster %d: expected %v, got %v", i, want[i], got)
+		}
+	}
+
+	if again := ClusterDocuments(topicDocuments(), 2); !reflect.DeepEqual(again, clusters) {
+		t.Error("Expected the same clusters for the same input")
+	}
+}
+
+func TestClusterDocuments_Bounds(t *testing.T) {
+	docs := topicDocuments()
+	if clusters := ClusterDocuments(nil, 3); len(clusters) != 0 {
+		t.Errorf("Expected no clusters for no documents, got %d", len(clusters))
+	}
+	if clusters := ClusterDocuments(docs, 0); len(clusters) != 1 || len(clusters[0]) != len(docs) {
+		t.Errorf("Expected a single cluster for numClusters 0, got %d", len(clusters))
+	}
+	if clusters := ClusterDocuments(docs[:2], 5); len(clusters) != 2 {
+		t.Errorf("Expected one cluster per document when numClusters exceeds them, got %d", len(clusters))
+	}
+}
+
+func TestClusteredReranker_InterleavesClusters(t *testing.T) {
+	// The inner order puts every cooking document ahead of astronomy
+	inner := &orderedReranker{name: "ordered", order: []string{"cook1", "cook2", "cook3", "space2", "space1", "space3"}}
+	r, err := NewClusteredReranker(inner, Config{Options: map[string]interface{}{"cluster_count": 2}})
+	if err != nil {
+		t.Fatalf("NewClusteredReranker failed: %v", err)
+	}
+
+	docs := topicDocuments()
+	results, err := r.ClusteredRank(context.Background(), "query", docs, 4)
+	if err != nil {
+		t.Fatalf("ClusteredRank failed: %v", err)
+	}
+
+	want := []string{"cook1", "space2", "cook2", "space1"}
+	if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i, result := range results {
+		wantCluster := 0
+		if result.Document.ID[:5] == "space" {
+			wantCluster = 1
+		}
+		if got := result.Document.Meta[MetaClusterID]; got != wantCluster {
+			t.Errorf("Expected %s in cluster %d, got %v", result.Document.ID, wantCluster, got)
+		}
+		if result.Rank != i+1 || docs[result.Index].ID != result.Document.ID {
+			t.Errorf("Expected rank %d and the input index, got %+v", i+1, result)
+		}
+	}
+	if docs[0].Meta != nil {
+		t.Error("Expected input documents to be left unchanged")
+	}
+}
+
+func TestClusteredReranker_Invalid(t *testing.T) {
+	if _, err := NewClusteredReranker(nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without an inner reranker, got %v", err)
+	}
+	inner := &orderedReranker{name: "ordered"}
+	if _, err := NewClusteredReranker(inner, Config{Options: map[string]interface{}{"cluster_count": 0}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for cluster_count 0, got %v", err)
+	}
+}
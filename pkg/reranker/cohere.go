@@ -0,0 +1,95 @@
+package reranker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CohereModelPrefix marks model names served by the Cohere Rerank API
+const CohereModelPrefix = "cohere/"
+
+// DefaultCohereEndpoint is the Cohere Rerank API endpoint
+const DefaultCohereEndpoint = "https://api.cohere.ai/v1/rerank"
+
+// CohereReranker implements reranking using the hosted Cohere Rerank API.
+//
+// Recognized options:
+//   - "api_key": Cohere API key (required)
+//   - "endpoint": override the API endpoint (default DefaultCohereEndpoint)
+//   - "return_documents": ask the API to echo document text back (default false)
+//   - "timeout_seconds", "max_retries": same as HTTPReranker
+type CohereReranker struct {
+	hostedReranker
+}
+
+// CohereRerankRequest represents the request body for the Cohere Rerank API
+type CohereRerankRequest struct {
+	Model           string   `json:"model"`
+	Query           string   `json:"query"`
+	Documents       []string `json:"documents"`
+	TopN            int      `json:"top_n,omitempty"`
+	ReturnDocuments bool     `json:"return_documents"`
+}
+
+// CohereRerankResponse represents the response body from the Cohere Rerank API
+type CohereRerankResponse struct {
+	ID      string `json:"id"`
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+		Document       *struct {
+			Text string `json:"text"`
+		} `json:"document,omitempty"`
+	} `json:"results"`
+}
+
+// NewCohereReranker creates a new reranker backed by the Cohere Rerank API
+func NewCohereReranker(config Config) (*CohereReranker, error) {
+	hosted, err := newHostedReranker(config, "Cohere", CohereModelPrefix, DefaultCohereEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	model := strings.TrimPrefix(config.Model, CohereModelPrefix)
+	returnDocuments := OptionBool(config.Options, "return_documents", false)
+	hosted.buildRequest = func(query string, documents []string, topN int) interface{} {
+		return CohereRerankRequest{
+			Model:           model,
+			Query:           query,
+			Documents:       documents,
+			TopN:            topN,
+			ReturnDocuments: returnDocuments,
+		}
+	}
+	hosted.decodeResponse = decodeCohereResponse
+
+	return &CohereReranker{hostedReranker: hosted}, nil
+}
+
+// decodeCohereResponse parses a Cohere Rerank API response body
+func decodeCohereResponse(payload []byte) ([]hostedResult, error) {
+	var response CohereRerankResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse Cohere response: %v", ErrInference, err)
+	}
+
+	results := make([]hostedResult, len(response.Results))
+	for i, result := range response.Results {
+		results[i] = hostedResult{Index: result.Index, Score: result.RelevanceScore}
+		if result.Document != nil {
+			results[i].Text = result.Document.Text
+		}
+	}
+	return results, nil
+}
+
+// Configure updates the reranker configuration
+func (r *CohereReranker) Configure(config Config) error {
+	updated, err := NewCohereReranker(config)
+	if err != nil {
+		return err
+	}
+	*r = *updated
+	return nil
+}
@@ -0,0 +1,144 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newCohereTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if got := req.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Expected bearer API key, got %q", got)
+		}
+
+		var body CohereRerankRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if body.Model != "rerank-english-v3.0" {
+			t.Errorf("Expected model prefix to be stripped, got %q", body.Model)
+		}
+
+		// Score documents in reverse order so the last one ranks first
+		type result struct {
+			Index          int                `json:"index"`
+			RelevanceScore float64            `json:"relevance_score"`
+			Document       *map[string]string `json:"document,omitempty"`
+		}
+		var results []result
+		for i := len(body.Documents) - 1; i >= 0; i-- {
+			res := result{Index: i, RelevanceScore: float64(i+1) / float64(len(body.Documents))}
+			if body.ReturnDocuments {
+				res.Document = &map[string]string{"text": body.Documents[i]}
+			}
+			results = append(results, res)
+		}
+		if body.TopN > 0 && len(results) > body.TopN {
+			results = results[:body.TopN]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "test", "results": results})
+	}))
+}
+
+func TestCohereReranker_Rank(t *testing.T) {
+	server := newCohereTestServer(t)
+	defer server.Close()
+
+	r, err := NewReranker(Config{
+		Model: "cohere/rerank-english-v3.0",
+		Options: map[string]interface{}{
+			"api_key":          "test-key",
+			"endpoint":         server.URL,
+			"return_documents": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*CohereReranker); !ok {
+		t.Fatalf("Expected *CohereReranker, got %T", r)
+	}
+
+	documents := []Document{
+		{ID: "1", Content: "first"},
+		{ID: "2", Content: "second"},
+		{ID: "3", Content: "third"},
+	}
+
+	results, err := r.Rank(context.Background(), "query", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Document.ID != "3" || results[0].Index != 2 {
+		t.Errorf("Expected document 3 first, got %+v", results[0])
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] >= scores[2] {
+		t.Errorf("Expected scores in document order, got %v", scores)
+	}
+}
+
+func TestCohereReranker_ContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	r, err := NewCohereReranker(Config{
+		Model:   "cohere/rerank-english-v3.0",
+		Options: map[string]interface{}{"api_key": "test-key", "endpoint": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewCohereReranker failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = r.ComputeScore(ctx, "query", []Document{{Content: "a"}})
+	if !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference on cancellation, got %v", err)
+	}
+}
+
+func TestCohereReranker_MissingAPIKey(t *testing.T) {
+	_, err := NewCohereReranker(Config{Model: "cohere/rerank-english-v3.0"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestCohereReranker_DuplicateResultIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"results":[{"index":1,"relevance_score":0.9},{"index":1,"relevance_score":0.1}]}`))
+	}))
+	defer server.Close()
+
+	r, err := NewCohereReranker(Config{
+		Model:   "cohere/rerank-english-v3.0",
+		Options: map[string]interface{}{"api_key": "test-key", "endpoint": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewCohereReranker failed: %v", err)
+	}
+
+	_, err = r.ComputeScore(context.Background(), "query", []Document{{Content: "a"}, {Content: "b"}})
+	if !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for a repeated index, got %v", err)
+	}
+}
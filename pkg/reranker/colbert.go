@@ -0,0 +1,190 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ColBERTReranker scores documents with ColBERT late interaction: query and
+// document are embedded per token by llama-embedding (--pooling none) and the
+// score is MaxSim, the sum over query tokens of the highest cosine similarity
+// to any document token.
+type ColBERTReranker struct {
+	gguf *GGUFLocalReranker
+}
+
+// NewColBERTReranker creates a ColBERT reranker; model resolution and options
+// are the same as for NewGGUFLocalReranker
+func NewColBERTReranker(config Config) (*ColBERTReranker, error) {
+	gguf, err := NewGGUFLocalReranker(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ColBERTReranker{gguf: gguf}, nil
+}
+
+// MaxSim returns the ColBERT late interaction score of a query against a
+// document, each given as one embedding per token. It is 0 when either side
+// has no tokens.
+func MaxSim(queryTokens, docTokens [][]float64) float64 {
+	if len(docTokens) == 0 {
+		return 0.0
+	}
+
+	var score float64
+	for _, q := range queryTokens {
+		best := cosineSimilarity(q, docTokens[0])
+		for _, d := range docTokens[1:] {
+			if sim := cosineSimilarity(q, d); sim > best {
+				best = sim
+			}
+		}
+		score += best
+	}
+	return score
+}
+
+// tokenEmbeddings returns one embedding per token of text; with --pooling none
+// llama-embedding emits a data entry for every token
+func (r *ColBERTReranker) tokenEmbeddings(ctx context.Context, text string) ([][]float64, error) {
+	response, err := r.gguf.runEmbedding(ctx, text, "--pooling", "none")
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([][]float64, 0, len(response.Data))
+	for _, entry := range response.Data {
+		if len(entry.Embedding) > 0 {
+			tokens = append(tokens, entry.Embedding)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no token embeddings returned")
+	}
+	return tokens, nil
+}
+
+// Rerank reorders documents by MaxSim score
+func (r *ColBERTReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	results, err := r.Rank(ctx, query, documents, r.gguf.config.MaxDocs)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := make([]Document, len(results))
+	for i, result := range results {
+		reranked[i] = result.Document
+		reranked[i].Score = result.Score
+	}
+	return reranked, nil
+}
+
+// ComputeScore returns the MaxSim score of each document in document order.
+// The query is embedded once; documents are embedded concurrently.
+func (r *ColBERTReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.gguf.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *ColBERTReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	queryTokens, err := r.tokenEmbeddings(ctx, query)
+	if err != nil {
+		return nil, inferenceError(fmt.Errorf("failed to get query token embeddings: %w", err))
+	}
+
+	scores := make([]float64, len(documents))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(r.gguf.workerCount())
+	for i, doc := range documents {
+		i, content := i, doc.Content
+		group.Go(func() error {
+			cacheKey := fmt.Sprintf("colbert|||%s|||%s", query, content)
+			if cached, exists := r.gguf.scoreCache.Get(cacheKey); exists {
+				scores[i] = cached
+				return nil
+			}
+
+			docTokens, err := r.tokenEmbeddings(groupCtx, content)
+			if err != nil {
+				return fmt.Errorf("failed to get document token embeddings: %w", err)
+			}
+			scores[i] = MaxSim(queryTokens, docTokens)
+			r.gguf.scoreCache.Set(cacheKey, scores[i])
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, inferenceError(err)
+	}
+
+	return r.gguf.config.transformScores(scores)
+}
+
+// Rank returns top-N documents by MaxSim score
+func (r *ColBERTReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.gguf.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := r.gguf.config.scoreThreshold(scores)
+	var results []RerankResult
+	for i, doc := range documents {
+		if scores[i] >= threshold {
+			results = append(results, RerankResult{Document: doc, Score: scores[i], Index: i})
+		}
+	}
+	sortResults(results, r.gguf.config.tieBreak())
+
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+	return assignRanks(results, r.gguf.config.NormalizeScores), nil
+}
+
+// Configure updates the reranker configuration
+func (r *ColBERTReranker) Configure(config Config) error {
+	return r.gguf.Configure(config)
+}
+
+// GetModelName returns the model name
+func (r *ColBERTReranker) GetModelName() string {
+	return r.gguf.GetModelName()
+}
+
+// Version returns the underlying GGUF model's version
+func (r *ColBERTReranker) Version() string {
+	return r.gguf.Version()
+}
+
+// HealthCheck checks the underlying GGUF model
+func (r *ColBERTReranker) HealthCheck(ctx context.Context) error {
+	return r.gguf.HealthCheck(ctx)
+}
+
+// Close cleans up resources (clears cache)
+func (r *ColBERTReranker) Close() {
+	r.gguf.Close()
+}
@@ -0,0 +1,60 @@
+package reranker
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestMaxSim(t *testing.T) {
+	query := [][]float64{{1, 0}, {0, 1}}
+	doc := [][]float64{{0.6, 0.8}, {1, 0}, {0, -1}}
+
+	// {1,0}: max(0.6, 1, 0) = 1; {0,1}: max(0.8, 0, -1) = 0.8
+	if got := MaxSim(query, doc); math.Abs(got-1.8) > 1e-9 {
+		t.Errorf("Expected MaxSim 1.8, got %v", got)
+	}
+
+	// Only the best document token counts, and the query tokens are summed
+	if got := MaxSim([][]float64{{3, 4}, {3, 4}}, [][]float64{{0, 1}}); math.Abs(got-1.6) > 1e-9 {
+		t.Errorf("Expected MaxSim 1.6, got %v", got)
+	}
+
+	if got := MaxSim(query, nil); got != 0 {
+		t.Errorf("Expected MaxSim 0 without document tokens, got %v", got)
+	}
+}
+
+func TestColBERTReranker_ComputeScore(t *testing.T) {
+	// The stub only answers per-token requests, with one entry per token
+	gguf := newFakeGGUFReranker(t, 2)
+	script := `#!/bin/sh
+case "$*" in
+*"--pooling none"*) ;;
+*) exit 1 ;;
+esac
+echo '{"object":"list","data":[{"object":"embedding","index":0,"embedding":[1,0]},{"object":"embedding","index":1,"embedding":[0.6,0.8]}]}'
+`
+	writeStubBinary(t, gguf.inferenceBinary, script)
+	reranker := &ColBERTReranker{gguf: gguf}
+
+	documents := []Document{{ID: "a", Content: "first"}, {ID: "b", Content: "second"}}
+	scores, err := reranker.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	// Every query token matches itself among the document tokens
+	for i, score := range scores {
+		if math.Abs(score-2.0) > 1e-9 {
+			t.Errorf("Expected score 2.0 for document %d, got %f", i, score)
+		}
+	}
+
+	results, err := reranker.Rank(context.Background(), "query", documents, 1)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Rank != 1 {
+		t.Errorf("Expected a single rank-1 result, got %+v", results)
+	}
+}
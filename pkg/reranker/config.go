@@ -0,0 +1,280 @@
+package reranker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxDocs is the document limit applied when MaxDocs is not set
+const DefaultMaxDocs = 100
+
+// Option mutates a Config during construction with NewConfig
+type Option func(*Config)
+
+// NewConfig builds a Config for model with explicit defaults, then applies opts in order
+func NewConfig(model string, opts ...Option) Config {
+	config := Config{
+		Model:   model,
+		MaxDocs: DefaultMaxDocs,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// WithMaxDocs sets the maximum number of documents returned by Rerank
+func WithMaxDocs(maxDocs int) Option {
+	return func(c *Config) {
+		c.MaxDocs = maxDocs
+	}
+}
+
+// WithThreshold sets the minimum score a document needs to be returned
+func WithThreshold(threshold float64) Option {
+	return func(c *Config) {
+		c.Threshold = threshold
+	}
+}
+
+// WithThresholdPercentile keeps documents scoring at or above the given
+// percentile (in [0, 1]) of each batch's scores
+func WithThresholdPercentile(percentile float64) Option {
+	return func(c *Config) {
+		c.Threshold = percentile
+		c.ThresholdMode = ThresholdPercentile
+	}
+}
+
+// WithDevice sets the inference device ("cpu", "cuda", "auto")
+func WithDevice(device string) Option {
+	return func(c *Config) {
+		c.Device = device
+	}
+}
+
+// WithOptions merges backend-specific options into Config.Options.
+// The caller's map is copied so later changes to it do not leak into the config.
+func WithOptions(options map[string]interface{}) Option {
+	return func(c *Config) {
+		if len(options) == 0 {
+			return
+		}
+		merged := make(map[string]interface{}, len(c.Options)+len(options))
+		for key, value := range c.Options {
+			merged[key] = value
+		}
+		for key, value := range options {
+			merged[key] = value
+		}
+		c.Options = merged
+	}
+}
+
+// WithNormalization sets how ComputeScore rescales raw model scores
+func WithNormalization(mode ScoreNormalization) Option {
+	return func(c *Config) {
+		c.NormalizeScores = mode
+	}
+}
+
+// WithTimeout sets the per-request timeout used by remote backends
+// (stored as Options["timeout_seconds"])
+func WithTimeout(timeout time.Duration) Option {
+	return WithOptions(map[string]interface{}{
+		"timeout_seconds": timeout.Seconds(),
+	})
+}
+
+// WithQueryExpansion appends terms to every query before scoring
+// (stored as Options["expansion_terms"]); NewReranker wraps the backend in a QueryExpander
+func WithQueryExpansion(terms []string) Option {
+	return WithOptions(map[string]interface{}{
+		"expansion_terms": append([]string(nil), terms...),
+	})
+}
+
+// WithDeduplication enables near-duplicate removal before ranking, treating
+// documents with Jaccard similarity above threshold as duplicates
+func WithDeduplication(threshold float64) Option {
+	dedup := WithOptions(map[string]interface{}{"dedup_threshold": threshold})
+	return func(c *Config) {
+		c.Deduplicate = true
+		dedup(c)
+	}
+}
+
+// WithPreFilter adds metadata predicates applied before inference
+func WithPreFilter(specs ...FilterSpec) Option {
+	return func(c *Config) {
+		c.PreFilter = append(c.PreFilter, specs...)
+	}
+}
+
+// WithPostFilter adds metadata predicates applied to ranked results
+func WithPostFilter(specs ...FilterSpec) Option {
+	return func(c *Config) {
+		c.PostFilter = append(c.PostFilter, specs...)
+	}
+}
+
+// WithStableSort keeps documents with equal scores in input order
+func WithStableSort() Option {
+	return func(c *Config) {
+		c.StableSort = true
+	}
+}
+
+// WithTiebreakerHash orders documents with equal scores by their content hash
+func WithTiebreakerHash() Option {
+	return func(c *Config) {
+		c.TiebreakerHash = true
+	}
+}
+
+// NewRerankerWithOptions is a convenience wrapper around NewReranker(NewConfig(model, opts...))
+func NewRerankerWithOptions(model string, opts ...Option) (Reranker, error) {
+	return NewReranker(NewConfig(model, opts...))
+}
+
+// Environment variables read by ConfigFromEnv
+const (
+	EnvModel         = "RERANKERS_MODEL"
+	EnvMaxDocs       = "RERANKERS_MAX_DOCS"
+	EnvThreshold     = "RERANKERS_THRESHOLD"
+	EnvDevice        = "RERANKERS_DEVICE"
+	EnvCacheSize     = "RERANKERS_CACHE_SIZE"
+	EnvOptionsPrefix = "RERANKERS_OPTIONS_"
+)
+
+// ConfigFromEnv builds a Config from RERANKERS_* environment variables.
+// RERANKERS_CACHE_SIZE is stored as Options["cache_size"], and each
+// RERANKERS_OPTIONS_<KEY> is stored as Options["<key>"] (lower-cased), parsed
+// as an int, float or bool when possible and kept as a string otherwise.
+// Unset variables leave fields at their zero values; malformed numbers are
+// logged and ignored.
+func ConfigFromEnv() Config {
+	config, err := configFromEnv(os.Environ())
+	if err != nil {
+		log.Printf("WARNING: ignoring invalid environment configuration: %v", err)
+	}
+	return config
+}
+
+// configFromEnv parses "KEY=value" entries, returning the config built from
+// the valid ones and the joined errors of the invalid ones
+func configFromEnv(environ []string) (Config, error) {
+	var config Config
+	var errs []error
+	setOption := func(key string, value interface{}) {
+		if config.Options == nil {
+			config.Options = make(map[string]interface{})
+		}
+		config.Options[key] = value
+	}
+
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(name, "RERANKERS_") {
+			continue
+		}
+
+		switch {
+		case name == EnvModel:
+			config.Model = value
+		case name == EnvDevice:
+			config.Device = value
+		case name == EnvMaxDocs:
+			maxDocs, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s must be an integer, got %q", ErrInvalidInput, name, value))
+				continue
+			}
+			config.MaxDocs = maxDocs
+		case name == EnvThreshold:
+			threshold, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s must be a number, got %q", ErrInvalidInput, name, value))
+				continue
+			}
+			config.Threshold = threshold
+		case name == EnvCacheSize:
+			cacheSize, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s must be an integer, got %q", ErrInvalidInput, name, value))
+				continue
+			}
+			setOption("cache_size", cacheSize)
+		case strings.HasPrefix(name, EnvOptionsPrefix) && len(name) > len(EnvOptionsPrefix):
+			setOption(strings.ToLower(strings.TrimPrefix(name, EnvOptionsPrefix)), parseEnvValue(value))
+		}
+	}
+	return config, errors.Join(errs...)
+}
+
+// parseEnvValue converts an environment value to an int, float64 or bool when
+// it parses as one, and returns it unchanged otherwise
+func parseEnvValue(value string) interface{} {
+	if parsed, err := strconv.Atoi(value); err == nil {
+		return parsed
+	}
+	if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+		return parsed
+	}
+	if parsed, err := strconv.ParseBool(value); err == nil {
+		return parsed
+	}
+	return value
+}
+
+// MergeConfig returns base with every non-zero field of override applied.
+// Options are merged key by key with override winning; filters in override
+// replace those of base. A zero Threshold in override cannot clear base's.
+func MergeConfig(base Config, override Config) Config {
+	merged := base
+	if override.Model != "" {
+		merged.Model = override.Model
+	}
+	if override.MaxDocs != 0 {
+		merged.MaxDocs = override.MaxDocs
+	}
+	if override.Threshold != 0 {
+		merged.Threshold = override.Threshold
+	}
+	if override.ThresholdMode != "" {
+		merged.ThresholdMode = override.ThresholdMode
+	}
+	if override.Device != "" {
+		merged.Device = override.Device
+	}
+	if override.NormalizeScores != "" {
+		merged.NormalizeScores = override.NormalizeScores
+	}
+	if override.Deduplicate {
+		merged.Deduplicate = true
+	}
+	if len(override.PreFilter) > 0 {
+		merged.PreFilter = override.PreFilter
+	}
+	if len(override.PostFilter) > 0 {
+		merged.PostFilter = override.PostFilter
+	}
+	if override.StableSort {
+		merged.StableSort = true
+	}
+	if override.TiebreakerHash {
+		merged.TiebreakerHash = true
+	}
+	if override.Language != "" {
+		merged.Language = override.Language
+	}
+	if len(override.Options) > 0 {
+		WithOptions(override.Options)(&merged)
+	}
+	return merged
+}
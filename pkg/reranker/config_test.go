@@ -0,0 +1,157 @@
+package reranker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewConfigDefaults(t *testing.T) {
+	config := NewConfig("simple")
+	want := Config{Model: "simple", MaxDocs: DefaultMaxDocs}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Expected %+v, got %+v", want, config)
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	base := NewConfig("simple")
+
+	tests := []struct {
+		name   string
+		option Option
+		want   func(Config) Config
+	}{
+		{"WithMaxDocs", WithMaxDocs(7), func(c Config) Config { c.MaxDocs = 7; return c }},
+		{"WithThreshold", WithThreshold(-2.5), func(c Config) Config { c.Threshold = -2.5; return c }},
+		{"WithThresholdPercentile", WithThresholdPercentile(0.8), func(c Config) Config {
+			c.Threshold = 0.8
+			c.ThresholdMode = ThresholdPercentile
+			return c
+		}},
+		{"WithDevice", WithDevice("cuda"), func(c Config) Config { c.Device = "cuda"; return c }},
+		{"WithNormalization", WithNormalization(NormalizationSigmoid), func(c Config) Config { c.NormalizeScores = NormalizationSigmoid; return c }},
+		{"WithStableSort", WithStableSort(), func(c Config) Config { c.StableSort = true; return c }},
+		{"WithTiebreakerHash", WithTiebreakerHash(), func(c Config) Config { c.TiebreakerHash = true; return c }},
+		{"WithOptions", WithOptions(map[string]interface{}{"threads": 4}), func(c Config) Config {
+			c.Options = map[string]interface{}{"threads": 4}
+			return c
+		}},
+		{"WithTimeout", WithTimeout(1500 * time.Millisecond), func(c Config) Config {
+			c.Options = map[string]interface{}{"timeout_seconds": 1.5}
+			return c
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewConfig("simple", tt.option)
+			if want := tt.want(base); !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestWithOptionsMerges(t *testing.T) {
+	options := map[string]interface{}{"threads": 2}
+	config := NewConfig("simple", WithOptions(options), WithTimeout(time.Second))
+
+	if config.Options["threads"] != 2 || config.Options["timeout_seconds"] != 1.0 {
+		t.Errorf("Expected merged options, got %v", config.Options)
+	}
+
+	options["threads"] = 8
+	if config.Options["threads"] != 2 {
+		t.Error("Expected config options to be isolated from the caller's map")
+	}
+}
+
+func TestNewRerankerWithOptions(t *testing.T) {
+	r, err := NewRerankerWithOptions("http/test", WithOptions(map[string]interface{}{"endpoint": "http://localhost:1"}))
+	if err != nil {
+		t.Fatalf("NewRerankerWithOptions failed: %v", err)
+	}
+	if r.GetModelName() != "http/test" {
+		t.Errorf("Expected model http/test, got %s", r.GetModelName())
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvModel, "mxbai-v2")
+	t.Setenv(EnvMaxDocs, "20")
+	t.Setenv(EnvThreshold, "-2.5")
+	t.Setenv(EnvDevice, "cuda")
+	t.Setenv(EnvCacheSize, "500")
+	t.Setenv("RERANKERS_OPTIONS_THREADS", "4")
+	t.Setenv("RERANKERS_OPTIONS_DEDUP_THRESHOLD", "0.8")
+	t.Setenv("RERANKERS_OPTIONS_WARMUP", "true")
+	t.Setenv("RERANKERS_OPTIONS_ENDPOINT", "http://localhost:8000/rerank")
+
+	want := Config{
+		Model:     "mxbai-v2",
+		MaxDocs:   20,
+		Threshold: -2.5,
+		Device:    "cuda",
+		Options: map[string]interface{}{
+			"cache_size":      500,
+			"threads":         4,
+			"dedup_threshold": 0.8,
+			"warmup":          true,
+			"endpoint":        "http://localhost:8000/rerank",
+		},
+	}
+	if got := ConfigFromEnv(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigFromEnv() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConfigFromEnv_InvalidNumbers(t *testing.T) {
+	config, err := configFromEnv([]string{
+		EnvModel + "=simple",
+		EnvMaxDocs + "=many",
+		EnvThreshold + "=high",
+		EnvCacheSize + "=1.5",
+		"OTHER=1",
+	})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+	want := Config{Model: "simple"}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Expected invalid values to be skipped, got %+v", config)
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	base := Config{
+		Model:     "simple",
+		MaxDocs:   50,
+		Threshold: -1,
+		Device:    "cpu",
+		Options:   map[string]interface{}{"threads": 2, "cache_size": 100},
+	}
+	override := Config{
+		MaxDocs:       10,
+		ThresholdMode: ThresholdPercentile,
+		StableSort:    true,
+		Options:       map[string]interface{}{"threads": 8},
+	}
+
+	want := Config{
+		Model:         "simple",
+		MaxDocs:       10,
+		Threshold:     -1,
+		ThresholdMode: ThresholdPercentile,
+		Device:        "cpu",
+		StableSort:    true,
+		Options:       map[string]interface{}{"threads": 8, "cache_size": 100},
+	}
+	if got := MergeConfig(base, override); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeConfig() = %+v, want %+v", got, want)
+	}
+	if base.Options["threads"] != 2 {
+		t.Error("Expected MergeConfig not to modify base options")
+	}
+}
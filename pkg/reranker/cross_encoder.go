@@ -3,9 +3,7 @@ package reranker
 import (
 	"context"
 	"log"
-	"sort"
 	"strings"
-	"time"
 )
 
 // CrossEncoderReranker implements reranking using a cross-encoder model
@@ -48,6 +46,13 @@ const (
 
 // Rerank reorders documents based on relevance to a query using cross-encoder scoring
 func (r *CrossEncoderReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
 	if len(documents) == 0 {
 		return documents, nil
 	}
@@ -62,7 +67,14 @@ func (r *CrossEncoderReranker) Rerank(ctx context.Context, query string, documen
 
 	// Calculate scores using cross-encoder logic
 	// In a real implementation, this would call a model service
-	scores := r.calculateScores(pairs)
+	rawScores, err := r.pairScores(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := r.config.transformScores(rawScores)
+	if err != nil {
+		return nil, err
+	}
 
 	// Apply scores to documents
 	for i := range documents {
@@ -70,14 +82,13 @@ func (r *CrossEncoderReranker) Rerank(ctx context.Context, query string, documen
 	}
 
 	// Sort by score (descending)
-	sort.Slice(documents, func(i, j int) bool {
-		return documents[i].Score > documents[j].Score
-	})
+	sortDocuments(documents, r.config.tieBreak())
 
 	// Apply threshold filter
+	threshold := r.config.scoreThreshold(scores)
 	var filtered []Document
 	for _, doc := range documents {
-		if doc.Score >= r.config.Threshold {
+		if doc.Score >= threshold {
 			filtered = append(filtered, doc)
 		}
 	}
@@ -140,24 +151,41 @@ func (r *CrossEncoderReranker) calculateScores(pairs [][2]string) []float64 {
 		similarity := float64(matches) / float64(totalQueryWords)
 		
 		// Convert to cross-encoder-like score range based on model
-		switch r.modelPath {
-		case ModelBGERerankerLarge, ModelBGERerankerBase, ModelBGERerankerV2M3, ModelBGERerankerV2Gemma, ModelBGERerankerV2MiniCPMLayerwise, ModelQwen3Reranker06B, ModelQwen3Reranker4B, ModelQwen3Reranker8B, ModelMxbaiRerankLargeV1, ModelMxbaiRerankLargeV2, ModelJinaRerankerV2BaseMultilingual:
-			// BGE reranker models typically output unbounded scores
-			// Qwen3 reranker models also use similar range
-			// Mxbai reranker models also use similar range
-			// Jina AI reranker models also use similar range
-			scores[i] = similarity * 20.0 - 10.0
-		default:
-			// Default cross-encoder model range
-			scores[i] = similarity * 15.0 - 5.0
-		}
+		scale, offset := r.scoreRange()
+		scores[i] = similarity * scale + offset
 	}
 	
 	return scores
 }
 
+// scoreRange returns the scale and offset mapping word-overlap similarity to the model's score range
+func (r *CrossEncoderReranker) scoreRange() (float64, float64) {
+	switch r.modelPath {
+	case ModelBGERerankerLarge, ModelBGERerankerBase, ModelBGERerankerV2M3, ModelBGERerankerV2Gemma, ModelBGERerankerV2MiniCPMLayerwise, ModelQwen3Reranker06B, ModelQwen3Reranker4B, ModelQwen3Reranker8B, ModelMxbaiRerankLargeV1, ModelMxbaiRerankLargeV2, ModelJinaRerankerV2BaseMultilingual:
+		// BGE reranker models typically output unbounded scores
+		// Qwen3 reranker models also use similar range
+		// Mxbai reranker models also use similar range
+		// Jina AI reranker models also use similar range
+		return 20.0, -10.0
+	default:
+		// Default cross-encoder model range
+		return 15.0, -5.0
+	}
+}
+
 // ComputeScore computes scores for query-document pairs
 func (r *CrossEncoderReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *CrossEncoderReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
 	if len(documents) == 0 {
 		return nil, nil
 	}
@@ -169,17 +197,40 @@ func (r *CrossEncoderReranker) ComputeScore(ctx context.Context, query string, d
 	}
 
 	// Calculate scores using cross-encoder logic
-	return r.calculateScores(pairs), nil
+	scores, err := r.pairScores(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return r.config.transformScores(scores)
+}
+
+// pairScores computes scores pair by pair, stopping once ctx is done
+func (r *CrossEncoderReranker) pairScores(ctx context.Context, pairs [][2]string) ([]float64, error) {
+	scores := make([]float64, len(pairs))
+	for i := range pairs {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+		scores[i] = r.calculateScores(pairs[i : i+1])[0]
+	}
+	return scores, nil
 }
 
 // Rank returns top-N ranked documents
 func (r *CrossEncoderReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
 	if len(documents) == 0 {
 		return nil, nil
 	}
 
 	// Calculate scores for all documents
-	scores, err := r.ComputeScore(ctx, query, documents)
+	scores, err := r.computeScores(ctx, query, documents)
 	if err != nil {
 		return nil, err
 	}
@@ -195,14 +246,13 @@ func (r *CrossEncoderReranker) Rank(ctx context.Context, query string, documents
 	}
 
 	// Sort by score (descending)
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+	sortResults(results, r.config.tieBreak())
 
 	// Apply threshold filter
+	threshold := r.config.scoreThreshold(scores)
 	var filtered []RerankResult
 	for _, result := range results {
-		if result.Score >= r.config.Threshold {
+		if result.Score >= threshold {
 			filtered = append(filtered, result)
 		}
 	}
@@ -212,6 +262,10 @@ func (r *CrossEncoderReranker) Rank(ctx context.Context, query string, documents
 		filtered = filtered[:topN]
 	}
 
+	// Record output positions
+	assignRanks(filtered, r.config.NormalizeScores)
+	r.explainResults(query, filtered)
+
 	return filtered, nil
 }
 
@@ -220,8 +274,21 @@ func (r *CrossEncoderReranker) GetModelName() string {
 	return r.config.Model
 }
 
+// Version returns "{model}@go-rerankers-{semver}"
+func (r *CrossEncoderReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck is a no-op; scoring needs no external resources
+func (r *CrossEncoderReranker) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
 // Configure updates the reranker configuration
 func (r *CrossEncoderReranker) Configure(config Config) error {
+	if err := validateThreshold(config); err != nil {
+		return err
+	}
 	r.config = config
 	if r.config.MaxDocs == 0 {
 		r.config.MaxDocs = 100
@@ -239,15 +306,3 @@ type CrossEncoderRequest struct {
 type CrossEncoderResponse struct {
 	Scores []float64 `json:"scores"`
 }
-
-// callCrossEncoderAPI would call a real cross-encoder API in production
-func (r *CrossEncoderReranker) callCrossEncoderAPI(ctx context.Context, pairs [][2]string) ([]float64, error) {
-	// This is a placeholder for actual API call
-	// In production, this would make an HTTP request to a model service
-	
-	// Simulate network delay
-	time.Sleep(100 * time.Millisecond)
-	
-	// Return simulated scores
-	return r.calculateScores(pairs), nil
-}
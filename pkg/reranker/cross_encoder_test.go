@@ -55,7 +55,7 @@ func TestCrossEncoderRerankerEmptyDocuments(t *testing.T) {
 	
 	reranker := NewCrossEncoderReranker(config)
 	
-	var documents []Document
+	documents := []Document{}
 	
 	reranked, err := reranker.Rerank(context.Background(), "test query", documents)
 	if err != nil {
@@ -85,3 +85,34 @@ func TestCrossEncoderRerankerConfigure(t *testing.T) {
 		t.Fatalf("Configure() returned error: %v", err)
 	}
 }
+
+func TestCrossEncoderRerankerRankPositions(t *testing.T) {
+	reranker := NewCrossEncoderReranker(Config{
+		Model:           "cross-encoder/ms-marco-MiniLM-L12-v2",
+		NormalizeScores: NormalizationMinMax,
+	})
+
+	documents := []Document{
+		{ID: "1", Content: "Berlin had a population of 3,520,031 registered inhabitants."},
+		{ID: "2", Content: "Berlin is well known for its museums."},
+		{ID: "3", Content: "New York City is famous for the Metropolitan Museum of Art."},
+	}
+
+	results, err := reranker.Rank(context.Background(), "How many people live in Berlin?", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank() returned error: %v", err)
+	}
+
+	for i, result := range results {
+		if result.Rank != i+1 {
+			t.Errorf("Expected rank %d, got %d", i+1, result.Rank)
+		}
+	}
+	if results[0].NormalizedRank != 1.0 {
+		t.Errorf("Expected top result normalized rank 1.0, got %v", results[0].NormalizedRank)
+	}
+	if results[0].RelativeScore != 1.0 || results[len(results)-1].RelativeScore != 0.0 {
+		t.Errorf("Expected relative scores spanning [0, 1], got %v and %v",
+			results[0].RelativeScore, results[len(results)-1].RelativeScore)
+	}
+}
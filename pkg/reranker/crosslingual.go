@@ -0,0 +1,229 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+)
+
+// MetaTranslatedQuery is the Meta key recording the translated query a
+// document was scored against
+const MetaTranslatedQuery = "translated_query"
+
+// TranslateFunc translates text between ISO 639-1 languages
+type TranslateFunc func(text, from, to string) (string, error)
+
+// CrossLingualReranker wraps another reranker for queries and documents in
+// different languages, which monolingual models score poorly without any
+// error. The query language is detected with DetectLanguage and each
+// document's language taken from Document.Language, Config.Language or
+// DetectLanguage, in that order. When they differ the query is translated to
+// the document's language before scoring, once per document language.
+// Queries or documents of unknown language are scored untranslated.
+//
+// Recognized options:
+//   - "translator": TranslateFunc translating the query (required)
+//   - "query_language": language of every query, skipping detection; short
+//     queries are often too short to detect
+type CrossLingualReranker struct {
+	config    Config
+	inner     Reranker
+	translate TranslateFunc
+}
+
+// NewCrossLingualReranker wraps inner; the translator is read from config options
+func NewCrossLingualReranker(inner Reranker, config Config) (*CrossLingualReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: cross-lingual reranking requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &CrossLingualReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// documentLanguage returns the primary language of doc, or "" when unknown
+func (r *CrossLingualReranker) documentLanguage(doc Document) string {
+	switch {
+	case doc.Language != "":
+		return primaryLanguage(doc.Language)
+	case r.config.Language != "":
+		return primaryLanguage(r.config.Language)
+	}
+	return DetectLanguage(doc.Content)
+}
+
+// translatedQueries returns the query to score each document against
+func (r *CrossLingualReranker) translatedQueries(query string, documents []Document) ([]string, error) {
+	from := primaryLanguage(OptionString(r.config.Options, "query_language", ""))
+	if from == "" {
+		from = DetectLanguage(query)
+	}
+
+	queries := make([]string, len(documents))
+	translations := make(map[string]string)
+	for i, doc := range documents {
+		to := r.documentLanguage(doc)
+		if from == "" || to == "" || to == from {
+			queries[i] = query
+			continue
+		}
+		translated, ok := translations[to]
+		if !ok {
+			var err error
+			if translated, err = r.translate(query, from, to); err != nil {
+				return nil, fmt.Errorf("%w: failed to translate query from %s to %s: %v", ErrInference, from, to, err)
+			}
+			translations[to] = translated
+		}
+		queries[i] = translated
+	}
+	return queries, nil
+}
+
+// scoreTranslated scores each document against its translated query, calling
+// the inner reranker once per distinct query
+func (r *CrossLingualReranker) scoreTranslated(ctx context.Context, query string, documents []Document) ([]float64, []string, error) {
+	queries, err := r.translatedQueries(query, documents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups := make(map[string][]int)
+	var order []string
+	for i, q := range queries {
+		if _, ok := groups[q]; !ok {
+			order = append(order, q)
+		}
+		groups[q] = append(groups[q], i)
+	}
+
+	scores := make([]float64, len(documents))
+	for _, q := range order {
+		indices := groups[q]
+		group := make([]Document, len(indices))
+		for j, index := range indices {
+			group[j] = documents[index]
+		}
+		groupScores, err := r.inner.ComputeScore(ctx, q, group)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(groupScores) != len(group) {
+			return nil, nil, fmt.Errorf("%w: expected %d scores for query %q, got %d", ErrInference, len(group), q, len(groupScores))
+		}
+		for j, index := range indices {
+			scores[index] = groupScores[j]
+		}
+	}
+	return scores, queries, nil
+}
+
+// Rerank reorders documents by their score against the translated query
+func (r *CrossLingualReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns each document's score against the query translated to its language
+func (r *CrossLingualReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *CrossLingualReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, _, err := r.scoreTranslated(ctx, query, documents)
+	return scores, err
+}
+
+// Rank returns top-N documents by their score against the translated query,
+// recording translated queries in Meta["translated_query"]
+func (r *CrossLingualReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, queries, err := r.scoreTranslated(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	results := assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores)
+	for i := range results {
+		translated := queries[results[i].Index]
+		if translated == query {
+			continue
+		}
+		meta := make(map[string]interface{}, len(results[i].Document.Meta)+1)
+		for key, value := range results[i].Document.Meta {
+			meta[key] = value
+		}
+		meta[MetaTranslatedQuery] = translated
+		results[i].Document.Meta = meta
+	}
+	return results, nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *CrossLingualReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *CrossLingualReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *CrossLingualReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the translator; the inner reranker is left unchanged
+func (r *CrossLingualReranker) Configure(config Config) error {
+	var translate TranslateFunc
+	switch fn := config.Options["translator"].(type) {
+	case TranslateFunc:
+		translate = fn
+	case func(string, string, string) (string, error):
+		translate = fn
+	}
+	if translate == nil {
+		return fmt.Errorf("%w: translator option is required for cross-lingual reranking", ErrInvalidInput)
+	}
+
+	r.config = config
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.translate = translate
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *CrossLingualReranker) Close() error {
+	return closeReranker(r.inner)
+}
@@ -0,0 +1,111 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// frenchDocuments are long enough for DetectLanguage to recognize as French
+var frenchDocuments = []Document{
+	{ID: "ml", Content: "Les modèles d'apprentissage automatique apprennent des données et de la statistique pour les prédictions"},
+	{ID: "pasta", Content: "La recette des pâtes est une des plus simples de la cuisine italienne pour les familles"},
+}
+
+// dictionaryTranslator translates whole texts from a fixed table, recording each call
+func dictionaryTranslator(calls *[]string, table map[string]string) TranslateFunc {
+	return func(text, from, to string) (string, error) {
+		*calls = append(*calls, from+">"+to)
+		if translated, ok := table[text]; ok {
+			return translated, nil
+		}
+		return "", errors.New("no translation")
+	}
+}
+
+func TestCrossLingualReranker_TranslatedQueryScoresHigher(t *testing.T) {
+	if got := DetectLanguage(frenchDocuments[0].Content); got != LanguageFrench {
+		t.Fatalf("Expected the fixture to be detected as French, got %q", got)
+	}
+
+	query := "machine learning models learn from the statistics of the data"
+	inner := NewSimpleReranker(Config{})
+	var calls []string
+	translator := dictionaryTranslator(&calls, map[string]string{query: "modèles apprentissage automatique données statistique"})
+	r, err := NewCrossLingualReranker(inner, Config{Options: map[string]interface{}{"translator": translator}})
+	if err != nil {
+		t.Fatalf("NewCrossLingualReranker failed: %v", err)
+	}
+
+	untranslated, err := inner.ComputeScore(context.Background(), query, frenchDocuments)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	translated, err := r.ComputeScore(context.Background(), query, frenchDocuments)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if translated[0] <= untranslated[0] {
+		t.Errorf("Expected the translated query to score higher, got %v versus %v", translated[0], untranslated[0])
+	}
+	if len(calls) != 1 || calls[0] != "en>fr" {
+		t.Errorf("Expected one en>fr translation for both documents, got %v", calls)
+	}
+
+	results, err := r.Rank(context.Background(), query, frenchDocuments, 1)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if results[0].Document.ID != "ml" || results[0].Document.Meta[MetaTranslatedQuery] == nil {
+		t.Errorf("Expected the machine learning document first with its translated query, got %+v", results[0])
+	}
+}
+
+func TestCrossLingualReranker_SameOrUnknownLanguage(t *testing.T) {
+	var calls []string
+	r, err := NewCrossLingualReranker(NewSimpleReranker(Config{}), Config{Options: map[string]interface{}{
+		"translator": dictionaryTranslator(&calls, nil),
+	}})
+	if err != nil {
+		t.Fatalf("NewCrossLingualReranker failed: %v", err)
+	}
+
+	english := []Document{
+		{ID: "en", Content: "Machine learning is the study of the algorithms that learn from the data"},
+		{ID: "short", Content: "pasta"},
+		{ID: "tagged", Content: "pasta", Language: "en-US"},
+	}
+	if _, err := r.Rank(context.Background(), "machine learning from the data", english, 3); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	// The query is too short to detect
+	if _, err := r.Rank(context.Background(), "pasta", frenchDocuments, 2); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Expected no translations, got %v", calls)
+	}
+
+	// An explicit query language enables translation of short queries, and
+	// translation failures are reported
+	r.config.Options["query_language"] = "en"
+	if _, err := r.Rank(context.Background(), "pasta", frenchDocuments, 2); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for a failed translation, got %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "en>fr" {
+		t.Errorf("Expected an en>fr translation, got %v", calls)
+	}
+}
+
+func TestNewCrossLingualReranker_Errors(t *testing.T) {
+	if _, err := NewCrossLingualReranker(nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without an inner reranker, got %v", err)
+	}
+	if _, err := NewCrossLingualReranker(NewSimpleReranker(Config{}), Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a translator, got %v", err)
+	}
+	plain := func(text, from, to string) (string, error) { return text, nil }
+	if _, err := NewCrossLingualReranker(NewSimpleReranker(Config{}), Config{Options: map[string]interface{}{"translator": plain}}); err != nil {
+		t.Errorf("Expected a plain function to be accepted as translator, got %v", err)
+	}
+}
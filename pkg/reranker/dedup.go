@@ -0,0 +1,194 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DefaultDedupThreshold is the Jaccard similarity above which documents are duplicates
+const DefaultDedupThreshold = 0.9
+
+// dedupShingleSize is the number of consecutive words in each shingle
+const dedupShingleSize = 3
+
+// shingles returns the set of word-level shingles of text; texts shorter
+// than a shingle produce a single shingle of all their words
+func shingles(text string) map[string]struct{} {
+	tokens := tokenize(text)
+	set := make(map[string]struct{})
+	if len(tokens) == 0 {
+		return set
+	}
+	if len(tokens) < dedupShingleSize {
+		set[strings.Join(tokens, " ")] = struct{}{}
+		return set
+	}
+	for i := 0; i+dedupShingleSize <= len(tokens); i++ {
+		set[strings.Join(tokens[i:i+dedupShingleSize], " ")] = struct{}{}
+	}
+	return set
+}
+
+// jaccard computes |a ∩ b| / |a ∪ b|
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1.0
+	}
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	intersection := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
+
+// deduplicateIndices returns the original indices of documents kept after
+// dropping every document whose similarity to an earlier kept one exceeds threshold
+func deduplicateIndices(documents []Document, threshold float64) []int {
+	kept := make([]int, 0, len(documents))
+	keptShingles := make([]map[string]struct{}, 0, len(documents))
+
+	for i, doc := range documents {
+		current := shingles(doc.Content)
+		duplicate := false
+		for _, previous := range keptShingles {
+			if jaccard(current, previous) > threshold {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			kept = append(kept, i)
+			keptShingles = append(keptShingles, current)
+		}
+	}
+	return kept
+}
+
+// DeduplicateDocuments removes near-duplicate documents using Jaccard similarity
+// of word shingles. Documents whose similarity to an earlier document exceeds
+// threshold are dropped, so the one with the lower original index is kept.
+func DeduplicateDocuments(documents []Document, threshold float64) []Document {
+	kept := deduplicateIndices(documents, threshold)
+	deduplicated := make([]Document, len(kept))
+	for i, index := range kept {
+		deduplicated[i] = documents[index]
+	}
+	return deduplicated
+}
+
+// DeduplicatingReranker drops near-duplicate documents before delegating Rank
+// and Rerank to the wrapped reranker. ComputeScore is passed through unchanged
+// since it must return one score per input document.
+//
+// Recognized options:
+//   - "dedup_threshold": Jaccard similarity above which documents are duplicates (default 0.9)
+type DeduplicatingReranker struct {
+	config    Config
+	inner     Reranker
+	threshold float64
+}
+
+// NewDeduplicatingReranker wraps inner with near-duplicate removal
+func NewDeduplicatingReranker(inner Reranker, config Config) (*DeduplicatingReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: deduplication requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &DeduplicatingReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rerank removes duplicates and reranks the remaining documents
+func (r *DeduplicatingReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+	return r.inner.Rerank(ctx, query, DeduplicateDocuments(documents, r.threshold))
+}
+
+// ComputeScore delegates to the wrapped reranker without deduplication
+func (r *DeduplicatingReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	return r.inner.ComputeScore(ctx, query, documents)
+}
+
+// Rank removes duplicates and ranks the remaining documents; result indices
+// refer to positions in the original documents slice
+func (r *DeduplicatingReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	kept := deduplicateIndices(documents, r.threshold)
+	unique := make([]Document, len(kept))
+	for i, index := range kept {
+		unique[i] = documents[index]
+	}
+
+	results, err := r.inner.Rank(ctx, query, unique, topN)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Index = kept[results[i].Index]
+	}
+	return assignRanks(results, r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *DeduplicatingReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *DeduplicatingReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *DeduplicatingReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the deduplication threshold; the inner reranker is left unchanged
+func (r *DeduplicatingReranker) Configure(config Config) error {
+	threshold := OptionFloat(config.Options, "dedup_threshold", DefaultDedupThreshold)
+	if threshold < 0 || threshold > 1 {
+		return fmt.Errorf("%w: dedup_threshold must be in [0, 1], got %f", ErrInvalidInput, threshold)
+	}
+
+	r.config = config
+	r.threshold = threshold
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *DeduplicatingReranker) Close() error {
+	return closeReranker(r.inner)
+}
+
+// Unwrap returns the wrapped reranker
+func (r *DeduplicatingReranker) Unwrap() Reranker {
+	return r.inner
+}
@@ -0,0 +1,69 @@
+package reranker
+
+import (
+	"context"
+	"testing"
+)
+
+func TestJaccardShingles(t *testing.T) {
+	a := shingles("one two three four")
+	if got := jaccard(a, shingles("One, two three four!")); got != 1.0 {
+		t.Errorf("Expected identical token streams to have similarity 1, got %v", got)
+	}
+	// {one two three, two three four} vs {one two three, two three five}
+	if got := jaccard(a, shingles("one two three five")); got != 1.0/3.0 {
+		t.Errorf("Expected similarity 1/3, got %v", got)
+	}
+}
+
+func TestDeduplicatingReranker_Rank(t *testing.T) {
+	inner := &orderedReranker{name: "inner", order: []string{"c", "a", "b"}}
+	r, err := NewDeduplicatingReranker(inner, Config{})
+	if err != nil {
+		t.Fatalf("NewDeduplicatingReranker failed: %v", err)
+	}
+
+	documents := []Document{
+		{ID: "a", Content: "shared passage about rerankers"},
+		{ID: "b", Content: "shared passage about rerankers"},
+		{ID: "c", Content: "a different passage entirely"},
+	}
+	results, err := r.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected duplicate to be dropped, got %d results", len(results))
+	}
+	if results[0].Document.ID != "c" || results[0].Index != 2 {
+		t.Errorf("Expected c at original index 2 first, got %+v", results[0])
+	}
+	if results[1].Document.ID != "a" || results[1].Index != 0 {
+		t.Errorf("Expected a at original index 0 second, got %+v", results[1])
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if len(scores) != len(documents) {
+		t.Errorf("Expected ComputeScore to score every document, got %d scores", len(scores))
+	}
+}
+
+func TestDeduplicatingReranker_Factory(t *testing.T) {
+	r, err := NewRerankerWithOptions("http/test",
+		WithOptions(map[string]interface{}{"endpoint": "http://localhost:1"}),
+		WithDeduplication(0.8),
+	)
+	if err != nil {
+		t.Fatalf("NewRerankerWithOptions failed: %v", err)
+	}
+	dedup, ok := r.(*DeduplicatingReranker)
+	if !ok {
+		t.Fatalf("Expected *DeduplicatingReranker wrapper, got %T", r)
+	}
+	if dedup.threshold != 0.8 {
+		t.Errorf("Expected threshold 0.8, got %v", dedup.threshold)
+	}
+}
@@ -0,0 +1,114 @@
+package reranker
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Inference devices
+const (
+	DeviceAuto  = "auto"
+	DeviceCPU   = "cpu"
+	DeviceCUDA  = "cuda"
+	DeviceMetal = "metal"
+)
+
+// DefaultGPULayers offloads every layer of the supported models to the GPU
+const DefaultGPULayers = 99
+
+// llamaDeviceNames maps devices to the llama.cpp --device names of their first GPU
+var llamaDeviceNames = map[string]string{
+	DeviceCUDA:  "CUDA0",
+	DeviceMetal: "Metal",
+}
+
+// deviceProbe abstracts the host inspection used by device detection
+type deviceProbe struct {
+	goos   string
+	goarch string
+	glob   func(pattern string) ([]string, error)
+	run    func(name string, args ...string) ([]byte, error)
+}
+
+// hostProbe inspects the current machine
+var hostProbe = deviceProbe{
+	goos:   runtime.GOOS,
+	goarch: runtime.GOARCH,
+	glob:   filepath.Glob,
+	run: func(name string, args ...string) ([]byte, error) {
+		return exec.Command(name, args...).Output()
+	},
+}
+
+var (
+	detectedDeviceOnce sync.Once
+	detectedDevice     string
+)
+
+// DetectDevice returns "cuda", "metal" or "cpu" for the current machine.
+// The result is detected once and cached.
+func DetectDevice() string {
+	detectedDeviceOnce.Do(func() {
+		detectedDevice = detectDevice(hostProbe)
+	})
+	return detectedDevice
+}
+
+// detectDevice looks for NVIDIA device nodes or nvidia-smi GPUs on Linux and
+// Windows, and for Metal support in system_profiler output on macOS
+func detectDevice(probe deviceProbe) string {
+	switch probe.goos {
+	case "linux", "windows":
+		if probe.goos == "linux" {
+			if nodes, err := probe.glob("/dev/nvidia[0-9]*"); err == nil && len(nodes) > 0 {
+				return DeviceCUDA
+			}
+		}
+		if output, err := probe.run("nvidia-smi", "-L"); err == nil && strings.Contains(string(output), "GPU ") {
+			return DeviceCUDA
+		}
+	case "darwin":
+		if output, err := probe.run("system_profiler", "SPDisplaysDataType"); err == nil {
+			if strings.Contains(string(output), "Metal") {
+				return DeviceMetal
+			}
+			return DeviceCPU
+		}
+		// Every Apple Silicon Mac supports Metal
+		if probe.goarch == "arm64" {
+			return DeviceMetal
+		}
+	}
+	return DeviceCPU
+}
+
+// resolveDevice maps a configured device to "cuda", "metal" or "cpu",
+// detecting the device when it is empty or "auto"
+func resolveDevice(device string) string {
+	switch strings.ToLower(device) {
+	case "", DeviceAuto:
+		return DetectDevice()
+	case DeviceCUDA, DeviceMetal:
+		return strings.ToLower(device)
+	}
+	return DeviceCPU
+}
+
+// gpuArgs returns the llama.cpp flags offloading inference to device.
+// Recognized options:
+//   - "gpu_layers": layers to offload (default 99)
+//   - "gpu_device": llama.cpp --device value (default CUDA0 or Metal)
+func gpuArgs(device string, opts map[string]interface{}) []string {
+	defaultName, ok := llamaDeviceNames[device]
+	if !ok {
+		return nil
+	}
+	return []string{
+		"--n-gpu-layers", strconv.Itoa(OptionInt(opts, "gpu_layers", DefaultGPULayers)),
+		"--device", OptionString(opts, "gpu_device", defaultName),
+	}
+}
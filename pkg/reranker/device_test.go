@@ -0,0 +1,122 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeProbe builds a deviceProbe with canned device nodes and command output
+func fakeProbe(goos, goarch string, nodes []string, outputs map[string]string) deviceProbe {
+	return deviceProbe{
+		goos:   goos,
+		goarch: goarch,
+		glob: func(pattern string) ([]string, error) {
+			return nodes, nil
+		},
+		run: func(name string, args ...string) ([]byte, error) {
+			output, ok := outputs[name]
+			if !ok {
+				return nil, errors.New("executable file not found")
+			}
+			return []byte(output), nil
+		},
+	}
+}
+
+func TestDetectDevice(t *testing.T) {
+	tests := []struct {
+		name  string
+		probe deviceProbe
+		want  string
+	}{
+		{"linux device nodes", fakeProbe("linux", "amd64", []string{"/dev/nvidia0"}, nil), DeviceCUDA},
+		{"linux nvidia-smi", fakeProbe("linux", "amd64", nil, map[string]string{
+			"nvidia-smi": "GPU 0: NVIDIA A100-SXM4-40GB (UUID: GPU-1234)\n",
+		}), DeviceCUDA},
+		{"linux no gpu", fakeProbe("linux", "amd64", nil, nil), DeviceCPU},
+		{"linux nvidia-smi without gpus", fakeProbe("linux", "amd64", nil, map[string]string{
+			"nvidia-smi": "No devices found.\n",
+		}), DeviceCPU},
+		{"windows nvidia-smi", fakeProbe("windows", "amd64", []string{"/dev/nvidia0"}, map[string]string{
+			"nvidia-smi": "GPU 0: NVIDIA GeForce RTX 4090\n",
+		}), DeviceCUDA},
+		{"darwin metal", fakeProbe("darwin", "amd64", nil, map[string]string{
+			"system_profiler": "Graphics/Displays:\n\n    Apple M2:\n      Chipset Model: Apple M2\n      Metal Support: Metal 3\n",
+		}), DeviceMetal},
+		{"darwin without metal", fakeProbe("darwin", "amd64", nil, map[string]string{
+			"system_profiler": "Graphics/Displays:\n\n    Intel GMA 950:\n      Chipset Model: GMA 950\n",
+		}), DeviceCPU},
+		{"darwin apple silicon fallback", fakeProbe("darwin", "arm64", nil, nil), DeviceMetal},
+		{"other os", fakeProbe("freebsd", "amd64", []string{"/dev/nvidia0"}, nil), DeviceCPU},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectDevice(tt.probe); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGPUArgs(t *testing.T) {
+	if args := gpuArgs(DeviceCPU, nil); args != nil {
+		t.Errorf("Expected no GPU flags on CPU, got %v", args)
+	}
+
+	want := []string{"--n-gpu-layers", "99", "--device", "CUDA0"}
+	if args := gpuArgs(DeviceCUDA, nil); !reflect.DeepEqual(args, want) {
+		t.Errorf("Expected %v, got %v", want, args)
+	}
+
+	opts := map[string]interface{}{"gpu_layers": 20, "gpu_device": "Metal1"}
+	want = []string{"--n-gpu-layers", "20", "--device", "Metal1"}
+	if args := gpuArgs(DeviceMetal, opts); !reflect.DeepEqual(args, want) {
+		t.Errorf("Expected %v, got %v", want, args)
+	}
+}
+
+func TestResolveDevice(t *testing.T) {
+	if got := resolveDevice("CUDA"); got != DeviceCUDA {
+		t.Errorf("Expected explicit cuda device, got %s", got)
+	}
+	if got := resolveDevice("tpu"); got != DeviceCPU {
+		t.Errorf("Expected unknown devices to run on CPU, got %s", got)
+	}
+	if got := resolveDevice(DeviceAuto); got != DetectDevice() {
+		t.Errorf("Expected auto to use the detected device, got %s", got)
+	}
+}
+
+func TestGGUFLocalReranker_GPUFlags(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 1)
+	argsFile := filepath.Join(t.TempDir(), "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\necho '{\"data\":[{\"index\":0,\"embedding\":[0.6,0.8]}]}'\n"
+	writeStubBinary(t, reranker.inferenceBinary, script)
+	reranker.config.Device = DeviceCUDA
+	reranker.config.Options["gpu_layers"] = 32
+
+	if _, err := reranker.getEmbedding(context.Background(), "text"); err != nil {
+		t.Fatalf("getEmbedding failed: %v", err)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("Failed to read recorded args: %v", err)
+	}
+	if !strings.Contains(string(args), "--n-gpu-layers 32 --device CUDA0") {
+		t.Errorf("Expected GPU flags, got %q", args)
+	}
+
+	reranker.config.Device = DeviceCPU
+	if _, err := reranker.getEmbedding(context.Background(), "text"); err != nil {
+		t.Fatalf("getEmbedding failed: %v", err)
+	}
+	if args, _ := os.ReadFile(argsFile); strings.Contains(string(args), "--n-gpu-layers") {
+		t.Errorf("Expected no GPU flags on CPU, got %q", args)
+	}
+}
@@ -0,0 +1,295 @@
+package reranker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultHuggingFaceEndpoint is the Hugging Face Hub base URL
+const DefaultHuggingFaceEndpoint = "https://huggingface.co"
+
+// DefaultGGUFQuantization is the quantization suffix assumed when only a repo ID is given
+const DefaultGGUFQuantization = "Q4_K_M"
+
+// defaultDownloadTimeout bounds a whole model download
+const defaultDownloadTimeout = 30 * time.Minute
+
+// knownGGUFRepos maps the GGUF files referenced by the built-in models to the
+// Hugging Face repositories they are fetched from. Use the hf_repo option for
+// files hosted elsewhere.
+var knownGGUFRepos = map[string]string{
+	"jina-reranker-v2-base-multilingual-Q4_K_M.gguf": "jinaai/jina-reranker-v2-base-multilingual",
+	"jina-reranker-v1-tiny-en-Q4_K_M.gguf":           "jinaai/jina-reranker-v1-tiny-en",
+	"jina-reranker-m0-Q4_K_M.gguf":                   "jinaai/jina-reranker-m0",
+	"mxbai-rerank-large-v2-Q4_K_M.gguf":              "mixedbread-ai/mxbai-rerank-large-v2",
+	"Qwen3-Reranker-0.6B.Q4_K_M.gguf":                "Qwen/Qwen3-Reranker-0.6B",
+	"Qwen3-Reranker-4B.Q4_K_M.gguf":                  "Qwen/Qwen3-Reranker-4B",
+	"Qwen3-Reranker-8B.Q4_K_M.gguf":                  "Qwen/Qwen3-Reranker-8B",
+	"ms-marco-MiniLM-L12-v2.Q4_K_M.gguf":             "cross-encoder/ms-marco-MiniLM-L12-v2",
+	"ms-marco-MiniLM-L4-v2.Q4_K_M.gguf":              "cross-encoder/ms-marco-MiniLM-L4-v2",
+	"bge-reranker-base-q4_k_m.gguf":                  "BAAI/bge-reranker-base",
+	"bge-reranker-large-q4_k_m.gguf":                 "BAAI/bge-reranker-large",
+	"bge-reranker-v2-m3-Q4_K_M.gguf":                 "BAAI/bge-reranker-v2-m3",
+	"bge-reranker-v2-gemma.Q4_K_M.gguf":              "BAAI/bge-reranker-v2-gemma",
+}
+
+// ProgressFunc reports download progress; total is -1 when the size is unknown
+type ProgressFunc func(downloaded, total int64)
+
+// ModelDownloader fetches GGUF files from the Hugging Face Hub
+type ModelDownloader struct {
+	Endpoint string       // Hub base URL, DefaultHuggingFaceEndpoint when empty
+	Token    string       // optional access token for gated or private repos
+	Revision string       // branch, tag or commit, "main" when empty
+	Client   *http.Client // defaults to a client with a 30 minute timeout
+	Progress ProgressFunc // optional progress callback
+}
+
+// DefaultDownloader is used by EnsureModel; its token is read from HF_TOKEN
+var DefaultDownloader = &ModelDownloader{
+	Endpoint: DefaultHuggingFaceEndpoint,
+	Token:    os.Getenv("HF_TOKEN"),
+}
+
+// EnsureModel makes sure the GGUF file identified by modelID is present in
+// destDir using DefaultDownloader, returning its local path
+func EnsureModel(modelID string, destDir string) (string, error) {
+	return DefaultDownloader.EnsureModel(modelID, destDir)
+}
+
+// ResolveHFModel splits modelID into a Hugging Face repo and file name.
+// Accepted forms are "owner/repo/file.gguf", "owner/repo" (which resolves to
+// "<repo>-Q4_K_M.gguf") and a bare file name listed in knownGGUFRepos.
+func ResolveHFModel(modelID string) (repo, filename string, err error) {
+	modelID = strings.Trim(modelID, "/")
+	if repo, exists := knownGGUFRepos[modelID]; exists {
+		return repo, modelID, nil
+	}
+
+	parts := strings.Split(modelID, "/")
+	switch {
+	case len(parts) >= 3 && strings.HasSuffix(modelID, ".gguf"):
+		return parts[0] + "/" + parts[1], strings.Join(parts[2:], "/"), nil
+	case len(parts) == 2 && !strings.HasSuffix(modelID, ".gguf"):
+		return modelID, parts[1] + "-" + DefaultGGUFQuantization + ".gguf", nil
+	}
+	return "", "", fmt.Errorf("%w: cannot resolve Hugging Face source for %q", ErrModelNotFound, modelID)
+}
+
+// hfTreeEntry is a file entry returned by the Hub tree API
+type hfTreeEntry struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	LFS  *struct {
+		OID  string `json:"oid"`
+		Size int64  `json:"size"`
+	} `json:"lfs,omitempty"`
+}
+
+// EnsureModel makes sure the GGUF file identified by modelID is present in
+// destDir, returning its local path. An existing file is kept when its SHA256
+// matches the Hub's LFS checksum (or when the checksum is unavailable);
+// otherwise the file is downloaded and verified.
+func (d *ModelDownloader) EnsureModel(modelID string, destDir string) (string, error) {
+	repo, filename, err := ResolveHFModel(modelID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return "", fmt.Errorf("%w: failed to create models directory: %v", ErrInitialization, err)
+	}
+	localPath := filepath.Join(destDir, filepath.Base(filename))
+
+	expectedSHA, err := d.fileSHA256(repo, filename)
+	if err != nil {
+		return "", err
+	}
+
+	if _, statErr := os.Stat(localPath); statErr == nil {
+		if expectedSHA == "" {
+			return localPath, nil
+		}
+		actualSHA, err := sha256File(localPath)
+		if err != nil {
+			return "", fmt.Errorf("%w: failed to hash %s: %v", ErrInitialization, localPath, err)
+		}
+		if actualSHA == expectedSHA {
+			return localPath, nil
+		}
+	}
+
+	if err := d.download(repo, filename, localPath, expectedSHA); err != nil {
+		return "", err
+	}
+	return localPath, nil
+}
+
+// endpoint returns the Hub base URL without a trailing slash
+func (d *ModelDownloader) endpoint() string {
+	if d.Endpoint == "" {
+		return DefaultHuggingFaceEndpoint
+	}
+	return strings.TrimRight(d.Endpoint, "/")
+}
+
+// revision returns the configured revision or "main"
+func (d *ModelDownloader) revision() string {
+	if d.Revision == "" {
+		return "main"
+	}
+	return d.Revision
+}
+
+// client returns the configured HTTP client or a default one
+func (d *ModelDownloader) client() *http.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return &http.Client{Timeout: defaultDownloadTimeout}
+}
+
+// get issues an authenticated GET request
+func (d *ModelDownloader) get(rawURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to build request: %v", ErrInvalidInput, err)
+	}
+	if d.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+d.Token)
+	}
+
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request to %s failed: %v", ErrInitialization, rawURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s returned %d: %s", ErrInitialization, rawURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// fileSHA256 looks up the LFS checksum of filename, returning "" for non-LFS files
+func (d *ModelDownloader) fileSHA256(repo, filename string) (string, error) {
+	treeURL := fmt.Sprintf("%s/api/models/%s/tree/%s", d.endpoint(), repo, url.PathEscape(d.revision()))
+	if dir := path.Dir(filename); dir != "." {
+		treeURL += "/" + dir
+	}
+
+	resp, err := d.get(treeURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var entries []hfTreeEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return "", fmt.Errorf("%w: failed to parse file listing for %s: %v", ErrInitialization, repo, err)
+	}
+	for _, entry := range entries {
+		if entry.Type == "file" && entry.Path == filename {
+			if entry.LFS != nil {
+				return entry.LFS.OID, nil
+			}
+			return "", nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s not found in %s", ErrModelNotFound, filename, repo)
+}
+
+// download streams filename into localPath through a temporary file,
+// verifying the checksum before moving it into place
+func (d *ModelDownloader) download(repo, filename, localPath, expectedSHA string) error {
+	resolveURL := fmt.Sprintf("%s/%s/resolve/%s/%s", d.endpoint(), repo, url.PathEscape(d.revision()), filename)
+	resp, err := d.get(resolveURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(localPath), filepath.Base(localPath)+".*.partial")
+	if err != nil {
+		return fmt.Errorf("%w: failed to create temporary file: %v", ErrInitialization, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	writer := &progressWriter{total: resp.ContentLength, progress: d.Progress}
+	if _, err := io.Copy(io.MultiWriter(tmp, hash, writer), resp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: download of %s failed: %v", ErrInitialization, filename, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: failed to write %s: %v", ErrInitialization, filename, err)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); expectedSHA != "" && actual != expectedSHA {
+		return fmt.Errorf("%w: checksum mismatch for %s: expected %s, got %s", ErrInitialization, filename, expectedSHA, actual)
+	}
+
+	if err := os.Rename(tmp.Name(), localPath); err != nil {
+		return fmt.Errorf("%w: failed to move %s into place: %v", ErrInitialization, filename, err)
+	}
+	return nil
+}
+
+// progressWriter counts written bytes and forwards them to a ProgressFunc
+type progressWriter struct {
+	downloaded int64
+	total      int64
+	progress   ProgressFunc
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.downloaded += int64(len(p))
+	if w.progress != nil {
+		w.progress(w.downloaded, w.total)
+	}
+	return len(p), nil
+}
+
+// sha256File returns the hex SHA256 digest of a file
+func sha256File(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// downloaderFromOptions builds a ModelDownloader from reranker options:
+// "hf_endpoint", "hf_token" (default HF_TOKEN), "hf_revision" and
+// "download_progress" (a ProgressFunc)
+func downloaderFromOptions(opts map[string]interface{}) *ModelDownloader {
+	downloader := &ModelDownloader{
+		Endpoint: OptionString(opts, "hf_endpoint", DefaultDownloader.Endpoint),
+		Token:    OptionString(opts, "hf_token", DefaultDownloader.Token),
+		Revision: OptionString(opts, "hf_revision", DefaultDownloader.Revision),
+	}
+	switch progress := opts["download_progress"].(type) {
+	case ProgressFunc:
+		downloader.Progress = progress
+	case func(downloaded, total int64):
+		downloader.Progress = progress
+	}
+	return downloader
+}
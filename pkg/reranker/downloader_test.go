@@ -0,0 +1,163 @@
+package reranker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// newHubTestServer serves a single LFS file from repo through the tree and resolve APIs
+func newHubTestServer(t *testing.T, repo, filename string, content []byte) (*httptest.Server, *int32) {
+	sum := sha256.Sum256(content)
+	var downloads int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/models/"+repo+"/tree/main", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"type": "file", "path": "README.md", "size": 10},
+			{"type": "file", "path": filename, "size": len(content), "lfs": map[string]interface{}{
+				"oid": hex.EncodeToString(sum[:]), "size": len(content),
+			}},
+		})
+	})
+	mux.HandleFunc("/"+repo+"/resolve/main/"+filename, func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		w.Write(content)
+	})
+	return httptest.NewServer(mux), &downloads
+}
+
+func TestResolveHFModel(t *testing.T) {
+	tests := []struct {
+		modelID, repo, filename string
+	}{
+		{"mixedbread-ai/mxbai-rerank-large-v2", "mixedbread-ai/mxbai-rerank-large-v2", "mxbai-rerank-large-v2-Q4_K_M.gguf"},
+		{"owner/repo/sub/model.gguf", "owner/repo", "sub/model.gguf"},
+		{"bge-reranker-v2-m3-Q4_K_M.gguf", "BAAI/bge-reranker-v2-m3", "bge-reranker-v2-m3-Q4_K_M.gguf"},
+	}
+	for _, tt := range tests {
+		repo, filename, err := ResolveHFModel(tt.modelID)
+		if err != nil {
+			t.Errorf("ResolveHFModel(%q) failed: %v", tt.modelID, err)
+			continue
+		}
+		if repo != tt.repo || filename != tt.filename {
+			t.Errorf("ResolveHFModel(%q) = %s, %s; want %s, %s", tt.modelID, repo, filename, tt.repo, tt.filename)
+		}
+	}
+
+	if _, _, err := ResolveHFModel("unknown.gguf"); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound for unknown file, got %v", err)
+	}
+}
+
+func TestModelDownloader_EnsureModel(t *testing.T) {
+	content := []byte("GGUF fake model weights")
+	server, downloads := newHubTestServer(t, "acme/reranker", "reranker-Q4_K_M.gguf", content)
+	defer server.Close()
+
+	var lastProgress, total int64
+	downloader := &ModelDownloader{
+		Endpoint: server.URL,
+		Progress: func(downloaded, size int64) {
+			lastProgress, total = downloaded, size
+		},
+	}
+	dir := t.TempDir()
+
+	path, err := downloader.EnsureModel("acme/reranker", dir)
+	if err != nil {
+		t.Fatalf("EnsureModel failed: %v", err)
+	}
+	if path != filepath.Join(dir, "reranker-Q4_K_M.gguf") {
+		t.Errorf("Unexpected model path %s", path)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(content) {
+		t.Errorf("Downloaded content mismatch: %q", data)
+	}
+	if lastProgress != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("Expected final progress %d/%d, got %d/%d", len(content), len(content), lastProgress, total)
+	}
+
+	// A file with a matching checksum is not downloaded again
+	if _, err := downloader.EnsureModel("acme/reranker", dir); err != nil {
+		t.Fatalf("Second EnsureModel failed: %v", err)
+	}
+	if got := atomic.LoadInt32(downloads); got != 1 {
+		t.Errorf("Expected 1 download, got %d", got)
+	}
+
+	// A corrupted file is replaced
+	if err := os.WriteFile(path, []byte("corrupted"), 0o644); err != nil {
+		t.Fatalf("Failed to corrupt file: %v", err)
+	}
+	if _, err := downloader.EnsureModel("acme/reranker", dir); err != nil {
+		t.Fatalf("EnsureModel after corruption failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(content) {
+		t.Errorf("Expected corrupted file to be replaced, got %q", data)
+	}
+}
+
+func TestModelDownloader_ChecksumMismatch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/models/acme/reranker/tree/main", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"type": "file", "path": "m.gguf", "lfs": map[string]interface{}{"oid": "deadbeef"}},
+		})
+	})
+	mux.HandleFunc("/acme/reranker/resolve/main/m.gguf", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("tampered"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir := t.TempDir()
+	_, err := (&ModelDownloader{Endpoint: server.URL}).EnsureModel("acme/reranker/m.gguf", dir)
+	if !errors.Is(err, ErrInitialization) {
+		t.Errorf("Expected checksum error, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no files left behind, found %d", len(entries))
+	}
+}
+
+func TestGGUFLocalReranker_AutoDownload(t *testing.T) {
+	content := []byte("GGUF fake model weights")
+	server, _ := newHubTestServer(t, "acme/reranker", "reranker-Q4_K_M.gguf", content)
+	defer server.Close()
+
+	// Lay out root/models next to a stub root/llama.cpp/build/bin/llama-embedding
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	binDir := filepath.Join(root, "llama.cpp", "build", "bin")
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		t.Fatalf("Failed to create binary directory: %v", err)
+	}
+	writeStubBinary(t, filepath.Join(binDir, "llama-embedding"), "#!/bin/sh\n")
+
+	r, err := NewGGUFLocalReranker(Config{
+		Model: "models/reranker-Q4_K_M.gguf",
+		Options: map[string]interface{}{
+			"auto_download": true,
+			"models_dir":    modelsDir,
+			"hf_repo":       "acme/reranker",
+			"hf_endpoint":   server.URL,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGGUFLocalReranker failed: %v", err)
+	}
+	if r.modelPath != filepath.Join(modelsDir, "reranker-Q4_K_M.gguf") {
+		t.Errorf("Expected model under models_dir, got %s", r.modelPath)
+	}
+	if _, err := os.Stat(r.modelPath); err != nil {
+		t.Errorf("Expected model to be downloaded: %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// EnsembleMember is a reranker and the weight of its scores in a
+// WeightedEnsembleReranker
+type EnsembleMember struct {
+	Reranker Reranker
+	Weight   float64
+}
+
+// WeightedEnsembleReranker runs several rerankers concurrently and ranks by
+// the weighted average of their scores. Each member's scores are min-max
+// normalized to [0, 1] first so models on larger scales do not dominate.
+// Members with weight 0 are not run.
+//
+// Recognized options:
+//   - "require_all": fail when any member fails (default false, which
+//     averages over the members that succeeded and fails only when all do)
+type WeightedEnsembleReranker struct {
+	config     Config
+	members    []EnsembleMember
+	requireAll bool
+}
+
+// NewWeightedEnsembleReranker creates an ensemble of members. Weights must
+// not be negative and at least one must be positive.
+func NewWeightedEnsembleReranker(config Config, members []EnsembleMember) (*WeightedEnsembleReranker, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%w: weighted ensemble requires at least one member", ErrInvalidInput)
+	}
+
+	var total float64
+	for i, member := range members {
+		if member.Reranker == nil {
+			return nil, fmt.Errorf("%w: ensemble member %d has no reranker", ErrInvalidInput, i)
+		}
+		if member.Weight < 0 {
+			return nil, fmt.Errorf("%w: ensemble member %s has negative weight %v", ErrInvalidInput, member.Reranker.GetModelName(), member.Weight)
+		}
+		total += member.Weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: ensemble weights must not all be 0", ErrInvalidInput)
+	}
+
+	r := &WeightedEnsembleReranker{members: append([]EnsembleMember(nil), members...)}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rerank reorders documents by their ensemble score
+func (r *WeightedEnsembleReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore runs the weighted members concurrently and returns, in
+// document order, the sum of each member's weighted normalized scores
+// divided by the total weight of the members that succeeded
+func (r *WeightedEnsembleReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *WeightedEnsembleReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Unless require_all is set, failures are collected per member so one
+	// failure does not cancel the others
+	memberScores := make([][]float64, len(r.members))
+	memberErrs := make([]error, len(r.members))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, member := range r.members {
+		if member.Weight == 0 {
+			continue
+		}
+		i, member := i, member
+		group.Go(func() error {
+			// Each member gets its own copy since some implementations reorder in place
+			docs := make([]Document, len(documents))
+			copy(docs, documents)
+			scores, err := member.Reranker.ComputeScore(groupCtx, query, docs)
+			if err == nil && len(scores) != len(documents) {
+				err = fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(documents), len(scores))
+			}
+			if err != nil {
+				memberErrs[i] = fmt.Errorf("reranker %s failed: %w", member.Reranker.GetModelName(), err)
+				if r.requireAll {
+					return memberErrs[i]
+				}
+				return nil
+			}
+			memberScores[i] = NormalizeMinMax(scores)
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(documents))
+	var firstErr error
+	var totalWeight float64
+	for i, member := range r.members {
+		if err := memberErrs[i]; err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			log.Printf("WARNING: ensemble member failed, ranking without it: %v", err)
+			continue
+		}
+		if memberScores[i] == nil {
+			continue
+		}
+		totalWeight += member.Weight
+		for j, score := range memberScores[i] {
+			scores[j] += member.Weight * score
+		}
+	}
+	if totalWeight == 0 {
+		return nil, firstErr
+	}
+	for i := range scores {
+		scores[i] /= totalWeight
+	}
+
+	return r.config.transformScores(scores)
+}
+
+// Rank returns top-N documents by ensemble score; Index is the input position
+func (r *WeightedEnsembleReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the member model names with their weights
+func (r *WeightedEnsembleReranker) GetModelName() string {
+	names := make([]string, len(r.members))
+	for i, member := range r.members {
+		names[i] = fmt.Sprintf("%s:%g", member.Reranker.GetModelName(), member.Weight)
+	}
+	return fmt.Sprintf("%s(%s)", r.config.Model, strings.Join(names, ","))
+}
+
+// Version returns the member versions like GetModelName
+func (r *WeightedEnsembleReranker) Version() string {
+	versions := make([]string, len(r.members))
+	for i, member := range r.members {
+		versions[i] = member.Reranker.Version()
+	}
+	return fmt.Sprintf("%s(%s)", packageVersion(r.config.Model), strings.Join(versions, ","))
+}
+
+// HealthCheck checks every member
+func (r *WeightedEnsembleReranker) HealthCheck(ctx context.Context) error {
+	return healthCheckAll(ctx, r.rerankers())
+}
+
+// Configure updates the ensemble configuration; members are left unchanged
+func (r *WeightedEnsembleReranker) Configure(config Config) error {
+	r.config = config
+	if r.config.Model == "" {
+		r.config.Model = "ensemble"
+	}
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.requireAll = OptionBool(config.Options, "require_all", false)
+	return nil
+}
+
+// Close releases resources held by the members
+func (r *WeightedEnsembleReranker) Close() error {
+	var errs []error
+	for _, member := range r.rerankers() {
+		errs = append(errs, closeReranker(member))
+	}
+	return errors.Join(errs...)
+}
+
+// rerankers returns the member rerankers
+func (r *WeightedEnsembleReranker) rerankers() []Reranker {
+	rerankers := make([]Reranker, len(r.members))
+	for i, member := range r.members {
+		rerankers[i] = member.Reranker
+	}
+	return rerankers
+}
@@ -0,0 +1,119 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func ensembleDocuments() []Document {
+	return []Document{{ID: "x", Content: "x"}, {ID: "y", Content: "y"}, {ID: "z", Content: "z"}}
+}
+
+func TestWeightedEnsembleReranker_ZeroWeightIgnored(t *testing.T) {
+	primary := &orderedReranker{name: "a", order: []string{"y", "z", "x"}}
+	ignored := &windowRecorder{scores: map[string]float64{"x": 1000, "y": -1000, "z": 0}}
+
+	ensemble, err := NewWeightedEnsembleReranker(Config{}, []EnsembleMember{
+		{Reranker: primary, Weight: 1},
+		{Reranker: ignored, Weight: 0},
+	})
+	if err != nil {
+		t.Fatalf("NewWeightedEnsembleReranker failed: %v", err)
+	}
+
+	results, err := ensemble.Rank(context.Background(), "query", ensembleDocuments(), 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	alone, _ := primary.Rank(context.Background(), "query", ensembleDocuments(), 0)
+	if got, want := resultIDs(results), resultIDs(alone); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the ranking of the weighted member %v, got %v", want, got)
+	}
+	if ignored.calls != 0 {
+		t.Errorf("Expected the zero-weight member not to run, got %d calls", ignored.calls)
+	}
+}
+
+func TestWeightedEnsembleReranker_NormalizesScales(t *testing.T) {
+	// Raw scores would let the large-scale member decide alone
+	large := &windowRecorder{scores: map[string]float64{"x": 1000, "y": 990, "z": 0}}
+	small := &windowRecorder{scores: map[string]float64{"x": 0, "y": 1, "z": 0.5}}
+
+	ensemble, err := NewWeightedEnsembleReranker(Config{}, []EnsembleMember{
+		{Reranker: large, Weight: 1},
+		{Reranker: small, Weight: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewWeightedEnsembleReranker failed: %v", err)
+	}
+
+	scores, err := ensemble.ComputeScore(context.Background(), "query", ensembleDocuments())
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	// x: (1 + 0) / 2, y: (0.99 + 1) / 2, z: (0 + 0.5) / 2
+	want := []float64{0.5, 0.995, 0.25}
+	for i := range want {
+		if diff := scores[i] - want[i]; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("Expected score %v for document %d, got %v", want[i], i, scores[i])
+		}
+	}
+}
+
+func TestWeightedEnsembleReranker_PartialFailure(t *testing.T) {
+	working := &orderedReranker{name: "a", order: []string{"z", "x", "y"}}
+	broken := &erroringReranker{err: errors.New("model crashed")}
+	members := []EnsembleMember{{Reranker: working, Weight: 1}, {Reranker: broken, Weight: 2}}
+
+	ensemble, err := NewWeightedEnsembleReranker(Config{}, members)
+	if err != nil {
+		t.Fatalf("NewWeightedEnsembleReranker failed: %v", err)
+	}
+	results, err := ensemble.Rank(context.Background(), "query", ensembleDocuments(), 1)
+	if err != nil {
+		t.Fatalf("Expected the working member to be used, got %v", err)
+	}
+	if len(results) != 1 || results[0].Document.ID != "z" || results[0].Score != 1 {
+		t.Errorf("Expected z first with score 1, got %+v", results)
+	}
+
+	strict, err := NewWeightedEnsembleReranker(Config{Options: map[string]interface{}{"require_all": true}}, members)
+	if err != nil {
+		t.Fatalf("NewWeightedEnsembleReranker failed: %v", err)
+	}
+	if _, err := strict.Rank(context.Background(), "query", ensembleDocuments(), 1); err == nil || !errors.Is(err, broken.err) {
+		t.Errorf("Expected the member error with require_all, got %v", err)
+	}
+}
+
+func TestWeightedEnsembleReranker_AllFail(t *testing.T) {
+	first := &erroringReranker{err: errors.New("first")}
+	second := &erroringReranker{err: errors.New("second")}
+	ensemble, err := NewWeightedEnsembleReranker(Config{}, []EnsembleMember{
+		{Reranker: first, Weight: 1},
+		{Reranker: second, Weight: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewWeightedEnsembleReranker failed: %v", err)
+	}
+
+	if _, err := ensemble.ComputeScore(context.Background(), "query", ensembleDocuments()); !errors.Is(err, first.err) {
+		t.Errorf("Expected the first member's error, got %v", err)
+	}
+}
+
+func TestNewWeightedEnsembleReranker_Invalid(t *testing.T) {
+	a := &orderedReranker{name: "a"}
+	for _, members := range [][]EnsembleMember{
+		nil,
+		{{Reranker: nil, Weight: 1}},
+		{{Reranker: a, Weight: -1}},
+		{{Reranker: a, Weight: 0}},
+	} {
+		if _, err := NewWeightedEnsembleReranker(Config{}, members); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %+v, got %v", members, err)
+		}
+	}
+}
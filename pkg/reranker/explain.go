@@ -0,0 +1,368 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	_ ExplainableReranker = (*SimpleReranker)(nil)
+	_ ExplainableReranker = (*CrossEncoderReranker)(nil)
+	_ ExplainableReranker = (*GGUFLocalReranker)(nil)
+
+	_ AttributableReranker = (*GGUFLocalReranker)(nil)
+)
+
+const (
+	// defaultExplainMaxTerms bounds the number of document tokens ablated by GGUF explanations
+	defaultExplainMaxTerms = 64
+	// explanationTerms is the number of attributed words named in a GGUF RerankResult.Explanation
+	explanationTerms = 3
+)
+
+// overlapTerms attributes word-overlap matches to tokens. Each query word of at
+// least minLen characters that matches a content word contributes perMatch; the
+// same weight is credited to the first content word it matched, mirroring the
+// matching loops of the simple and cross-encoder scorers.
+func overlapTerms(queryWords, contentWords []string, minLen int, perMatch float64) ([]TermContribution, []TermContribution) {
+	queryTerms := make([]TermContribution, len(queryWords))
+	docTerms := make([]TermContribution, len(contentWords))
+	for i, cword := range contentWords {
+		docTerms[i].Token = cword
+	}
+
+	for i, qword := range queryWords {
+		queryTerms[i].Token = qword
+		if len(qword) < minLen {
+			continue
+		}
+		for j, cword := range contentWords {
+			if strings.Contains(cword, qword) || strings.Contains(qword, cword) {
+				queryTerms[i].Weight = perMatch
+				docTerms[j].Weight += perMatch
+				break
+			}
+		}
+	}
+	return queryTerms, docTerms
+}
+
+// Explain attributes the word-overlap similarity to the query and document tokens
+func (r *SimpleReranker) Explain(ctx context.Context, query string, doc Document) (*ScoreExplanation, error) {
+	queryWords := strings.Fields(strings.ToLower(query))
+	contentWords := strings.Fields(strings.ToLower(doc.Content))
+
+	explanation := &ScoreExplanation{Score: r.calculateSimilarity(query, doc.Content)}
+	if len(queryWords) == 0 || len(contentWords) == 0 {
+		return explanation, nil
+	}
+
+	perMatch := 1.0 / float64(len(queryWords))
+	explanation.QueryTerms, explanation.DocTerms = overlapTerms(queryWords, contentWords, 0, perMatch)
+	return explanation, nil
+}
+
+// Explain attributes the simulated cross-encoder score to the query and
+// document tokens; weights sum to the score minus the model's base offset
+func (r *CrossEncoderReranker) Explain(ctx context.Context, query string, doc Document) (*ScoreExplanation, error) {
+	queryWords := strings.Fields(strings.ToLower(query))
+	contentWords := strings.Fields(strings.ToLower(doc.Content))
+
+	explanation := &ScoreExplanation{Score: r.calculateScores([][2]string{{query, doc.Content}})[0]}
+
+	totalQueryWords := 0
+	for _, qword := range queryWords {
+		if len(qword) >= 2 {
+			totalQueryWords++
+		}
+	}
+	if totalQueryWords == 0 || len(contentWords) == 0 {
+		return explanation, nil
+	}
+
+	scale, _ := r.scoreRange()
+	perMatch := scale / float64(totalQueryWords)
+	explanation.QueryTerms, explanation.DocTerms = overlapTerms(queryWords, contentWords, 2, perMatch)
+	return explanation, nil
+}
+
+// Explain estimates token contributions by ablation: each token's weight is the
+// full score minus the score with that token removed. Removing the only token
+// of a text is scored as 0. Document tokens beyond the "explain_max_terms"
+// option (default 64) are reported with zero weight.
+func (r *GGUFLocalReranker) Explain(ctx context.Context, query string, doc Document) (*ScoreExplanation, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	score, err := r.computeRerankerScore(ctx, query, doc.Content)
+	if err != nil {
+		return nil, inferenceError(err)
+	}
+
+	queryWords := strings.Fields(query)
+	contentWords := strings.Fields(doc.Content)
+	explanation := &ScoreExplanation{
+		Score:      score,
+		QueryTerms: make([]TermContribution, len(queryWords)),
+		DocTerms:   make([]TermContribution, len(contentWords)),
+	}
+
+	maxTerms := OptionInt(r.config.Options, "explain_max_terms", defaultExplainMaxTerms)
+	if maxTerms < 0 {
+		maxTerms = 0
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(r.workerCount())
+	ablate := func(terms []TermContribution, words []string, index int, score func(ablated string) (float64, error)) {
+		terms[index].Token = words[index]
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
+			ablated := withoutWord(words, index)
+			if ablated == "" {
+				terms[index].Weight = explanation.Score
+				return nil
+			}
+			reduced, err := score(ablated)
+			if err != nil {
+				return err
+			}
+			terms[index].Weight = explanation.Score - reduced
+			return nil
+		})
+	}
+
+	for i := range queryWords {
+		ablate(explanation.QueryTerms, queryWords, i, func(ablated string) (float64, error) {
+			return r.computeRerankerScore(groupCtx, ablated, doc.Content)
+		})
+	}
+	for i := range contentWords {
+		if i >= maxTerms {
+			explanation.DocTerms[i].Token = contentWords[i]
+			continue
+		}
+		ablate(explanation.DocTerms, contentWords, i, func(ablated string) (float64, error) {
+			return r.computeRerankerScore(groupCtx, query, ablated)
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, inferenceError(err)
+	}
+	return explanation, nil
+}
+
+// AttributeScores estimates how much each unique document word contributes to
+// the query-document score: base score minus the score with every occurrence
+// of the word removed. Words are compared lower-cased without surrounding
+// punctuation; negative values mean the word hurts relevance. Intermediate
+// scores are cached like any other score. Only the first "explain_max_terms"
+// unique words (default 64) are attributed.
+func (r *GGUFLocalReranker) AttributeScores(ctx context.Context, query string, doc Document) (map[string]float64, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	base, err := r.computeRerankerScore(ctx, query, doc.Content)
+	if err != nil {
+		return nil, inferenceError(err)
+	}
+
+	contentWords := strings.Fields(doc.Content)
+	keys := make([]string, len(contentWords))
+	var unique []string
+	seen := make(map[string]bool)
+	for i, word := range contentWords {
+		keys[i] = attributionKey(word)
+		if keys[i] != "" && !seen[keys[i]] {
+			seen[keys[i]] = true
+			unique = append(unique, keys[i])
+		}
+	}
+	maxTerms := OptionInt(r.config.Options, "explain_max_terms", defaultExplainMaxTerms)
+	if maxTerms < 0 {
+		maxTerms = 0
+	}
+	if len(unique) > maxTerms {
+		unique = unique[:maxTerms]
+	}
+
+	var mu sync.Mutex
+	contributions := make(map[string]float64, len(unique))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(r.workerCount())
+	for _, key := range unique {
+		key := key
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
+			remaining := make([]string, 0, len(contentWords))
+			for i, word := range contentWords {
+				if keys[i] != key {
+					remaining = append(remaining, word)
+				}
+			}
+
+			// Removing every word is scored as 0, as in Explain
+			reduced := 0.0
+			if len(remaining) > 0 {
+				var err error
+				if reduced, err = r.computeRerankerScore(groupCtx, query, strings.Join(remaining, " ")); err != nil {
+					return err
+				}
+			}
+
+			mu.Lock()
+			contributions[key] = base - reduced
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, inferenceError(err)
+	}
+	return contributions, nil
+}
+
+// attributionKey lower-cases word and trims surrounding punctuation
+func attributionKey(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
+
+// withoutWord joins words, skipping the one at index
+func withoutWord(words []string, index int) string {
+	remaining := make([]string, 0, len(words)-1)
+	remaining = append(remaining, words[:index]...)
+	remaining = append(remaining, words[index+1:]...)
+	return strings.Join(remaining, " ")
+}
+
+// explainOverlap describes a word-overlap score: how often each distinct query
+// word of at least minLen characters occurs in content, and how relevant the
+// share of matched query words makes the document
+func explainOverlap(score float64, query, content string, minLen int) string {
+	counts := make(map[string]int)
+	for _, word := range strings.Fields(content) {
+		counts[attributionKey(word)]++
+	}
+
+	var terms []string
+	var found []string
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(query) {
+		key := attributionKey(word)
+		if len(key) < minLen || key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		terms = append(terms, key)
+		if counts[key] == 0 {
+			continue
+		}
+		if len(found) == 0 {
+			found = append(found, fmt.Sprintf("query term '%s' found %dx in document", key, counts[key]))
+		} else {
+			found = append(found, fmt.Sprintf("term '%s' found %dx", key, counts[key]))
+		}
+	}
+
+	var verdict string
+	switch {
+	case len(found) == 0:
+		return fmt.Sprintf("Score %.2f: no query terms found in document; document is not relevant.", score)
+	case len(found) == len(terms):
+		verdict = "document is highly relevant"
+	case 2*len(found) >= len(terms):
+		verdict = "document is relevant"
+	default:
+		verdict = "document is partially relevant"
+	}
+	return fmt.Sprintf("Score %.2f: %s; %s.", score, strings.Join(found, "; "), verdict)
+}
+
+// explainAttribution describes a score by the explanationTerms words whose
+// removal changed it most
+func explainAttribution(score float64, contributions map[string]float64) string {
+	var terms []TermContribution
+	for word, weight := range contributions {
+		if weight != 0 {
+			terms = append(terms, TermContribution{Token: word, Weight: weight})
+		}
+	}
+	if len(terms) == 0 {
+		return fmt.Sprintf("Score %.2f: no single document term changed the score.", score)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if math.Abs(terms[i].Weight) != math.Abs(terms[j].Weight) {
+			return math.Abs(terms[i].Weight) > math.Abs(terms[j].Weight)
+		}
+		return terms[i].Token < terms[j].Token
+	})
+	if len(terms) > explanationTerms {
+		terms = terms[:explanationTerms]
+	}
+
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		direction := "raised"
+		if term.Weight < 0 {
+			direction = "lowered"
+		}
+		parts[i] = fmt.Sprintf("term '%s' %s the score by %.2f", term.Token, direction, math.Abs(term.Weight))
+	}
+	return fmt.Sprintf("Score %.2f: %s.", score, strings.Join(parts, "; "))
+}
+
+// explainResults sets each result's Explanation from its word overlap with the
+// query when Options["explain"] is true
+func (r *SimpleReranker) explainResults(query string, results []RerankResult) {
+	if !OptionBool(r.config.Options, "explain", false) {
+		return
+	}
+	for i := range results {
+		results[i].Explanation = explainOverlap(results[i].Score, query, results[i].Document.Content, 0)
+	}
+}
+
+// explainResults sets each result's Explanation from its word overlap with the
+// query when Options["explain"] is true, counting words as the scorer does
+func (r *CrossEncoderReranker) explainResults(query string, results []RerankResult) {
+	if !OptionBool(r.config.Options, "explain", false) {
+		return
+	}
+	for i := range results {
+		results[i].Explanation = explainOverlap(results[i].Score, query, results[i].Document.Content, 2)
+	}
+}
+
+// explainResults sets each result's Explanation from AttributeScores when
+// Options["explain"] is true; this runs one inference per distinct document
+// word, so only the returned results are explained
+func (r *GGUFLocalReranker) explainResults(ctx context.Context, query string, results []RerankResult) error {
+	if !OptionBool(r.config.Options, "explain", false) {
+		return nil
+	}
+	for i := range results {
+		contributions, err := r.AttributeScores(ctx, query, results[i].Document)
+		if err != nil {
+			return err
+		}
+		results[i].Explanation = explainAttribution(results[i].Score, contributions)
+	}
+	return nil
+}
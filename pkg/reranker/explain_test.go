@@ -0,0 +1,215 @@
+package reranker
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func sumWeights(terms []TermContribution) float64 {
+	total := 0.0
+	for _, term := range terms {
+		total += term.Weight
+	}
+	return total
+}
+
+func TestSimpleRerankerExplain(t *testing.T) {
+	reranker := NewSimpleReranker(Config{Model: "simple"})
+	query := "machine learning models"
+
+	relevant, err := reranker.Explain(context.Background(), query, Document{Content: "Machine learning models learn from data"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	unrelated, err := reranker.Explain(context.Background(), query, Document{Content: "The weather is sunny today"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	if relevant.Score <= unrelated.Score {
+		t.Fatalf("Expected relevant score %f to exceed unrelated score %f", relevant.Score, unrelated.Score)
+	}
+	if sumWeights(relevant.QueryTerms) <= sumWeights(unrelated.QueryTerms) {
+		t.Errorf("Expected query term weights to follow the score direction")
+	}
+	if got := sumWeights(relevant.QueryTerms); got < relevant.Score-1e-9 || got > relevant.Score+1e-9 {
+		t.Errorf("Expected query term weights to sum to %f, got %f", relevant.Score, got)
+	}
+	if len(relevant.QueryTerms) != 3 || relevant.QueryTerms[0].Token != "machine" {
+		t.Errorf("Unexpected query terms: %+v", relevant.QueryTerms)
+	}
+	if len(relevant.DocTerms) != 6 || relevant.DocTerms[0].Weight <= 0 || relevant.DocTerms[5].Weight != 0 {
+		t.Errorf("Unexpected document terms: %+v", relevant.DocTerms)
+	}
+}
+
+func TestCrossEncoderRerankerExplain(t *testing.T) {
+	reranker := NewCrossEncoderReranker(Config{Model: ModelBGERerankerBase})
+	query := "what is a reranker"
+
+	relevant, err := reranker.Explain(context.Background(), query, Document{Content: "A reranker is a model that reorders search results"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	unrelated, err := reranker.Explain(context.Background(), query, Document{Content: "Bananas grow in tropical climates"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	if relevant.Score <= unrelated.Score {
+		t.Fatalf("Expected relevant score %f to exceed unrelated score %f", relevant.Score, unrelated.Score)
+	}
+	if sumWeights(relevant.QueryTerms) <= sumWeights(unrelated.QueryTerms) {
+		t.Errorf("Expected query term weights to follow the score direction")
+	}
+
+	// Weights account for everything above the model's base offset
+	_, offset := reranker.scoreRange()
+	if got := sumWeights(relevant.QueryTerms) + offset; got < relevant.Score-1e-9 || got > relevant.Score+1e-9 {
+		t.Errorf("Expected weights plus offset to equal %f, got %f", relevant.Score, got)
+	}
+
+	// The single-character query word "a" is skipped by the scorer
+	if relevant.QueryTerms[2].Token != "a" || relevant.QueryTerms[2].Weight != 0 {
+		t.Errorf("Expected short word to carry no weight, got %+v", relevant.QueryTerms[2])
+	}
+}
+
+func TestGGUFLocalRerankerExplain(t *testing.T) {
+	// Texts mentioning "relevant" embed to one axis, everything else to the other
+	reranker := newFakeGGUFReranker(t, 2)
+	writeStubBinary(t, reranker.inferenceBinary, keywordStubScript("relevant"))
+	query := "relevant question"
+
+	relevant, err := reranker.Explain(context.Background(), query, Document{Content: "relevant answer"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	unrelated, err := reranker.Explain(context.Background(), query, Document{Content: "other answer"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	if relevant.Score <= unrelated.Score {
+		t.Fatalf("Expected relevant score %f to exceed unrelated score %f", relevant.Score, unrelated.Score)
+	}
+	if sumWeights(relevant.QueryTerms) <= sumWeights(unrelated.QueryTerms) {
+		t.Errorf("Expected query term weights to follow the score direction")
+	}
+	if relevant.QueryTerms[0].Weight <= 0 || relevant.QueryTerms[1].Weight != 0 {
+		t.Errorf("Expected only %q to raise the score, got %+v", "relevant", relevant.QueryTerms)
+	}
+	if unrelated.QueryTerms[0].Weight >= 0 {
+		t.Errorf("Expected %q to lower the unrelated score, got %+v", "relevant", unrelated.QueryTerms)
+	}
+	if relevant.DocTerms[0].Token != "relevant" || relevant.DocTerms[0].Weight <= 0 {
+		t.Errorf("Unexpected document terms: %+v", relevant.DocTerms)
+	}
+}
+
+func TestGGUFLocalRerankerExplainMaxTerms(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 2)
+	reranker.config.Options["explain_max_terms"] = 1
+
+	explanation, err := reranker.Explain(context.Background(), "query", Document{Content: "one two three"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if len(explanation.DocTerms) != 3 || explanation.DocTerms[2].Token != "three" {
+		t.Errorf("Expected all document tokens to be listed, got %+v", explanation.DocTerms)
+	}
+}
+
+func TestGGUFLocalRerankerAttributeScores(t *testing.T) {
+	// Texts mentioning "learning" embed to one axis, everything else to the other
+	reranker := newFakeGGUFReranker(t, 2)
+	writeStubBinary(t, reranker.inferenceBinary, keywordStubScript("learning"))
+
+	contributions, err := reranker.AttributeScores(context.Background(), "machine learning", Document{Content: "Learning and more learning, with cooking."})
+	if err != nil {
+		t.Fatalf("AttributeScores failed: %v", err)
+	}
+
+	// Every occurrence of "learning" is removed together, whatever its case or punctuation
+	if len(contributions) != 5 {
+		t.Errorf("Expected one contribution per unique word, got %v", contributions)
+	}
+	if contributions["learning"] <= 0 {
+		t.Errorf("Expected the query keyword to contribute positively, got %v", contributions)
+	}
+	for _, word := range []string{"and", "more", "with", "cooking"} {
+		if contributions[word] != 0 {
+			t.Errorf("Expected %q to contribute nothing, got %f", word, contributions[word])
+		}
+	}
+	if reranker.CacheLen() != 6 {
+		t.Errorf("Expected the base and ablated scores to be cached, got %d entries", reranker.CacheLen())
+	}
+}
+
+func TestRankExplanation_WordOverlap(t *testing.T) {
+	documents := []Document{
+		{ID: "ml", Content: "Machine learning: a machine learns from data"},
+		{ID: "pasta", Content: "Cooking pasta at home"},
+	}
+	explain := map[string]interface{}{"explain": true}
+
+	rerankers := map[string]Reranker{
+		"simple":        NewSimpleReranker(Config{Options: explain}),
+		"cross-encoder": NewCrossEncoderReranker(Config{Model: "ms-marco-v2", Options: explain}),
+	}
+	for name, r := range rerankers {
+		results, err := r.Rank(context.Background(), "machine learning", documents, 2)
+		if err != nil {
+			t.Fatalf("%s: Rank failed: %v", name, err)
+		}
+		for _, result := range results {
+			if result.Explanation == "" {
+				t.Errorf("%s: expected an explanation for %s", name, result.Document.ID)
+			}
+		}
+		top := results[0].Explanation
+		if results[0].Document.ID != "ml" || !strings.Contains(top, "query term 'machine' found 2x in document") ||
+			!strings.Contains(top, "term 'learning' found 1x") || !strings.HasSuffix(top, "document is highly relevant.") {
+			t.Errorf("%s: unexpected explanation %q", name, top)
+		}
+	}
+
+	quiet, err := NewSimpleReranker(Config{}).Rank(context.Background(), "machine learning", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if quiet[0].Explanation != "" {
+		t.Errorf("Expected no explanation by default, got %q", quiet[0].Explanation)
+	}
+}
+
+func TestRankExplanation_GGUFAttribution(t *testing.T) {
+	// Texts mentioning "learning" embed to one axis, everything else to the other
+	reranker := newFakeGGUFReranker(t, 2)
+	writeStubBinary(t, reranker.inferenceBinary, keywordStubScript("learning"))
+	reranker.config.Options["explain"] = true
+
+	results, err := reranker.Rank(context.Background(), "machine learning", []Document{{ID: "ml", Content: "Learning and more learning, with cooking."}}, 1)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0].Explanation, "term 'learning' raised the score by") {
+		t.Errorf("Expected the query keyword to be credited, got %+v", results)
+	}
+}
+
+func TestExplainAttribution(t *testing.T) {
+	got := explainAttribution(2.5, map[string]float64{"a": 0.5, "b": -1.25, "c": 0.1, "d": 0.75, "e": 0})
+	want := "Score 2.50: term 'b' lowered the score by 1.25; term 'd' raised the score by 0.75; term 'a' raised the score by 0.50."
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got := explainAttribution(1, nil); !strings.Contains(got, "no single document term") {
+		t.Errorf("Unexpected explanation without contributions: %q", got)
+	}
+	if got := explainOverlap(0.2, "quantum physics", "Cooking pasta", 0); !strings.HasSuffix(got, "document is not relevant.") {
+		t.Errorf("Unexpected explanation without matches: %q", got)
+	}
+}
@@ -1,18 +1,91 @@
 package reranker
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 )
 
 // RerankerType represents different reranker implementation types
 type RerankerType string
 
 const (
-	TypeGGUFLocal   RerankerType = "gguf-local"
+	TypeGGUFLocal    RerankerType = "gguf-local"
+	TypeHTTP         RerankerType = "http"
+	TypeCohere       RerankerType = "cohere"
+	TypeRRF          RerankerType = "rrf"
+	TypeGRPC         RerankerType = "grpc"
+	TypeJinaCloud    RerankerType = "jina-cloud"
+	TypeOpenAICompat RerankerType = "openai-compat"
+	TypeLlamaServer  RerankerType = "llama-server"
+	TypeColBERT      RerankerType = "colbert"
+	TypeVoyage       RerankerType = "voyage-cloud"
+	TypeLayerwise    RerankerType = "gguf-layerwise"
+	TypeBM25         RerankerType = "bm25"
+	TypeTFIDF        RerankerType = "tfidf"
 )
 
-// NewReranker creates a new reranker based on the model name and configuration
+// modelPrefixToType maps model name prefixes to non-local backends,
+// e.g. "http/<model>" for remote inference servers
+var modelPrefixToType = map[string]RerankerType{
+	HTTPModelPrefix:         TypeHTTP,
+	CohereModelPrefix:       TypeCohere,
+	GRPCModelPrefix:         TypeGRPC,
+	JinaCloudModelPrefix:    TypeJinaCloud,
+	OpenAICompatModelPrefix: TypeOpenAICompat,
+	LlamaServerModelPrefix:  TypeLlamaServer,
+	VoyageModelPrefix:       TypeVoyage,
+}
+
+// typeFromModelPrefix resolves the reranker type from a model name prefix
+func typeFromModelPrefix(model string) (RerankerType, bool) {
+	for prefix, rerankType := range modelPrefixToType {
+		if strings.HasPrefix(model, prefix) {
+			return rerankType, true
+		}
+	}
+	return "", false
+}
+
+// NewReranker creates a new reranker based on the model name and configuration.
+// When Options["fallback_model"] is set, calls that fail on the model (or a
+// model that fails to load) are served by the fallback model instead. When
+// Options["warmup"] is set, the model is warmed up before returning. When
+// Options["retry_max_attempts"] is set, inference errors are retried.
+// A warning is logged when config.Language is not supported by the model.
 func NewReranker(config Config) (Reranker, error) {
+	warnUnsupportedLanguage(config)
+
+	reranker, resolved, err := newBackend(config)
+	if err == nil {
+		err = warmupIfRequested(reranker, resolved)
+	}
+	// Retries wrap the backend alone so the fallback only runs once they are exhausted
+	if err == nil && retryEnabled(config.Options) {
+		reranker, err = NewRetryReranker(reranker, resolved)
+	}
+	if OptionString(config.Options, "fallback_model", "") == "" {
+		if err != nil {
+			return nil, err
+		}
+		return wrapReranker(reranker, resolved)
+	}
+
+	fallback, fallbackErr := newFallbackFromConfig(config)
+	if fallbackErr != nil {
+		return nil, errors.Join(err, fallbackErr)
+	}
+	if err != nil {
+		log.Printf("WARNING: failed to create %s (%v), using fallback %s", config.Model, err, fallback.GetModelName())
+		return wrapReranker(fallback, resolved)
+	}
+	return wrapReranker(WithFallback(reranker, fallback), resolved)
+}
+
+// newBackend resolves the model name and creates the backend reranker,
+// returning it together with the resolved configuration
+func newBackend(config Config) (Reranker, Config, error) {
 	// All models use GGUF local inference with real llama.cpp
 	modelToType := map[string]RerankerType{
 		// All models now use GGUF local inference with real llama.cpp
@@ -21,6 +94,7 @@ func NewReranker(config Config) (Reranker, error) {
 		"BAAI/bge-reranker-large":                    TypeGGUFLocal,
 		"BAAI/bge-reranker-v2-m3":                    TypeGGUFLocal,
 		"BAAI/bge-reranker-v2-gemma":                 TypeGGUFLocal,
+		"BAAI/bge-reranker-v2-minicpm-layerwise":     TypeLayerwise,
 
 		"Qwen/Qwen3-Reranker-0.6B":                  TypeGGUFLocal,
 		"Qwen/Qwen3-Reranker-4B":                     TypeGGUFLocal,
@@ -54,7 +128,23 @@ func NewReranker(config Config) (Reranker, error) {
 		"bge-large":       TypeGGUFLocal,
 		"bge-v2-m3":       TypeGGUFLocal,
 		"bge-v2-gemma":    TypeGGUFLocal,
-		"colbert-v2":               TypeGGUFLocal,
+		"colbert-v2":               TypeColBERT,
+		"bge-v2-minicpm-layerwise": TypeLayerwise,
+
+		// ColBERT late interaction over per-token embeddings
+		"models/colbertv2.0.Q4_K_M.gguf": TypeColBERT,
+
+		// Scores from an intermediate layer selected by Options["layer_index"]
+		"models/bge-reranker-v2-minicpm-layerwise-Q4_K_M.gguf": TypeLayerwise,
+
+		// Fusion of several sub-rerankers listed in Options["rerankers"]
+		"rrf": TypeRRF,
+
+		// Lexical BM25 scoring, indexing each call's documents
+		"bm25": TypeBM25,
+
+		// TF-IDF cosine similarity, indexing each call's documents
+		"tfidf": TypeTFIDF,
 	}
 
 	// Map friendly names to GGUF model files - all models now use real llama.cpp inference
@@ -72,6 +162,7 @@ func NewReranker(config Config) (Reranker, error) {
 		"bge-v2-m3":       "models/bge-reranker-v2-m3-Q4_K_M.gguf",
 		"bge-v2-gemma":    "models/bge-reranker-v2-gemma.Q4_K_M.gguf",
 		"colbert-v2":               "models/colbertv2.0.Q4_K_M.gguf",
+		"bge-v2-minicpm-layerwise": "models/bge-reranker-v2-minicpm-layerwise-Q4_K_M.gguf",
 		"jina-m0":                  "models/jina-reranker-m0-Q4_K_M.gguf",
 		"jina-v1-tiny":             "models/jina-reranker-v1-tiny-en-Q4_K_M.gguf",
 		"ms-marco-l4-v2":           "models/ms-marco-MiniLM-L4-v2.Q4_K_M.gguf",
@@ -88,6 +179,7 @@ func NewReranker(config Config) (Reranker, error) {
 		"BAAI/bge-reranker-large":                    "models/bge-reranker-large-q4_k_m.gguf",
 		"BAAI/bge-reranker-v2-m3":                    "models/bge-reranker-v2-m3-Q4_K_M.gguf",
 		"BAAI/bge-reranker-v2-gemma":                 "models/bge-reranker-v2-gemma.Q4_K_M.gguf",
+		"BAAI/bge-reranker-v2-minicpm-layerwise":     "models/bge-reranker-v2-minicpm-layerwise-Q4_K_M.gguf",
 
 		
 		// GGUF model paths (explicit GGUF paths)
@@ -99,8 +191,16 @@ func NewReranker(config Config) (Reranker, error) {
 		"gguf/bge-v2-m3":  "models/bge-reranker-v2-m3-Q4_K_M.gguf",
 	}
 
-	// If using a friendly name, convert to model ID
-	if modelID, exists := friendlyNameToModelID[config.Model]; exists {
+	if err := loadEnvRegistry(); err != nil {
+		return nil, config, err
+	}
+
+	// Models from a loaded registry take precedence over the built-in tables
+	registered, fromRegistry := lookupRegisteredModel(config.Model)
+	if fromRegistry {
+		config.Model = registered.ModelID
+	} else if modelID, exists := friendlyNameToModelID[config.Model]; exists {
+		// If using a friendly name, convert to model ID
 		config.Model = modelID
 	}
 
@@ -113,6 +213,12 @@ func NewReranker(config Config) (Reranker, error) {
 	}
 
 	rerankType, exists := modelToType[config.Model]
+	if fromRegistry {
+		rerankType, exists = RerankerType(registered.Type), true
+	}
+	if !exists {
+		rerankType, exists = typeFromModelPrefix(config.Model)
+	}
 	if !exists {
 		// Check if it's a friendly name we haven't mapped
 		originalModel := config.Model
@@ -125,12 +231,103 @@ func NewReranker(config Config) (Reranker, error) {
 		}
 	}
 
-	// Only GGUF local inference is supported
-	if rerankType != TypeGGUFLocal {
-		return nil, fmt.Errorf("%w: only GGUF local inference is supported, got: %s", ErrUnsupportedModel, rerankType)
+	var reranker Reranker
+	var err error
+	switch rerankType {
+	case TypeGGUFLocal:
+		reranker, err = NewGGUFLocalReranker(config)
+	case TypeHTTP:
+		reranker, err = NewHTTPReranker(config)
+	case TypeCohere:
+		reranker, err = NewCohereReranker(config)
+	case TypeGRPC:
+		reranker, err = NewGRPCReranker(config)
+	case TypeJinaCloud:
+		reranker, err = NewJinaReranker(config)
+	case TypeOpenAICompat:
+		reranker, err = NewOpenAICompatReranker(config)
+	case TypeLlamaServer:
+		reranker, err = NewLlamaServerReranker(config)
+	case TypeVoyage:
+		reranker, err = NewVoyageReranker(config)
+	case TypeColBERT:
+		reranker, err = NewColBERTReranker(config)
+	case TypeLayerwise:
+		reranker, err = NewLayerwiseGGUFReranker(config)
+	case TypeRRF:
+		reranker, err = newRRFFromConfig(config)
+	case TypeBM25:
+		reranker, err = NewBM25Reranker(config, nil)
+	case TypeTFIDF:
+		reranker, err = NewTFIDFReranker(config, nil)
+	default:
+		return nil, config, fmt.Errorf("%w: unsupported reranker type: %s", ErrUnsupportedModel, rerankType)
+	}
+	if err != nil {
+		return nil, config, err
+	}
+
+	return reranker, config, nil
+}
+
+// wrapReranker applies the optional wrappers requested through config
+// options. Each wraps the previous one, so the order from innermost to
+// outermost is chunk, expand, dedup, filter, then cache: chunked documents
+// are scored against the expanded query, pre-filtered documents are never
+// deduplicated or scored, and a cache hit skips all other work.
+func wrapReranker(reranker Reranker, config Config) (Reranker, error) {
+	var err error
+	if chunkingEnabled(config.Options) {
+		if reranker, err = NewChunkingPreprocessor(reranker, config); err != nil {
+			return nil, err
+		}
+	}
+	if expansionEnabled(config.Options) {
+		if reranker, err = NewQueryExpander(reranker, config); err != nil {
+			return nil, err
+		}
+	}
+	if config.Deduplicate {
+		if reranker, err = NewDeduplicatingReranker(reranker, config); err != nil {
+			return nil, err
+		}
+	}
+	if len(config.PreFilter) > 0 || len(config.PostFilter) > 0 {
+		if reranker, err = NewFilteringReranker(reranker, config); err != nil {
+			return nil, err
+		}
+	}
+	if queryCacheEnabled(config.Options) {
+		if reranker, err = NewQueryCachingReranker(reranker, config); err != nil {
+			return nil, err
+		}
+	}
+	return reranker, nil
+}
+
+// closeReranker releases resources held by r when it supports closing
+func closeReranker(r Reranker) error {
+	switch closer := r.(type) {
+	case interface{ Close() error }:
+		return closer.Close()
+	case interface{ Close() }:
+		closer.Close()
+	}
+	return nil
+}
+
+// Unwrap returns the backend beneath the wrappers NewReranker applies, such
+// as retries, chunking, filtering or the query cache, so callers can check
+// which optional interfaces the model itself implements. A fallback unwraps
+// to its primary reranker.
+func Unwrap(r Reranker) Reranker {
+	for {
+		wrapper, ok := r.(interface{ Unwrap() Reranker })
+		if !ok {
+			return r
+		}
+		r = wrapper.Unwrap()
 	}
-	
-	return NewGGUFLocalReranker(config)
 }
 
 // GetAvailableModels returns a list of all available model names
@@ -153,3 +350,75 @@ func GetModelByName(name string) (*ModelInfo, error) {
 	}
 	return nil, fmt.Errorf("%w: model %s not found", ErrModelNotFound, name)
 }
+
+// builtinCapabilities records the capabilities of the built-in models by
+// name; "gguf/" aliases share the entry of the model they alias
+var builtinCapabilities = map[string]ModelCapabilities{
+	"jina-v2":                  {MaxContextTokens: 1024, Multilingual: true, QuantizationBits: 4},
+	"jina-m0":                  {MaxContextTokens: 10240, Multilingual: true, QuantizationBits: 4},
+	"jina-v1-tiny":             {MaxContextTokens: 8192, QuantizationBits: 4},
+	"mxbai-v1":                 {MaxContextTokens: 8192, Multilingual: true, InstructionFollowing: true, QuantizationBits: 4},
+	"mxbai-v2":                 {MaxContextTokens: 8192, Multilingual: true, InstructionFollowing: true, QuantizationBits: 4},
+	"qwen-0.6b":                {MaxContextTokens: 32768, Multilingual: true, InstructionFollowing: true, CalibratedScores: true, QuantizationBits: 4},
+	"qwen-4b":                  {MaxContextTokens: 32768, Multilingual: true, InstructionFollowing: true, CalibratedScores: true, QuantizationBits: 4},
+	"qwen-8b":                  {MaxContextTokens: 32768, Multilingual: true, InstructionFollowing: true, CalibratedScores: true, QuantizationBits: 4},
+	"ms-marco-v2":              {MaxContextTokens: 512, QuantizationBits: 4},
+	"ms-marco-l4-v2":           {MaxContextTokens: 512, QuantizationBits: 4},
+	"bge-base":                 {MaxContextTokens: 512, QuantizationBits: 4},
+	"bge-large":                {MaxContextTokens: 512, QuantizationBits: 4},
+	"bge-v2-m3":                {MaxContextTokens: 8192, Multilingual: true, QuantizationBits: 4},
+	"bge-v2-gemma":             {MaxContextTokens: 8192, Multilingual: true, InstructionFollowing: true, QuantizationBits: 4},
+	"bge-v2-minicpm-layerwise": {MaxContextTokens: 2048, Multilingual: true, InstructionFollowing: true, QuantizationBits: 4},
+	"colbert-v2":               {MaxContextTokens: 512, QuantizationBits: 4},
+
+	"jina-cloud/jina-reranker-v2-base-multilingual": {MaxContextTokens: 1024, Multilingual: true},
+	"voyage/rerank-2":      {MaxContextTokens: 16000, Multilingual: true},
+	"voyage/rerank-lite-1": {MaxContextTokens: 8000},
+}
+
+// GetModelCapabilities returns the capabilities of a built-in model by name.
+// Models without recorded capabilities, such as registry additions, return
+// ErrModelNotFound.
+func GetModelCapabilities(name string) (*ModelCapabilities, error) {
+	capabilities, ok := builtinCapabilities[strings.TrimPrefix(name, "gguf/")]
+	if !ok {
+		return nil, fmt.Errorf("%w: no capabilities recorded for model %s", ErrModelNotFound, name)
+	}
+	return &capabilities, nil
+}
+
+// warnUnsupportedLanguage logs a warning when config.Language is set and the
+// model's ModelInfo lists languages that do not include it
+func warnUnsupportedLanguage(config Config) {
+	if config.Language == "" {
+		return
+	}
+	for _, model := range GetSupportedModels() {
+		if model.Name != config.Model && model.ModelID != config.Model {
+			continue
+		}
+		if len(model.Languages) > 0 && !supportsLanguage(model.Languages, config.Language) {
+			log.Printf("WARNING: model %s supports languages %s, not %q; consider a multilingual model", config.Model, strings.Join(model.Languages, ", "), config.Language)
+		}
+		return
+	}
+}
+
+// supportsLanguage reports whether language (e.g. "en" or "en-US") matches one of languages
+func supportsLanguage(languages []string, language string) bool {
+	primary := primaryLanguage(language)
+	for _, supported := range languages {
+		if primaryLanguage(supported) == primary {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryLanguage returns the lower-cased primary subtag of a language tag
+func primaryLanguage(language string) string {
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return strings.ToLower(language)
+}
@@ -0,0 +1,140 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// FallbackReranker delegates every call to a primary reranker and retries
+// failed calls with a fallback reranker
+type FallbackReranker struct {
+	primary  Reranker
+	fallback Reranker
+}
+
+// WithFallback returns a reranker that delegates to primary and, when a call
+// fails, logs a warning and repeats it with fallback. If both fail the
+// returned error joins both errors.
+func WithFallback(primary Reranker, fallback Reranker) Reranker {
+	return &FallbackReranker{primary: primary, fallback: fallback}
+}
+
+// warnFallback logs a primary failure before the fallback is tried
+func (r *FallbackReranker) warnFallback(method string, err error) {
+	log.Printf("WARNING: %s failed on %s (%v), falling back to %s", method, r.primary.GetModelName(), err, r.fallback.GetModelName())
+}
+
+// Rerank reranks with the primary reranker, falling back on error
+func (r *FallbackReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := validateInput(query, documents, 0); err != nil {
+		return nil, err
+	}
+
+	// Copy documents since rerankers may reorder the slice in place before failing
+	reranked, primaryErr := r.primary.Rerank(ctx, query, append([]Document(nil), documents...))
+	if primaryErr == nil {
+		return reranked, nil
+	}
+
+	r.warnFallback("Rerank", primaryErr)
+	reranked, err := r.fallback.Rerank(ctx, query, documents)
+	if err != nil {
+		return nil, errors.Join(primaryErr, err)
+	}
+	return reranked, nil
+}
+
+// ComputeScore scores with the primary reranker, falling back on error
+func (r *FallbackReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := validateInput(query, documents, 0); err != nil {
+		return nil, err
+	}
+
+	scores, primaryErr := r.primary.ComputeScore(ctx, query, documents)
+	if primaryErr == nil {
+		return scores, nil
+	}
+
+	r.warnFallback("ComputeScore", primaryErr)
+	scores, err := r.fallback.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, errors.Join(primaryErr, err)
+	}
+	return scores, nil
+}
+
+// Rank ranks with the primary reranker, falling back on error
+func (r *FallbackReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := validateInput(query, documents, 0); err != nil {
+		return nil, err
+	}
+
+	results, primaryErr := r.primary.Rank(ctx, query, documents, topN)
+	if primaryErr == nil {
+		return results, nil
+	}
+
+	r.warnFallback("Rank", primaryErr)
+	results, err := r.fallback.Rank(ctx, query, documents, topN)
+	if err != nil {
+		return nil, errors.Join(primaryErr, err)
+	}
+	return results, nil
+}
+
+// GetModelName returns both model names as "primary|fallback"
+func (r *FallbackReranker) GetModelName() string {
+	return r.primary.GetModelName() + "|" + r.fallback.GetModelName()
+}
+
+// Version returns both versions as "primary|fallback"
+func (r *FallbackReranker) Version() string {
+	return r.primary.Version() + "|" + r.fallback.Version()
+}
+
+// HealthCheck succeeds when either the primary or the fallback reranker is healthy
+func (r *FallbackReranker) HealthCheck(ctx context.Context) error {
+	primaryErr := r.primary.HealthCheck(ctx)
+	if primaryErr == nil {
+		return nil
+	}
+	if err := r.fallback.HealthCheck(ctx); err != nil {
+		return errors.Join(primaryErr, err)
+	}
+	return nil
+}
+
+// Configure updates both rerankers, returning their joined errors
+func (r *FallbackReranker) Configure(config Config) error {
+	return errors.Join(r.primary.Configure(config), r.fallback.Configure(config))
+}
+
+// Close releases resources held by both rerankers
+func (r *FallbackReranker) Close() error {
+	return errors.Join(closeReranker(r.primary), closeReranker(r.fallback))
+}
+
+// Unwrap returns the primary reranker
+func (r *FallbackReranker) Unwrap() Reranker {
+	return r.primary
+}
+
+// newFallbackFromConfig builds the reranker named by Options["fallback_model"].
+// "simple" selects a SimpleReranker; other names are resolved by NewReranker.
+func newFallbackFromConfig(config Config) (Reranker, error) {
+	fallbackConfig := config
+	fallbackConfig.Model = OptionString(config.Options, "fallback_model", "")
+	fallbackConfig.Options = make(map[string]interface{}, len(config.Options))
+	for key, value := range config.Options {
+		if key != "fallback_model" {
+			fallbackConfig.Options[key] = value
+		}
+	}
+
+	if fallbackConfig.Model == "simple" {
+		return NewSimpleReranker(fallbackConfig), nil
+	}
+	fallback, _, err := newBackend(fallbackConfig)
+	return fallback, err
+}
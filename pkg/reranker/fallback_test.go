@@ -0,0 +1,135 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// erroringReranker fails every call with the configured error
+type erroringReranker struct {
+	err   error
+	calls int
+}
+
+func (r *erroringReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	r.calls++
+	return nil, r.err
+}
+
+func (r *erroringReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	r.calls++
+	return nil, r.err
+}
+
+func (r *erroringReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	r.calls++
+	return nil, r.err
+}
+
+func (r *erroringReranker) Configure(config Config) error { return nil }
+
+func (r *erroringReranker) GetModelName() string { return "broken" }
+
+func (r *erroringReranker) Version() string { return packageVersion("broken") }
+
+func (r *erroringReranker) HealthCheck(ctx context.Context) error { return r.err }
+
+func TestWithFallback_UsesFallbackOnError(t *testing.T) {
+	primary := &erroringReranker{err: errors.New("binary missing")}
+	r := WithFallback(primary, NewSimpleReranker(Config{Model: "simple"}))
+
+	if got := r.GetModelName(); got != "broken|simple" {
+		t.Errorf("Expected model name 'broken|simple', got %q", got)
+	}
+
+	documents := []Document{{ID: "1", Content: "cooking"}, {ID: "2", Content: "machine learning"}}
+	results, err := r.Rank(context.Background(), "machine learning", documents, 1)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Document.ID != "2" {
+		t.Errorf("Expected fallback ranking, got %+v", results)
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil || len(scores) != 2 {
+		t.Fatalf("Expected fallback scores, got %v, %v", scores, err)
+	}
+
+	reranked, err := r.Rerank(context.Background(), "machine learning", documents)
+	if err != nil || len(reranked) != 2 || reranked[0].ID != "2" {
+		t.Fatalf("Expected fallback rerank, got %+v, %v", reranked, err)
+	}
+	if primary.calls != 3 {
+		t.Errorf("Expected primary to be tried for every call, got %d calls", primary.calls)
+	}
+}
+
+func TestWithFallback_BothFail(t *testing.T) {
+	primaryErr := errors.New("primary down")
+	fallbackErr := errors.New("fallback down")
+	r := WithFallback(&erroringReranker{err: primaryErr}, &erroringReranker{err: fallbackErr})
+
+	_, err := r.Rank(context.Background(), "query", []Document{{ID: "1", Content: "one"}}, 0)
+	if !errors.Is(err, primaryErr) || !errors.Is(err, fallbackErr) {
+		t.Errorf("Expected both errors to be joined, got %v", err)
+	}
+}
+
+func TestWithFallback_PrimarySucceeds(t *testing.T) {
+	fallback := &erroringReranker{err: errors.New("unused")}
+	r := WithFallback(NewSimpleReranker(Config{Model: "simple"}), fallback)
+
+	if _, err := r.Rank(context.Background(), "query", []Document{{ID: "1", Content: "query"}}, 0); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("Expected fallback to be unused, got %d calls", fallback.calls)
+	}
+}
+
+func TestNewReranker_FallbackModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	r, err := NewReranker(Config{
+		Model: "http/broken",
+		Options: map[string]interface{}{
+			"endpoint":       server.URL,
+			"max_retries":    0,
+			"fallback_model": "simple",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if got := r.GetModelName(); got != "http/broken|simple" {
+		t.Errorf("Expected model name 'http/broken|simple', got %q", got)
+	}
+
+	results, err := r.Rank(context.Background(), "query", []Document{{ID: "1", Content: "query text"}}, 0)
+	if err != nil {
+		t.Fatalf("Expected fallback to serve the call, got %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+}
+
+func TestNewReranker_FallbackModelOnLoadFailure(t *testing.T) {
+	r, err := NewReranker(Config{
+		Model:   "http/no-endpoint",
+		Options: map[string]interface{}{"fallback_model": "simple"},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*SimpleReranker); !ok {
+		t.Errorf("Expected the fallback reranker when the primary cannot be created, got %T", r)
+	}
+}
@@ -0,0 +1,359 @@
+package reranker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultFeedbackWeight scales click boosts when feedback_weight is not set
+const DefaultFeedbackWeight = 0.1
+
+// Kinds of FeedbackEvent
+const (
+	FeedbackClick      = "click"
+	FeedbackImpression = "impression"
+)
+
+// FeedbackEvent is one line of a feedback file
+type FeedbackEvent struct {
+	Type      string    `json:"type"`
+	QueryID   string    `json:"query_id"`
+	DocID     string    `json:"doc_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FeedbackConfig configures a FeedbackCollector
+type FeedbackConfig struct {
+	// Path is the JSONL file events are appended to and loaded from; empty
+	// keeps events in memory only
+	Path string
+	// BoostDecay is the exponential decay rate per day of a click's weight,
+	// so clicks 1/BoostDecay days old count about a third; 0 disables decay
+	BoostDecay float64
+}
+
+// FeedbackCollector records impressions and clicks on documents and turns
+// their click-through rates into score boosts. It is safe for concurrent use.
+type FeedbackCollector struct {
+	config      FeedbackConfig
+	mutex       sync.Mutex
+	clicks      map[string][]time.Time
+	impressions map[string]int
+	now         func() time.Time
+}
+
+// NewFeedbackCollector creates a collector, loading the events already in
+// config.Path when the file exists
+func NewFeedbackCollector(config FeedbackConfig) (*FeedbackCollector, error) {
+	if config.BoostDecay < 0 {
+		return nil, fmt.Errorf("%w: boost decay must not be negative, got %v", ErrInvalidInput, config.BoostDecay)
+	}
+
+	c := &FeedbackCollector{
+		config:      config,
+		clicks:      make(map[string][]time.Time),
+		impressions: make(map[string]int),
+		now:         time.Now,
+	}
+	if config.Path == "" {
+		return c, nil
+	}
+
+	file, err := os.Open(config.Path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open feedback file: %v", ErrInitialization, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event FeedbackEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("%w: invalid feedback line %d: %v", ErrInitialization, lineNumber, err)
+		}
+		c.apply(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: failed to read feedback file: %v", ErrInitialization, err)
+	}
+	return c, nil
+}
+
+// RecordImpression records that docIDs were shown for queryID
+func (c *FeedbackCollector) RecordImpression(queryID string, docIDs []string) error {
+	now := c.now().UTC()
+	events := make([]FeedbackEvent, len(docIDs))
+	for i, docID := range docIDs {
+		events[i] = FeedbackEvent{Type: FeedbackImpression, QueryID: queryID, DocID: docID, Timestamp: now}
+	}
+	return c.record(events)
+}
+
+// RecordClick records that docID was clicked for queryID
+func (c *FeedbackCollector) RecordClick(queryID, docID string) error {
+	return c.record([]FeedbackEvent{{Type: FeedbackClick, QueryID: queryID, DocID: docID, Timestamp: c.now().UTC()}})
+}
+
+// record persists events, then applies them
+func (c *FeedbackCollector) record(events []FeedbackEvent) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.config.Path != "" {
+		file, err := os.OpenFile(c.config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open feedback file: %w", err)
+		}
+		encoder := json.NewEncoder(file)
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				file.Close()
+				return fmt.Errorf("failed to write feedback event: %w", err)
+			}
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write feedback event: %w", err)
+		}
+	}
+
+	for _, event := range events {
+		c.apply(event)
+	}
+	return nil
+}
+
+// apply adds event to the counts; the caller holds the mutex or owns c
+func (c *FeedbackCollector) apply(event FeedbackEvent) {
+	switch event.Type {
+	case FeedbackClick:
+		c.clicks[event.DocID] = append(c.clicks[event.DocID], event.Timestamp)
+	case FeedbackImpression:
+		c.impressions[event.DocID]++
+	}
+}
+
+// ComputeClickBoost returns docID's click-through rate relative to the mean
+// over all documents, minus one: 0 for an average document or one never
+// shown, -1 for one never clicked and 1 for one clicked twice as often.
+// Clicks are weighted down by BoostDecay as they age.
+func (c *FeedbackCollector) ComputeClickBoost(docID string) float64 {
+	return c.clickBoosts([]string{docID})[0]
+}
+
+// clickBoosts returns the boost of each document, computing the mean
+// click-through rate once
+func (c *FeedbackCollector) clickBoosts(docIDs []string) []float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	var totalClicks, totalImpressions float64
+	weighted := make(map[string]float64, len(c.clicks))
+	for docID, clicks := range c.clicks {
+		for _, clickedAt := range clicks {
+			weighted[docID] += c.clickWeight(now, clickedAt)
+		}
+		totalClicks += weighted[docID]
+	}
+	for docID := range c.shownDocuments() {
+		totalImpressions += float64(c.shown(docID))
+	}
+
+	boosts := make([]float64, len(docIDs))
+	if totalClicks == 0 || totalImpressions == 0 {
+		return boosts
+	}
+	meanCTR := totalClicks / totalImpressions
+	for i, docID := range docIDs {
+		shown := c.shown(docID)
+		if shown == 0 {
+			continue
+		}
+		boosts[i] = weighted[docID]/float64(shown)/meanCTR - 1
+	}
+	return boosts
+}
+
+// shownDocuments returns the documents with impressions or clicks
+func (c *FeedbackCollector) shownDocuments() map[string]struct{} {
+	docIDs := make(map[string]struct{}, len(c.impressions))
+	for docID := range c.impressions {
+		docIDs[docID] = struct{}{}
+	}
+	for docID := range c.clicks {
+		docIDs[docID] = struct{}{}
+	}
+	return docIDs
+}
+
+// shown returns docID's impressions, counting a click without a recorded
+// impression as one
+func (c *FeedbackCollector) shown(docID string) int {
+	if clicks := len(c.clicks[docID]); clicks > c.impressions[docID] {
+		return clicks
+	}
+	return c.impressions[docID]
+}
+
+// clickWeight decays a click exponentially with its age in days
+func (c *FeedbackCollector) clickWeight(now, clickedAt time.Time) float64 {
+	if c.config.BoostDecay == 0 {
+		return 1
+	}
+	ageDays := now.Sub(clickedAt).Hours() / 24
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	return math.Exp(-c.config.BoostDecay * ageDays)
+}
+
+// FeedbackAwareReranker adds each document's click boost, scaled by
+// feedback_weight, to the wrapped reranker's scores before sorting.
+// Documents are matched to feedback by ID.
+//
+// Recognized options:
+//   - "feedback_weight": multiplier of the click boost (default 0.1)
+type FeedbackAwareReranker struct {
+	config    Config
+	inner     Reranker
+	collector *FeedbackCollector
+	weight    float64
+}
+
+// NewFeedbackAwareReranker wraps inner, boosting scores with collector's feedback
+func NewFeedbackAwareReranker(inner Reranker, collector *FeedbackCollector, config Config) (*FeedbackAwareReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: feedback-aware reranking requires an inner reranker", ErrInvalidInput)
+	}
+	if collector == nil {
+		return nil, fmt.Errorf("%w: feedback-aware reranking requires a feedback collector", ErrInvalidInput)
+	}
+
+	r := &FeedbackAwareReranker{inner: inner, collector: collector}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Collector returns the feedback collector, e.g. to record clicks on results
+func (r *FeedbackAwareReranker) Collector() *FeedbackCollector {
+	return r.collector
+}
+
+// Rerank reorders documents by their boosted score
+func (r *FeedbackAwareReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns each document's model score plus its weighted click
+// boost, in document order
+func (r *FeedbackAwareReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *FeedbackAwareReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.inner.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) != len(documents) {
+		return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(documents), len(scores))
+	}
+
+	docIDs := make([]string, len(documents))
+	for i, doc := range documents {
+		docIDs[i] = doc.ID
+	}
+	boosts := r.collector.clickBoosts(docIDs)
+
+	boosted := make([]float64, len(scores))
+	for i := range scores {
+		boosted[i] = scores[i] + r.weight*boosts[i]
+	}
+	return boosted, nil
+}
+
+// Rank returns top-N documents by their boosted score
+func (r *FeedbackAwareReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *FeedbackAwareReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *FeedbackAwareReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *FeedbackAwareReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the boost weight; the inner reranker and collector are
+// left unchanged
+func (r *FeedbackAwareReranker) Configure(config Config) error {
+	weight := OptionFloat(config.Options, "feedback_weight", DefaultFeedbackWeight)
+	if weight < 0 {
+		return fmt.Errorf("%w: feedback_weight must not be negative, got %v", ErrInvalidInput, weight)
+	}
+
+	r.config = config
+	r.weight = weight
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *FeedbackAwareReranker) Close() error {
+	return closeReranker(r.inner)
+}
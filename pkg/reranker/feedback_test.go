@@ -0,0 +1,137 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeFeedbackFile writes synthetic events: "popular" is clicked on both of
+// its impressions, "average" on one of two and "ignored" never
+func writeFeedbackFile(t *testing.T, clickedAt time.Time) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "feedback.jsonl")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, query := range []string{"q1", "q2"} {
+		for _, docID := range []string{"popular", "average", "ignored"} {
+			encoder.Encode(FeedbackEvent{Type: FeedbackImpression, QueryID: query, DocID: docID, Timestamp: clickedAt})
+		}
+		encoder.Encode(FeedbackEvent{Type: FeedbackClick, QueryID: query, DocID: "popular", Timestamp: clickedAt})
+	}
+	encoder.Encode(FeedbackEvent{Type: FeedbackClick, QueryID: "q1", DocID: "average", Timestamp: clickedAt})
+	return path
+}
+
+func TestFeedbackCollector_ComputeClickBoost(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	collector, err := NewFeedbackCollector(FeedbackConfig{Path: writeFeedbackFile(t, now)})
+	if err != nil {
+		t.Fatalf("NewFeedbackCollector failed: %v", err)
+	}
+	collector.now = func() time.Time { return now }
+
+	// Mean CTR is 3 clicks over 6 impressions
+	for docID, want := range map[string]float64{"popular": 1, "average": 0, "ignored": -1, "unseen": 0} {
+		if got := collector.ComputeClickBoost(docID); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Expected boost %v for %s, got %v", want, docID, got)
+		}
+	}
+}
+
+func TestFeedbackCollector_BoostDecay(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	path := writeFeedbackFile(t, now.AddDate(0, 0, -10))
+	collector, err := NewFeedbackCollector(FeedbackConfig{Path: path, BoostDecay: 0.1})
+	if err != nil {
+		t.Fatalf("NewFeedbackCollector failed: %v", err)
+	}
+	collector.now = func() time.Time { return now }
+
+	// Old clicks all decay equally, so boosts are unchanged...
+	if got := collector.ComputeClickBoost("popular"); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected boost 1 with uniformly aged clicks, got %v", got)
+	}
+
+	// ...until fresh clicks outweigh them
+	for i := 0; i < 2; i++ {
+		if err := collector.RecordImpression("q3", []string{"average"}); err != nil {
+			t.Fatalf("RecordImpression failed: %v", err)
+		}
+		if err := collector.RecordClick("q3", "average"); err != nil {
+			t.Fatalf("RecordClick failed: %v", err)
+		}
+	}
+	if collector.ComputeClickBoost("average") <= collector.ComputeClickBoost("popular") {
+		t.Errorf("Expected fresh clicks to outweigh decayed ones, got average %v and popular %v",
+			collector.ComputeClickBoost("average"), collector.ComputeClickBoost("popular"))
+	}
+
+	reloaded, err := NewFeedbackCollector(FeedbackConfig{Path: path, BoostDecay: 0.1})
+	if err != nil {
+		t.Fatalf("NewFeedbackCollector failed to reload: %v", err)
+	}
+	reloaded.now = collector.now
+	if got, want := reloaded.ComputeClickBoost("average"), collector.ComputeClickBoost("average"); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected persisted events to give boost %v, got %v", want, got)
+	}
+}
+
+func TestFeedbackCollector_InvalidInput(t *testing.T) {
+	if _, err := NewFeedbackCollector(FeedbackConfig{BoostDecay: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for a negative decay, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "feedback.jsonl")
+	os.WriteFile(path, []byte("{\"type\":\"click\"}\nnot json\n"), 0644)
+	if _, err := NewFeedbackCollector(FeedbackConfig{Path: path}); !errors.Is(err, ErrInitialization) {
+		t.Errorf("Expected ErrInitialization for a corrupt feedback file, got %v", err)
+	}
+}
+
+func TestFeedbackAwareReranker_AppliesBoost(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	collector, err := NewFeedbackCollector(FeedbackConfig{Path: writeFeedbackFile(t, now)})
+	if err != nil {
+		t.Fatalf("NewFeedbackCollector failed: %v", err)
+	}
+	collector.now = func() time.Time { return now }
+
+	inner := NewFixedScoreMock(map[string]float64{"popular": 0.5, "average": 0.6, "ignored": 0.7})
+	r, err := NewFeedbackAwareReranker(inner, collector, Config{Options: map[string]interface{}{"feedback_weight": 0.2}})
+	if err != nil {
+		t.Fatalf("NewFeedbackAwareReranker failed: %v", err)
+	}
+
+	documents := []Document{
+		{ID: "ignored", Content: "ignored"},
+		{ID: "average", Content: "average"},
+		{ID: "popular", Content: "popular"},
+	}
+	results, err := r.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if ids := resultIDs(results); ids[0] != "popular" || ids[1] != "average" || ids[2] != "ignored" {
+		t.Errorf("Expected clicks to reverse the model order, got %v", ids)
+	}
+
+	scores, _ := r.ComputeScore(context.Background(), "query", documents)
+	if want := []float64{0.5, 0.6, 0.7}; !approxEqualScores(scores, want) {
+		t.Errorf("Expected boosted scores %v, got %v", want, scores)
+	}
+
+	if _, err := NewFeedbackAwareReranker(inner, nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a collector, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter operators supported by FilterSpec
+const (
+	FilterEq       = "eq"
+	FilterNe       = "ne"
+	FilterGt       = "gt"
+	FilterLt       = "lt"
+	FilterContains = "contains"
+)
+
+// filterDateLayouts are the layouts tried when comparing values as dates
+var filterDateLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// ApplyFilter returns the documents matching every spec, preserving order.
+// Documents missing a filtered field never match, and neither do unknown operators.
+func ApplyFilter(docs []Document, specs []FilterSpec) []Document {
+	if len(specs) == 0 {
+		return docs
+	}
+
+	var filtered []Document
+	for _, doc := range docs {
+		if matchesFilters(doc, specs) {
+			filtered = append(filtered, doc)
+		}
+	}
+	return filtered
+}
+
+// matchesFilters reports whether doc satisfies every spec
+func matchesFilters(doc Document, specs []FilterSpec) bool {
+	for _, spec := range specs {
+		if !spec.Matches(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether doc's metadata satisfies the predicate
+func (f FilterSpec) Matches(doc Document) bool {
+	value, ok := lookupMeta(doc.Meta, f.Field)
+	if !ok {
+		return false
+	}
+
+	switch f.Op {
+	case FilterEq:
+		return compareValues(value, f.Value) == 0
+	case FilterNe:
+		return compareValues(value, f.Value) != 0
+	case FilterGt:
+		cmp, ordered := orderValues(value, f.Value)
+		return ordered && cmp > 0
+	case FilterLt:
+		cmp, ordered := orderValues(value, f.Value)
+		return ordered && cmp < 0
+	case FilterContains:
+		return containsValue(value, f.Value)
+	}
+	return false
+}
+
+// validateFilters rejects specs with an empty field or unknown operator
+func validateFilters(specs []FilterSpec) error {
+	for _, spec := range specs {
+		if spec.Field == "" {
+			return fmt.Errorf("%w: filter field is required", ErrInvalidInput)
+		}
+		switch spec.Op {
+		case FilterEq, FilterNe, FilterGt, FilterLt, FilterContains:
+		default:
+			return fmt.Errorf("%w: unsupported filter operator %q", ErrInvalidInput, spec.Op)
+		}
+	}
+	return nil
+}
+
+// lookupMeta resolves a dot-separated path through nested metadata maps
+func lookupMeta(meta map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = meta
+	for _, key := range strings.Split(path, ".") {
+		node, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = node[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// toFloat coerces numeric values, json.Number and numeric strings to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toTime coerces time.Time and date strings to time.Time
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range filterDateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// orderValues compares a and b as numbers, then dates, then strings.
+// The second return is false when the values have no common ordering.
+func orderValues(a, b interface{}) (int, bool) {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if x, ok := toTime(a); ok {
+		if y, ok := toTime(b); ok {
+			return x.Compare(y), true
+		}
+	}
+	x, xok := a.(string)
+	y, yok := b.(string)
+	if xok && yok {
+		return strings.Compare(x, y), true
+	}
+	return 0, false
+}
+
+// compareValues returns 0 when a and b are equal after coercion
+func compareValues(a, b interface{}) int {
+	if cmp, ok := orderValues(a, b); ok {
+		return cmp
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok && x == y {
+			return 0
+		}
+	}
+	if fmt.Sprint(a) == fmt.Sprint(b) {
+		return 0
+	}
+	return 1
+}
+
+// containsValue checks substring containment for strings and membership for lists
+func containsValue(container, value interface{}) bool {
+	switch c := container.(type) {
+	case string:
+		return strings.Contains(c, fmt.Sprint(value))
+	case []string:
+		for _, item := range c {
+			if compareValues(item, value) == 0 {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range c {
+			if compareValues(item, value) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FilteringReranker applies Config.PreFilter before delegating to the wrapped
+// reranker and Config.PostFilter to the ranked results. ComputeScore is passed
+// through unchanged since it must return one score per input document.
+type FilteringReranker struct {
+	config Config
+	inner  Reranker
+}
+
+// NewFilteringReranker wraps inner with the config's pre- and post-filters
+func NewFilteringReranker(inner Reranker, config Config) (*FilteringReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: filtering requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &FilteringReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rerank filters, reranks and filters again
+func (r *FilteringReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	candidates := ApplyFilter(documents, r.config.PreFilter)
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	reranked, err := r.inner.Rerank(ctx, query, candidates)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyFilter(reranked, r.config.PostFilter), nil
+}
+
+// ComputeScore delegates to the wrapped reranker without filtering
+func (r *FilteringReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	return r.inner.ComputeScore(ctx, query, documents)
+}
+
+// Rank filters, ranks and filters again; result indices refer to positions in
+// the original documents slice
+func (r *FilteringReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	var kept []int
+	var candidates []Document
+	for i, doc := range documents {
+		if matchesFilters(doc, r.config.PreFilter) {
+			kept = append(kept, i)
+			candidates = append(candidates, doc)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Post-filtering may drop results, so only cut to topN afterwards
+	innerTopN := topN
+	if len(r.config.PostFilter) > 0 {
+		innerTopN = 0
+	}
+	results, err := r.inner.Rank(ctx, query, candidates, innerTopN)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []RerankResult
+	for _, result := range results {
+		if !matchesFilters(result.Document, r.config.PostFilter) {
+			continue
+		}
+		result.Index = kept[result.Index]
+		filtered = append(filtered, result)
+	}
+
+	if topN > 0 && len(filtered) > topN {
+		filtered = filtered[:topN]
+	}
+	return assignRanks(filtered, r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *FilteringReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *FilteringReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *FilteringReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure validates and updates the filters; the inner reranker is left unchanged
+func (r *FilteringReranker) Configure(config Config) error {
+	if err := validateFilters(config.PreFilter); err != nil {
+		return err
+	}
+	if err := validateFilters(config.PostFilter); err != nil {
+		return err
+	}
+	r.config = config
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *FilteringReranker) Close() error {
+	return closeReranker(r.inner)
+}
+
+// Unwrap returns the wrapped reranker
+func (r *FilteringReranker) Unwrap() Reranker {
+	return r.inner
+}
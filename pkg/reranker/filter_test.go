@@ -0,0 +1,107 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func filterTestDocuments() []Document {
+	return []Document{
+		{ID: "a", Content: "a", Meta: map[string]interface{}{
+			"language": "en",
+			"date":     "2023-06-01",
+			"views":    150,
+			"tags":     []interface{}{"ml", "search"},
+			"author":   map[string]interface{}{"name": "Ada", "age": "36"},
+		}},
+		{ID: "b", Content: "b", Meta: map[string]interface{}{
+			"language": "de",
+			"date":     "2022-12-31",
+			"views":    float64(90),
+			"tags":     []string{"search"},
+			"author":   map[string]interface{}{"name": "Bob", "age": 52.0},
+		}},
+		{ID: "c", Content: "c"},
+	}
+}
+
+func filteredIDs(docs []Document) []string {
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids
+}
+
+func TestApplyFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs []FilterSpec
+		want  []string
+	}{
+		{"eq", []FilterSpec{{Field: "language", Op: FilterEq, Value: "en"}}, []string{"a"}},
+		{"ne skips missing", []FilterSpec{{Field: "language", Op: FilterNe, Value: "en"}}, []string{"b"}},
+		{"date after", []FilterSpec{{Field: "date", Op: FilterGt, Value: "2023-01-01"}}, []string{"a"}},
+		{"int vs float", []FilterSpec{{Field: "views", Op: FilterLt, Value: 100}}, []string{"b"}},
+		{"numeric string", []FilterSpec{{Field: "author.age", Op: FilterGt, Value: 40}}, []string{"b"}},
+		{"nested eq", []FilterSpec{{Field: "author.name", Op: FilterEq, Value: "Ada"}}, []string{"a"}},
+		{"list membership", []FilterSpec{{Field: "tags", Op: FilterContains, Value: "ml"}}, []string{"a"}},
+		{"substring", []FilterSpec{{Field: "author.name", Op: FilterContains, Value: "o"}}, []string{"b"}},
+		{"conjunction", []FilterSpec{
+			{Field: "tags", Op: FilterContains, Value: "search"},
+			{Field: "views", Op: FilterGt, Value: "100"},
+		}, []string{"a"}},
+		{"missing nested", []FilterSpec{{Field: "author.email", Op: FilterEq, Value: "x"}}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filteredIDs(ApplyFilter(filterTestDocuments(), tt.specs))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyFilter_JSONMeta(t *testing.T) {
+	var doc Document
+	if err := json.Unmarshal([]byte(`{"id":"j","meta":{"stats":{"score":4.5}}}`), &doc); err != nil {
+		t.Fatalf("Failed to decode document: %v", err)
+	}
+	got := ApplyFilter([]Document{doc}, []FilterSpec{{Field: "stats.score", Op: FilterGt, Value: "4"}})
+	if len(got) != 1 {
+		t.Errorf("Expected JSON-decoded nested number to match, got %v", got)
+	}
+}
+
+func TestFilteringReranker_Rank(t *testing.T) {
+	inner := &orderedReranker{name: "inner", order: []string{"c", "b", "a"}}
+	r, err := NewFilteringReranker(inner, NewConfig("inner",
+		WithPreFilter(FilterSpec{Field: "date", Op: FilterGt, Value: "2000-01-01"}),
+		WithPostFilter(FilterSpec{Field: "views", Op: FilterGt, Value: 100}),
+	))
+	if err != nil {
+		t.Fatalf("NewFilteringReranker failed: %v", err)
+	}
+
+	results, err := r.Rank(context.Background(), "query", filterTestDocuments(), 1)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	// c is pre-filtered, b outranks a but is post-filtered
+	if len(results) != 1 || results[0].Document.ID != "a" || results[0].Index != 0 {
+		t.Errorf("Expected only a at index 0, got %+v", results)
+	}
+
+	_, err = NewFilteringReranker(inner, Config{PreFilter: []FilterSpec{{Field: "x", Op: "like"}}})
+	if err == nil {
+		t.Error("Expected error for unsupported operator")
+	}
+}
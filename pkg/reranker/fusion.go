@@ -0,0 +1,226 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultRRFK is the standard Reciprocal Rank Fusion smoothing constant
+const DefaultRRFK = 60
+
+// RRFFusionReranker merges rankings from several rerankers using
+// Reciprocal Rank Fusion: score(d) = sum over rerankers of 1/(k + rank_i(d)).
+// Documents are matched across rerankers by Document.ID.
+type RRFFusionReranker struct {
+	config    Config
+	rerankers []Reranker
+	k         float64
+}
+
+// NewRRFFusionReranker creates a fusion reranker over the given child rerankers.
+// A non-positive k falls back to DefaultRRFK.
+func NewRRFFusionReranker(config Config, rerankers []Reranker, k float64) (*RRFFusionReranker, error) {
+	if len(rerankers) == 0 {
+		return nil, fmt.Errorf("%w: RRF fusion requires at least one reranker", ErrInvalidInput)
+	}
+	if k <= 0 {
+		k = DefaultRRFK
+	}
+	if config.Model == "" {
+		config.Model = "rrf"
+	}
+	if config.MaxDocs == 0 {
+		config.MaxDocs = 100
+	}
+
+	return &RRFFusionReranker{
+		config:    config,
+		rerankers: rerankers,
+		k:         k,
+	}, nil
+}
+
+// newRRFFromConfig builds a fusion reranker from Options["rerankers"] sub-configs
+func newRRFFromConfig(config Config) (*RRFFusionReranker, error) {
+	subConfigs, err := subConfigsOption(config.Options, "rerankers")
+	if err != nil {
+		return nil, err
+	}
+
+	rerankers := make([]Reranker, 0, len(subConfigs))
+	for _, subConfig := range subConfigs {
+		child, err := NewReranker(subConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create fused reranker %s: %w", subConfig.Model, err)
+		}
+		rerankers = append(rerankers, child)
+	}
+
+	return NewRRFFusionReranker(config, rerankers, OptionFloat(config.Options, "k", DefaultRRFK))
+}
+
+// subConfigsOption reads a list of child configs, accepting either []Config
+// or the generic JSON form ([]interface{} of objects)
+func subConfigsOption(opts map[string]interface{}, key string) ([]Config, error) {
+	if opts == nil || opts[key] == nil {
+		return nil, fmt.Errorf("%w: %s option is required", ErrInvalidInput, key)
+	}
+	if configs, ok := opts[key].([]Config); ok {
+		return configs, nil
+	}
+
+	raw, err := json.Marshal(opts[key])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s option: %v", ErrInvalidInput, key, err)
+	}
+	var configs []Config
+	if err := json.Unmarshal(raw, &configs); err != nil {
+		return nil, fmt.Errorf("%w: invalid %s option: %v", ErrInvalidInput, key, err)
+	}
+	return configs, nil
+}
+
+// documentKey identifies a document across rerankers, falling back to its position
+func documentKey(doc Document, index int) string {
+	if doc.ID != "" {
+		return doc.ID
+	}
+	return fmt.Sprintf("#%d", index)
+}
+
+// Rerank reorders documents by fused RRF score
+func (r *RRFFusionReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore runs every child reranker concurrently and returns RRF scores in document order
+func (r *RRFFusionReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *RRFFusionReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Each child gets its own copy since some implementations reorder in place
+	rankings := make([][]RerankResult, len(r.rerankers))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, child := range r.rerankers {
+		i, child := i, child
+		group.Go(func() error {
+			docs := make([]Document, len(documents))
+			copy(docs, documents)
+			results, err := child.Rank(groupCtx, query, docs, 0)
+			if err != nil {
+				return fmt.Errorf("reranker %s failed: %w", child.GetModelName(), err)
+			}
+			rankings[i] = results
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	positions := make(map[string]int, len(documents))
+	for i, doc := range documents {
+		key := documentKey(doc, i)
+		if _, exists := positions[key]; !exists {
+			positions[key] = i
+		}
+	}
+
+	scores := make([]float64, len(documents))
+	for _, results := range rankings {
+		for rank, result := range results {
+			position, ok := positions[documentKey(result.Document, result.Index)]
+			if !ok {
+				continue
+			}
+			scores[position] += 1.0 / (r.k + float64(rank+1))
+		}
+	}
+
+	return r.config.transformScores(scores)
+}
+
+// Rank returns top-N documents by fused RRF score; Index is the input position
+func (r *RRFFusionReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the fused model names
+func (r *RRFFusionReranker) GetModelName() string {
+	names := make([]string, len(r.rerankers))
+	for i, child := range r.rerankers {
+		names[i] = child.GetModelName()
+	}
+	return fmt.Sprintf("%s(%s)", r.config.Model, strings.Join(names, ","))
+}
+
+// Version returns the fused versions like GetModelName
+func (r *RRFFusionReranker) Version() string {
+	versions := make([]string, len(r.rerankers))
+	for i, child := range r.rerankers {
+		versions[i] = child.Version()
+	}
+	return fmt.Sprintf("%s(%s)", packageVersion(r.config.Model), strings.Join(versions, ","))
+}
+
+// HealthCheck checks every child reranker
+func (r *RRFFusionReranker) HealthCheck(ctx context.Context) error {
+	return healthCheckAll(ctx, r.rerankers)
+}
+
+// Configure updates the fusion configuration; child rerankers are left unchanged
+func (r *RRFFusionReranker) Configure(config Config) error {
+	r.config = config
+	if r.config.Model == "" {
+		r.config.Model = "rrf"
+	}
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	if k := OptionFloat(config.Options, "k", 0); k > 0 {
+		r.k = k
+	}
+	return nil
+}
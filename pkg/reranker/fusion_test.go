@@ -0,0 +1,110 @@
+package reranker
+
+import (
+	"context"
+	"testing"
+)
+
+// orderedReranker ranks documents in a fixed ID order, for fusion tests
+type orderedReranker struct {
+	name  string
+	order []string
+}
+
+func (r *orderedReranker) scores(documents []Document) []float64 {
+	scores := make([]float64, len(documents))
+	for i, doc := range documents {
+		for rank, id := range r.order {
+			if doc.ID == id {
+				scores[i] = float64(len(r.order) - rank)
+			}
+		}
+	}
+	return scores
+}
+
+func (r *orderedReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	return rerankByScores(documents, r.scores(documents), 0, 0, tieInputOrder), nil
+}
+
+func (r *orderedReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	return r.scores(documents), nil
+}
+
+func (r *orderedReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	return rankByScores(documents, r.scores(documents), 0, topN, tieInputOrder), nil
+}
+
+func (r *orderedReranker) Configure(config Config) error { return nil }
+
+func (r *orderedReranker) GetModelName() string { return r.name }
+
+func (r *orderedReranker) Version() string { return packageVersion(r.name) }
+
+func (r *orderedReranker) HealthCheck(ctx context.Context) error { return nil }
+
+func TestRRFFusionReranker_Agreement(t *testing.T) {
+	rerankers := []Reranker{
+		&orderedReranker{name: "a", order: []string{"x", "y", "z"}},
+		&orderedReranker{name: "b", order: []string{"x", "z", "y"}},
+		&orderedReranker{name: "c", order: []string{"y", "x", "z"}},
+	}
+
+	fusion, err := NewRRFFusionReranker(Config{}, rerankers, 0)
+	if err != nil {
+		t.Fatalf("NewRRFFusionReranker failed: %v", err)
+	}
+
+	documents := []Document{{ID: "z", Content: "z"}, {ID: "y", Content: "y"}, {ID: "x", Content: "x"}}
+	results, err := fusion.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+
+	// x is first for two rerankers, y only for one
+	if results[0].Document.ID != "x" || results[1].Document.ID != "y" {
+		t.Errorf("Expected order x, y, got %s, %s", results[0].Document.ID, results[1].Document.ID)
+	}
+	if results[0].Index != 2 {
+		t.Errorf("Expected Index to reflect input position 2, got %d", results[0].Index)
+	}
+
+	expected := 2.0/61.0 + 1.0/62.0
+	if diff := results[0].Score - expected; diff > 1e-12 || diff < -1e-12 {
+		t.Errorf("Expected RRF score %f, got %f", expected, results[0].Score)
+	}
+}
+
+func TestRRFFusionReranker_Factory(t *testing.T) {
+	config := Config{
+		Model: "rrf",
+		Options: map[string]interface{}{
+			"k": 10,
+			"rerankers": []interface{}{
+				map[string]interface{}{"model": "http/a", "options": map[string]interface{}{"endpoint": "http://localhost:1"}},
+				map[string]interface{}{"model": "http/b", "options": map[string]interface{}{"endpoint": "http://localhost:2"}},
+			},
+		},
+	}
+
+	r, err := NewReranker(config)
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	fusion, ok := r.(*RRFFusionReranker)
+	if !ok {
+		t.Fatalf("Expected *RRFFusionReranker, got %T", r)
+	}
+	if len(fusion.rerankers) != 2 || fusion.k != 10 {
+		t.Errorf("Expected 2 rerankers with k=10, got %d with k=%f", len(fusion.rerankers), fusion.k)
+	}
+}
+
+func TestRRFFusionReranker_NoRerankers(t *testing.T) {
+	if _, err := NewRRFFusionReranker(Config{}, nil, 0); err == nil {
+		t.Error("Expected error for empty reranker list")
+	}
+}
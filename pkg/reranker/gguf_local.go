@@ -3,15 +3,31 @@ package reranker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
+	"runtime"
 	"strconv"
 	"strings"
-	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Limits on a single llama-embedding run
+const (
+	// DefaultInferenceTimeout bounds each subprocess unless
+	// Options["inference_timeout_seconds"] is set
+	DefaultInferenceTimeout = 30 * time.Second
+
+	// inferenceKillGrace is how long a subprocess may take to exit after
+	// SIGTERM before it is killed
+	inferenceKillGrace = 2 * time.Second
 )
 
 // GGUFLocalReranker implements reranking using GGUF models with llama.cpp inference
@@ -19,8 +35,11 @@ type GGUFLocalReranker struct {
 	config          Config
 	modelPath       string
 	inferenceBinary string
-	scoreCache      map[string]float64
-	cacheMutex      sync.RWMutex
+	scoreCache      *ScoreCache
+	maxTokens       int
+	truncate        string
+	pool            *SubprocessPool
+	version         string
 }
 
 // EmbeddingResponse represents the JSON response from llama-embedding
@@ -43,8 +62,19 @@ func NewGGUFLocalReranker(config Config) (*GGUFLocalReranker, error) {
 		config.MaxDocs = 100
 	}
 	
-	// Resolve model path
+	maxTokens, truncate, err := truncationFromOptions(config.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateThreshold(config); err != nil {
+		return nil, err
+	}
+	
+	// Resolve model path, relative to models_dir when configured
 	modelPath := config.Model
+	if modelsDir := OptionString(config.Options, "models_dir", ""); modelsDir != "" && !filepath.IsAbs(modelPath) {
+		modelPath = filepath.Join(modelsDir, filepath.Base(modelPath))
+	}
 	if !filepath.IsAbs(modelPath) {
 		// If relative path, assume it's relative to project root
 		var err error
@@ -54,6 +84,17 @@ func NewGGUFLocalReranker(config Config) (*GGUFLocalReranker, error) {
 		}
 	}
 	
+	// Fetch a missing model from the Hugging Face Hub when auto_download is set
+	if _, err := os.Stat(modelPath); os.IsNotExist(err) && OptionBool(config.Options, "auto_download", false) {
+		source := filepath.Base(modelPath)
+		if repo := OptionString(config.Options, "hf_repo", ""); repo != "" {
+			source = repo + "/" + source
+		}
+		if _, err := downloaderFromOptions(config.Options).EnsureModel(source, filepath.Dir(modelPath)); err != nil {
+			return nil, err
+		}
+	}
+	
 	// Find the llama-embedding binary for reranker inference
 	inferenceBinary := filepath.Join(filepath.Dir(modelPath), "..", "llama.cpp", "build", "bin", "llama-embedding")
 	if _, err := os.Stat(inferenceBinary); os.IsNotExist(err) {
@@ -84,15 +125,34 @@ func NewGGUFLocalReranker(config Config) (*GGUFLocalReranker, error) {
 		return nil, fmt.Errorf("%w: model file not found: %s", ErrInitialization, modelPath)
 	}
 	
+	// Hash the model once so Version is cheap and reflects the loaded file
+	version, err := modelFileVersion(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read model file: %v", ErrInitialization, err)
+	}
+	
 	reranker := &GGUFLocalReranker{
 		config:          config,
 		modelPath:       modelPath,
 		inferenceBinary: inferenceBinary,
-		scoreCache:      make(map[string]float64),
+		scoreCache:      newScoreCacheFromOptions(config.Options),
+		maxTokens:       maxTokens,
+		truncate:        truncate,
+		version:         version,
+	}
+	
+	// Keep warm workers when subprocess_pool is set
+	if OptionBool(config.Options, "subprocess_pool", false) {
+		pool, err := reranker.newSubprocessPool()
+		if err != nil {
+			return nil, err
+		}
+		reranker.pool = pool
 	}
 	
 	// Test the model by computing a simple embedding
 	if err := reranker.testModel(); err != nil {
+		reranker.Close()
 		return nil, fmt.Errorf("%w: model test failed: %v", ErrInitialization, err)
 	}
 	
@@ -117,54 +177,69 @@ func (r *GGUFLocalReranker) testModel() error {
 
 // computeRerankerScore computes relevance score for a query-document pair using llama-embedding with --pooling rank
 // Falls back to embedding similarity if reranker fails
-func (r *GGUFLocalReranker) computeRerankerScore(query, document string) (float64, error) {
+func (r *GGUFLocalReranker) computeRerankerScore(ctx context.Context, query, document string) (float64, error) {
+	query, document = r.preparePair(ctx, query, document)
+	
 	// Create cache key
 	cacheKey := fmt.Sprintf("%s|||%s", query, document)
 	
 	// Check cache first
-	r.cacheMutex.RLock()
-	if cached, exists := r.scoreCache[cacheKey]; exists {
-		r.cacheMutex.RUnlock()
+	if cached, exists := r.scoreCache.Get(cacheKey); exists {
 		return cached, nil
 	}
-	r.cacheMutex.RUnlock()
 	
 	// Try reranker approach first
-	score, err := r.tryRerankerInference(query, document)
+	score, err := r.tryRerankerInference(ctx, query, document)
 	if err == nil {
 		// Cache the result
-		r.cacheMutex.Lock()
-		r.scoreCache[cacheKey] = score
-		r.cacheMutex.Unlock()
+		r.scoreCache.Set(cacheKey, score)
 		return score, nil
 	}
 	
+	// A timed out or cancelled run would only stall again in the fallback
+	if isContextError(err) {
+		return 0.0, err
+	}
+	
 	// Fallback to embedding similarity
-	fmt.Printf("DEBUG: Reranker failed (%v), falling back to embedding similarity\n", err)
-	score, err = r.computeEmbeddingSimilarity(query, document)
+	log.Printf("DEBUG: Reranker failed (%v), falling back to embedding similarity", err)
+	score, err = r.computeEmbeddingSimilarity(ctx, query, document)
 	if err != nil {
 		return 0.0, err
 	}
 	
 	// Cache the result
-	r.cacheMutex.Lock()
-	r.scoreCache[cacheKey] = score
-	r.cacheMutex.Unlock()
+	r.scoreCache.Set(cacheKey, score)
 	
 	return score, nil
 }
 
+// preparePair prefixes the task instruction for instruction-following models
+// and keeps the pair, instruction included, within the model's token budget
+func (r *GGUFLocalReranker) preparePair(ctx context.Context, query, document string) (string, string) {
+	budget := r.maxTokens
+	if budget > 0 {
+		// Leave room for the instruction, keeping a token each for query and document
+		budget -= len(strings.Fields(r.instructedQuery(ctx, query))) - len(strings.Fields(query))
+		if budget < 2 {
+			budget = 2
+		}
+	}
+	query, document = truncatePair(query, document, budget, r.truncate)
+	return r.instructedQuery(ctx, query), document
+}
+
 // tryRerankerInference attempts to use llama-embedding for reranking by calculating cosine similarity
-func (r *GGUFLocalReranker) tryRerankerInference(query, document string) (float64, error) {
+func (r *GGUFLocalReranker) tryRerankerInference(ctx context.Context, query, document string) (float64, error) {
 	// Get embeddings for query and document separately
-	queryEmbedding, err := r.getEmbedding(query)
+	queryEmbedding, err := r.getEmbedding(ctx, query)
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to get query embedding: %v", err)
+		return 0.0, fmt.Errorf("failed to get query embedding: %w", err)
 	}
 	
-	docEmbedding, err := r.getEmbedding(document)
+	docEmbedding, err := r.getEmbedding(ctx, document)
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to get document embedding: %v", err)
+		return 0.0, fmt.Errorf("failed to get document embedding: %w", err)
 	}
 	
 	// Calculate cosine similarity between query and document embeddings
@@ -213,16 +288,16 @@ func (r *GGUFLocalReranker) parseRerankerScore(stdout, stderr string) (float64,
 }
 
 // computeEmbeddingSimilarity computes similarity using embeddings as fallback
-func (r *GGUFLocalReranker) computeEmbeddingSimilarity(query, document string) (float64, error) {
+func (r *GGUFLocalReranker) computeEmbeddingSimilarity(ctx context.Context, query, document string) (float64, error) {
 	// Get embeddings for query and document
-	queryEmb, err := r.getEmbedding(query)
+	queryEmb, err := r.getEmbedding(ctx, query)
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to get query embedding: %v", err)
+		return 0.0, fmt.Errorf("failed to get query embedding: %w", err)
 	}
 	
-	docEmb, err := r.getEmbedding(document)
+	docEmb, err := r.getEmbedding(ctx, document)
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to get document embedding: %v", err)
+		return 0.0, fmt.Errorf("failed to get document embedding: %w", err)
 	}
 	
 	// Compute cosine similarity
@@ -233,23 +308,36 @@ func (r *GGUFLocalReranker) computeEmbeddingSimilarity(query, document string) (
 }
 
 // getEmbedding computes embedding for a text using llama-embedding
-func (r *GGUFLocalReranker) getEmbedding(text string) ([]float64, error) {
-	// Prepare command for embedding extraction
-	args := []string{
-		"-m", r.modelPath,
-		"-p", text,
-		"--embd-output-format", "json",
-		"--embd-normalize", "2", // L2 normalization
+func (r *GGUFLocalReranker) getEmbedding(ctx context.Context, text string) ([]float64, error) {
+	response, err := r.runEmbedding(ctx, text)
+	if err != nil {
+		return nil, err
 	}
+	return response.Data[0].Embedding, nil
+}
+
+// runEmbedding runs llama-embedding on text with any extra arguments and
+// returns its non-empty JSON response. The run is bounded by ctx and
+// Options["inference_timeout_seconds"]; when either expires the subprocess
+// gets SIGTERM, then SIGKILL if it has not exited 2s later.
+func (r *GGUFLocalReranker) runEmbedding(ctx context.Context, text string, extraArgs ...string) (*EmbeddingResponse, error) {
+	timeout := OptionDuration(r.config.Options, "inference_timeout_seconds", DefaultInferenceTimeout)
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 	
-	// Determine number of threads
-	if r.config.Options != nil {
-		if threads, ok := r.config.Options["threads"].(int); ok && threads > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", threads))
-		}
+	// Pooled workers run with the default arguments only
+	if r.pool != nil && len(extraArgs) == 0 {
+		return r.pool.Embed(runCtx, text)
 	}
 	
-	cmd := exec.Command(r.inferenceBinary, args...)
+	// Prepare command for embedding extraction
+	args := append([]string{"-m", r.modelPath, "-p", text}, r.embeddingArgs(extraArgs...)...)
+	
+	cmd := exec.CommandContext(runCtx, r.inferenceBinary, args...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = inferenceKillGrace
 	
 	// Capture output
 	var stdout, stderr strings.Builder
@@ -258,6 +346,9 @@ func (r *GGUFLocalReranker) getEmbedding(text string) ([]float64, error) {
 	
 	// Run command
 	if err := cmd.Run(); err != nil {
+		if ctxErr := runCtx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("%w: embedding command stopped (timeout %v): %w", ErrInference, timeout, ctxErr)
+		}
 		return nil, fmt.Errorf("embedding command failed: %v, stderr: %s", err, stderr.String())
 	}
 	
@@ -271,7 +362,60 @@ func (r *GGUFLocalReranker) getEmbedding(text string) ([]float64, error) {
 		return nil, fmt.Errorf("no embedding data returned")
 	}
 	
-	return response.Data[0].Embedding, nil
+	return &response, nil
+}
+
+// embeddingArgs returns the llama-embedding arguments shared by every run,
+// other than the model and prompt
+func (r *GGUFLocalReranker) embeddingArgs(extraArgs ...string) []string {
+	args := []string{
+		"--embd-output-format", "json",
+		"--embd-normalize", "2", // L2 normalization
+	}
+	args = append(args, extraArgs...)
+	
+	// Threads used by each llama-embedding process
+	if threads := OptionInt(r.config.Options, "threads", 0); threads > 0 {
+		args = append(args, "-t", fmt.Sprintf("%d", threads))
+	}
+	
+	// Offload to the GPU when one is configured or detected
+	return append(args, gpuArgs(resolveDevice(r.config.Device), r.config.Options)...)
+}
+
+// newSubprocessPool starts Options["pool_size"] (default runtime.NumCPU())
+// workers running Options["pool_command"], default the inference binary,
+// with the model and embedding arguments. Workers must serve the
+// SubprocessPool line protocol; stock llama-embedding handles one prompt per
+// process, so pool_command usually names a wrapper around it.
+func (r *GGUFLocalReranker) newSubprocessPool() (*SubprocessPool, error) {
+	command := OptionString(r.config.Options, "pool_command", r.inferenceBinary)
+	args := append([]string{"-m", r.modelPath}, r.embeddingArgs()...)
+	return NewSubprocessPool(OptionInt(r.config.Options, "pool_size", runtime.NumCPU()), func() *exec.Cmd {
+		return exec.Command(command, args...)
+	})
+}
+
+// isContextError reports whether err stems from a cancelled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
+// inferenceError wraps err in ErrInference unless it already is one
+func inferenceError(err error) error {
+	if errors.Is(err, ErrInference) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrInference, err)
+}
+
+// contextError returns ctx's error wrapped in ErrInference once ctx is done,
+// so calls with a cancelled or expired context return before any work
+func contextError(ctx context.Context) error {
+	if ctx == nil || ctx.Err() == nil {
+		return nil
+	}
+	return inferenceError(ctx.Err())
 }
 
 // cosineSimilarity computes cosine similarity between two vectors
@@ -296,12 +440,19 @@ func cosineSimilarity(a, b []float64) float64 {
 
 // Rerank reorders documents based on relevance to a query using GGUF model
 func (r *GGUFLocalReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
 	if len(documents) == 0 {
 		return documents, nil
 	}
 	
 	// Calculate scores using GGUF model
-	scores, err := r.ComputeScore(ctx, query, documents)
+	scores, err := r.computeScores(ctx, query, documents)
 	if err != nil {
 		return nil, err
 	}
@@ -312,14 +463,13 @@ func (r *GGUFLocalReranker) Rerank(ctx context.Context, query string, documents
 	}
 	
 	// Sort by score (descending)
-	sort.Slice(documents, func(i, j int) bool {
-		return documents[i].Score > documents[j].Score
-	})
+	sortDocuments(documents, r.config.tieBreak())
 	
 	// Apply threshold filter
+	threshold := r.config.scoreThreshold(scores)
 	var filtered []Document
 	for _, doc := range documents {
-		if doc.Score >= r.config.Threshold {
+		if doc.Score >= threshold {
 			filtered = append(filtered, doc)
 		}
 	}
@@ -334,33 +484,93 @@ func (r *GGUFLocalReranker) Rerank(ctx context.Context, query string, documents
 
 // ComputeScore computes scores for query-document pairs using GGUF reranker model
 func (r *GGUFLocalReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *GGUFLocalReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
 	if len(documents) == 0 {
 		return nil, nil
 	}
 	
-	// Compute relevance scores for each document
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	
+	// Fan out per-document inference across a bounded worker pool;
+	// each worker writes only its own slot so score order is preserved
 	scores := make([]float64, len(documents))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(r.workerCount())
 	for i, doc := range documents {
-		score, err := r.computeRerankerScore(query, doc.Content)
-		if err != nil {
-			// If scoring fails, assign a low score
-			scores[i] = -5.0
-			continue
-		}
-		scores[i] = score
+		i, content := i, doc.Content
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
+			score, err := r.computeRerankerScore(groupCtx, query, content)
+			if isContextError(err) {
+				return err
+			}
+			if err != nil {
+				// If scoring fails, assign a low score
+				scores[i] = -5.0
+				return nil
+			}
+			scores[i] = score
+			return nil
+		})
 	}
 	
-	return scores, nil
+	if err := group.Wait(); err != nil {
+		return nil, inferenceError(err)
+	}
+	
+	return r.config.transformScores(scores)
+}
+
+// workerCount returns the number of concurrent inference subprocesses:
+// Options["workers"], by default the number of CPUs divided by the
+// Options["threads"] each subprocess runs, so the CPU is not oversubscribed
+func (r *GGUFLocalReranker) workerCount() int {
+	if workers := OptionInt(r.config.Options, "workers", 0); workers > 0 {
+		return workers
+	}
+	workers := runtime.NumCPU()
+	if threads := OptionInt(r.config.Options, "threads", 0); threads > 0 {
+		workers /= threads
+	}
+	if workers < 1 {
+		return 1
+	}
+	return workers
 }
 
 // Rank returns top-N ranked documents using GGUF model
 func (r *GGUFLocalReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.rank(ctx, query, documents, topN)
+}
+
+// rank is Rank without input validation
+func (r *GGUFLocalReranker) rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
 	if len(documents) == 0 {
 		return nil, nil
 	}
 	
 	// Calculate scores for all documents
-	scores, err := r.ComputeScore(ctx, query, documents)
+	scores, err := r.computeScores(ctx, query, documents)
 	if err != nil {
 		return nil, err
 	}
@@ -376,14 +586,13 @@ func (r *GGUFLocalReranker) Rank(ctx context.Context, query string, documents []
 	}
 	
 	// Sort by score (descending)
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+	sortResults(results, r.config.tieBreak())
 	
 	// Apply threshold filter
+	threshold := r.config.scoreThreshold(scores)
 	var filtered []RerankResult
 	for _, result := range results {
-		if result.Score >= r.config.Threshold {
+		if result.Score >= threshold {
 			filtered = append(filtered, result)
 		}
 	}
@@ -393,16 +602,109 @@ func (r *GGUFLocalReranker) Rank(ctx context.Context, query string, documents []
 		filtered = filtered[:topN]
 	}
 	
+	// Record output positions
+	assignRanks(filtered, r.config.NormalizeScores)
+	if err := r.explainResults(ctx, query, filtered); err != nil {
+		return nil, err
+	}
+
 	return filtered, nil
 }
 
+// RankIDs returns the IDs and scores of the top-N documents in Rank's order,
+// without copying documents into results. Explanations are not computed.
+func (r *GGUFLocalReranker) RankIDs(ctx context.Context, query string, documents []Document, topN int) ([]string, []float64, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids, sorted := rankIDsByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak())
+	return ids, sorted, nil
+}
+
+// RerankWithMetaFilter ranks only the documents whose metadata matches
+// filter, so the others never reach the model. When
+// Options["include_filtered"] is true, they follow the ranked documents in
+// input order with a score of -Inf; otherwise they are omitted. Index is the
+// input position and topN applies to the combined list.
+func (r *GGUFLocalReranker) RerankWithMetaFilter(ctx context.Context, query string, documents []Document, filter FilterSpec, topN int) ([]RerankResult, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateFilters([]FilterSpec{filter}); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	var kept []int
+	var candidates []Document
+	var excluded []RerankResult
+	for i, doc := range documents {
+		if filter.Matches(doc) {
+			kept = append(kept, i)
+			candidates = append(candidates, doc)
+		} else {
+			excluded = append(excluded, RerankResult{Document: doc, Score: math.Inf(-1), Index: i})
+		}
+	}
+
+	var results []RerankResult
+	if len(candidates) > 0 {
+		ranked, err := r.rank(ctx, query, candidates, topN)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range ranked {
+			result.Index = kept[result.Index]
+			results = append(results, result)
+		}
+	}
+	if OptionBool(r.config.Options, "include_filtered", false) {
+		results = append(results, excluded...)
+	}
+
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+	// Renumber without touching RelativeScore, which -Inf scores would spoil
+	return assignRanks(results, NormalizationNone), nil
+}
+
 // GetModelName returns the model name
 func (r *GGUFLocalReranker) GetModelName() string {
 	return r.config.Model
 }
 
+// Version returns "{model_filename}@{sha256_first8_chars}", computed from the
+// model file in NewGGUFLocalReranker
+func (r *GGUFLocalReranker) Version() string {
+	return r.version
+}
+
 // Configure updates the reranker configuration
 func (r *GGUFLocalReranker) Configure(config Config) error {
+	maxTokens, truncate, err := truncationFromOptions(config.Options)
+	if err != nil {
+		return err
+	}
+	if err := validateThreshold(config); err != nil {
+		return err
+	}
+	r.maxTokens = maxTokens
+	r.truncate = truncate
 	r.config = config
 	if r.config.MaxDocs == 0 {
 		r.config.MaxDocs = 100
@@ -410,9 +712,15 @@ func (r *GGUFLocalReranker) Configure(config Config) error {
 	return nil
 }
 
-// Close cleans up resources (clears cache)
+// Close cleans up resources (clears cache and stops pooled workers)
 func (r *GGUFLocalReranker) Close() {
-	r.cacheMutex.Lock()
-	r.scoreCache = make(map[string]float64)
-	r.cacheMutex.Unlock()
+	r.scoreCache.Clear()
+	if r.pool != nil {
+		r.pool.Close()
+	}
+}
+
+// CacheLen returns the number of cached query-document scores
+func (r *GGUFLocalReranker) CacheLen() int {
+	return r.scoreCache.Len()
 }
@@ -1,7 +1,18 @@
 package reranker
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 )
 
 func TestGGUFLocalReranker_Initialization(t *testing.T) {
@@ -56,3 +67,268 @@ func TestGGUFLocalReranker_InvalidModel(t *testing.T) {
 func TestGGUFLocalReranker_Basic_Skip(t *testing.T) {
 	t.Skip("Skipping embedding test - llama-embedding binary has issues in test environment")
 }
+
+// newFakeGGUFReranker builds a reranker around a stub llama-embedding script
+// that sleeps briefly and returns a fixed embedding.
+func newFakeGGUFReranker(tb testing.TB, workers int) *GGUFLocalReranker {
+	tb.Helper()
+	
+	dir := tb.TempDir()
+	binary := filepath.Join(dir, "llama-embedding")
+	script := "#!/bin/sh\nsleep 0.01\necho '{\"object\":\"list\",\"data\":[{\"object\":\"embedding\",\"index\":0,\"embedding\":[0.6,0.8]}]}'\n"
+	writeStubBinary(tb, binary, script)
+	
+	return &GGUFLocalReranker{
+		config: Config{
+			Model:   "stub.gguf",
+			MaxDocs: 100,
+			Options: map[string]interface{}{"workers": workers},
+		},
+		modelPath:       filepath.Join(dir, "stub.gguf"),
+		inferenceBinary: binary,
+		scoreCache:      NewScoreCache(DefaultCacheSize, 0),
+	}
+}
+
+func TestGGUFLocalReranker_ComputeScoreConcurrent(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 4)
+	documents := make([]Document, 12)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("document %d", i)}
+	}
+	
+	scores, err := reranker.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if len(scores) != len(documents) {
+		t.Fatalf("Expected %d scores, got %d", len(documents), len(scores))
+	}
+	for i, score := range scores {
+		if math.Abs(score-1.0) > 1e-9 {
+			t.Errorf("Expected score 1.0 for document %d, got %f", i, score)
+		}
+	}
+}
+
+func TestGGUFLocalReranker_ThreadsAndWorkers(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 3)
+	reranker.config.Options["threads"] = 8.0 // as decoded from JSON or YAML
+	
+	args := strings.Join(reranker.embeddingArgs(), " ")
+	if !strings.Contains(args, "-t 8") {
+		t.Errorf("Expected -t 8 in llama-embedding arguments, got %q", args)
+	}
+	if workers := reranker.workerCount(); workers != 3 {
+		t.Errorf("Expected the workers option to set 3 workers, got %d", workers)
+	}
+	
+	delete(reranker.config.Options, "workers")
+	want := runtime.NumCPU() / 8
+	if want < 1 {
+		want = 1
+	}
+	if workers := reranker.workerCount(); workers != want {
+		t.Errorf("Expected %d workers of 8 threads on %d CPUs, got %d", want, runtime.NumCPU(), workers)
+	}
+}
+
+func TestGGUFLocalReranker_RankPositions(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 2)
+	documents := []Document{{ID: "a", Content: "first"}, {ID: "b", Content: "second"}}
+	
+	results, err := reranker.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	for i, result := range results {
+		if result.Rank != i+1 {
+			t.Errorf("Expected rank %d, got %d", i+1, result.Rank)
+		}
+		if want := 1 - float64(i)/2; result.NormalizedRank != want {
+			t.Errorf("Expected normalized rank %v, got %v", want, result.NormalizedRank)
+		}
+	}
+}
+
+// newHangingGGUFReranker returns a reranker whose inference binary records its
+// PID and then runs body forever, with a short inference timeout
+func newHangingGGUFReranker(t *testing.T, body string) (*GGUFLocalReranker, string) {
+	t.Helper()
+
+	reranker := newFakeGGUFReranker(t, 1)
+	reranker.config.Options["inference_timeout_seconds"] = 0.2
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	script := fmt.Sprintf("#!/bin/sh\necho $$ > %q\n%s\n", pidFile, body)
+	writeStubBinary(t, reranker.inferenceBinary, script)
+	return reranker, pidFile
+}
+
+// assertProcessGone fails unless the PID recorded in pidFile no longer exists
+func assertProcessGone(t *testing.T, pidFile string) {
+	t.Helper()
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("Failed to read PID: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("Invalid PID %q: %v", data, err)
+	}
+	process, _ := os.FindProcess(pid)
+	if err := process.Signal(syscall.Signal(0)); err == nil {
+		t.Errorf("Expected subprocess %d to be gone", pid)
+	}
+}
+
+func TestGGUFLocalReranker_InferenceTimeout(t *testing.T) {
+	reranker, pidFile := newHangingGGUFReranker(t, "exec sleep 30")
+	start := time.Now()
+	_, err := reranker.ComputeScore(context.Background(), "query", []Document{{Content: "document"}})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrInference) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected ErrInference wrapping DeadlineExceeded, got %v", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected SIGTERM to stop the subprocess promptly, took %v", elapsed)
+	}
+	assertProcessGone(t, pidFile)
+}
+
+func TestGGUFLocalReranker_InferenceTimeoutKillsIgnoredTerm(t *testing.T) {
+	reranker, pidFile := newHangingGGUFReranker(t, "trap '' TERM\nwhile true; do sleep 0.1; done")
+	start := time.Now()
+	_, err := reranker.computeRerankerScore(context.Background(), "query", "document")
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed < inferenceKillGrace || elapsed > inferenceKillGrace+2*time.Second {
+		t.Errorf("Expected SIGKILL after the %v grace period, took %v", inferenceKillGrace, elapsed)
+	}
+	assertProcessGone(t, pidFile)
+}
+
+func TestGGUFLocalReranker_InferenceCancelled(t *testing.T) {
+	reranker, pidFile := newHangingGGUFReranker(t, "exec sleep 30")
+	reranker.config.Options["inference_timeout_seconds"] = 30
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := reranker.ComputeScore(ctx, "query", []Document{{Content: "document"}})
+	if !errors.Is(err, ErrInference) || !isContextError(err) {
+		t.Fatalf("Expected ErrInference wrapping the context error, got %v", err)
+	}
+	assertProcessGone(t, pidFile)
+}
+
+func BenchmarkComputeScore(b *testing.B) {
+	documents := make([]Document, 24)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("document %d", i)}
+	}
+	
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			reranker := newFakeGGUFReranker(b, workers)
+			ctx := context.Background()
+			for i := 0; i < b.N; i++ {
+				// Clear the cache so every iteration runs real subprocess calls
+				reranker.Close()
+				if _, err := reranker.ComputeScore(ctx, "query", documents); err != nil {
+					b.Fatalf("ComputeScore failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestGGUFLocalReranker_RankIDsMatchesRank(t *testing.T) {
+	// Texts mentioning "learning" embed to one axis, everything else to the other
+	reranker := newFakeGGUFReranker(t, 2)
+	reranker.config.StableSort = true
+	writeStubBinary(t, reranker.inferenceBinary, keywordStubScript("learning"))
+
+	documents := []Document{
+		{ID: "cooking", Content: "cooking pasta"},
+		{ID: "ml", Content: "machine learning"},
+		{ID: "weather", Content: "sunny weather"},
+		{ID: "dl", Content: "deep learning"},
+	}
+	results, err := reranker.Rank(context.Background(), "learning", documents, 3)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	ids, scores, err := reranker.RankIDs(context.Background(), "learning", documents, 3)
+	if err != nil {
+		t.Fatalf("RankIDs failed: %v", err)
+	}
+
+	want := resultIDs(results)
+	if strings.Join(ids, ",") != strings.Join(want, ",") || want[0] != "ml" || want[1] != "dl" {
+		t.Errorf("Expected IDs %v, got %v", want, ids)
+	}
+	for i, result := range results {
+		if scores[i] != result.Score {
+			t.Errorf("Expected score %v at %d, got %v", result.Score, i, scores[i])
+		}
+	}
+}
+
+func TestGGUFLocalReranker_RerankWithMetaFilter(t *testing.T) {
+	// Texts mentioning "learning" embed to one axis, everything else to the other
+	reranker := newFakeGGUFReranker(t, 2)
+	writeStubBinary(t, reranker.inferenceBinary, keywordStubScript("learning"))
+
+	documents := []Document{
+		{ID: "fr-ml", Content: "apprentissage automatique et learning", Meta: map[string]interface{}{"lang": "fr"}},
+		{ID: "en-cooking", Content: "cooking pasta", Meta: map[string]interface{}{"lang": "en"}},
+		{ID: "untagged", Content: "deep learning"},
+		{ID: "en-ml", Content: "machine learning", Meta: map[string]interface{}{"lang": "en"}},
+	}
+	filter := FilterSpec{Field: "lang", Op: FilterEq, Value: "en"}
+
+	results, err := reranker.RerankWithMetaFilter(context.Background(), "learning", documents, filter, 0)
+	if err != nil {
+		t.Fatalf("RerankWithMetaFilter failed: %v", err)
+	}
+	if ids := resultIDs(results); strings.Join(ids, ",") != "en-ml,en-cooking" {
+		t.Errorf("Expected only the English documents, got %v", ids)
+	}
+	if results[0].Index != 3 || results[1].Index != 1 || results[1].Rank != 2 {
+		t.Errorf("Expected input positions and ranks to be kept, got %+v", results)
+	}
+	if reranker.CacheLen() != 2 {
+		t.Errorf("Expected only the 2 matching documents to be scored, got %d cache entries", reranker.CacheLen())
+	}
+
+	reranker.config.Options["include_filtered"] = true
+	results, err = reranker.RerankWithMetaFilter(context.Background(), "learning", documents, filter, 3)
+	if err != nil {
+		t.Fatalf("RerankWithMetaFilter failed: %v", err)
+	}
+	if ids := resultIDs(results); strings.Join(ids, ",") != "en-ml,en-cooking,fr-ml" {
+		t.Errorf("Expected filtered documents after the ranked ones, cut to top 3, got %v", ids)
+	}
+	if last := results[2]; !math.IsInf(last.Score, -1) || last.Index != 0 || last.Rank != 3 {
+		t.Errorf("Expected the filtered document to score -Inf at rank 3, got %+v", last)
+	}
+	if reranker.CacheLen() != 2 {
+		t.Errorf("Expected filtered documents never to be scored, got %d cache entries", reranker.CacheLen())
+	}
+
+	if _, err := reranker.RerankWithMetaFilter(context.Background(), "learning", documents, FilterSpec{Field: "lang", Op: "like"}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for an unknown operator, got %v", err)
+	}
+
+	// A cancelled context fails even when no document matches the filter
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	none := FilterSpec{Field: "lang", Op: FilterEq, Value: "de"}
+	if _, err := reranker.RerankWithMetaFilter(cancelled, "learning", documents, none, 0); !errors.Is(err, ErrInference) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected ErrInference wrapping context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package reranker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ggufMagic opens every GGUF file
+const ggufMagic = "GGUF"
+
+// maxGGUFStringBytes bounds metadata strings so a corrupt length cannot
+// trigger a huge allocation
+const maxGGUFStringBytes = 1 << 24
+
+// GGUF metadata value types
+const (
+	ggufTypeUint8 uint32 = iota
+	ggufTypeInt8
+	ggufTypeUint16
+	ggufTypeInt16
+	ggufTypeUint32
+	ggufTypeInt32
+	ggufTypeFloat32
+	ggufTypeBool
+	ggufTypeString
+	ggufTypeArray
+	ggufTypeUint64
+	ggufTypeInt64
+	ggufTypeFloat64
+)
+
+// ggufFileTypes names the values of general.file_type, the predominant
+// quantization of a model's tensors
+var ggufFileTypes = map[uint64]string{
+	0: "F32", 1: "F16", 2: "Q4_0", 3: "Q4_1", 7: "Q8_0", 8: "Q5_0", 9: "Q5_1",
+	10: "Q2_K", 11: "Q3_K_S", 12: "Q3_K_M", 13: "Q3_K_L", 14: "Q4_K_S", 15: "Q4_K_M",
+	16: "Q5_K_S", 17: "Q5_K_M", 18: "Q6_K", 19: "IQ2_XXS", 20: "IQ2_XS", 21: "Q2_K_S",
+	22: "IQ3_XS", 23: "IQ3_XXS", 24: "IQ1_S", 25: "IQ4_NL", 26: "IQ3_S", 27: "IQ3_M",
+	28: "IQ2_S", 29: "IQ2_M", 30: "IQ4_XS", 31: "IQ1_M", 32: "BF16",
+}
+
+// GGUFMetadata describes a GGUF model from its file header
+type GGUFMetadata struct {
+	// Version is the GGUF format version
+	Version uint32 `json:"version"`
+	// Architecture is general.architecture, e.g. "bert" or "qwen3"
+	Architecture string `json:"architecture"`
+	// ContextLength is {architecture}.context_length, the maximum tokens per input
+	ContextLength uint64 `json:"context_length"`
+	// QuantizationType names general.file_type, e.g. "Q4_K_M"
+	QuantizationType string `json:"quantization_type"`
+	// EmbeddingDim is {architecture}.embedding_length
+	EmbeddingDim uint64 `json:"embedding_dim"`
+}
+
+// ReadGGUFMetadata reads the metadata of the GGUF file at path from its
+// header, without loading any tensors. Only format versions 2 and 3 are
+// supported.
+func ReadGGUFMetadata(path string) (*GGUFMetadata, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open GGUF file: %v", ErrInvalidInput, err)
+	}
+	defer file.Close()
+
+	metadata, err := readGGUFMetadata(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidInput, path, err)
+	}
+	return metadata, nil
+}
+
+// readGGUFMetadata decodes the header and key-value pairs of a GGUF stream
+func readGGUFMetadata(r io.Reader) (*GGUFMetadata, error) {
+	magic := make([]byte, len(ggufMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != ggufMagic {
+		return nil, fmt.Errorf("not a GGUF file")
+	}
+
+	var header struct {
+		Version     uint32
+		TensorCount uint64
+		KVCount     uint64
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header.Version); err != nil {
+		return nil, fmt.Errorf("failed to read GGUF version: %v", err)
+	}
+	if header.Version < 2 || header.Version > 3 {
+		return nil, fmt.Errorf("unsupported GGUF version %d", header.Version)
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header.TensorCount); err != nil {
+		return nil, fmt.Errorf("failed to read GGUF header: %v", err)
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header.KVCount); err != nil {
+		return nil, fmt.Errorf("failed to read GGUF header: %v", err)
+	}
+
+	values := make(map[string]interface{})
+	for i := uint64(0); i < header.KVCount; i++ {
+		key, err := readGGUFString(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata key %d: %v", i, err)
+		}
+		var valueType uint32
+		if err := binary.Read(r, binary.LittleEndian, &valueType); err != nil {
+			return nil, fmt.Errorf("failed to read type of %s: %v", key, err)
+		}
+		value, err := readGGUFValue(r, valueType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", key, err)
+		}
+		if value != nil {
+			values[key] = value
+		}
+	}
+
+	metadata := &GGUFMetadata{Version: header.Version}
+	metadata.Architecture, _ = values["general.architecture"].(string)
+	metadata.ContextLength, _ = ggufUint(values[metadata.Architecture+".context_length"])
+	metadata.EmbeddingDim, _ = ggufUint(values[metadata.Architecture+".embedding_length"])
+	if fileType, ok := ggufUint(values["general.file_type"]); ok {
+		metadata.QuantizationType = ggufFileTypes[fileType]
+		if metadata.QuantizationType == "" {
+			metadata.QuantizationType = fmt.Sprintf("type %d", fileType)
+		}
+	}
+	return metadata, nil
+}
+
+// readGGUFString reads a length-prefixed string
+func readGGUFString(r io.Reader) (string, error) {
+	var length uint64
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return "", err
+	}
+	if length > maxGGUFStringBytes {
+		return "", fmt.Errorf("string of %d bytes exceeds the %d byte limit", length, maxGGUFStringBytes)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// readGGUFValue reads a value of valueType. Arrays, such as tokenizer
+// vocabularies, are skipped and returned as nil.
+func readGGUFValue(r io.Reader, valueType uint32) (interface{}, error) {
+	switch valueType {
+	case ggufTypeUint8, ggufTypeInt8, ggufTypeBool:
+		var value uint8
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return uint64(value), err
+	case ggufTypeUint16, ggufTypeInt16:
+		var value uint16
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return uint64(value), err
+	case ggufTypeUint32:
+		var value uint32
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return uint64(value), err
+	case ggufTypeInt32:
+		var value int32
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return int64(value), err
+	case ggufTypeFloat32:
+		var value float32
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return float64(value), err
+	case ggufTypeUint64:
+		var value uint64
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return value, err
+	case ggufTypeInt64:
+		var value int64
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return value, err
+	case ggufTypeFloat64:
+		var value float64
+		err := binary.Read(r, binary.LittleEndian, &value)
+		return value, err
+	case ggufTypeString:
+		return readGGUFString(r)
+	case ggufTypeArray:
+		var elementType uint32
+		var count uint64
+		if err := binary.Read(r, binary.LittleEndian, &elementType); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < count; i++ {
+			if _, err := readGGUFValue(r, elementType); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown value type %d", valueType)
+}
+
+// ggufUint reads an unsigned metadata value; signed values must not be negative
+func ggufUint(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case uint64:
+		return v, true
+	case int64:
+		if v >= 0 {
+			return uint64(v), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,79 @@
+package reranker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ggufWriter builds a minimal GGUF header in memory
+type ggufWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *ggufWriter) write(values ...interface{}) {
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			binary.Write(&w.buf, binary.LittleEndian, uint64(len(s)))
+			w.buf.WriteString(s)
+			continue
+		}
+		binary.Write(&w.buf, binary.LittleEndian, value)
+	}
+}
+
+// syntheticGGUF returns a version 3 header of a BERT reranker quantized to
+// Q4_K_M, with a tokenizer array and a float to skip
+func syntheticGGUF() []byte {
+	w := &ggufWriter{}
+	w.buf.WriteString("GGUF")
+	w.write(uint32(3), uint64(0), uint64(6))
+	w.write("general.architecture", ggufTypeString, "bert")
+	w.write("tokenizer.ggml.tokens", ggufTypeArray, ggufTypeString, uint64(3), "[CLS]", "hello", "[SEP]")
+	w.write("bert.attention.layer_norm_epsilon", ggufTypeFloat32, float32(1e-12))
+	w.write("bert.context_length", ggufTypeUint32, uint32(512))
+	w.write("bert.embedding_length", ggufTypeUint32, uint32(384))
+	w.write("general.file_type", ggufTypeUint32, uint32(15))
+	return w.buf.Bytes()
+}
+
+func TestReadGGUFMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gguf")
+	if err := os.WriteFile(path, syntheticGGUF(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := ReadGGUFMetadata(path)
+	if err != nil {
+		t.Fatalf("ReadGGUFMetadata failed: %v", err)
+	}
+	want := GGUFMetadata{Version: 3, Architecture: "bert", ContextLength: 512, QuantizationType: "Q4_K_M", EmbeddingDim: 384}
+	if *metadata != want {
+		t.Errorf("Expected %+v, got %+v", want, *metadata)
+	}
+}
+
+func TestReadGGUFMetadata_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	valid := syntheticGGUF()
+	oldVersion := append([]byte(nil), valid...)
+	binary.LittleEndian.PutUint32(oldVersion[4:], 1)
+
+	for name, data := range map[string][]byte{
+		"not gguf":  []byte("PK\x03\x04 not a model"),
+		"version 1": oldVersion,
+		"truncated": valid[:len(valid)-3],
+	} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, data, 0o644)
+		if _, err := ReadGGUFMetadata(path); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+	if _, err := ReadGGUFMetadata(filepath.Join(dir, "missing.gguf")); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for a missing file, got %v", err)
+	}
+}
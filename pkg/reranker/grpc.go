@@ -0,0 +1,229 @@
+package reranker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+
+	rerankpb "go-rerankers/pkg/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GRPCModelPrefix marks model names served by a remote gRPC inference service
+const GRPCModelPrefix = "grpc/"
+
+// GRPCReranker implements reranking against a gRPC service speaking the
+// rerank.v1.Reranker protocol defined in pkg/proto/rerank.proto.
+// A single ClientConn is created at initialization and reused for every call.
+//
+// Recognized options:
+//   - "grpc_address": host:port of the service (required)
+//   - "grpc_tls": enable TLS (default false)
+//   - "grpc_tls_ca_file": PEM CA bundle used to verify the server
+//   - "grpc_tls_server_name": override the server name used for verification
+//   - "grpc_tls_insecure_skip_verify": disable certificate verification
+//   - "timeout_seconds": per-call deadline (default 30)
+type GRPCReranker struct {
+	config   Config
+	conn     *grpc.ClientConn
+	client   rerankpb.RerankerClient
+	mutex    sync.RWMutex
+	closed   bool
+	inflight sync.WaitGroup
+}
+
+// NewGRPCReranker creates a new reranker and its persistent gRPC connection
+func NewGRPCReranker(config Config) (*GRPCReranker, error) {
+	address := OptionString(config.Options, "grpc_address", "")
+	if address == "" {
+		return nil, fmt.Errorf("%w: grpc_address option is required for gRPC reranker", ErrInvalidInput)
+	}
+
+	if config.MaxDocs == 0 {
+		config.MaxDocs = 100
+	}
+
+	creds, err := grpcTransportCredentials(config.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create gRPC connection to %s: %v", ErrInitialization, address, err)
+	}
+
+	return &GRPCReranker{
+		config: config,
+		conn:   conn,
+		client: rerankpb.NewRerankerClient(conn),
+	}, nil
+}
+
+// grpcTransportCredentials builds TLS or plaintext credentials from options
+func grpcTransportCredentials(opts map[string]interface{}) (credentials.TransportCredentials, error) {
+	if !OptionBool(opts, "grpc_tls", false) {
+		return insecure.NewCredentials(), nil
+	}
+
+	serverName := OptionString(opts, "grpc_tls_server_name", "")
+	if caFile := OptionString(opts, "grpc_tls_ca_file", ""); caFile != "" {
+		creds, err := credentials.NewClientTLSFromFile(caFile, serverName)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to load TLS CA file: %v", ErrInitialization, err)
+		}
+		return creds, nil
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: OptionBool(opts, "grpc_tls_insecure_skip_verify", false),
+	}), nil
+}
+
+// Rerank reorders documents based on scores returned by the gRPC service
+func (r *GRPCReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore requests scores for query-document pairs from the gRPC service
+func (r *GRPCReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *GRPCReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Register the call so Close can wait for it to finish
+	r.mutex.RLock()
+	if r.closed {
+		r.mutex.RUnlock()
+		return nil, fmt.Errorf("%w: gRPC reranker is closed", ErrInference)
+	}
+	r.inflight.Add(1)
+	r.mutex.RUnlock()
+	defer r.inflight.Done()
+
+	ctx, cancel := context.WithTimeout(ctx, OptionDuration(r.config.Options, "timeout_seconds", defaultHTTPTimeout))
+	defer cancel()
+
+	request := &rerankpb.RerankRequest{
+		Model:     strings.TrimPrefix(r.config.Model, GRPCModelPrefix),
+		Query:     query,
+		Documents: make([]string, len(documents)),
+	}
+	for i, doc := range documents {
+		request.Documents[i] = doc.Content
+	}
+
+	response, err := r.client.Rerank(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: gRPC rerank failed: %v", ErrInference, err)
+	}
+	if len(response.GetScores()) != len(documents) {
+		return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(documents), len(response.GetScores()))
+	}
+
+	return r.config.transformScores(response.GetScores())
+}
+
+// Rank returns top-N ranked documents using scores from the gRPC service
+func (r *GRPCReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the model name
+func (r *GRPCReranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"; the service does not report
+// the version of the model it serves
+func (r *GRPCReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck connects if the connection is idle and fails unless it
+// becomes ready before ctx ends
+func (r *GRPCReranker) HealthCheck(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	r.conn.Connect()
+	for {
+		state := r.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("%w: gRPC connection is %s", ErrInference, state)
+		}
+		if !r.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("%w: gRPC connection still %s: %v", ErrInference, state, ctx.Err())
+		}
+	}
+}
+
+// Configure updates the reranker configuration; the connection is kept as is
+func (r *GRPCReranker) Configure(config Config) error {
+	r.config = config
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	return nil
+}
+
+// Close rejects new calls, waits for in-flight calls to drain and closes the connection
+func (r *GRPCReranker) Close() error {
+	r.mutex.Lock()
+	if r.closed {
+		r.mutex.Unlock()
+		return nil
+	}
+	r.closed = true
+	r.mutex.Unlock()
+
+	r.inflight.Wait()
+	return r.conn.Close()
+}
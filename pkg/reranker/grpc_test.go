@@ -0,0 +1,123 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	rerankpb "go-rerankers/pkg/proto"
+
+	"google.golang.org/grpc"
+)
+
+// keywordRerankServer scores documents by whether they contain the query
+type keywordRerankServer struct {
+	rerankpb.UnimplementedRerankerServer
+}
+
+func (s *keywordRerankServer) Rerank(ctx context.Context, req *rerankpb.RerankRequest) (*rerankpb.RerankResponse, error) {
+	scores := make([]float64, len(req.GetDocuments()))
+	for i, doc := range req.GetDocuments() {
+		if strings.Contains(strings.ToLower(doc), strings.ToLower(req.GetQuery())) {
+			scores[i] = 1.0
+		}
+	}
+	return &rerankpb.RerankResponse{Scores: scores}, nil
+}
+
+func startTestGRPCServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	server := grpc.NewServer()
+	rerankpb.RegisterRerankerServer(server, &keywordRerankServer{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestGRPCReranker_Rank(t *testing.T) {
+	address := startTestGRPCServer(t)
+
+	r, err := NewReranker(Config{
+		Model:   "grpc/test-model",
+		Options: map[string]interface{}{"grpc_address": address},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	grpcReranker, ok := r.(*GRPCReranker)
+	if !ok {
+		t.Fatalf("Expected *GRPCReranker, got %T", r)
+	}
+	defer grpcReranker.Close()
+
+	documents := []Document{
+		{ID: "1", Content: "Cooking pasta"},
+		{ID: "2", Content: "Berlin museums"},
+		{ID: "3", Content: "Museums in Paris"},
+	}
+
+	// Reuse the same connection across calls
+	for i := 0; i < 3; i++ {
+		results, err := r.Rank(context.Background(), "museums", documents, 2)
+		if err != nil {
+			t.Fatalf("Rank failed: %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(results))
+		}
+		for _, result := range results {
+			if result.Score != 1.0 {
+				t.Errorf("Expected museum documents first, got %+v", result)
+			}
+		}
+	}
+
+	if err := grpcReranker.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if _, err := r.ComputeScore(context.Background(), "museums", documents); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference after Close, got %v", err)
+	}
+}
+
+func TestGRPCReranker_MissingAddress(t *testing.T) {
+	if _, err := NewGRPCReranker(Config{Model: "grpc/test"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestGRPCReranker_HealthCheck(t *testing.T) {
+	r, err := NewGRPCReranker(Config{Model: "grpc/test", Options: map[string]interface{}{"grpc_address": startTestGRPCServer(t)}})
+	if err != nil {
+		t.Fatalf("NewGRPCReranker failed: %v", err)
+	}
+	defer r.Close()
+	if err := r.HealthCheck(context.Background()); err != nil {
+		t.Errorf("Expected a healthy connection, got %v", err)
+	}
+
+	// Nothing listens on a closed listener's address
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	listener.Close()
+	down, err := NewGRPCReranker(Config{Model: "grpc/down", Options: map[string]interface{}{"grpc_address": listener.Addr().String()}})
+	if err != nil {
+		t.Fatalf("NewGRPCReranker failed: %v", err)
+	}
+	defer down.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := down.HealthCheck(ctx); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for an unreachable service, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package reranker
+
+import "hash/fnv"
+
+// tieBreak selects how documents with equal scores are ordered
+type tieBreak int
+
+const (
+	// tieUnordered uses the faster unstable sort; tie order is unspecified
+	tieUnordered tieBreak = iota
+	// tieInputOrder keeps ties in input order
+	tieInputOrder
+	// tieContentHash orders ties by HashDocument, then input order
+	tieContentHash
+)
+
+// tieBreak returns the tie ordering selected by TiebreakerHash and StableSort
+func (c Config) tieBreak() tieBreak {
+	switch {
+	case c.TiebreakerHash:
+		return tieContentHash
+	case c.StableSort:
+		return tieInputOrder
+	}
+	return tieUnordered
+}
+
+// HashDocument returns the FNV-64a hash of doc.Content. It depends only on
+// the content, so it is the same across processes and input orders.
+func HashDocument(doc Document) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(doc.Content))
+	return h.Sum64()
+}
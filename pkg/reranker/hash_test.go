@@ -0,0 +1,111 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// tiebreakOutputEnv makes the test binary write one tie-broken ranking to the
+// named file and exit, for TestTiebreakerHash_AcrossProcesses
+const tiebreakOutputEnv = "GO_RERANKERS_TIEBREAK_OUTPUT"
+
+// tiedDocuments returns 40 documents in two groups of tied scores for the
+// query "machine learning", shuffled with seed
+func tiedDocuments(seed int64) []Document {
+	documents := make([]Document, 40)
+	for i := range documents {
+		content := fmt.Sprintf("machine learning note %d", i)
+		if i%2 == 1 {
+			content = fmt.Sprintf("cooking note %d", i)
+		}
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: content}
+	}
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(documents), func(i, j int) { documents[i], documents[j] = documents[j], documents[i] })
+	return documents
+}
+
+// tiebreakOrder ranks tiedDocuments(seed) with content-hash tie-breaking and
+// returns the ranked IDs
+func tiebreakOrder(t *testing.T, seed int64) []string {
+	t.Helper()
+	r := NewSimpleReranker(Config{Model: "simple", TiebreakerHash: true})
+	results, err := r.Rank(context.Background(), "machine learning", tiedDocuments(seed), 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.Document.ID
+	}
+	return ids
+}
+
+func TestHashDocument(t *testing.T) {
+	// FNV-64a offset basis
+	if got := HashDocument(Document{}); got != 0xcbf29ce484222325 {
+		t.Errorf("Expected the FNV-64a offset basis for empty content, got %#x", got)
+	}
+	a := HashDocument(Document{ID: "1", Content: "machine learning", Score: 1})
+	if b := HashDocument(Document{ID: "2", Content: "machine learning"}); a != b {
+		t.Errorf("Expected the hash to depend only on content, got %#x and %#x", a, b)
+	}
+	if c := HashDocument(Document{Content: "machine learnings"}); a == c {
+		t.Error("Expected different content to hash differently")
+	}
+}
+
+func TestTiebreakerHash_IndependentOfInputOrder(t *testing.T) {
+	want := tiebreakOrder(t, 1)
+	for seed := int64(2); seed < 6; seed++ {
+		if got := tiebreakOrder(t, seed); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("Seed %d: expected %v, got %v", seed, want, got)
+		}
+	}
+
+	r := NewSimpleReranker(Config{Model: "simple", TiebreakerHash: true})
+	reranked, err := r.Rerank(context.Background(), "machine learning", tiedDocuments(7))
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	for i, doc := range reranked {
+		if doc.ID != want[i] {
+			t.Fatalf("Rerank position %d: expected %s, got %s", i, want[i], doc.ID)
+		}
+	}
+}
+
+func TestTiebreakerHash_AcrossProcesses(t *testing.T) {
+	if path := os.Getenv(tiebreakOutputEnv); path != "" {
+		seed, _ := strconv.ParseInt(os.Getenv(tiebreakOutputEnv+"_SEED"), 10, 64)
+		if err := os.WriteFile(path, []byte(strings.Join(tiebreakOrder(t, seed), "\n")), 0o644); err != nil {
+			t.Fatalf("Failed to write ranking: %v", err)
+		}
+		return
+	}
+
+	want := strings.Join(tiebreakOrder(t, 1), "\n")
+	for _, seed := range []int64{11, 12} {
+		path := filepath.Join(t.TempDir(), "ranking.txt")
+		cmd := exec.Command(os.Args[0], "-test.run=^TestTiebreakerHash_AcrossProcesses$")
+		cmd.Env = append(os.Environ(), tiebreakOutputEnv+"="+path, tiebreakOutputEnv+"_SEED="+strconv.FormatInt(seed, 10))
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("Subprocess failed: %v\n%s", err, output)
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read ranking: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("Seed %d: ranking differs across processes:\n%s\nwant:\n%s", seed, got, want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// Synthetic two-token pair scored by HealthCheck
+const (
+	healthCheckQuery    = "health"
+	healthCheckDocument = "check"
+)
+
+// HealthCheck scores a synthetic two-token pair, bypassing the score cache,
+// and fails unless a finite score is returned within the inference timeout
+// (Options["inference_timeout_seconds"])
+func (r *GGUFLocalReranker) HealthCheck(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	score, err := r.tryRerankerInference(ctx, healthCheckQuery, healthCheckDocument)
+	if err != nil {
+		return inferenceError(fmt.Errorf("health check failed: %w", err))
+	}
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		return fmt.Errorf("%w: health check returned invalid score %v", ErrInference, score)
+	}
+	return nil
+}
+
+// healthCheckAll checks every reranker, joining the errors of unhealthy ones
+func healthCheckAll(ctx context.Context, rerankers []Reranker) error {
+	var errs []error
+	for _, r := range rerankers {
+		if err := r.HealthCheck(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.GetModelName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkReachable sends a cheap GET to url and fails on transport errors and
+// 5xx responses. Any other status, including 404 or 405 from a POST-only
+// endpoint, shows the service is up.
+func checkReachable(ctx context.Context, client *http.Client, url string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to build health request: %v", ErrInvalidInput, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s unreachable: %v", ErrInference, url, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("%w: %s returned %d", ErrInference, url, resp.StatusCode)
+	}
+	return nil
+}
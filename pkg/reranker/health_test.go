@@ -0,0 +1,88 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGGUFLocalReranker_HealthCheck(t *testing.T) {
+	r := newFakeGGUFReranker(t, 1)
+	if err := r.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("Expected a healthy reranker, got %v", err)
+	}
+	if r.CacheLen() != 0 {
+		t.Errorf("Expected the health check to bypass the score cache, got %d entries", r.CacheLen())
+	}
+}
+
+func TestGGUFLocalReranker_HealthCheckTimeout(t *testing.T) {
+	r, pidFile := newHangingGGUFReranker(t, "exec sleep 5")
+	start := time.Now()
+	err := r.HealthCheck(context.Background())
+	if !errors.Is(err, ErrInference) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected ErrInference wrapping context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the inference timeout to bound the check, took %v", elapsed)
+	}
+	assertProcessGone(t, pidFile)
+}
+
+func TestHealthCheck_Wrappers(t *testing.T) {
+	healthy := NewSimpleReranker(Config{Model: "simple"})
+	broken := &erroringReranker{err: errors.New("binary missing")}
+
+	tee, err := NewTeeReranker(Config{}, []Reranker{healthy, broken})
+	if err != nil {
+		t.Fatalf("NewTeeReranker failed: %v", err)
+	}
+	if err := tee.HealthCheck(context.Background()); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Expected the tee to report the broken child, got %v", err)
+	}
+
+	if err := WithFallback(broken, healthy).HealthCheck(context.Background()); err != nil {
+		t.Errorf("Expected a healthy fallback to keep the chain healthy, got %v", err)
+	}
+	if err := WithFallback(broken, broken).HealthCheck(context.Background()); err == nil {
+		t.Error("Expected an error when both primary and fallback are unhealthy")
+	}
+}
+
+func TestHealthCheck_RemoteHTTPBackends(t *testing.T) {
+	status := http.StatusMethodNotAllowed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cohere, err := NewCohereReranker(Config{Model: "rerank-v3.5", Options: map[string]interface{}{"api_key": "k", "endpoint": server.URL}})
+	if err != nil {
+		t.Fatalf("NewCohereReranker failed: %v", err)
+	}
+	openai, err := NewOpenAICompatReranker(Config{Model: "embedder", Options: map[string]interface{}{"base_url": server.URL}})
+	if err != nil {
+		t.Fatalf("NewOpenAICompatReranker failed: %v", err)
+	}
+
+	for _, r := range []Reranker{cohere, openai} {
+		// A POST-only endpoint rejecting GET is still up
+		status = http.StatusMethodNotAllowed
+		if err := r.HealthCheck(context.Background()); err != nil {
+			t.Errorf("%T: expected a reachable service, got %v", r, err)
+		}
+		status = http.StatusBadGateway
+		if err := r.HealthCheck(context.Background()); !errors.Is(err, ErrInference) {
+			t.Errorf("%T: expected ErrInference for a 502, got %v", r, err)
+		}
+	}
+
+	server.Close()
+	if err := cohere.HealthCheck(context.Background()); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for an unreachable service, got %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// hostedResult is one scored document decoded from a hosted rerank API response
+type hostedResult struct {
+	Index int
+	Score float64
+	// Text is the document text echoed back by the API, empty when not returned
+	Text string
+}
+
+// hostedReranker implements Rerank, ComputeScore and Rank for hosted rerank
+// APIs that score a query against a list of documents and return indexed
+// relevance scores, such as Cohere, Jina AI and Voyage AI. Each adapter
+// supplies how to build its request body and how to decode its response.
+type hostedReranker struct {
+	config     Config
+	service    string
+	endpoint   string
+	apiKey     string
+	maxRetries int
+	client     *http.Client
+
+	// buildRequest returns the JSON request body; topN of zero requests
+	// scores for every document
+	buildRequest func(query string, documents []string, topN int) interface{}
+	// decodeResponse parses the response payload into its results
+	decodeResponse func(payload []byte) ([]hostedResult, error)
+}
+
+// newHostedReranker reads the options shared by hosted rerank APIs: the
+// required "api_key", "endpoint" (default defaultEndpoint),
+// "timeout_seconds" and "max_retries". The model name without modelPrefix
+// must not be empty.
+func newHostedReranker(config Config, service, modelPrefix, defaultEndpoint string) (hostedReranker, error) {
+	apiKey := OptionString(config.Options, "api_key", "")
+	if apiKey == "" {
+		return hostedReranker{}, fmt.Errorf("%w: api_key option is required for %s reranker", ErrInvalidInput, service)
+	}
+	if strings.TrimPrefix(config.Model, modelPrefix) == "" {
+		return hostedReranker{}, fmt.Errorf("%w: model name is required for %s reranker", ErrInvalidInput, service)
+	}
+
+	if config.MaxDocs == 0 {
+		config.MaxDocs = 100
+	}
+
+	maxRetries := OptionInt(config.Options, "max_retries", defaultHTTPMaxRetries)
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	return hostedReranker{
+		config:     config,
+		service:    service,
+		endpoint:   OptionString(config.Options, "endpoint", defaultEndpoint),
+		apiKey:     apiKey,
+		maxRetries: maxRetries,
+		client: &http.Client{
+			Timeout: OptionDuration(config.Options, "timeout_seconds", defaultHTTPTimeout),
+		},
+	}, nil
+}
+
+// rerank calls the API; topN of zero requests scores for every document
+func (r *hostedReranker) rerank(ctx context.Context, query string, documents []Document, topN int) ([]hostedResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	contents := make([]string, len(documents))
+	for i, doc := range documents {
+		contents[i] = doc.Content
+	}
+
+	body, err := json.Marshal(r.buildRequest(query, contents, topN))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %v", ErrInvalidInput, err)
+	}
+
+	payload, err := postJSONWithRetry(ctx, r.client, r.endpoint, r.apiKey, body, r.maxRetries)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.decodeResponse(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if result.Index < 0 || result.Index >= len(documents) {
+			return nil, fmt.Errorf("%w: %s result index %d out of range", ErrInference, r.service, result.Index)
+		}
+	}
+
+	return results, nil
+}
+
+// Rerank reorders documents based on the API's relevance scores
+func (r *hostedReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns the API's relevance scores in original document order
+func (r *hostedReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *hostedReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	results, err := r.rerank(ctx, query, documents, 0)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := r.documentScores(results, len(documents))
+	if err != nil {
+		return nil, err
+	}
+	return r.config.transformScores(scores)
+}
+
+// documentScores returns the raw scores of results, which must cover each
+// of numDocs documents exactly once, in original document order
+func (r *hostedReranker) documentScores(results []hostedResult, numDocs int) ([]float64, error) {
+	if len(results) != numDocs {
+		return nil, fmt.Errorf("%w: expected %d %s results, got %d", ErrInference, numDocs, r.service, len(results))
+	}
+
+	scores := make([]float64, numDocs)
+	seen := make([]bool, numDocs)
+	for _, result := range results {
+		if seen[result.Index] {
+			return nil, fmt.Errorf("%w: duplicate %s result index %d", ErrInference, r.service, result.Index)
+		}
+		seen[result.Index] = true
+		scores[result.Index] = result.Score
+	}
+	return scores, nil
+}
+
+// batchDependentScores reports whether a document's final score or the
+// threshold depends on the scores of the other documents in the batch
+func (r *hostedReranker) batchDependentScores() bool {
+	_, capped := r.config.Options["score_cap_percentile"]
+	normalized := r.config.NormalizeScores != "" && r.config.NormalizeScores != NormalizationNone
+	return normalized || capped || r.config.ThresholdMode == ThresholdPercentile
+}
+
+// Rank returns top-N ranked documents, letting the API apply the top-N cut.
+// When scores depend on the whole batch (normalization, a percentile
+// threshold or score cap), every document is scored and the cut is made
+// locally, so result scores match ComputeScore.
+func (r *hostedReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	if r.batchDependentScores() {
+		return r.rankAll(ctx, query, documents, topN)
+	}
+
+	response, err := r.rerank(ctx, query, documents, topN)
+	if err != nil {
+		return nil, err
+	}
+
+	rawScores := make([]float64, len(response))
+	for i, result := range response {
+		rawScores[i] = result.Score
+	}
+	scores, err := r.config.transformScores(rawScores)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := r.config.scoreThreshold(scores)
+	var results []RerankResult
+	for i, result := range response {
+		if scores[i] < threshold {
+			continue
+		}
+		doc := documents[result.Index]
+		if result.Text != "" {
+			doc.Content = result.Text
+		}
+		results = append(results, RerankResult{
+			Document: doc,
+			Score:    scores[i],
+			Index:    result.Index,
+		})
+	}
+
+	// Hosted APIs already return results by relevance; sort defensively
+	sortResults(results, r.config.tieBreak())
+
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+
+	return assignRanks(results, r.config.NormalizeScores), nil
+}
+
+// rankAll requests scores for every document and ranks them locally
+func (r *hostedReranker) rankAll(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	response, err := r.rerank(ctx, query, documents, 0)
+	if err != nil {
+		return nil, err
+	}
+	rawScores, err := r.documentScores(response, len(documents))
+	if err != nil {
+		return nil, err
+	}
+	scores, err := r.config.transformScores(rawScores)
+	if err != nil {
+		return nil, err
+	}
+
+	returned := append([]Document(nil), documents...)
+	for _, result := range response {
+		if result.Text != "" {
+			returned[result.Index].Content = result.Text
+		}
+	}
+
+	ranked := rankByScores(returned, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak())
+	return assignRanks(ranked, r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the model name
+func (r *hostedReranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"; hosted APIs do not report
+// the version of the model they serve
+func (r *hostedReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck fails when the rerank endpoint cannot be reached
+func (r *hostedReranker) HealthCheck(ctx context.Context) error {
+	return checkReachable(ctx, r.client, r.endpoint)
+}
@@ -0,0 +1,287 @@
+package reranker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPModelPrefix marks model names that are served by a remote HTTP endpoint
+const HTTPModelPrefix = "http/"
+
+// Default settings for remote HTTP inference
+const (
+	defaultHTTPTimeout    = 30 * time.Second
+	defaultHTTPMaxRetries = 2
+	defaultHTTPRetryDelay = 200 * time.Millisecond
+)
+
+// HTTPReranker implements reranking by calling a remote inference server
+// that exposes a /rerank or /score style endpoint.
+//
+// Recognized options:
+//   - "endpoint": full URL of the scoring endpoint (required)
+//   - "auth_token": bearer token sent in the Authorization header
+//   - "timeout_seconds": per-request timeout (default 30)
+//   - "max_retries": retries on network errors, 429 and 5xx (default 2)
+type HTTPReranker struct {
+	config     Config
+	endpoint   string
+	authToken  string
+	maxRetries int
+	client     *http.Client
+}
+
+// HTTPRerankRequest represents the request body sent to the inference server
+type HTTPRerankRequest struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+}
+
+// HTTPRerankResponse represents the response body returned by the inference server.
+// Servers may either return scores in document order or a list of indexed results.
+type HTTPRerankResponse struct {
+	Scores  []float64 `json:"scores,omitempty"`
+	Results []struct {
+		Index          int      `json:"index"`
+		Score          *float64 `json:"score,omitempty"`
+		RelevanceScore *float64 `json:"relevance_score,omitempty"`
+	} `json:"results,omitempty"`
+}
+
+// NewHTTPReranker creates a new reranker backed by a remote HTTP endpoint
+func NewHTTPReranker(config Config) (*HTTPReranker, error) {
+	endpoint := OptionString(config.Options, "endpoint", "")
+	if endpoint == "" {
+		return nil, fmt.Errorf("%w: endpoint option is required for HTTP reranker", ErrInvalidInput)
+	}
+
+	if config.MaxDocs == 0 {
+		config.MaxDocs = 100
+	}
+
+	maxRetries := OptionInt(config.Options, "max_retries", defaultHTTPMaxRetries)
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	return &HTTPReranker{
+		config:     config,
+		endpoint:   endpoint,
+		authToken:  OptionString(config.Options, "auth_token", ""),
+		maxRetries: maxRetries,
+		client: &http.Client{
+			Timeout: OptionDuration(config.Options, "timeout_seconds", defaultHTTPTimeout),
+		},
+	}, nil
+}
+
+// Rerank reorders documents based on relevance scores returned by the remote server
+func (r *HTTPReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore requests scores for query-document pairs from the remote server
+func (r *HTTPReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *HTTPReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	request := HTTPRerankRequest{
+		Model:     strings.TrimPrefix(r.config.Model, HTTPModelPrefix),
+		Query:     query,
+		Documents: make([]string, len(documents)),
+	}
+	for i, doc := range documents {
+		request.Documents[i] = doc.Content
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %v", ErrInvalidInput, err)
+	}
+
+	payload, err := postJSONWithRetry(ctx, r.client, r.endpoint, r.authToken, body, r.maxRetries)
+	if err != nil {
+		return nil, err
+	}
+
+	var response HTTPRerankResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse response: %v", ErrInference, err)
+	}
+
+	scores, err := response.scores(len(documents))
+	if err != nil {
+		return nil, err
+	}
+	return r.config.transformScores(scores)
+}
+
+// postJSONWithRetry POSTs a JSON body and returns the raw response payload,
+// retrying transport errors, 429 and 5xx responses with exponential backoff
+func postJSONWithRetry(ctx context.Context, client *http.Client, endpoint, authToken string, body []byte, maxRetries int) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := defaultHTTPRetryDelay * time.Duration(1<<(attempt-1))
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w: %v", ErrInference, ctx.Err())
+			case <-time.After(delay):
+			}
+		}
+
+		payload, retryable, err := postJSON(ctx, client, endpoint, authToken, body)
+		if err == nil {
+			return payload, nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+
+	return nil, lastErr
+}
+
+// postJSON performs a single POST and reports whether a failure is worth retrying
+func postJSON(ctx context.Context, client *http.Client, endpoint, authToken string, body []byte) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: failed to build request: %v", ErrInvalidInput, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Context cancellation is final, transport errors are retried
+		return nil, ctx.Err() == nil, fmt.Errorf("%w: request to %s failed: %v", ErrInference, endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: failed to read response: %v", ErrInference, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retryable, fmt.Errorf("%w: server returned %d: %s", ErrInference, resp.StatusCode, strings.TrimSpace(string(payload)))
+	}
+
+	return payload, false, nil
+}
+
+// scores returns the response scores in original document order
+func (resp *HTTPRerankResponse) scores(numDocs int) ([]float64, error) {
+	if len(resp.Scores) > 0 {
+		if len(resp.Scores) != numDocs {
+			return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, numDocs, len(resp.Scores))
+		}
+		return resp.Scores, nil
+	}
+
+	if len(resp.Results) != numDocs {
+		return nil, fmt.Errorf("%w: expected %d results, got %d", ErrInference, numDocs, len(resp.Results))
+	}
+
+	scores := make([]float64, numDocs)
+	seen := make([]bool, numDocs)
+	for _, result := range resp.Results {
+		if result.Index < 0 || result.Index >= numDocs {
+			return nil, fmt.Errorf("%w: result index %d out of range", ErrInference, result.Index)
+		}
+		if seen[result.Index] {
+			return nil, fmt.Errorf("%w: duplicate result index %d", ErrInference, result.Index)
+		}
+		seen[result.Index] = true
+		switch {
+		case result.Score != nil:
+			scores[result.Index] = *result.Score
+		case result.RelevanceScore != nil:
+			scores[result.Index] = *result.RelevanceScore
+		default:
+			return nil, fmt.Errorf("%w: result %d has no score", ErrInference, result.Index)
+		}
+	}
+	return scores, nil
+}
+
+// Rank returns top-N ranked documents using scores from the remote server
+func (r *HTTPReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the model name
+func (r *HTTPReranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"; the service does not report
+// the version of the model it serves
+func (r *HTTPReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck fails when the scoring endpoint cannot be reached
+func (r *HTTPReranker) HealthCheck(ctx context.Context) error {
+	return checkReachable(ctx, r.client, r.endpoint)
+}
+
+// Configure updates the reranker configuration
+func (r *HTTPReranker) Configure(config Config) error {
+	updated, err := NewHTTPReranker(config)
+	if err != nil {
+		return err
+	}
+	*r = *updated
+	return nil
+}
@@ -0,0 +1,166 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHTTPReranker_RoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", req.Method)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Expected bearer token, got %q", got)
+		}
+
+		var body HTTPRerankRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if body.Query != "machine learning" {
+			t.Errorf("Expected query 'machine learning', got %q", body.Query)
+		}
+
+		scores := make([]float64, len(body.Documents))
+		for i, doc := range body.Documents {
+			if strings.Contains(doc, "machine") {
+				scores[i] = 1.0
+			}
+		}
+		json.NewEncoder(w).Encode(HTTPRerankResponse{Scores: scores})
+	}))
+	defer server.Close()
+
+	config := Config{
+		Model: "http/test-model",
+		Options: map[string]interface{}{
+			"endpoint":   server.URL,
+			"auth_token": "secret",
+		},
+	}
+
+	r, err := NewReranker(config)
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*HTTPReranker); !ok {
+		t.Fatalf("Expected *HTTPReranker, got %T", r)
+	}
+
+	documents := []Document{
+		{ID: "1", Content: "Cooking is an art form"},
+		{ID: "2", Content: "Machine learning is powerful"},
+		{ID: "3", Content: "machine translation"},
+	}
+
+	results, err := r.Rank(context.Background(), "machine learning", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Document.ID != "3" {
+		t.Errorf("Expected document 3 first, got %s", results[0].Document.ID)
+	}
+}
+
+func TestHTTPReranker_IndexedResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"results":[{"index":1,"relevance_score":0.9},{"index":0,"score":0.1}]}`))
+	}))
+	defer server.Close()
+
+	r, err := NewHTTPReranker(Config{Options: map[string]interface{}{"endpoint": server.URL}})
+	if err != nil {
+		t.Fatalf("NewHTTPReranker failed: %v", err)
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "q", []Document{{Content: "a"}, {Content: "b"}})
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] != 0.1 || scores[1] != 0.9 {
+		t.Errorf("Expected scores [0.1 0.9], got %v", scores)
+	}
+}
+
+func TestHTTPReranker_Retries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"scores":[0.5]}`))
+	}))
+	defer server.Close()
+
+	r, err := NewHTTPReranker(Config{Options: map[string]interface{}{
+		"endpoint":    server.URL,
+		"max_retries": 2,
+	}})
+	if err != nil {
+		t.Fatalf("NewHTTPReranker failed: %v", err)
+	}
+
+	if _, err := r.ComputeScore(context.Background(), "q", []Document{{Content: "a"}}); err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestHTTPReranker_ErrorStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	r, err := NewHTTPReranker(Config{Options: map[string]interface{}{"endpoint": server.URL}})
+	if err != nil {
+		t.Fatalf("NewHTTPReranker failed: %v", err)
+	}
+
+	_, err = r.ComputeScore(context.Background(), "q", []Document{{Content: "a"}})
+	if !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected client errors not to be retried, got %d calls", calls)
+	}
+}
+
+func TestHTTPReranker_MissingEndpoint(t *testing.T) {
+	_, err := NewHTTPReranker(Config{Model: "http/test"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestHTTPReranker_DuplicateResultIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"results":[{"index":0,"score":0.9},{"index":0,"score":0.1}]}`))
+	}))
+	defer server.Close()
+
+	r, err := NewHTTPReranker(Config{Options: map[string]interface{}{"endpoint": server.URL}})
+	if err != nil {
+		t.Fatalf("NewHTTPReranker failed: %v", err)
+	}
+
+	_, err = r.ComputeScore(context.Background(), "q", []Document{{Content: "a"}, {Content: "b"}})
+	if !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for a repeated index, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// DefaultHybridAlpha weighs dense and reranker scores equally
+const DefaultHybridAlpha = 0.5
+
+// EmbeddingFunc returns the dense embedding of a query
+type EmbeddingFunc func(text string) ([]float32, error)
+
+// HybridReranker wraps another reranker and blends its score with the cosine
+// similarity between the query embedding and each Document.Vector:
+// alpha * dense + (1-alpha) * reranker. Documents without a Vector have a
+// dense score of 0. Reranker scores are used as the inner reranker returns
+// them, so set its NormalizeScores to bring both onto a comparable scale.
+//
+// Recognized options:
+//   - "embedding_func": EmbeddingFunc computing the query embedding (required)
+//   - "hybrid_alpha": weight of the dense score in [0, 1] (default 0.5)
+type HybridReranker struct {
+	config Config
+	inner  Reranker
+	embed  EmbeddingFunc
+	alpha  float64
+}
+
+// NewHybridReranker wraps inner; the embedding function and alpha are read
+// from config options
+func NewHybridReranker(inner Reranker, config Config) (*HybridReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: hybrid reranking requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &HybridReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// denseScores returns the cosine similarity of each document vector to the query embedding
+func (r *HybridReranker) denseScores(query string, documents []Document) ([]float64, error) {
+	queryVector, err := r.embed(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to embed query: %v", ErrInference, err)
+	}
+
+	scores := make([]float64, len(documents))
+	for i, doc := range documents {
+		scores[i] = cosineSimilarity32(queryVector, doc.Vector)
+	}
+	return scores, nil
+}
+
+// cosineSimilarity32 is cosineSimilarity for float32 vectors; it is 0 when
+// either vector is empty or their lengths differ
+func cosineSimilarity32(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0.0
+	}
+
+	var dotProduct, normA, normB float64
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dotProduct += x * y
+		normA += x * x
+		normB += y * y
+	}
+
+	if normA == 0.0 || normB == 0.0 {
+		return 0.0
+	}
+
+	return dotProduct / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// Rerank reorders documents by their blended score
+func (r *HybridReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns each document's blended score in document order. The
+// side with zero weight is not computed.
+func (r *HybridReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *HybridReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores := make([]float64, len(documents))
+	if r.alpha > 0 {
+		dense, err := r.denseScores(query, documents)
+		if err != nil {
+			return nil, err
+		}
+		for i, score := range dense {
+			scores[i] = r.alpha * score
+		}
+	}
+	if r.alpha < 1 {
+		relevance, err := r.inner.ComputeScore(ctx, query, documents)
+		if err != nil {
+			return nil, err
+		}
+		if len(relevance) != len(documents) {
+			return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(documents), len(relevance))
+		}
+		for i, score := range relevance {
+			scores[i] += (1 - r.alpha) * score
+		}
+	}
+	return scores, nil
+}
+
+// Rank returns top-N documents by their blended score
+func (r *HybridReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *HybridReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *HybridReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *HybridReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the embedding function and alpha; the inner reranker is left unchanged
+func (r *HybridReranker) Configure(config Config) error {
+	var embed EmbeddingFunc
+	switch fn := config.Options["embedding_func"].(type) {
+	case EmbeddingFunc:
+		embed = fn
+	case func(string) ([]float32, error):
+		embed = fn
+	}
+	if embed == nil {
+		return fmt.Errorf("%w: embedding_func option is required for hybrid reranking", ErrInvalidInput)
+	}
+
+	alpha := OptionFloat(config.Options, "hybrid_alpha", DefaultHybridAlpha)
+	if alpha < 0 || alpha > 1 {
+		return fmt.Errorf("%w: hybrid_alpha must be in [0, 1], got %f", ErrInvalidInput, alpha)
+	}
+
+	r.config = config
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.embed = embed
+	r.alpha = alpha
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *HybridReranker) Close() error {
+	return closeReranker(r.inner)
+}
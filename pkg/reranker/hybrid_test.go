@@ -0,0 +1,97 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// hybridDocuments are ordered a, b, c by the inner reranker and c, b, a by vector similarity to [1, 0]
+func hybridDocuments() []Document {
+	return []Document{
+		{ID: "a", Content: "alpha", Vector: []float32{0, 1}},
+		{ID: "b", Content: "beta", Vector: []float32{1, 1}},
+		{ID: "c", Content: "gamma", Vector: []float32{1, 0}},
+	}
+}
+
+func newTestHybridReranker(t *testing.T, alpha float64) *HybridReranker {
+	t.Helper()
+	embed := func(string) ([]float32, error) { return []float32{1, 0}, nil }
+	inner := &orderedReranker{name: "inner", order: []string{"a", "b", "c"}}
+	r, err := NewHybridReranker(inner, Config{Options: map[string]interface{}{
+		"embedding_func": embed,
+		"hybrid_alpha":   alpha,
+	}})
+	if err != nil {
+		t.Fatalf("NewHybridReranker failed: %v", err)
+	}
+	return r
+}
+
+func TestHybridReranker_DenseOnly(t *testing.T) {
+	r := newTestHybridReranker(t, 1.0)
+	results, err := r.Rank(context.Background(), "query", hybridDocuments(), 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	want := []struct {
+		id    string
+		score float64
+	}{{"c", 1}, {"b", math.Sqrt2 / 2}, {"a", 0}}
+	for i, w := range want {
+		if results[i].Document.ID != w.id || math.Abs(results[i].Score-w.score) > 1e-6 {
+			t.Errorf("Result %d: expected %s with cosine %v, got %s with %v", i, w.id, w.score, results[i].Document.ID, results[i].Score)
+		}
+	}
+}
+
+func TestHybridReranker_Blend(t *testing.T) {
+	r := newTestHybridReranker(t, 0.0)
+	scores, err := r.ComputeScore(context.Background(), "query", hybridDocuments())
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] != 3 || scores[1] != 2 || scores[2] != 1 {
+		t.Errorf("Expected inner scores with alpha=0, got %v", scores)
+	}
+
+	// 0.25 * cosine + 0.75 * inner score
+	r = newTestHybridReranker(t, 0.25)
+	scores, err = r.ComputeScore(context.Background(), "query", hybridDocuments())
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	want := []float64{2.25, 0.25*math.Sqrt2/2 + 1.5, 1.0}
+	for i := range want {
+		if math.Abs(scores[i]-want[i]) > 1e-6 {
+			t.Errorf("Expected blended score %v for document %d, got %v", want[i], i, scores[i])
+		}
+	}
+}
+
+func TestHybridReranker_InvalidOptions(t *testing.T) {
+	inner := &orderedReranker{name: "inner"}
+	if _, err := NewHybridReranker(inner, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without embedding_func, got %v", err)
+	}
+
+	embed := EmbeddingFunc(func(string) ([]float32, error) { return nil, nil })
+	_, err := NewHybridReranker(inner, Config{Options: map[string]interface{}{"embedding_func": embed, "hybrid_alpha": 1.5}})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for alpha out of range, got %v", err)
+	}
+}
+
+func TestHybridReranker_EmbeddingError(t *testing.T) {
+	embed := func(string) ([]float32, error) { return nil, errors.New("embedding service down") }
+	r, err := NewHybridReranker(&orderedReranker{name: "inner"}, Config{Options: map[string]interface{}{"embedding_func": embed}})
+	if err != nil {
+		t.Fatalf("NewHybridReranker failed: %v", err)
+	}
+	if _, err := r.ComputeScore(context.Background(), "query", hybridDocuments()); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var _ InstructableReranker = (*GGUFLocalReranker)(nil)
+
+// instructionKey carries a per-call instruction through the context
+type instructionKey struct{}
+
+// withInstruction returns ctx carrying instruction for GGUF inference
+func withInstruction(ctx context.Context, instruction string) context.Context {
+	return context.WithValue(ctx, instructionKey{}, instruction)
+}
+
+// FormatInstructedQuery prepends instruction to query in the
+// "Instruct: {instruction}\nQuery: {query}" format used by instruction-following
+// rerankers; an empty instruction returns query unchanged
+func FormatInstructedQuery(instruction, query string) string {
+	if instruction == "" {
+		return query
+	}
+	return fmt.Sprintf("Instruct: %s\nQuery: %s", instruction, query)
+}
+
+// qwen3SystemPrompt opens the chat template of Qwen3 rerankers, up to the
+// start of the user turn
+const qwen3SystemPrompt = "<|im_start|>system\nJudge whether the Document meets the requirements based on the Query and the Instruct provided. " +
+	"Note that the answer can only be \"yes\" or \"no\".<|im_end|>\n<|im_start|>user\n"
+
+// FormatQwen3Query places instruction between the Qwen3 reranker system
+// prompt and query, as "<Instruct>: {instruction}\n<Query>: {query}" in the
+// user turn; an empty instruction returns query unchanged
+func FormatQwen3Query(instruction, query string) string {
+	if instruction == "" {
+		return query
+	}
+	return fmt.Sprintf("%s<Instruct>: %s\n<Query>: %s", qwen3SystemPrompt, instruction, query)
+}
+
+// isQwen3Reranker reports whether modelPath is a Qwen3 reranker GGUF file
+func isQwen3Reranker(modelPath string) bool {
+	return strings.Contains(strings.ToLower(filepath.Base(modelPath)), "qwen3-reranker")
+}
+
+// instructedQuery prefixes the call's instruction to query in the prompt
+// format of the loaded model
+func (r *GGUFLocalReranker) instructedQuery(ctx context.Context, query string) string {
+	if isQwen3Reranker(r.modelPath) {
+		return FormatQwen3Query(r.instruction(ctx), query)
+	}
+	return FormatInstructedQuery(r.instruction(ctx), query)
+}
+
+// instruction returns the instruction for a call: the one attached by
+// RankWithInstruction, otherwise Options["instruction"]
+func (r *GGUFLocalReranker) instruction(ctx context.Context) string {
+	if instruction, ok := ctx.Value(instructionKey{}).(string); ok && instruction != "" {
+		return instruction
+	}
+	return OptionString(r.config.Options, "instruction", "")
+}
+
+// RankWithInstruction returns top-N documents scored against the instructed query
+func (r *GGUFLocalReranker) RankWithInstruction(ctx context.Context, instruction, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return r.Rank(withInstruction(ctx, instruction), query, documents, topN)
+}
@@ -0,0 +1,110 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testInstruction = "Given a web search query, retrieve relevant passages"
+
+// newInstructionGGUFReranker returns a reranker whose stub model embeds
+// instructed prompts differently from plain ones and logs every prompt
+func newInstructionGGUFReranker(t *testing.T) (*GGUFLocalReranker, string) {
+	t.Helper()
+
+	reranker := newFakeGGUFReranker(t, 1)
+	prompts := filepath.Join(t.TempDir(), "prompts")
+	script := fmt.Sprintf(`#!/bin/sh
+printf '%%s\n---\n' "$4" >> %q
+case "$4" in
+Instruct:*) embedding="[1.0,0.0]" ;;
+*) embedding="[0.6,0.8]" ;;
+esac
+echo "{\"object\":\"list\",\"data\":[{\"object\":\"embedding\",\"index\":0,\"embedding\":$embedding}]}"
+`, prompts)
+	writeStubBinary(t, reranker.inferenceBinary, script)
+	return reranker, prompts
+}
+
+func TestFormatInstructedQuery(t *testing.T) {
+	if got := FormatInstructedQuery("", "what is go"); got != "what is go" {
+		t.Errorf("Expected query unchanged without instruction, got %q", got)
+	}
+	want := "Instruct: " + testInstruction + "\nQuery: what is go"
+	if got := FormatInstructedQuery(testInstruction, "what is go"); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestGGUFLocalReranker_RankWithInstruction(t *testing.T) {
+	reranker, prompts := newInstructionGGUFReranker(t)
+	documents := []Document{{ID: "go", Content: "Go is a programming language"}}
+	ctx := context.Background()
+
+	plain, err := reranker.Rank(ctx, "what is go", documents, 1)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	instructed, err := reranker.RankWithInstruction(ctx, testInstruction, "what is go", documents, 1)
+	if err != nil {
+		t.Fatalf("RankWithInstruction failed: %v", err)
+	}
+
+	if plain[0].Score == instructed[0].Score {
+		t.Errorf("Expected the instruction to change the score, both were %v", plain[0].Score)
+	}
+
+	data, err := os.ReadFile(prompts)
+	if err != nil {
+		t.Fatalf("Failed to read prompts: %v", err)
+	}
+	if !strings.Contains(string(data), "Instruct: "+testInstruction+"\nQuery: what is go\n---") {
+		t.Errorf("Expected instructed prompt to reach the model, got:\n%s", data)
+	}
+}
+
+func TestGGUFLocalReranker_InstructionOption(t *testing.T) {
+	reranker, _ := newInstructionGGUFReranker(t)
+	documents := []Document{{Content: "Go is a programming language"}}
+	ctx := context.Background()
+
+	plain, err := reranker.ComputeScore(ctx, "what is go", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+
+	reranker.config.Options["instruction"] = testInstruction
+	configured, err := reranker.ComputeScore(ctx, "what is go", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if plain[0] == configured[0] {
+		t.Errorf("Expected Options[\"instruction\"] to change the score, both were %v", plain[0])
+	}
+
+	// An explicit instruction matches the configured one, so it is served from cache
+	explicit, err := reranker.RankWithInstruction(ctx, testInstruction, "what is go", documents, 1)
+	if err != nil {
+		t.Fatalf("RankWithInstruction failed: %v", err)
+	}
+	if explicit[0].Score != configured[0] {
+		t.Errorf("Expected %v, got %v", configured[0], explicit[0].Score)
+	}
+}
+
+func TestFormatQwen3Query(t *testing.T) {
+	if got := FormatQwen3Query("", "what is go"); got != "what is go" {
+		t.Errorf("Expected query unchanged without instruction, got %q", got)
+	}
+	want := qwen3SystemPrompt + "<Instruct>: " + testInstruction + "\n<Query>: what is go"
+	if got := FormatQwen3Query(testInstruction, "what is go"); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if !isQwen3Reranker("models/Qwen3-Reranker-4B.Q4_K_M.gguf") || isQwen3Reranker("models/bge-reranker-base-q4_k_m.gguf") {
+		t.Error("Expected only Qwen3 reranker files to be detected")
+	}
+}
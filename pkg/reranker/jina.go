@@ -0,0 +1,96 @@
+package reranker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JinaCloudModelPrefix marks model names served by the hosted Jina AI Rerank API,
+// distinguishing them from the local Jina GGUF models
+const JinaCloudModelPrefix = "jina-cloud/"
+
+// DefaultJinaEndpoint is the Jina AI Rerank API endpoint
+const DefaultJinaEndpoint = "https://api.jina.ai/v1/rerank"
+
+// JinaReranker implements reranking using the hosted Jina AI Rerank API.
+//
+// Recognized options:
+//   - "api_key": Jina AI API key (required)
+//   - "endpoint": override the API endpoint (default DefaultJinaEndpoint)
+//   - "return_documents": ask the API to echo document text back (default false)
+//   - "timeout_seconds", "max_retries": same as HTTPReranker
+type JinaReranker struct {
+	hostedReranker
+}
+
+// JinaRerankRequest represents the request body for the Jina AI Rerank API
+type JinaRerankRequest struct {
+	Model           string   `json:"model"`
+	Query           string   `json:"query"`
+	Documents       []string `json:"documents"`
+	TopN            int      `json:"top_n,omitempty"`
+	ReturnDocuments bool     `json:"return_documents"`
+}
+
+// JinaRerankResponse represents the response body from the Jina AI Rerank API
+type JinaRerankResponse struct {
+	Model   string `json:"model"`
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+		Document       *struct {
+			Text string `json:"text"`
+		} `json:"document,omitempty"`
+	} `json:"results"`
+}
+
+// NewJinaReranker creates a new reranker backed by the Jina AI Rerank API
+func NewJinaReranker(config Config) (*JinaReranker, error) {
+	hosted, err := newHostedReranker(config, "Jina", JinaCloudModelPrefix, DefaultJinaEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	model := strings.TrimPrefix(config.Model, JinaCloudModelPrefix)
+	returnDocuments := OptionBool(config.Options, "return_documents", false)
+	hosted.buildRequest = func(query string, documents []string, topN int) interface{} {
+		return JinaRerankRequest{
+			Model:           model,
+			Query:           query,
+			Documents:       documents,
+			TopN:            topN,
+			ReturnDocuments: returnDocuments,
+		}
+	}
+	hosted.decodeResponse = decodeJinaResponse
+
+	return &JinaReranker{hostedReranker: hosted}, nil
+}
+
+// decodeJinaResponse parses a Jina AI Rerank API response body
+func decodeJinaResponse(payload []byte) ([]hostedResult, error) {
+	var response JinaRerankResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse Jina response: %v", ErrInference, err)
+	}
+
+	results := make([]hostedResult, len(response.Results))
+	for i, result := range response.Results {
+		results[i] = hostedResult{Index: result.Index, Score: result.RelevanceScore}
+		if result.Document != nil {
+			results[i].Text = result.Document.Text
+		}
+	}
+	return results, nil
+}
+
+// Configure updates the reranker configuration
+func (r *JinaReranker) Configure(config Config) error {
+	updated, err := NewJinaReranker(config)
+	if err != nil {
+		return err
+	}
+	*r = *updated
+	return nil
+}
@@ -0,0 +1,167 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newJinaTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if got := req.Header.Get("Authorization"); got != "Bearer jina-key" {
+			t.Errorf("Expected bearer API key, got %q", got)
+		}
+
+		var body JinaRerankRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if body.Model != "jina-reranker-v2-base-multilingual" {
+			t.Errorf("Expected model prefix to be stripped, got %q", body.Model)
+		}
+
+		// Score documents in reverse order so the last one ranks first
+		type result struct {
+			Index          int                `json:"index"`
+			RelevanceScore float64            `json:"relevance_score"`
+			Document       *map[string]string `json:"document,omitempty"`
+		}
+		var results []result
+		for i := len(body.Documents) - 1; i >= 0; i-- {
+			res := result{Index: i, RelevanceScore: float64(i+1) / float64(len(body.Documents))}
+			if body.ReturnDocuments {
+				res.Document = &map[string]string{"text": body.Documents[i]}
+			}
+			results = append(results, res)
+		}
+		if body.TopN > 0 && len(results) > body.TopN {
+			results = results[:body.TopN]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"model": body.Model, "results": results})
+	}))
+}
+
+func newTestJinaReranker(t *testing.T, endpoint string) Reranker {
+	r, err := NewReranker(Config{
+		Model: "jina-cloud/jina-reranker-v2-base-multilingual",
+		Options: map[string]interface{}{
+			"api_key":          "jina-key",
+			"endpoint":         endpoint,
+			"return_documents": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*JinaReranker); !ok {
+		t.Fatalf("Expected *JinaReranker, got %T", r)
+	}
+	return r
+}
+
+func TestJinaReranker_Rank(t *testing.T) {
+	server := newJinaTestServer(t)
+	defer server.Close()
+
+	r := newTestJinaReranker(t, server.URL)
+	documents := []Document{
+		{ID: "1", Content: "first"},
+		{ID: "2", Content: "second"},
+		{ID: "3", Content: "third"},
+	}
+
+	results, err := r.Rank(context.Background(), "query", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Document.ID != "3" || results[0].Index != 2 {
+		t.Errorf("Expected document 3 first, got %+v", results[0])
+	}
+	if results[0].Document.Content != "third" {
+		t.Errorf("Expected returned document text, got %q", results[0].Document.Content)
+	}
+	if results[0].Score < results[1].Score {
+		t.Errorf("Results not sorted: %v < %v", results[0].Score, results[1].Score)
+	}
+}
+
+func TestJinaReranker_ComputeScore(t *testing.T) {
+	server := newJinaTestServer(t)
+	defer server.Close()
+
+	r := newTestJinaReranker(t, server.URL)
+	documents := []Document{{Content: "a"}, {Content: "b"}}
+
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if len(scores) != 2 || scores[0] != 0.5 || scores[1] != 1.0 {
+		t.Errorf("Expected scores in document order [0.5 1], got %v", scores)
+	}
+	if err := closeReranker(r); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+func TestJinaReranker_RequiresAPIKey(t *testing.T) {
+	_, err := NewJinaReranker(Config{Model: "jina-cloud/jina-reranker-v2-base-multilingual"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestJinaReranker_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	r := newTestJinaReranker(t, server.URL)
+	_, err := r.ComputeScore(context.Background(), "query", []Document{{Content: "a"}})
+	if !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference, got %v", err)
+	}
+}
+
+func TestJinaReranker_RankMatchesComputeScore(t *testing.T) {
+	server := newJinaTestServer(t)
+	defer server.Close()
+
+	r, err := NewReranker(Config{
+		Model:           "jina-cloud/jina-reranker-v2-base-multilingual",
+		NormalizeScores: NormalizationMinMax,
+		Options:         map[string]interface{}{"api_key": "jina-key", "endpoint": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	documents := []Document{
+		{ID: "1", Content: "first"},
+		{ID: "2", Content: "second"},
+		{ID: "3", Content: "third"},
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	results, err := r.Rank(context.Background(), "query", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Score != scores[result.Index] {
+			t.Errorf("Document %s: Rank score %v differs from ComputeScore %v", result.Document.ID, result.Score, scores[result.Index])
+		}
+	}
+}
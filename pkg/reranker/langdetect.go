@@ -0,0 +1,113 @@
+package reranker
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages recognized by DetectLanguage (ISO 639-1 codes)
+const (
+	LanguageEnglish = "en"
+	LanguageFrench  = "fr"
+	LanguageSpanish = "es"
+	LanguageGerman  = "de"
+)
+
+// minDetectionTrigrams is the number of trigrams below which text is too short to classify
+const minDetectionTrigrams = 8
+
+// languageTrigrams lists the most frequent word trigrams of each language, most
+// frequent first. Words are lower-cased and padded with one space on each side.
+var languageTrigrams = map[string][]string{
+	LanguageEnglish: {
+		" th", "the", "he ", " an", "and", "nd ", "ing", "ng ", " of", "of ",
+		" to", "to ", " in", "in ", "ion", "tio", " is", "is ", "ed ", "er ",
+		"ent", " co", "re ", "on ", "at ", "es ", " re", "ati", "for", " fo",
+		"or ", "her", "ter", "hat", "tha", "ly ", " be", "al ", " wa", "as ",
+		"his", "ere", "con", "ver", "st ", "nt ", " ha", "all", "ith", "wit",
+	},
+	LanguageFrench: {
+		" de", "de ", "es ", " le", "le ", "ent", "nt ", " la", "la ", "ion",
+		"les", " co", "re ", "on ", "que", " qu", "ue ", " et", "et ", "tio",
+		" pa", "ne ", "des", " un", "une", "men", "ons", " po", "our", "par",
+		"ait", "est", " es", "st ", " en", "en ", "eme", "ur ", " du", "du ",
+		"ans", " da", "dan", "sur", " su", " pl", "pou", "lle", "ais", "ux ",
+	},
+	LanguageSpanish: {
+		" de", "de ", "os ", " la", "la ", "el ", " el", "es ", " en", "en ",
+		" qu", "que", "ue ", "ión", "ón ", " co", "as ", "ent", "nte", " lo",
+		"los", " se", "ado", "do ", " po", "con", "por", " un", "er ", " y ",
+		"ndo", "ra ", "ar ", "del", " es", "est", "las", " pa", "ara", "par",
+		"ien", "cia", "una", "no ", " no", "ida", "mos", "sta", "ero", "aci",
+	},
+	LanguageGerman: {
+		"en ", "er ", " de", "der", "ie ", "ich", " di", "die", "ein", "sch",
+		"che", "nd ", " un", "und", "den", "ch ", "cht", "gen", " ei", "ine",
+		"in ", "te ", " zu", "zu ", "ung", "ng ", "das", " da", "ten", "sie",
+		" si", "ist", " is", "st ", "nde", " ge", "ter", "ber", "auf", " au",
+		" mi", "mit", "it ", "nen", "ht ", "eit", "ge ", "ür ", "für", " fü",
+	},
+}
+
+// languageWeights maps each language to trigram weights derived from rank
+var languageWeights = buildLanguageWeights()
+
+// buildLanguageWeights weights each trigram by its rank: the most frequent
+// trigram of an n-entry table weighs n and the least frequent 1
+func buildLanguageWeights() map[string]map[string]int {
+	weights := make(map[string]map[string]int, len(languageTrigrams))
+	for language, trigrams := range languageTrigrams {
+		weights[language] = make(map[string]int, len(trigrams))
+		for rank, trigram := range trigrams {
+			weights[language][trigram] = len(trigrams) - rank
+		}
+	}
+	return weights
+}
+
+// textTrigrams counts the padded word trigrams of text
+func textTrigrams(text string) map[string]int {
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+	return counts
+}
+
+// DetectLanguage guesses the language of text from trigram frequencies,
+// returning "en", "fr", "es" or "de", or "" when the text is too short or
+// matches no language better than the others.
+func DetectLanguage(text string) string {
+	counts := textTrigrams(text)
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	if total < minDetectionTrigrams {
+		return ""
+	}
+
+	best, bestScore, tied := "", 0, false
+	for language, weights := range languageWeights {
+		score := 0
+		for trigram, count := range counts {
+			score += weights[trigram] * count
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = language, score, false
+		case score == bestScore && score > 0:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
+}
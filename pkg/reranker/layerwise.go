@@ -0,0 +1,223 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// LastLayer selects the final transformer layer of a layerwise model
+const LastLayer = -1
+
+// LayerwiseGGUFReranker scores with embeddings taken from an intermediate
+// transformer layer of a layerwise model (e.g. bge-reranker-v2-minicpm-layerwise).
+// Earlier layers trade accuracy for speed, since inference stops at the
+// selected layer. Scores are the cosine similarity of the query and document
+// embeddings at that layer.
+//
+// Stock llama-embedding always runs every layer, so selecting an earlier one
+// needs a patched build with a flag that stops after the given layer and
+// emits one JSON data entry per layer, indexed by layer number.
+//
+// Recognized options, in addition to those of GGUFLocalReranker:
+//   - "layer_index": layer to score with, counted from 0 (default -1, the last layer)
+//   - "layer_flag": the patched build's layer flag, e.g. "--embd-layer"
+//     (required when layer_index is not -1)
+type LayerwiseGGUFReranker struct {
+	gguf      *GGUFLocalReranker
+	layer     int
+	layerFlag string
+}
+
+// NewLayerwiseGGUFReranker creates a layerwise reranker; model resolution and
+// options are the same as for NewGGUFLocalReranker
+func NewLayerwiseGGUFReranker(config Config) (*LayerwiseGGUFReranker, error) {
+	layer, layerFlag, err := layerFromOptions(config.Options)
+	if err != nil {
+		return nil, err
+	}
+	gguf, err := NewGGUFLocalReranker(config)
+	if err != nil {
+		return nil, err
+	}
+	return &LayerwiseGGUFReranker{gguf: gguf, layer: layer, layerFlag: layerFlag}, nil
+}
+
+// layerFromOptions reads and validates Options["layer_index"] and Options["layer_flag"]
+func layerFromOptions(opts map[string]interface{}) (int, string, error) {
+	layer := OptionInt(opts, "layer_index", LastLayer)
+	if layer < LastLayer {
+		return 0, "", fmt.Errorf("%w: layer_index must be -1 (last layer) or a layer number, got %d", ErrInvalidInput, layer)
+	}
+	layerFlag := OptionString(opts, "layer_flag", "")
+	if layer != LastLayer && layerFlag == "" {
+		return 0, "", fmt.Errorf("%w: layer_index %d requires a llama-embedding build patched to stop at a layer; set layer_flag to its flag", ErrInvalidInput, layer)
+	}
+	return layer, layerFlag, nil
+}
+
+// layerEmbedding returns the embedding of text at the configured layer. The
+// last data entry is used when no layer is selected or the model has fewer layers.
+func (r *LayerwiseGGUFReranker) layerEmbedding(ctx context.Context, text string) ([]float64, error) {
+	var extraArgs []string
+	if r.layer != LastLayer {
+		extraArgs = append(extraArgs, r.layerFlag, strconv.Itoa(r.layer))
+	}
+	response, err := r.gguf.runEmbedding(ctx, text, extraArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	embedding := response.Data[len(response.Data)-1].Embedding
+	for _, entry := range response.Data {
+		if entry.Index == r.layer {
+			embedding = entry.Embedding
+			break
+		}
+	}
+	if len(embedding) == 0 {
+		return nil, fmt.Errorf("no embedding returned for layer %d", r.layer)
+	}
+	return embedding, nil
+}
+
+// Rerank reorders documents by their layer score
+func (r *LayerwiseGGUFReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.gguf.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.gguf.config.scoreThreshold(scores), r.gguf.config.MaxDocs, r.gguf.config.tieBreak()), nil
+}
+
+// ComputeScore returns the layer score of each document in document order.
+// Pairs are truncated and instructed as in GGUFLocalReranker; each distinct
+// prepared query is embedded once and documents are embedded concurrently.
+func (r *LayerwiseGGUFReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.gguf.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *LayerwiseGGUFReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Truncation only shortens the query for long documents, so most pairs
+	// share one prepared query
+	queries := make([]string, len(documents))
+	contents := make([]string, len(documents))
+	queryEmbeddings := make(map[string][]float64)
+	for i, doc := range documents {
+		queries[i], contents[i] = r.gguf.preparePair(ctx, query, doc.Content)
+		if _, exists := queryEmbeddings[queries[i]]; exists {
+			continue
+		}
+		embedding, err := r.layerEmbedding(ctx, queries[i])
+		if err != nil {
+			return nil, inferenceError(fmt.Errorf("failed to get query embedding: %w", err))
+		}
+		queryEmbeddings[queries[i]] = embedding
+	}
+
+	scores := make([]float64, len(documents))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(r.gguf.workerCount())
+	for i := range documents {
+		i, query, content := i, queries[i], contents[i]
+		group.Go(func() error {
+			cacheKey := fmt.Sprintf("layer %d|||%s|||%s", r.layer, query, content)
+			if cached, exists := r.gguf.scoreCache.Get(cacheKey); exists {
+				scores[i] = cached
+				return nil
+			}
+
+			docEmbedding, err := r.layerEmbedding(groupCtx, content)
+			if err != nil {
+				return fmt.Errorf("failed to get document embedding: %w", err)
+			}
+			scores[i] = cosineSimilarity(queryEmbeddings[query], docEmbedding)
+			r.gguf.scoreCache.Set(cacheKey, scores[i])
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, inferenceError(err)
+	}
+
+	return r.gguf.config.transformScores(scores)
+}
+
+// Rank returns top-N documents by their layer score
+func (r *LayerwiseGGUFReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.gguf.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	config := r.gguf.config
+	return assignRanks(rankByScores(documents, scores, config.scoreThreshold(scores), topN, config.tieBreak()), config.NormalizeScores), nil
+}
+
+// Layer returns the layer scores are taken from, or LastLayer
+func (r *LayerwiseGGUFReranker) Layer() int {
+	return r.layer
+}
+
+// Configure updates the reranker configuration, including the layer
+func (r *LayerwiseGGUFReranker) Configure(config Config) error {
+	layer, layerFlag, err := layerFromOptions(config.Options)
+	if err != nil {
+		return err
+	}
+	if err := r.gguf.Configure(config); err != nil {
+		return err
+	}
+	r.layer, r.layerFlag = layer, layerFlag
+	return nil
+}
+
+// GetModelName returns the model name
+func (r *LayerwiseGGUFReranker) GetModelName() string {
+	return r.gguf.GetModelName()
+}
+
+// Version returns the underlying GGUF model's version
+func (r *LayerwiseGGUFReranker) Version() string {
+	return r.gguf.Version()
+}
+
+// HealthCheck checks the underlying GGUF model
+func (r *LayerwiseGGUFReranker) HealthCheck(ctx context.Context) error {
+	return r.gguf.HealthCheck(ctx)
+}
+
+// Close cleans up resources (clears cache)
+func (r *LayerwiseGGUFReranker) Close() {
+	r.gguf.Close()
+}
@@ -0,0 +1,122 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// layerwiseStubScript emits one entry per layer up to --embd-layer (default
+// the last of 8), spending 20ms per layer. Layer 0 embeds every text alike;
+// later layers tell texts mentioning "relevant" apart.
+const layerwiseStubScript = `#!/bin/sh
+layer=7
+text=""
+while [ $# -gt 0 ]; do
+	case "$1" in
+	-p) text="$2"; shift ;;
+	--embd-layer) layer="$2"; shift ;;
+	esac
+	shift
+done
+case "$text" in
+*relevant*) e="1,0" ;;
+*) e="0,1" ;;
+esac
+data=""
+i=0
+while [ $i -le $layer ]; do
+	sleep 0.02
+	v="$e"
+	[ $i -eq 0 ] && v="1,1"
+	[ -n "$data" ] && data="$data,"
+	data="$data{\"index\":$i,\"embedding\":[$v]}"
+	i=$((i+1))
+done
+echo "{\"object\":\"list\",\"data\":[$data]}"
+`
+
+func newFakeLayerwiseReranker(t *testing.T, layer int) *LayerwiseGGUFReranker {
+	t.Helper()
+	gguf := newFakeGGUFReranker(t, 1)
+	writeStubBinary(t, gguf.inferenceBinary, layerwiseStubScript)
+	return &LayerwiseGGUFReranker{gguf: gguf, layer: layer, layerFlag: "--embd-layer"}
+}
+
+func TestLayerwiseGGUFReranker_ScoreOrdering(t *testing.T) {
+	documents := []Document{{ID: "other", Content: "other answer"}, {ID: "relevant", Content: "relevant answer"}}
+	results, err := newFakeLayerwiseReranker(t, LastLayer).Rank(context.Background(), "relevant question", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Document.ID != "relevant" || results[0].Score <= results[1].Score {
+		t.Errorf("Expected the relevant document first at the last layer, got %+v", results)
+	}
+
+	// Layer 0 cannot tell the documents apart, so its entry must have been used
+	scores, err := newFakeLayerwiseReranker(t, 0).ComputeScore(context.Background(), "relevant question", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if math.Abs(scores[0]-1) > 1e-9 || math.Abs(scores[1]-1) > 1e-9 {
+		t.Errorf("Expected identical layer-0 scores of 1, got %v", scores)
+	}
+}
+
+func TestLayerwiseGGUFReranker_LowerLayersFaster(t *testing.T) {
+	documents := []Document{{Content: "relevant answer"}, {Content: "other answer"}}
+	elapsed := func(layer int) time.Duration {
+		start := time.Now()
+		if _, err := newFakeLayerwiseReranker(t, layer).ComputeScore(context.Background(), "relevant question", documents); err != nil {
+			t.Fatalf("ComputeScore at layer %d failed: %v", layer, err)
+		}
+		return time.Since(start)
+	}
+
+	early, last := elapsed(1), elapsed(LastLayer)
+	if early >= last {
+		t.Errorf("Expected layer 1 (%v) to be faster than the last layer (%v)", early, last)
+	}
+}
+
+func TestLayerwiseGGUFReranker_InvalidLayer(t *testing.T) {
+	r := &LayerwiseGGUFReranker{gguf: &GGUFLocalReranker{}}
+	err := r.Configure(Config{Options: map[string]interface{}{"layer_index": -2}})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+
+	// Stock llama-embedding cannot stop at a layer
+	_, err = NewLayerwiseGGUFReranker(Config{Model: "bge-v2-minicpm-layerwise", Options: map[string]interface{}{"layer_index": 3}})
+	if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "layer_flag") {
+		t.Errorf("Expected ErrInvalidInput naming layer_flag, got %v", err)
+	}
+}
+
+func TestLayerwiseGGUFReranker_PreparesPairs(t *testing.T) {
+	// The stub embeds texts mentioning "relevant" apart from all others
+	documents := []Document{{ID: "other", Content: "other answer"}, {ID: "cut", Content: "other filler words relevant"}}
+
+	r := newFakeLayerwiseReranker(t, LastLayer)
+	r.gguf.config.Options["instruction"] = "find relevant answers"
+	scores, err := r.ComputeScore(context.Background(), "question", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if math.Abs(scores[0]) > 1e-9 || math.Abs(scores[1]-1) > 1e-9 {
+		t.Errorf("Expected the instructed query to match only the relevant document, got %v", scores)
+	}
+
+	r = newFakeLayerwiseReranker(t, LastLayer)
+	r.gguf.maxTokens = 4
+	scores, err = r.ComputeScore(context.Background(), "relevant", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if math.Abs(scores[1]) > 1e-9 {
+		t.Errorf("Expected truncation to drop \"relevant\" from the document, got %v", scores)
+	}
+}
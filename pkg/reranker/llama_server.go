@@ -0,0 +1,404 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LlamaServerModelPrefix marks model names served by a running llama-server
+const LlamaServerModelPrefix = "llama-server/"
+
+// Default settings for llama-server backends
+const (
+	DefaultLlamaServerURL = "http://localhost:8080"
+
+	llamaServerStartTimeout = 2 * time.Minute
+	llamaServerPollInterval = 100 * time.Millisecond
+)
+
+// Scoring modes for llama-server
+const (
+	LlamaServerModeRerank    = "rerank"
+	LlamaServerModeEmbedding = "embedding"
+)
+
+// llamaServerBinaries lists where StartLocalServer looks for llama-server
+var llamaServerBinaries = []string{
+	"./llama.cpp/build/bin/llama-server",
+	"../llama.cpp/build/bin/llama-server",
+	"../../llama.cpp/build/bin/llama-server",
+	"llama-server", // In PATH
+}
+
+// LlamaServerReranker scores documents with an already running llama.cpp
+// llama-server, avoiding the per-call process spawn of GGUFLocalReranker. In
+// rerank mode the server must be started with --reranking; in embedding mode
+// documents are scored by cosine similarity to the query embedding.
+//
+// Recognized options:
+//   - "base_url": server URL (default http://localhost:8080)
+//   - "llama_mode": "rerank" (default, POST /v1/rerank) or "embedding" (POST /embedding)
+//   - "api_key": bearer token sent in the Authorization header
+//   - "timeout_seconds", "max_retries": same as HTTPReranker
+type LlamaServerReranker struct {
+	config     Config
+	baseURL    string
+	mode       string
+	apiKey     string
+	maxRetries int
+	client     *http.Client
+}
+
+// LlamaServerRerankRequest represents the body of POST /v1/rerank
+type LlamaServerRerankRequest struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+}
+
+// LlamaServerEmbeddingRequest represents the body of POST /embedding
+type LlamaServerEmbeddingRequest struct {
+	Content []string `json:"content"`
+}
+
+// NewLlamaServerReranker creates a new reranker backed by a llama-server instance
+func NewLlamaServerReranker(config Config) (*LlamaServerReranker, error) {
+	mode := OptionString(config.Options, "llama_mode", LlamaServerModeRerank)
+	if mode != LlamaServerModeRerank && mode != LlamaServerModeEmbedding {
+		return nil, fmt.Errorf("%w: llama_mode must be %q or %q, got %q", ErrInvalidInput, LlamaServerModeRerank, LlamaServerModeEmbedding, mode)
+	}
+
+	if config.MaxDocs == 0 {
+		config.MaxDocs = 100
+	}
+
+	maxRetries := OptionInt(config.Options, "max_retries", defaultHTTPMaxRetries)
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	return &LlamaServerReranker{
+		config:     config,
+		baseURL:    strings.TrimRight(OptionString(config.Options, "base_url", DefaultLlamaServerURL), "/"),
+		mode:       mode,
+		apiKey:     OptionString(config.Options, "api_key", ""),
+		maxRetries: maxRetries,
+		client: &http.Client{
+			Timeout: OptionDuration(config.Options, "timeout_seconds", defaultHTTPTimeout),
+		},
+	}, nil
+}
+
+// Rerank reorders documents based on relevance scores from the server
+func (r *LlamaServerReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore scores query-document pairs using the configured mode
+func (r *LlamaServerReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *LlamaServerReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var scores []float64
+	var err error
+	if r.mode == LlamaServerModeEmbedding {
+		scores, err = r.embeddingScores(ctx, query, documents)
+	} else {
+		scores, err = r.rerankScores(ctx, query, documents)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.config.transformScores(scores)
+}
+
+// post sends a JSON request to path under the base URL and returns the raw response
+func (r *LlamaServerReranker) post(ctx context.Context, path string, request interface{}) ([]byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %v", ErrInvalidInput, err)
+	}
+	return postJSONWithRetry(ctx, r.client, r.baseURL+path, r.apiKey, body, r.maxRetries)
+}
+
+// rerankScores calls POST /v1/rerank and returns scores in document order
+func (r *LlamaServerReranker) rerankScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	request := LlamaServerRerankRequest{
+		Model:     strings.TrimPrefix(r.config.Model, LlamaServerModelPrefix),
+		Query:     query,
+		Documents: make([]string, len(documents)),
+	}
+	for i, doc := range documents {
+		request.Documents[i] = doc.Content
+	}
+
+	payload, err := r.post(ctx, "/v1/rerank", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response HTTPRerankResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse /v1/rerank response: %v", ErrInference, err)
+	}
+	return response.scores(len(documents))
+}
+
+// embeddingScores embeds the query and documents in one batch and returns cosine similarities
+func (r *LlamaServerReranker) embeddingScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	request := LlamaServerEmbeddingRequest{Content: make([]string, 0, len(documents)+1)}
+	request.Content = append(request.Content, query)
+	for _, doc := range documents {
+		request.Content = append(request.Content, doc.Content)
+	}
+
+	payload, err := r.post(ctx, "/embedding", request)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err := parseLlamaServerEmbeddings(payload, len(request.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(documents))
+	for i := range documents {
+		scores[i] = cosineSimilarity(embeddings[0], embeddings[i+1])
+	}
+	return scores, nil
+}
+
+// parseLlamaServerEmbeddings decodes a /embedding response, which is a list of
+// {"index", "embedding"} items. Embeddings may be a single pooled vector or,
+// for servers without pooling, a list of per-token vectors of which the first
+// is used.
+func parseLlamaServerEmbeddings(payload []byte, count int) ([][]float64, error) {
+	var items []struct {
+		Index     int             `json:"index"`
+		Embedding json.RawMessage `json:"embedding"`
+	}
+	if err := json.Unmarshal(payload, &items); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse /embedding response: %v", ErrInference, err)
+	}
+	if len(items) != count {
+		return nil, fmt.Errorf("%w: expected %d embeddings, got %d", ErrInference, count, len(items))
+	}
+
+	embeddings := make([][]float64, count)
+	seen := make([]bool, count)
+	for _, item := range items {
+		if item.Index < 0 || item.Index >= count {
+			return nil, fmt.Errorf("%w: embedding index %d out of range", ErrInference, item.Index)
+		}
+		if seen[item.Index] {
+			return nil, fmt.Errorf("%w: duplicate embedding index %d", ErrInference, item.Index)
+		}
+		seen[item.Index] = true
+
+		var pooled []float64
+		if err := json.Unmarshal(item.Embedding, &pooled); err != nil {
+			var perToken [][]float64
+			if err := json.Unmarshal(item.Embedding, &perToken); err != nil || len(perToken) == 0 {
+				return nil, fmt.Errorf("%w: invalid embedding for index %d", ErrInference, item.Index)
+			}
+			pooled = perToken[0]
+		}
+		embeddings[item.Index] = pooled
+	}
+	return embeddings, nil
+}
+
+// Rank returns top-N ranked documents
+func (r *LlamaServerReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the model name
+func (r *LlamaServerReranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"; the service does not report
+// the version of the model it serves
+func (r *LlamaServerReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck fails unless the server's GET /health answers 200
+func (r *LlamaServerReranker) HealthCheck(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return checkLlamaServerHealth(ctx, r.client, r.baseURL)
+}
+
+// Configure updates the reranker configuration
+func (r *LlamaServerReranker) Configure(config Config) error {
+	updated, err := NewLlamaServerReranker(config)
+	if err != nil {
+		return err
+	}
+	*r = *updated
+	return nil
+}
+
+// LlamaServerHandle controls a llama-server process started by StartLocalServer
+type LlamaServerHandle struct {
+	// URL is the base URL of the server, suitable for the "base_url" option
+	URL string
+
+	cmd       *exec.Cmd
+	exited    chan struct{}
+	waitErr   error
+	closeOnce sync.Once
+}
+
+// StartLocalServer spawns llama-server for modelPath in reranking mode on
+// 127.0.0.1:port and waits until GET /health reports the model is loaded.
+// Call Close on the returned handle to stop the server.
+func StartLocalServer(modelPath string, port int) (*LlamaServerHandle, error) {
+	if _, err := os.Stat(modelPath); err != nil {
+		return nil, fmt.Errorf("%w: model file not found: %s", ErrInitialization, modelPath)
+	}
+
+	binary, err := findLlamaServerBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"-m", modelPath,
+		"--host", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+		"--reranking",
+	}
+	args = append(args, gpuArgs(resolveDevice(DeviceAuto), nil)...)
+
+	return startLlamaServer(binary, args, fmt.Sprintf("http://127.0.0.1:%d", port), llamaServerStartTimeout)
+}
+
+// findLlamaServerBinary returns the first llama-server found in llamaServerBinaries
+func findLlamaServerBinary() (string, error) {
+	for _, candidate := range llamaServerBinaries {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w: llama-server binary not found", ErrInitialization)
+}
+
+// startLlamaServer runs binary with args and polls baseURL/health until it
+// answers 200, the process exits or timeout elapses
+func startLlamaServer(binary string, args []string, baseURL string, timeout time.Duration) (*LlamaServerHandle, error) {
+	cmd := exec.Command(binary, args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%w: failed to start llama-server: %v", ErrInitialization, err)
+	}
+
+	handle := &LlamaServerHandle{URL: baseURL, cmd: cmd, exited: make(chan struct{})}
+	go func() {
+		handle.waitErr = cmd.Wait()
+		close(handle.exited)
+	}()
+
+	client := &http.Client{Timeout: time.Second}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(llamaServerPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if checkLlamaServerHealth(context.Background(), client, baseURL) == nil {
+			return handle, nil
+		}
+
+		select {
+		case <-handle.exited:
+			return nil, fmt.Errorf("%w: llama-server exited before becoming healthy: %v", ErrInitialization, handle.waitErr)
+		case <-deadline.C:
+			handle.Close()
+			return nil, fmt.Errorf("%w: llama-server not healthy after %v", ErrInitialization, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkLlamaServerHealth fails unless baseURL/health answers 200, which
+// llama-server does once its model is loaded
+func checkLlamaServerHealth(ctx context.Context, client *http.Client, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to build health request: %v", ErrInvalidInput, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: llama-server health check failed: %v", ErrInference, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: llama-server health returned %d", ErrInference, resp.StatusCode)
+	}
+	return nil
+}
+
+// Close kills the server process and waits for it to exit
+func (h *LlamaServerHandle) Close() error {
+	var err error
+	h.closeOnce.Do(func() {
+		if killErr := h.cmd.Process.Kill(); killErr != nil && !errors.Is(killErr, os.ErrProcessDone) {
+			err = killErr
+		}
+		<-h.exited
+	})
+	return err
+}
@@ -0,0 +1,236 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// llamaServerHelperEnv makes the test binary act as a fake llama-server
+const llamaServerHelperEnv = "GO_RERANKERS_FAKE_LLAMA_SERVER"
+
+func TestMain(m *testing.M) {
+	if addr := os.Getenv(llamaServerHelperEnv); addr != "" {
+		runFakeLlamaServer(addr)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeLlamaServer serves /health on addr after a short loading delay
+func runFakeLlamaServer(addr string) {
+	ready := time.Now().Add(200 * time.Millisecond)
+	http.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
+		if time.Now().Before(ready) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	http.ListenAndServe(addr, nil)
+	os.Exit(1)
+}
+
+func TestLlamaServerReranker_Rerank(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/rerank" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+
+		var body LlamaServerRerankRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if body.Model != "bge-reranker-v2-m3" {
+			t.Errorf("Expected model prefix to be stripped, got %q", body.Model)
+		}
+		if body.Query != "pets" || len(body.Documents) != 3 {
+			t.Errorf("Unexpected request %+v", body)
+		}
+
+		// Results arrive sorted by score, not by input position
+		fmt.Fprint(w, `{"results":[
+			{"index":2,"relevance_score":4.5},
+			{"index":0,"relevance_score":1.0},
+			{"index":1,"relevance_score":-3.0}]}`)
+	}))
+	defer server.Close()
+
+	r, err := NewReranker(Config{
+		Model:   "llama-server/bge-reranker-v2-m3",
+		Options: map[string]interface{}{"base_url": server.URL + "/"},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*LlamaServerReranker); !ok {
+		t.Fatalf("Expected *LlamaServerReranker, got %T", r)
+	}
+
+	documents := []Document{{ID: "a", Content: "a"}, {ID: "b", Content: "b"}, {ID: "c", Content: "c"}}
+	scores, err := r.ComputeScore(context.Background(), "pets", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	expected := []float64{1.0, -3.0, 4.5}
+	for i := range expected {
+		if scores[i] != expected[i] {
+			t.Errorf("Score %d: expected %v, got %v", i, expected[i], scores[i])
+		}
+	}
+
+	results, err := r.Rank(context.Background(), "pets", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Document.ID != "c" || results[1].Document.ID != "a" {
+		t.Errorf("Unexpected ranking %+v", results)
+	}
+}
+
+func TestLlamaServerReranker_Embedding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/embedding" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+
+		var body LlamaServerEmbeddingRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+
+		// The query and anything mentioning "cats" point the same way; the
+		// second document uses the unpooled per-token form
+		var items []string
+		for i, content := range body.Content {
+			embedding := "[0,1]"
+			if i == 0 || strings.Contains(content, "cats") {
+				embedding = "[1,0]"
+			}
+			if i == 2 {
+				embedding = "[" + embedding + ",[0,0]]"
+			}
+			items = append(items, fmt.Sprintf(`{"index":%d,"embedding":%s}`, i, embedding))
+		}
+		fmt.Fprint(w, "["+strings.Join(items, ",")+"]")
+	}))
+	defer server.Close()
+
+	r, err := NewLlamaServerReranker(Config{
+		Model:   "llama-server/nomic-embed-text",
+		Options: map[string]interface{}{"base_url": server.URL, "llama_mode": "embedding"},
+	})
+	if err != nil {
+		t.Fatalf("NewLlamaServerReranker failed: %v", err)
+	}
+
+	documents := []Document{{ID: "dogs", Content: "all about dogs"}, {ID: "cats", Content: "all about cats"}}
+	reranked, err := r.Rerank(context.Background(), "pets", documents)
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if reranked[0].ID != "cats" || reranked[0].Score != 1.0 || reranked[1].Score != 0.0 {
+		t.Errorf("Unexpected reranking %+v", reranked)
+	}
+}
+
+func TestLlamaServerReranker_Errors(t *testing.T) {
+	if _, err := NewLlamaServerReranker(Config{Options: map[string]interface{}{"llama_mode": "chat"}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for unknown mode, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `[{"index":0,"embedding":[1,0]}]`)
+	}))
+	defer server.Close()
+
+	r, err := NewLlamaServerReranker(Config{Options: map[string]interface{}{"base_url": server.URL, "llama_mode": "embedding"}})
+	if err != nil {
+		t.Fatalf("NewLlamaServerReranker failed: %v", err)
+	}
+	_, err = r.ComputeScore(context.Background(), "query", []Document{{Content: "doc"}})
+	if !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for missing embeddings, got %v", err)
+	}
+}
+
+func TestLlamaServerReranker_HealthCheck(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/health" {
+			t.Errorf("Expected GET /health, got %s %s", req.Method, req.URL.Path)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	r, err := NewLlamaServerReranker(Config{Options: map[string]interface{}{"base_url": server.URL}})
+	if err != nil {
+		t.Fatalf("NewLlamaServerReranker failed: %v", err)
+	}
+	if err := r.HealthCheck(context.Background()); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference while the model loads, got %v", err)
+	}
+	status = http.StatusOK
+	if err := r.HealthCheck(context.Background()); err != nil {
+		t.Errorf("Expected a healthy server, got %v", err)
+	}
+
+	server.Close()
+	if err := r.HealthCheck(context.Background()); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for a stopped server, got %v", err)
+	}
+}
+
+func TestStartLlamaServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve port: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	t.Setenv(llamaServerHelperEnv, addr)
+	handle, err := startLlamaServer(os.Args[0], nil, "http://"+addr, 10*time.Second)
+	if err != nil {
+		t.Fatalf("startLlamaServer failed: %v", err)
+	}
+
+	resp, err := http.Get(handle.URL + "/health")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected healthy server, got %v %v", resp, err)
+	}
+	resp.Body.Close()
+
+	if err := handle.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if err := handle.Close(); err != nil {
+		t.Errorf("Second Close failed: %v", err)
+	}
+	if _, err := http.Get(handle.URL + "/health"); err == nil {
+		t.Error("Expected server to be stopped after Close")
+	}
+}
+
+func TestStartLlamaServer_ExitsEarly(t *testing.T) {
+	_, err := startLlamaServer("false", nil, "http://127.0.0.1:1", 10*time.Second)
+	if !errors.Is(err, ErrInitialization) {
+		t.Errorf("Expected ErrInitialization when process exits, got %v", err)
+	}
+}
+
+func TestStartLocalServer_MissingModel(t *testing.T) {
+	_, err := StartLocalServer("/nonexistent/model.gguf", 8080)
+	if !errors.Is(err, ErrInitialization) {
+		t.Errorf("Expected ErrInitialization, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// DefaultMMRLambda balances relevance against diversity equally
+const DefaultMMRLambda = 0.5
+
+// MMRReranker wraps another reranker and reorders its output with
+// Maximal Marginal Relevance: at each step it selects the document maximizing
+// λ * relevance - (1-λ) * max cosine similarity to the documents already selected.
+// Relevance scores are min-max normalized to [0, 1] so they are comparable with
+// the TF-IDF cosine similarities used for the diversity penalty.
+type MMRReranker struct {
+	config Config
+	inner  Reranker
+	lambda float64
+}
+
+// NewMMRReranker wraps inner; λ is read from Config.Options["mmr_lambda"]
+func NewMMRReranker(inner Reranker, config Config) (*MMRReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: MMR requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &MMRReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rerank reorders documents in MMR selection order, setting Score to the MMR score
+func (r *MMRReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	results, err := r.Rank(ctx, query, documents, r.config.MaxDocs)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := make([]Document, len(results))
+	for i, result := range results {
+		reranked[i] = result.Document
+		reranked[i].Score = result.Score
+	}
+	return reranked, nil
+}
+
+// ComputeScore returns each document's MMR score in original document order
+func (r *MMRReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	relevance, err := r.inner.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]int, len(documents))
+	for i := range candidates {
+		candidates[i] = i
+	}
+
+	scores := make([]float64, len(documents))
+	for _, selection := range r.selectMMR(documents, relevance, candidates, 0) {
+		scores[selection.index] = selection.score
+	}
+	return scores, nil
+}
+
+// Rank returns up to topN documents in MMR selection order
+func (r *MMRReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	relevance, err := r.inner.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Threshold applies to the inner reranker's relevance scores
+	threshold := r.config.scoreThreshold(relevance)
+	var candidates []int
+	for i, score := range relevance {
+		if score >= threshold {
+			candidates = append(candidates, i)
+		}
+	}
+
+	selections := r.selectMMR(documents, relevance, candidates, topN)
+	results := make([]RerankResult, len(selections))
+	for i, selection := range selections {
+		results[i] = RerankResult{
+			Document: documents[selection.index],
+			Score:    selection.score,
+			Index:    selection.index,
+		}
+	}
+	return assignRanks(results, r.config.NormalizeScores), nil
+}
+
+// mmrSelection records a selected document and its MMR score at selection time
+type mmrSelection struct {
+	index int
+	score float64
+}
+
+// selectMMR greedily selects up to limit candidates (all when limit <= 0)
+func (r *MMRReranker) selectMMR(documents []Document, relevance []float64, candidates []int, limit int) []mmrSelection {
+	if limit <= 0 || limit > len(candidates) {
+		limit = len(candidates)
+	}
+
+	normalized := NormalizeMinMax(relevance)
+	texts := make([]string, len(documents))
+	for i, doc := range documents {
+		texts[i] = doc.Content
+	}
+	vectors := tfidfVectors(texts)
+
+	// maxSimilarity[i] tracks the highest similarity of candidate i to any selected document
+	maxSimilarity := make([]float64, len(documents))
+	remaining := append([]int(nil), candidates...)
+	selections := make([]mmrSelection, 0, limit)
+
+	for len(selections) < limit {
+		bestPos, bestScore := -1, math.Inf(-1)
+		for pos, candidate := range remaining {
+			score := r.lambda*normalized[candidate] - (1-r.lambda)*maxSimilarity[candidate]
+			if score > bestScore {
+				bestPos, bestScore = pos, score
+			}
+		}
+
+		chosen := remaining[bestPos]
+		selections = append(selections, mmrSelection{index: chosen, score: bestScore})
+		remaining = append(remaining[:bestPos], remaining[bestPos+1:]...)
+
+		for _, candidate := range remaining {
+			similarity := sparseCosine(vectors[candidate], vectors[chosen])
+			if similarity > maxSimilarity[candidate] {
+				maxSimilarity[candidate] = similarity
+			}
+		}
+	}
+
+	return selections
+}
+
+// GetModelName returns the wrapped model name
+func (r *MMRReranker) GetModelName() string {
+	return "mmr(" + r.inner.GetModelName() + ")"
+}
+
+// Version returns the wrapped reranker's version
+func (r *MMRReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *MMRReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the MMR configuration; the inner reranker is left unchanged
+func (r *MMRReranker) Configure(config Config) error {
+	lambda := OptionFloat(config.Options, "mmr_lambda", DefaultMMRLambda)
+	if lambda < 0 || lambda > 1 {
+		return fmt.Errorf("%w: mmr_lambda must be in [0, 1], got %f", ErrInvalidInput, lambda)
+	}
+
+	r.config = config
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.lambda = lambda
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *MMRReranker) Close() error {
+	return closeReranker(r.inner)
+}
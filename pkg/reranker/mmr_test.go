@@ -0,0 +1,73 @@
+package reranker
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMMRReranker_Diversity(t *testing.T) {
+	inner := &orderedReranker{name: "inner", order: []string{"a", "a-dup", "b", "c"}}
+	documents := []Document{
+		{ID: "b", Content: "Deep learning powers modern speech recognition systems"},
+		{ID: "a", Content: "Machine learning models learn patterns from training data"},
+		{ID: "c", Content: "Gradient boosting is a popular tabular learning method"},
+		{ID: "a-dup", Content: "Machine learning models learn patterns from the training data"},
+	}
+
+	// Without diversity the duplicates take the top-2 slots
+	plain, err := NewMMRReranker(inner, Config{Options: map[string]interface{}{"mmr_lambda": 1.0}})
+	if err != nil {
+		t.Fatalf("NewMMRReranker failed: %v", err)
+	}
+	results, err := plain.Rank(context.Background(), "machine learning", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if results[0].Document.ID != "a" || results[1].Document.ID != "a-dup" {
+		t.Fatalf("Expected relevance order a, a-dup with lambda=1, got %s, %s", results[0].Document.ID, results[1].Document.ID)
+	}
+
+	mmr, err := NewMMRReranker(inner, Config{})
+	if err != nil {
+		t.Fatalf("NewMMRReranker failed: %v", err)
+	}
+	results, err = mmr.Rank(context.Background(), "machine learning", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Document.ID != "a" {
+		t.Errorf("Expected most relevant document first, got %s", results[0].Document.ID)
+	}
+	if results[1].Document.ID == "a-dup" {
+		t.Error("Expected near-duplicate to be pushed out of the top-2")
+	}
+	if results[1].Index != 0 || results[0].Score < results[1].Score {
+		t.Errorf("Expected Index to be the input position and MMR scores non-increasing, got %+v", results)
+	}
+}
+
+func TestMMRReranker_ComputeScore(t *testing.T) {
+	inner := &orderedReranker{name: "inner", order: []string{"x", "y"}}
+	mmr, err := NewMMRReranker(inner, Config{})
+	if err != nil {
+		t.Fatalf("NewMMRReranker failed: %v", err)
+	}
+
+	scores, err := mmr.ComputeScore(context.Background(), "q", []Document{{ID: "y", Content: "beta"}, {ID: "x", Content: "alpha"}})
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[1] != DefaultMMRLambda || scores[0] != 0 {
+		t.Errorf("Expected MMR scores [0 0.5], got %v", scores)
+	}
+}
+
+func TestMMRReranker_InvalidLambda(t *testing.T) {
+	inner := &orderedReranker{name: "inner"}
+	if _, err := NewMMRReranker(inner, Config{Options: map[string]interface{}{"mmr_lambda": 1.5}}); err == nil {
+		t.Error("Expected error for lambda outside [0, 1]")
+	}
+}
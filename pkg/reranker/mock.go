@@ -0,0 +1,135 @@
+package reranker
+
+import "context"
+
+// ScoreFunc scores one document against a query
+type ScoreFunc func(query string, doc Document) float64
+
+// MockReranker is a Reranker backed by a ScoreFunc, for testing code that
+// uses rerankers without model files. Threshold, MaxDocs, NormalizeScores and
+// the tie-breaking options apply as for the other rerankers; a new mock keeps
+// tied documents in input order (StableSort).
+type MockReranker struct {
+	config Config
+
+	// ScoreFunc scores each document; nil scores every document 0
+	ScoreFunc ScoreFunc
+	// Err, when set, is returned by every scoring call and by HealthCheck
+	Err error
+}
+
+// NewMockReranker creates a mock scoring documents with score
+func NewMockReranker(score ScoreFunc) *MockReranker {
+	return &MockReranker{config: Config{Model: "mock", MaxDocs: 100, StableSort: true}, ScoreFunc: score}
+}
+
+// NewFixedScoreMock creates a mock scoring documents by ID; unknown IDs score 0
+func NewFixedScoreMock(scores map[string]float64) *MockReranker {
+	return NewMockReranker(func(query string, doc Document) float64 {
+		return scores[doc.ID]
+	})
+}
+
+// NewRelevanceOrderMock creates a mock scoring relevantIDs 1.0 and every other document 0.0
+func NewRelevanceOrderMock(relevantIDs []string) *MockReranker {
+	relevant := make(map[string]float64, len(relevantIDs))
+	for _, id := range relevantIDs {
+		relevant[id] = 1.0
+	}
+	return NewFixedScoreMock(relevant)
+}
+
+// Rerank reorders documents by their mock scores
+func (r *MockReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns the mock score of each document in document order. It
+// fails with Err when set, or with the context's error once ctx is done.
+func (r *MockReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *MockReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if ctx != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	scores := make([]float64, len(documents))
+	if r.ScoreFunc != nil {
+		for i, doc := range documents {
+			scores[i] = r.ScoreFunc(query, doc)
+		}
+	}
+	return r.config.transformScores(scores)
+}
+
+// Rank returns top-N documents by their mock scores
+func (r *MockReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// Configure replaces the configuration; the Model name defaults to "mock"
+func (r *MockReranker) Configure(config Config) error {
+	if err := validateThreshold(config); err != nil {
+		return err
+	}
+	if _, err := applyNormalization(nil, config.NormalizeScores); err != nil {
+		return err
+	}
+
+	r.config = config
+	if r.config.Model == "" {
+		r.config.Model = "mock"
+	}
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	return nil
+}
+
+// GetModelName returns the configured model name, "mock" by default
+func (r *MockReranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"
+func (r *MockReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck returns Err
+func (r *MockReranker) HealthCheck(ctx context.Context) error {
+	return r.Err
+}
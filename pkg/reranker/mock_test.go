@@ -0,0 +1,140 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var _ Reranker = (*MockReranker)(nil)
+
+func mockDocuments() []Document {
+	return []Document{
+		{ID: "a", Content: "first"},
+		{ID: "b", Content: "second"},
+		{ID: "c", Content: "third"},
+		{ID: "d", Content: "fourth"},
+	}
+}
+
+func TestMockReranker_ScoreFunc(t *testing.T) {
+	r := NewMockReranker(func(query string, doc Document) float64 {
+		return float64(strings.Count(doc.Content, query))
+	})
+	scores, err := r.ComputeScore(context.Background(), "i", mockDocuments())
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if want := []float64{1, 0, 1, 0}; !reflect.DeepEqual(scores, want) {
+		t.Errorf("Expected %v, got %v", want, scores)
+	}
+	if r.GetModelName() != "mock" {
+		t.Errorf("Expected model name mock, got %q", r.GetModelName())
+	}
+	if err := r.HealthCheck(context.Background()); err != nil {
+		t.Errorf("Expected a healthy mock, got %v", err)
+	}
+}
+
+func TestFixedScoreMock_RankAndRerank(t *testing.T) {
+	r := NewFixedScoreMock(map[string]float64{"a": 0.2, "b": 0.9, "c": 0.5})
+	documents := mockDocuments()
+
+	results, err := r.Rank(context.Background(), "query", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if got := resultIDs(results); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Fatalf("Expected [b c], got %v", got)
+	}
+	if results[0].Score != 0.9 || results[0].Index != 1 || results[0].Rank != 1 || results[1].Rank != 2 {
+		t.Errorf("Unexpected ranked results %+v", results)
+	}
+
+	reranked, err := r.Rerank(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	var ids []string
+	for _, doc := range reranked {
+		ids = append(ids, doc.ID)
+	}
+	if want := []string{"b", "c", "a", "d"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+	if reranked[0].Score != 0.9 || reranked[3].Score != 0 {
+		t.Errorf("Expected reranked documents to carry their scores, got %+v", reranked)
+	}
+}
+
+func TestRelevanceOrderMock(t *testing.T) {
+	r := NewRelevanceOrderMock([]string{"d", "b"})
+	results, err := r.Rank(context.Background(), "query", mockDocuments(), 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	// Equal scores keep input order
+	if got := resultIDs(results); !reflect.DeepEqual(got, []string{"b", "d", "a", "c"}) {
+		t.Fatalf("Expected [b d a c], got %v", got)
+	}
+	for _, result := range results {
+		want := 0.0
+		if result.Document.ID == "b" || result.Document.ID == "d" {
+			want = 1.0
+		}
+		if result.Score != want {
+			t.Errorf("Expected %s to score %v, got %v", result.Document.ID, want, result.Score)
+		}
+	}
+}
+
+func TestMockReranker_Configure(t *testing.T) {
+	r := NewRelevanceOrderMock([]string{"a"})
+	if err := r.Configure(Config{Model: "custom", Threshold: 0.5, StableSort: true}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if r.GetModelName() != "custom" {
+		t.Errorf("Expected model name custom, got %q", r.GetModelName())
+	}
+	results, err := r.Rank(context.Background(), "query", mockDocuments(), 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if got := resultIDs(results); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Expected the threshold to keep only [a], got %v", got)
+	}
+
+	if err := r.Configure(Config{NormalizeScores: "bogus"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for an unknown normalization, got %v", err)
+	}
+	if err := r.Configure(Config{}); err != nil || r.GetModelName() != "mock" {
+		t.Errorf("Expected the default model name after reconfiguring, got %q (%v)", r.GetModelName(), err)
+	}
+}
+
+func TestMockReranker_Errors(t *testing.T) {
+	r := NewFixedScoreMock(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.Rank(ctx, "query", mockDocuments(), 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	r.Err = ErrInference
+	if _, err := r.Rerank(context.Background(), "query", mockDocuments()); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected Err from Rerank, got %v", err)
+	}
+	if err := r.HealthCheck(context.Background()); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected Err from HealthCheck, got %v", err)
+	}
+
+	r.Err = nil
+	if results, err := r.Rank(context.Background(), "query", []Document{}, 0); err != nil || results != nil {
+		t.Errorf("Expected no results and no error for no documents, got %v, %v", results, err)
+	}
+	if _, err := r.Rank(context.Background(), "query", nil, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for nil documents, got %v", err)
+	}
+}
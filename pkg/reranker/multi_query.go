@@ -0,0 +1,239 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Multi-query score aggregations
+const (
+	MultiQueryMean         = "mean"
+	MultiQueryMax          = "max"
+	MultiQueryHarmonicMean = "harmonic_mean"
+)
+
+// MultiQueryReranker scores documents against several query variants (e.g.
+// HyDE passages or rephrasings) and ranks them by the aggregated score. The
+// query passed to each call is scored alongside the configured variants.
+// Ranked documents record the variant with the highest score in
+// Meta["best_query"].
+//
+// Recognized options:
+//   - "multi_query_agg": "mean" (default), "max" or "harmonic_mean"; the harmonic
+//     mean is only defined for positive scores and is 0 otherwise
+type MultiQueryReranker struct {
+	config  Config
+	inner   Reranker
+	queries []string
+	agg     string
+}
+
+// NewMultiQueryReranker wraps inner, scoring every call against queries as well
+func NewMultiQueryReranker(inner Reranker, queries []string, config Config) (*MultiQueryReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: multi-query reranking requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &MultiQueryReranker{inner: inner, queries: append([]string(nil), queries...)}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Queries returns the distinct non-blank variants scored for query, starting with query itself
+func (r *MultiQueryReranker) Queries(query string) []string {
+	seen := make(map[string]bool, len(r.queries)+1)
+	var variants []string
+	for _, variant := range append([]string{query}, r.queries...) {
+		if strings.TrimSpace(variant) == "" || seen[variant] {
+			continue
+		}
+		seen[variant] = true
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// validateInput checks the input like ValidateInput, accepting an empty call
+// query when query variants are configured
+func (r *MultiQueryReranker) validateInput(query string, documents []Document) error {
+	if variants := r.Queries(query); len(variants) > 0 {
+		query = variants[0]
+	}
+	return r.config.validateWrappedInput(query, documents)
+}
+
+// scoreVariants calls ComputeScore for every variant in parallel and aggregates
+// the scores, also returning the index of the best variant per document
+func (r *MultiQueryReranker) scoreVariants(ctx context.Context, variants []string, documents []Document) ([]float64, []int, error) {
+	if len(variants) == 0 {
+		return nil, nil, fmt.Errorf("%w: at least one query is required", ErrInvalidInput)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	perQuery := make([][]float64, len(variants))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, variant := range variants {
+		i, variant := i, variant
+		group.Go(func() error {
+			scores, err := r.inner.ComputeScore(groupCtx, variant, documents)
+			if err != nil {
+				return err
+			}
+			if len(scores) != len(documents) {
+				return fmt.Errorf("%w: expected %d scores for query %q, got %d", ErrInference, len(documents), variant, len(scores))
+			}
+			perQuery[i] = scores
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	scores := make([]float64, len(documents))
+	best := make([]int, len(documents))
+	values := make([]float64, len(variants))
+	for d := range documents {
+		for q := range variants {
+			values[q] = perQuery[q][d]
+			if values[q] > values[best[d]] {
+				best[d] = q
+			}
+		}
+		scores[d] = aggregateScores(values, r.agg)
+	}
+	return scores, best, nil
+}
+
+// aggregateScores combines one document's scores across query variants
+func aggregateScores(values []float64, agg string) float64 {
+	switch agg {
+	case MultiQueryMax:
+		highest := values[0]
+		for _, value := range values[1:] {
+			if value > highest {
+				highest = value
+			}
+		}
+		return highest
+	case MultiQueryHarmonicMean:
+		reciprocals := 0.0
+		for _, value := range values {
+			if value <= 0 {
+				return 0
+			}
+			reciprocals += 1 / value
+		}
+		return float64(len(values)) / reciprocals
+	default:
+		sum := 0.0
+		for _, value := range values {
+			sum += value
+		}
+		return sum / float64(len(values))
+	}
+}
+
+// Rerank reorders documents by their aggregated score
+func (r *MultiQueryReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, _, err := r.scoreVariants(ctx, r.Queries(query), documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns the aggregated score of each document across query variants
+func (r *MultiQueryReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, _, err := r.scoreVariants(ctx, r.Queries(query), documents)
+	return scores, err
+}
+
+// Rank returns top-N documents by their aggregated score, recording the best
+// scoring variant in Meta["best_query"]
+func (r *MultiQueryReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	variants := r.Queries(query)
+	scores, best, err := r.scoreVariants(ctx, variants, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	results := assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores)
+	for i := range results {
+		meta := make(map[string]interface{}, len(results[i].Document.Meta)+1)
+		for key, value := range results[i].Document.Meta {
+			meta[key] = value
+		}
+		meta["best_query"] = variants[best[results[i].Index]]
+		results[i].Document.Meta = meta
+	}
+	return results, nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *MultiQueryReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *MultiQueryReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *MultiQueryReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the aggregation; the inner reranker and queries are left unchanged
+func (r *MultiQueryReranker) Configure(config Config) error {
+	agg := OptionString(config.Options, "multi_query_agg", MultiQueryMean)
+	switch agg {
+	case MultiQueryMean, MultiQueryMax, MultiQueryHarmonicMean:
+	default:
+		return fmt.Errorf("%w: multi_query_agg must be %q, %q or %q, got %q", ErrInvalidInput, MultiQueryMean, MultiQueryMax, MultiQueryHarmonicMean, agg)
+	}
+
+	r.config = config
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.agg = agg
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *MultiQueryReranker) Close() error {
+	return closeReranker(r.inner)
+}
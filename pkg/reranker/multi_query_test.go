@@ -0,0 +1,86 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMultiQueryReranker_SingleRelevantQuery(t *testing.T) {
+	queries := []string{"python programming", "cooking recipes", "mountain hiking"}
+	documents := []Document{
+		{ID: "unrelated", Content: "quarterly tax filing deadlines"},
+		{ID: "hiking", Content: "best trails for mountain hiking"},
+	}
+
+	for _, agg := range []string{MultiQueryMean, MultiQueryMax} {
+		t.Run(agg, func(t *testing.T) {
+			inner := NewSimpleReranker(Config{Model: "simple"})
+			r, err := NewMultiQueryReranker(inner, queries[1:], Config{Options: map[string]interface{}{"multi_query_agg": agg}})
+			if err != nil {
+				t.Fatalf("NewMultiQueryReranker failed: %v", err)
+			}
+
+			results, err := r.Rank(context.Background(), queries[0], documents, 0)
+			if err != nil {
+				t.Fatalf("Rank failed: %v", err)
+			}
+			if len(results) != 2 || results[0].Document.ID != "hiking" {
+				t.Fatalf("Expected the hiking document first, got %+v", results)
+			}
+			if results[0].Score <= results[1].Score {
+				t.Errorf("Expected hiking score %f to exceed unrelated score %f", results[0].Score, results[1].Score)
+			}
+			if got := results[0].Document.Meta["best_query"]; got != "mountain hiking" {
+				t.Errorf("Expected best_query 'mountain hiking', got %v", got)
+			}
+			if results[0].Index != 1 || results[0].Rank != 1 {
+				t.Errorf("Expected index 1 at rank 1, got %+v", results[0])
+			}
+		})
+	}
+}
+
+func TestMultiQueryReranker_Queries(t *testing.T) {
+	r, err := NewMultiQueryReranker(NewSimpleReranker(Config{}), []string{"a", "", "b", "a"}, Config{})
+	if err != nil {
+		t.Fatalf("NewMultiQueryReranker failed: %v", err)
+	}
+
+	got := r.Queries("b")
+	if len(got) != 2 || got[0] != "b" || got[1] != "a" {
+		t.Errorf("Expected [b a], got %v", got)
+	}
+	if _, err := r.ComputeScore(context.Background(), "", []Document{{Content: "x"}}); err != nil {
+		t.Errorf("Expected configured variants to be scored without a call query: %v", err)
+	}
+}
+
+func TestAggregateScores(t *testing.T) {
+	tests := []struct {
+		agg    string
+		values []float64
+		want   float64
+	}{
+		{MultiQueryMean, []float64{1, 2, 3}, 2},
+		{MultiQueryMax, []float64{-1, 3, 2}, 3},
+		{MultiQueryHarmonicMean, []float64{1, 2, 4}, 3 / 1.75},
+		{MultiQueryHarmonicMean, []float64{1, 0, 4}, 0},
+	}
+	for _, tt := range tests {
+		if got := aggregateScores(tt.values, tt.agg); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("aggregateScores(%v, %s) = %f, want %f", tt.values, tt.agg, got, tt.want)
+		}
+	}
+}
+
+func TestMultiQueryReranker_InvalidAggregation(t *testing.T) {
+	_, err := NewMultiQueryReranker(NewSimpleReranker(Config{}), nil, Config{Options: map[string]interface{}{"multi_query_agg": "median"}})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := NewMultiQueryReranker(nil, nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for nil inner reranker, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Default field weights of MultiFieldReranker
+const (
+	DefaultTitleWeight = 0.3
+	DefaultBodyWeight  = 0.7
+)
+
+// Meta keys holding the fields of a document built by MultiFieldDocument.ToDocument
+const (
+	MetaTitle = "title"
+	MetaBody  = "body"
+)
+
+// MultiFieldDocument is a document with a separate title and body
+type MultiFieldDocument struct {
+	Document
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// ToDocument returns the document with Content set to the title and body
+// joined by a blank line, for display, and the fields kept in Meta["title"]
+// and Meta["body"] for MultiFieldReranker
+func (d MultiFieldDocument) ToDocument() Document {
+	doc := d.Document
+	doc.Meta = make(map[string]interface{}, len(d.Meta)+2)
+	for key, value := range d.Meta {
+		doc.Meta[key] = value
+	}
+	doc.Meta[MetaTitle] = d.Title
+	doc.Meta[MetaBody] = d.Body
+
+	switch {
+	case d.Title == "":
+		doc.Content = d.Body
+	case d.Body == "":
+		doc.Content = d.Title
+	default:
+		doc.Content = d.Title + "\n\n" + d.Body
+	}
+	return doc
+}
+
+// MultiFieldDocuments converts documents with ToDocument
+func MultiFieldDocuments(documents []MultiFieldDocument) []Document {
+	converted := make([]Document, len(documents))
+	for i, doc := range documents {
+		converted[i] = doc.ToDocument()
+	}
+	return converted
+}
+
+// MultiFieldReranker scores the title and body of each document separately
+// with the wrapped reranker and combines them as
+// title_weight * title_score + body_weight * body_score. Fields are read from
+// Meta["title"] and Meta["body"] (see MultiFieldDocument.ToDocument); a
+// document without them is scored as a body-only document using its Content.
+// Empty fields score 0, and a field with zero weight is not scored.
+//
+// Recognized options:
+//   - "title_weight": weight of the title score, at least 0 (default 0.3)
+//   - "body_weight": weight of the body score, at least 0 (default 0.7)
+type MultiFieldReranker struct {
+	config      Config
+	inner       Reranker
+	titleWeight float64
+	bodyWeight  float64
+}
+
+// NewMultiFieldReranker wraps inner; field weights are read from config options
+func NewMultiFieldReranker(inner Reranker, config Config) (*MultiFieldReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: multi-field reranking requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &MultiFieldReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// documentFields returns the title and body of doc
+func documentFields(doc Document) (string, string) {
+	title, hasTitle := doc.Meta[MetaTitle].(string)
+	body, hasBody := doc.Meta[MetaBody].(string)
+	if !hasTitle && !hasBody {
+		return "", doc.Content
+	}
+	return title, body
+}
+
+// fieldScores scores the non-empty field of each document selected by field,
+// leaving 0 for empty or blank fields
+func (r *MultiFieldReranker) fieldScores(ctx context.Context, query string, documents []Document, field func(Document) string) ([]float64, error) {
+	scores := make([]float64, len(documents))
+	var fieldDocs []Document
+	var positions []int
+	for i, doc := range documents {
+		if content := field(doc); strings.TrimSpace(content) != "" {
+			doc.Content = content
+			fieldDocs = append(fieldDocs, doc)
+			positions = append(positions, i)
+		}
+	}
+	if len(fieldDocs) == 0 {
+		return scores, nil
+	}
+
+	fieldScores, err := r.inner.ComputeScore(ctx, query, fieldDocs)
+	if err != nil {
+		return nil, err
+	}
+	if len(fieldScores) != len(fieldDocs) {
+		return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(fieldDocs), len(fieldScores))
+	}
+	for i, position := range positions {
+		scores[position] = fieldScores[i]
+	}
+	return scores, nil
+}
+
+// Rerank reorders documents by their combined field score
+func (r *MultiFieldReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns each document's combined field score in document order
+func (r *MultiFieldReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *MultiFieldReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores := make([]float64, len(documents))
+	fields := []struct {
+		weight float64
+		value  func(Document) string
+	}{
+		{r.titleWeight, func(doc Document) string { title, _ := documentFields(doc); return title }},
+		{r.bodyWeight, func(doc Document) string { _, body := documentFields(doc); return body }},
+	}
+	for _, field := range fields {
+		if field.weight == 0 {
+			continue
+		}
+		fieldScores, err := r.fieldScores(ctx, query, documents, field.value)
+		if err != nil {
+			return nil, err
+		}
+		for i, score := range fieldScores {
+			scores[i] += field.weight * score
+		}
+	}
+	return scores, nil
+}
+
+// Rank returns top-N documents by their combined field score
+func (r *MultiFieldReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// RankFields converts documents with ToDocument and ranks them
+func (r *MultiFieldReranker) RankFields(ctx context.Context, query string, documents []MultiFieldDocument, topN int) ([]RerankResult, error) {
+	return r.Rank(ctx, query, MultiFieldDocuments(documents), topN)
+}
+
+// GetModelName returns the wrapped model name
+func (r *MultiFieldReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *MultiFieldReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *MultiFieldReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the field weights; the inner reranker is left unchanged
+func (r *MultiFieldReranker) Configure(config Config) error {
+	titleWeight := OptionFloat(config.Options, "title_weight", DefaultTitleWeight)
+	bodyWeight := OptionFloat(config.Options, "body_weight", DefaultBodyWeight)
+	if titleWeight < 0 || bodyWeight < 0 {
+		return fmt.Errorf("%w: title_weight and body_weight must not be negative, got %v and %v", ErrInvalidInput, titleWeight, bodyWeight)
+	}
+	if titleWeight == 0 && bodyWeight == 0 {
+		return fmt.Errorf("%w: title_weight and body_weight must not both be 0", ErrInvalidInput)
+	}
+
+	r.config = config
+	r.titleWeight = titleWeight
+	r.bodyWeight = bodyWeight
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *MultiFieldReranker) Close() error {
+	return closeReranker(r.inner)
+}
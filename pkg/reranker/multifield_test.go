@@ -0,0 +1,76 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMultiFieldReranker_TitleMatchWithoutBodyMatch(t *testing.T) {
+	r, err := NewMultiFieldReranker(NewSimpleReranker(Config{Model: "simple"}), Config{})
+	if err != nil {
+		t.Fatalf("NewMultiFieldReranker failed: %v", err)
+	}
+
+	documents := []MultiFieldDocument{
+		{Document: Document{ID: "title"}, Title: "Machine learning", Body: "Cooking pasta recipes for busy weeknights"},
+		{Document: Document{ID: "none"}, Title: "Gardening", Body: "Tomatoes need sun"},
+	}
+	results, err := r.RankFields(context.Background(), "machine learning", documents, 0)
+	if err != nil {
+		t.Fatalf("RankFields failed: %v", err)
+	}
+
+	top := results[0]
+	if top.Document.ID != "title" || math.Abs(top.Score-DefaultTitleWeight) > 1e-9 {
+		t.Errorf("Expected the title match first with score %v, got %s with %v", DefaultTitleWeight, top.Document.ID, top.Score)
+	}
+	if top.Document.Content != "Machine learning\n\nCooking pasta recipes for busy weeknights" {
+		t.Errorf("Expected the combined content for display, got %q", top.Document.Content)
+	}
+	if results[1].Score != 0 {
+		t.Errorf("Expected no score without matches, got %v", results[1].Score)
+	}
+}
+
+func TestMultiFieldReranker_Weights(t *testing.T) {
+	r, err := NewMultiFieldReranker(NewSimpleReranker(Config{Model: "simple"}), Config{Options: map[string]interface{}{
+		"title_weight": 2.0,
+		"body_weight":  0.5,
+	}})
+	if err != nil {
+		t.Fatalf("NewMultiFieldReranker failed: %v", err)
+	}
+
+	documents := []Document{
+		MultiFieldDocument{Title: "machine", Body: "machine learning"}.ToDocument(),
+		{Content: "learning only"},
+	}
+	scores, err := r.ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	// Title matches half the query words, the body all of them; plain documents are body-only
+	want := []float64{2.0*0.5 + 0.5*1, 0.5 * 0.5}
+	for i := range want {
+		if math.Abs(scores[i]-want[i]) > 1e-9 {
+			t.Errorf("Document %d: expected %v, got %v", i, want[i], scores[i])
+		}
+	}
+}
+
+func TestMultiFieldReranker_InvalidWeights(t *testing.T) {
+	inner := NewSimpleReranker(Config{})
+	if _, err := NewMultiFieldReranker(nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without inner reranker, got %v", err)
+	}
+	for _, opts := range []map[string]interface{}{
+		{"title_weight": -0.1},
+		{"title_weight": 0.0, "body_weight": 0.0},
+	} {
+		if _, err := NewMultiFieldReranker(inner, Config{Options: opts}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %v, got %v", opts, err)
+		}
+	}
+}
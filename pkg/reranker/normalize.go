@@ -0,0 +1,146 @@
+package reranker
+
+import (
+	"fmt"
+	"math"
+)
+
+// ScoreNormalization selects how raw model scores are rescaled
+type ScoreNormalization string
+
+const (
+	// NormalizationNone returns raw model scores unchanged (unbounded, model specific)
+	NormalizationNone ScoreNormalization = "none"
+	// NormalizationMinMax rescales scores linearly into [0, 1] per batch
+	NormalizationMinMax ScoreNormalization = "minmax"
+	// NormalizationSigmoid maps each score independently into (0, 1)
+	NormalizationSigmoid ScoreNormalization = "sigmoid"
+	// NormalizationSoftmax maps scores into (0, 1] so that a batch sums to 1
+	NormalizationSoftmax ScoreNormalization = "softmax"
+)
+
+// NormalizeMinMax rescales scores linearly so the lowest becomes 0 and the highest 1.
+// When all scores are equal (including a single score) every value maps to 1.
+func NormalizeMinMax(scores []float64) []float64 {
+	normalized := make([]float64, len(scores))
+	if len(scores) == 0 {
+		return normalized
+	}
+
+	minScore, maxScore := scores[0], scores[0]
+	for _, score := range scores[1:] {
+		minScore = math.Min(minScore, score)
+		maxScore = math.Max(maxScore, score)
+	}
+
+	spread := maxScore - minScore
+	for i, score := range scores {
+		if spread == 0 {
+			normalized[i] = 1.0
+			continue
+		}
+		normalized[i] = (score - minScore) / spread
+	}
+	return normalized
+}
+
+// NormalizeSigmoid applies the logistic function to each score, giving values in (0, 1).
+// Unlike min-max and softmax, each output depends only on its own input score.
+func NormalizeSigmoid(scores []float64) []float64 {
+	normalized := make([]float64, len(scores))
+	for i, score := range scores {
+		normalized[i] = 1.0 / (1.0 + math.Exp(-score))
+	}
+	return normalized
+}
+
+// NormalizeSoftmax converts scores into a probability distribution over the batch:
+// every value is in (0, 1] and the values sum to 1.
+func NormalizeSoftmax(scores []float64) []float64 {
+	normalized := make([]float64, len(scores))
+	if len(scores) == 0 {
+		return normalized
+	}
+
+	// Subtract the maximum for numerical stability
+	maxScore := scores[0]
+	for _, score := range scores[1:] {
+		maxScore = math.Max(maxScore, score)
+	}
+
+	var sum float64
+	for i, score := range scores {
+		normalized[i] = math.Exp(score - maxScore)
+		sum += normalized[i]
+	}
+	for i := range normalized {
+		normalized[i] /= sum
+	}
+	return normalized
+}
+
+// applyNormalization rescales scores according to mode; an empty mode means none
+func applyNormalization(scores []float64, mode ScoreNormalization) ([]float64, error) {
+	switch mode {
+	case "", NormalizationNone:
+		return scores, nil
+	case NormalizationMinMax:
+		return NormalizeMinMax(scores), nil
+	case NormalizationSigmoid:
+		return NormalizeSigmoid(scores), nil
+	case NormalizationSoftmax:
+		return NormalizeSoftmax(scores), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown score normalization %q", ErrInvalidInput, mode)
+	}
+}
+
+// CapScores returns a copy of scores with every score above their
+// percentile-th percentile (see Percentile) clamped to it, so a few outliers
+// cannot compress the others after normalization. Scores at or below the
+// percentile are unchanged; a percentile of 1 or more changes nothing.
+func CapScores(scores []float64, percentile float64) []float64 {
+	capped := append([]float64(nil), scores...)
+	if percentile >= 1 {
+		return capped
+	}
+
+	limit := Percentile(scores, percentile)
+	for i, score := range capped {
+		capped[i] = math.Min(score, limit)
+	}
+	return capped
+}
+
+// transformScores applies Platt calibration when Options["calibration_a"] or
+// Options["calibration_b"] is set, caps scores at
+// Options["score_cap_percentile"] (default 1, no cap), applies
+// NormalizeScores and then the linear transform
+// Options["score_scale"] * score + Options["score_offset"] (defaults 1 and 0),
+// so thresholds compare against the transformed scores
+func (c Config) transformScores(scores []float64) ([]float64, error) {
+	capPercentile := OptionFloat(c.Options, "score_cap_percentile", 1)
+	if capPercentile <= 0 || capPercentile > 1 {
+		return nil, fmt.Errorf("%w: score_cap_percentile must be in (0, 1], got %v", ErrInvalidInput, capPercentile)
+	}
+	scores = applyCalibration(scores, c.Options)
+	if capPercentile < 1 {
+		scores = CapScores(scores, capPercentile)
+	}
+
+	normalized, err := applyNormalization(scores, c.NormalizeScores)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := OptionFloat(c.Options, "score_scale", 1)
+	offset := OptionFloat(c.Options, "score_offset", 0)
+	if scale == 1 && offset == 0 {
+		return normalized, nil
+	}
+	transformed := make([]float64, len(normalized))
+	for i, score := range normalized {
+		transformed[i] = scale*score + offset
+	}
+	return transformed, nil
+}
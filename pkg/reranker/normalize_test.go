@@ -0,0 +1,208 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestNormalizeMinMax(t *testing.T) {
+	got := NormalizeMinMax([]float64{-4, 0, 4})
+	want := []float64{0, 0.5, 1}
+	for i := range want {
+		if !approxEqual(got[i], want[i]) {
+			t.Errorf("Expected %v, got %v", want, got)
+			break
+		}
+	}
+
+	for _, scores := range [][]float64{{3, 3, 3}, {-7}} {
+		for _, score := range NormalizeMinMax(scores) {
+			if score != 1.0 {
+				t.Errorf("Expected equal scores %v to map to 1.0, got %f", scores, score)
+			}
+		}
+	}
+
+	if len(NormalizeMinMax(nil)) != 0 {
+		t.Error("Expected empty result for empty input")
+	}
+}
+
+func TestNormalizeSigmoid(t *testing.T) {
+	got := NormalizeSigmoid([]float64{-10, 0, 10})
+	if !approxEqual(got[1], 0.5) {
+		t.Errorf("Expected sigmoid(0) = 0.5, got %f", got[1])
+	}
+	if got[0] <= 0 || got[0] >= 0.5 || got[2] <= 0.5 || got[2] >= 1 {
+		t.Errorf("Expected values in (0, 1) preserving order, got %v", got)
+	}
+
+	single := NormalizeSigmoid([]float64{2})
+	if !approxEqual(single[0], 1/(1+math.Exp(-2))) {
+		t.Errorf("Unexpected sigmoid for single score: %f", single[0])
+	}
+}
+
+func TestNormalizeSoftmax(t *testing.T) {
+	for _, scores := range [][]float64{{-3, -1, 2}, {5, 5}, {-1000}, {1000, 999}} {
+		got := NormalizeSoftmax(scores)
+		var sum float64
+		for _, value := range got {
+			if math.IsNaN(value) || value <= 0 || value > 1 {
+				t.Errorf("Expected values in (0, 1] for %v, got %v", scores, got)
+			}
+			sum += value
+		}
+		if !approxEqual(sum, 1.0) {
+			t.Errorf("Expected softmax of %v to sum to 1, got %f", scores, sum)
+		}
+	}
+
+	equal := NormalizeSoftmax([]float64{2, 2, 2, 2})
+	if !approxEqual(equal[0], 0.25) {
+		t.Errorf("Expected uniform distribution for equal scores, got %v", equal)
+	}
+}
+
+func TestComputeScoreNormalization(t *testing.T) {
+	reranker := NewSimpleReranker(Config{Model: "simple", NormalizeScores: NormalizationMinMax})
+	documents := []Document{
+		{ID: "1", Content: "machine learning"},
+		{ID: "2", Content: "cooking"},
+		{ID: "3", Content: "machine"},
+	}
+
+	scores, err := reranker.ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] != 1.0 || scores[1] != 0.0 || !approxEqual(scores[2], 0.5) {
+		t.Errorf("Expected min-max scores [1 0 0.5], got %v", scores)
+	}
+
+	reranker.Configure(Config{NormalizeScores: "bogus"})
+	if _, err := reranker.ComputeScore(context.Background(), "q", documents); err == nil {
+		t.Error("Expected error for unknown normalization mode")
+	}
+}
+
+func TestComputeScoreScaleOffset(t *testing.T) {
+	documents := []Document{
+		{ID: "1", Content: "machine learning"},
+		{ID: "2", Content: "cooking"},
+		{ID: "3", Content: "machine"},
+	}
+	transform := map[string]interface{}{"score_scale": 2.0, "score_offset": "1"}
+
+	rerankers := map[string]func(Config) Reranker{
+		"simple":        func(c Config) Reranker { return NewSimpleReranker(c) },
+		"cross-encoder": func(c Config) Reranker { return NewCrossEncoderReranker(c) },
+		"mock": func(c Config) Reranker {
+			r := NewFixedScoreMock(map[string]float64{"1": 0.8, "2": -0.4, "3": 0.3})
+			r.Configure(c)
+			return r
+		},
+	}
+	for name, newReranker := range rerankers {
+		raw, err := newReranker(Config{Model: "m"}).ComputeScore(context.Background(), "machine learning", documents)
+		if err != nil {
+			t.Fatalf("%s: ComputeScore failed: %v", name, err)
+		}
+		scaled, err := newReranker(Config{Model: "m", Options: transform}).ComputeScore(context.Background(), "machine learning", documents)
+		if err != nil {
+			t.Fatalf("%s: ComputeScore failed: %v", name, err)
+		}
+		for i := range raw {
+			if !approxEqual(scaled[i], 2*raw[i]+1) {
+				t.Errorf("%s: expected score %d scaled from %v to %v, got %v", name, i, raw[i], 2*raw[i]+1, scaled[i])
+			}
+		}
+	}
+
+	// Normalization runs first, then the transform
+	normalized, err := NewSimpleReranker(Config{NormalizeScores: NormalizationMinMax, Options: transform}).ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if !approxEqual(normalized[0], 3) || !approxEqual(normalized[1], 1) || !approxEqual(normalized[2], 2) {
+		t.Errorf("Expected min-max scores transformed to [3 1 2], got %v", normalized)
+	}
+}
+
+func TestScoreScaleOffsetThreshold(t *testing.T) {
+	r := NewFixedScoreMock(map[string]float64{"a": 0.8, "b": 0.3, "c": -0.4})
+	// Raw scores 0.8, 0.3 and -0.4 become 2.6, 1.6 and 0.2
+	if err := r.Configure(Config{Threshold: 1.5, Options: map[string]interface{}{"score_scale": 2, "score_offset": 1}}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	documents := []Document{{ID: "a", Content: "a"}, {ID: "b", Content: "b"}, {ID: "c", Content: "c"}}
+
+	results, err := r.Rank(context.Background(), "q", documents, 3)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if ids := resultIDs(results); len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Fatalf("Expected a and b above the transformed threshold, got %v", ids)
+	}
+	if !approxEqual(results[0].Score, 2.6) || !approxEqual(results[1].Score, 1.6) {
+		t.Errorf("Expected transformed scores 2.6 and 1.6, got %v and %v", results[0].Score, results[1].Score)
+	}
+}
+
+func TestCapScores(t *testing.T) {
+	// 20 scores in shuffled order with two outliers
+	scores := []float64{3, 50, 1, 7, 2, 9, 4, 12, 6, 8, 40, 5, 10, 11, 13, 14, 15, 16, 17, 18}
+	limit := Percentile(scores, 0.9)
+	capped := CapScores(scores, 0.9)
+
+	clamped := 0
+	for i, score := range scores {
+		switch {
+		case score > limit:
+			clamped++
+			if capped[i] != limit {
+				t.Errorf("Expected outlier %v clamped to %v, got %v", score, limit, capped[i])
+			}
+		case capped[i] != score:
+			t.Errorf("Expected %v below the cap to be unchanged, got %v", score, capped[i])
+		}
+	}
+	if clamped != 2 {
+		t.Errorf("Expected the top 10%% (2 of 20) to be clamped, got %d", clamped)
+	}
+	if scores[1] != 50 {
+		t.Error("Expected CapScores not to modify its input")
+	}
+	if uncapped := CapScores(scores, 1); !reflect.DeepEqual(uncapped, scores) {
+		t.Errorf("Expected a cap of 1 to change nothing, got %v", uncapped)
+	}
+}
+
+func TestScoreCapPercentileOption(t *testing.T) {
+	r := NewFixedScoreMock(map[string]float64{"a": 1, "b": 2, "c": 3, "d": 4, "outlier": 50})
+	documents := []Document{{ID: "a", Content: "a"}, {ID: "b", Content: "b"}, {ID: "c", Content: "c"}, {ID: "d", Content: "d"}, {ID: "outlier", Content: "o"}}
+
+	// Capping at the 75th percentile (4) keeps min-max scores spread over [0, 1]
+	if err := r.Configure(Config{NormalizeScores: NormalizationMinMax, Options: map[string]interface{}{"score_cap_percentile": 0.75}}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	scores, err := r.ComputeScore(context.Background(), "q", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if want := []float64{0, 1.0 / 3, 2.0 / 3, 1, 1}; !approxEqualScores(scores, want) {
+		t.Errorf("Expected capped min-max scores %v, got %v", want, scores)
+	}
+
+	r.Configure(Config{Options: map[string]interface{}{"score_cap_percentile": 1.5}})
+	if _, err := r.ComputeScore(context.Background(), "q", documents); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for a cap above 1, got %v", err)
+	}
+}
@@ -0,0 +1,340 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// OpenAICompatModelPrefix marks model names served by an OpenAI-compatible API
+const OpenAICompatModelPrefix = "openai/"
+
+// Scoring modes for OpenAI-compatible servers
+const (
+	OpenAIModeEmbeddings = "embeddings"
+	OpenAIModeChat       = "chat"
+)
+
+// DefaultRelevancePrompt asks a chat model for a single relevance number
+const DefaultRelevancePrompt = "Rate how relevant the document is to the query on a scale from 0 to 1. " +
+	"Respond with only the number.\n\nQuery: {{query}}\nDocument: {{document}}\nRelevance:"
+
+// defaultOpenAIConcurrency bounds concurrent chat-completion requests
+const defaultOpenAIConcurrency = 4
+
+// relevanceNumber matches the first number in a chat completion
+var relevanceNumber = regexp.MustCompile(`-?\d+(?:\.\d+)?`)
+
+// OpenAICompatReranker implements reranking against self-hosted servers
+// exposing an OpenAI-compatible API (Ollama, LocalAI, llama-cpp-python, ...).
+// In embeddings mode documents are scored by cosine similarity to the query
+// embedding; in chat mode the model is asked to judge relevance per document.
+//
+// Recognized options:
+//   - "base_url": API base URL, e.g. "http://localhost:11434/v1" (required)
+//   - "api_key": bearer token sent in the Authorization header
+//   - "openai_mode": "embeddings" (default) or "chat"
+//   - "prompt_template": chat prompt with {{query}} and {{document}} placeholders
+//   - "concurrency": parallel chat requests (default 4)
+//   - "timeout_seconds", "max_retries": same as HTTPReranker
+type OpenAICompatReranker struct {
+	config         Config
+	baseURL        string
+	apiKey         string
+	mode           string
+	promptTemplate string
+	concurrency    int
+	maxRetries     int
+	client         *http.Client
+}
+
+// OpenAIEmbeddingsRequest represents the body of POST /embeddings
+type OpenAIEmbeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// OpenAIEmbeddingsResponse represents the response of POST /embeddings
+type OpenAIEmbeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// OpenAIChatMessage is a single chat message
+type OpenAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// OpenAIChatRequest represents the body of POST /chat/completions
+type OpenAIChatRequest struct {
+	Model       string              `json:"model"`
+	Messages    []OpenAIChatMessage `json:"messages"`
+	Temperature float64             `json:"temperature"`
+	MaxTokens   int                 `json:"max_tokens,omitempty"`
+}
+
+// OpenAIChatResponse represents the response of POST /chat/completions
+type OpenAIChatResponse struct {
+	Choices []struct {
+		Message OpenAIChatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// NewOpenAICompatReranker creates a new reranker backed by an OpenAI-compatible server
+func NewOpenAICompatReranker(config Config) (*OpenAICompatReranker, error) {
+	baseURL := strings.TrimRight(OptionString(config.Options, "base_url", ""), "/")
+	if baseURL == "" {
+		return nil, fmt.Errorf("%w: base_url option is required for OpenAI-compatible reranker", ErrInvalidInput)
+	}
+
+	mode := OptionString(config.Options, "openai_mode", OpenAIModeEmbeddings)
+	if mode != OpenAIModeEmbeddings && mode != OpenAIModeChat {
+		return nil, fmt.Errorf("%w: openai_mode must be %q or %q, got %q", ErrInvalidInput, OpenAIModeEmbeddings, OpenAIModeChat, mode)
+	}
+
+	if config.MaxDocs == 0 {
+		config.MaxDocs = 100
+	}
+
+	maxRetries := OptionInt(config.Options, "max_retries", defaultHTTPMaxRetries)
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	concurrency := OptionInt(config.Options, "concurrency", defaultOpenAIConcurrency)
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	return &OpenAICompatReranker{
+		config:         config,
+		baseURL:        baseURL,
+		apiKey:         OptionString(config.Options, "api_key", ""),
+		mode:           mode,
+		promptTemplate: OptionString(config.Options, "prompt_template", DefaultRelevancePrompt),
+		concurrency:    concurrency,
+		maxRetries:     maxRetries,
+		client: &http.Client{
+			Timeout: OptionDuration(config.Options, "timeout_seconds", defaultHTTPTimeout),
+		},
+	}, nil
+}
+
+// Rerank reorders documents based on relevance scores from the server
+func (r *OpenAICompatReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore scores query-document pairs using the configured mode
+func (r *OpenAICompatReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *OpenAICompatReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var scores []float64
+	var err error
+	if r.mode == OpenAIModeChat {
+		scores, err = r.chatScores(ctx, query, documents)
+	} else {
+		scores, err = r.embeddingScores(ctx, query, documents)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.config.transformScores(scores)
+}
+
+// modelID strips the routing prefix from the configured model name
+func (r *OpenAICompatReranker) modelID() string {
+	return strings.TrimPrefix(r.config.Model, OpenAICompatModelPrefix)
+}
+
+// post sends a JSON request to path under the base URL and decodes the response
+func (r *OpenAICompatReranker) post(ctx context.Context, path string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("%w: failed to encode request: %v", ErrInvalidInput, err)
+	}
+
+	payload, err := postJSONWithRetry(ctx, r.client, r.baseURL+path, r.apiKey, body, r.maxRetries)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(payload, response); err != nil {
+		return fmt.Errorf("%w: failed to parse %s response: %v", ErrInference, path, err)
+	}
+	return nil
+}
+
+// embeddingScores embeds the query and documents in one batch and returns cosine similarities
+func (r *OpenAICompatReranker) embeddingScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	request := OpenAIEmbeddingsRequest{
+		Model: r.modelID(),
+		Input: make([]string, 0, len(documents)+1),
+	}
+	request.Input = append(request.Input, query)
+	for _, doc := range documents {
+		request.Input = append(request.Input, doc.Content)
+	}
+
+	var response OpenAIEmbeddingsResponse
+	if err := r.post(ctx, "/embeddings", request, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Data) != len(request.Input) {
+		return nil, fmt.Errorf("%w: expected %d embeddings, got %d", ErrInference, len(request.Input), len(response.Data))
+	}
+
+	embeddings := make([][]float64, len(request.Input))
+	seen := make([]bool, len(request.Input))
+	for _, item := range response.Data {
+		if item.Index < 0 || item.Index >= len(embeddings) {
+			return nil, fmt.Errorf("%w: embedding index %d out of range", ErrInference, item.Index)
+		}
+		if seen[item.Index] {
+			return nil, fmt.Errorf("%w: duplicate embedding index %d", ErrInference, item.Index)
+		}
+		seen[item.Index] = true
+		embeddings[item.Index] = item.Embedding
+	}
+
+	scores := make([]float64, len(documents))
+	for i := range documents {
+		scores[i] = cosineSimilarity(embeddings[0], embeddings[i+1])
+	}
+	return scores, nil
+}
+
+// chatScores asks the chat model to judge each document, bounded by the concurrency option
+func (r *OpenAICompatReranker) chatScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	scores := make([]float64, len(documents))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(r.concurrency)
+
+	for i, doc := range documents {
+		i, doc := i, doc
+		group.Go(func() error {
+			request := OpenAIChatRequest{
+				Model:       r.modelID(),
+				Messages:    []OpenAIChatMessage{{Role: "user", Content: r.RenderPrompt(query, doc.Content)}},
+				Temperature: 0,
+				MaxTokens:   16,
+			}
+
+			var response OpenAIChatResponse
+			if err := r.post(groupCtx, "/chat/completions", request, &response); err != nil {
+				return err
+			}
+			if len(response.Choices) == 0 {
+				return fmt.Errorf("%w: chat completion returned no choices", ErrInference)
+			}
+
+			score, err := parseRelevance(response.Choices[0].Message.Content)
+			if err != nil {
+				return err
+			}
+			scores[i] = score
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// RenderPrompt fills the {{query}} and {{document}} placeholders of the prompt template
+func (r *OpenAICompatReranker) RenderPrompt(query, document string) string {
+	return strings.NewReplacer("{{query}}", query, "{{document}}", document).Replace(r.promptTemplate)
+}
+
+// parseRelevance extracts the first number from a chat completion
+func parseRelevance(content string) (float64, error) {
+	match := relevanceNumber.FindString(content)
+	if match == "" {
+		return 0, fmt.Errorf("%w: no relevance score in completion %q", ErrInference, content)
+	}
+	score, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid relevance score %q: %v", ErrInference, match, err)
+	}
+	return score, nil
+}
+
+// Rank returns top-N ranked documents
+func (r *OpenAICompatReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the model name
+func (r *OpenAICompatReranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"; the service does not report
+// the version of the model it serves
+func (r *OpenAICompatReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck fails when GET {base_url}/models cannot be reached
+func (r *OpenAICompatReranker) HealthCheck(ctx context.Context) error {
+	return checkReachable(ctx, r.client, r.baseURL+"/models")
+}
+
+// Configure updates the reranker configuration
+func (r *OpenAICompatReranker) Configure(config Config) error {
+	updated, err := NewOpenAICompatReranker(config)
+	if err != nil {
+		return err
+	}
+	*r = *updated
+	return nil
+}
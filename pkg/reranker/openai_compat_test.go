@@ -0,0 +1,147 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestOpenAICompatReranker_Embeddings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/embeddings" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer local-key" {
+			t.Errorf("Expected bearer API key, got %q", got)
+		}
+
+		var body OpenAIEmbeddingsRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if body.Model != "nomic-embed-text" {
+			t.Errorf("Expected model prefix to be stripped, got %q", body.Model)
+		}
+
+		// The query and anything mentioning "cats" point the same way
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		}
+		var data []item
+		for i, input := range body.Input {
+			embedding := []float64{0, 1}
+			if i == 0 || strings.Contains(input, "cats") {
+				embedding = []float64{1, 0}
+			}
+			data = append(data, item{Index: i, Embedding: embedding})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	r, err := NewReranker(Config{
+		Model:   "openai/nomic-embed-text",
+		Options: map[string]interface{}{"base_url": server.URL + "/v1/", "api_key": "local-key"},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*OpenAICompatReranker); !ok {
+		t.Fatalf("Expected *OpenAICompatReranker, got %T", r)
+	}
+
+	documents := []Document{{ID: "dogs", Content: "all about dogs"}, {ID: "cats", Content: "all about cats"}}
+	results, err := r.Rank(context.Background(), "pets", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if results[0].Document.ID != "cats" || results[0].Score != 1.0 {
+		t.Errorf("Expected cats first with similarity 1, got %+v", results[0])
+	}
+	if results[1].Score != 0.0 {
+		t.Errorf("Expected orthogonal document to score 0, got %v", results[1].Score)
+	}
+}
+
+func TestOpenAICompatReranker_Chat(t *testing.T) {
+	var mutex sync.Mutex
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/chat/completions" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+
+		var body OpenAIChatRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		prompt := body.Messages[0].Content
+		mutex.Lock()
+		prompts = append(prompts, prompt)
+		mutex.Unlock()
+
+		answer := "0.1"
+		if strings.Contains(prompt, "relevant text") {
+			answer = "Score: 0.9"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"role": "assistant", "content": answer}}},
+		})
+	}))
+	defer server.Close()
+
+	r, err := NewOpenAICompatReranker(Config{
+		Model: "openai/llama3",
+		Options: map[string]interface{}{
+			"base_url":        server.URL,
+			"openai_mode":     OpenAIModeChat,
+			"prompt_template": "Q={{query}} D={{document}}",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAICompatReranker failed: %v", err)
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "q", []Document{{Content: "noise"}, {Content: "relevant text"}})
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] != 0.1 || scores[1] != 0.9 {
+		t.Errorf("Expected scores [0.1 0.9], got %v", scores)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, prompt := range prompts {
+		if !strings.HasPrefix(prompt, "Q=q D=") {
+			t.Errorf("Prompt template not applied: %q", prompt)
+		}
+	}
+}
+
+func TestOpenAICompatReranker_InvalidConfig(t *testing.T) {
+	if _, err := NewOpenAICompatReranker(Config{Model: "openai/x"}); err == nil {
+		t.Error("Expected error when base_url is missing")
+	}
+	_, err := NewOpenAICompatReranker(Config{
+		Model:   "openai/x",
+		Options: map[string]interface{}{"base_url": "http://localhost:1", "openai_mode": "completions"},
+	})
+	if err == nil {
+		t.Error("Expected error for unknown openai_mode")
+	}
+}
+
+func TestParseRelevance(t *testing.T) {
+	if score, err := parseRelevance(" 0.75\n"); err != nil || score != 0.75 {
+		t.Errorf("Expected 0.75, got %v (%v)", score, err)
+	}
+	if _, err := parseRelevance("not relevant"); err == nil {
+		t.Error("Expected error for completion without a number")
+	}
+}
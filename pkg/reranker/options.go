@@ -0,0 +1,114 @@
+package reranker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OptionString reads a string option, returning def when missing or empty
+func OptionString(opts map[string]interface{}, key, def string) string {
+	if opts == nil {
+		return def
+	}
+	if value, ok := opts[key].(string); ok && value != "" {
+		return value
+	}
+	return def
+}
+
+// OptionInt reads an integer option, accepting int, float64 (JSON) and numeric strings
+func OptionInt(opts map[string]interface{}, key string, def int) int {
+	if opts == nil {
+		return def
+	}
+	switch value := opts[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	case string:
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
+
+// OptionFloat reads a float option, accepting float64, int and numeric strings
+func OptionFloat(opts map[string]interface{}, key string, def float64) float64 {
+	if opts == nil {
+		return def
+	}
+	switch value := opts[key].(type) {
+	case float64:
+		return value
+	case float32:
+		return float64(value)
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case string:
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
+
+// OptionBool reads a boolean option, accepting bool and strconv-style strings
+func OptionBool(opts map[string]interface{}, key string, def bool) bool {
+	if opts == nil {
+		return def
+	}
+	switch value := opts[key].(type) {
+	case bool:
+		return value
+	case string:
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
+
+// OptionDuration reads a duration expressed in whole seconds
+func OptionDuration(opts map[string]interface{}, key string, def time.Duration) time.Duration {
+	seconds := OptionFloat(opts, key, -1)
+	if seconds <= 0 {
+		return def
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// OptionStrings reads a string list option, accepting []string, []interface{}
+// (JSON) and comma-separated strings
+func OptionStrings(opts map[string]interface{}, key string) []string {
+	if opts == nil {
+		return nil
+	}
+	switch value := opts[key].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case string:
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package reranker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+var (
+	_ PaginatedReranker = (*SimpleReranker)(nil)
+	_ PaginatedReranker = (*CrossEncoderReranker)(nil)
+)
+
+// pageCursor is the position of the last result of a page
+type pageCursor struct {
+	Score float64 `json:"s"`
+	Index int     `json:"i"`
+}
+
+// encodeCursor returns the opaque token for the position after result
+func encodeCursor(result RerankResult) string {
+	data, _ := json.Marshal(positionOf(result))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a token produced by encodeCursor
+func decodeCursor(token string) (pageCursor, error) {
+	var cursor pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, fmt.Errorf("%w: malformed cursor: %v", ErrInvalidInput, err)
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, fmt.Errorf("%w: malformed cursor: %v", ErrInvalidInput, err)
+	}
+	return cursor, nil
+}
+
+// positionOf returns the cursor position of a result
+func positionOf(result RerankResult) pageCursor {
+	return pageCursor{Score: result.Score, Index: result.Index}
+}
+
+// rankedBefore orders positions by descending score, then ascending index
+func rankedBefore(a, b pageCursor) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.Index < b.Index
+}
+
+// pageByScores ranks every document above threshold by descending score,
+// breaking ties by input position so pages are stable across calls, and
+// returns the page following cursor. Rank and NormalizedRank describe the
+// position in the full ranking.
+func pageByScores(documents []Document, scores []float64, threshold float64, mode ScoreNormalization, pageSize int, cursor string) ([]RerankResult, string, error) {
+	if pageSize <= 0 {
+		return nil, "", fmt.Errorf("%w: page size must be positive, got %d", ErrInvalidInput, pageSize)
+	}
+
+	results := assignRanks(rankByScores(documents, scores, threshold, 0, tieInputOrder), mode)
+
+	start := 0
+	if cursor != "" {
+		last, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.Search(len(results), func(i int) bool {
+			return rankedBefore(last, positionOf(results[i]))
+		})
+	}
+
+	end := start + pageSize
+	if end >= len(results) {
+		return results[start:], "", nil
+	}
+	return results[start:end], encodeCursor(results[end-1]), nil
+}
+
+// RankPage returns one page of the ranking; see PaginatedReranker
+func (r *SimpleReranker) RankPage(ctx context.Context, query string, documents []Document, pageSize int, cursor string) ([]RerankResult, string, error) {
+	scores, err := r.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, "", err
+	}
+	return pageByScores(documents, scores, r.config.scoreThreshold(scores), r.config.NormalizeScores, pageSize, cursor)
+}
+
+// RankPage returns one page of the ranking; see PaginatedReranker
+func (r *CrossEncoderReranker) RankPage(ctx context.Context, query string, documents []Document, pageSize int, cursor string) ([]RerankResult, string, error) {
+	scores, err := r.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, "", err
+	}
+	return pageByScores(documents, scores, r.config.scoreThreshold(scores), r.config.NormalizeScores, pageSize, cursor)
+}
@@ -0,0 +1,115 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func paginationDocuments() []Document {
+	// Distinct top scores keep the comparison independent of tie order
+	contents := []string{
+		"gardening tips",
+		"fast cars race",
+		"night tracks",
+		"fast cars",
+		"cooking pasta",
+		"fast cars race tracks",
+		"weather report",
+		"snow",
+	}
+	documents := make([]Document, len(contents))
+	for i, content := range contents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: content}
+	}
+	return documents
+}
+
+func TestRankPage_ConcatenatesToRank(t *testing.T) {
+	rerankers := map[string]PaginatedReranker{
+		"simple":        NewSimpleReranker(Config{Model: "simple"}),
+		"cross-encoder": NewCrossEncoderReranker(Config{Model: "cross-encoder", Threshold: -100}),
+	}
+	query := "fast cars race tracks"
+	const pageSize = 2
+
+	for name, r := range rerankers {
+		t.Run(name, func(t *testing.T) {
+			documents := paginationDocuments()
+			first, cursor, err := r.RankPage(context.Background(), query, documents, pageSize, "")
+			if err != nil {
+				t.Fatalf("RankPage failed: %v", err)
+			}
+			if cursor == "" {
+				t.Fatal("Expected a cursor after the first page")
+			}
+			second, _, err := r.RankPage(context.Background(), query, documents, pageSize, cursor)
+			if err != nil {
+				t.Fatalf("RankPage failed: %v", err)
+			}
+
+			want, err := r.Rank(context.Background(), query, documents, 2*pageSize)
+			if err != nil {
+				t.Fatalf("Rank failed: %v", err)
+			}
+			got := append(first, second...)
+			if len(got) != len(want) {
+				t.Fatalf("Expected %d results, got %d", len(want), len(got))
+			}
+			for i := range want {
+				if got[i].Index != want[i].Index || got[i].Score != want[i].Score {
+					t.Errorf("Position %d: expected index %d (%f), got index %d (%f)", i, want[i].Index, want[i].Score, got[i].Index, got[i].Score)
+				}
+				if got[i].Rank != i+1 {
+					t.Errorf("Position %d: expected rank %d, got %d", i, i+1, got[i].Rank)
+				}
+			}
+		})
+	}
+}
+
+func TestRankPage_WalksAllResults(t *testing.T) {
+	r := NewSimpleReranker(Config{Model: "simple"})
+	documents := paginationDocuments()
+
+	seen := make(map[int]bool)
+	cursor := ""
+	pages := 0
+	for {
+		page, next, err := r.RankPage(context.Background(), "fast cars", documents, 3, cursor)
+		if err != nil {
+			t.Fatalf("RankPage failed: %v", err)
+		}
+		pages++
+		for _, result := range page {
+			if seen[result.Index] {
+				t.Errorf("Document %d returned twice", result.Index)
+			}
+			seen[result.Index] = true
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(seen) != len(documents) {
+		t.Errorf("Expected all %d documents across pages, got %d", len(documents), len(seen))
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 pages of size 3 for 8 documents, got %d", pages)
+	}
+}
+
+func TestRankPage_InvalidInput(t *testing.T) {
+	r := NewSimpleReranker(Config{Model: "simple"})
+	documents := paginationDocuments()
+
+	if _, _, err := r.RankPage(context.Background(), "q", documents, 0, ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for zero page size, got %v", err)
+	}
+	if _, _, err := r.RankPage(context.Background(), "q", documents, 2, "not a cursor!"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for malformed cursor, got %v", err)
+	}
+}
@@ -0,0 +1,346 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Meta keys set on the chunks produced by ChunkingStep
+const (
+	MetaParentID   = "parent_id"
+	MetaChunkIndex = "chunk_index"
+)
+
+// PipelineStep transforms the documents flowing through a RerankPipeline.
+// Steps may drop, split, reorder or rescore documents.
+type PipelineStep interface {
+	Process(ctx context.Context, query string, docs []Document) ([]Document, error)
+}
+
+// DeduplicationStep drops near-duplicate documents (see DeduplicateDocuments)
+type DeduplicationStep struct {
+	// Threshold is the Jaccard similarity above which documents are duplicates
+	Threshold float64
+}
+
+// Process keeps the first of each group of near-duplicates
+func (s DeduplicationStep) Process(ctx context.Context, query string, docs []Document) ([]Document, error) {
+	return DeduplicateDocuments(docs, s.Threshold), nil
+}
+
+// ChunkingStep splits documents longer than Size whitespace tokens into
+// windows sharing Overlap tokens. Each chunk keeps the document's fields and
+// Meta, gets the ID "<id>#<n>" and records Meta["parent_id"] and
+// Meta["chunk_index"]; documents that fit are passed through unchanged.
+type ChunkingStep struct {
+	Size    int
+	Overlap int
+}
+
+// Process replaces long documents by their chunks, in order
+func (s ChunkingStep) Process(ctx context.Context, query string, docs []Document) ([]Document, error) {
+	chunked := make([]Document, 0, len(docs))
+	for _, doc := range docs {
+		tokens := strings.Fields(doc.Content)
+		if len(tokens) <= s.Size {
+			chunked = append(chunked, doc)
+			continue
+		}
+
+		for i, content := range chunkTokens(tokens, s.Size, s.Overlap) {
+			chunk := doc
+			chunk.ID = fmt.Sprintf("%s#%d", doc.ID, i)
+			chunk.Content = content
+			chunk.Meta = make(map[string]interface{}, len(doc.Meta)+2)
+			for key, value := range doc.Meta {
+				chunk.Meta[key] = value
+			}
+			chunk.Meta[MetaParentID] = doc.ID
+			chunk.Meta[MetaChunkIndex] = i
+			chunked = append(chunked, chunk)
+		}
+	}
+	return chunked, nil
+}
+
+// RerankStep scores documents with Reranker and sorts them by descending
+// score, keeping ties in input order
+type RerankStep struct {
+	Reranker Reranker
+}
+
+// Process sets each document's Score and sorts the documents
+func (s RerankStep) Process(ctx context.Context, query string, docs []Document) ([]Document, error) {
+	if len(docs) == 0 {
+		return docs, nil
+	}
+
+	scores, err := s.Reranker.ComputeScore(ctx, query, docs)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) != len(docs) {
+		return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(docs), len(scores))
+	}
+
+	scored := make([]Document, len(docs))
+	copy(scored, docs)
+	for i := range scored {
+		scored[i].Score = scores[i]
+	}
+	sortDocuments(scored, tieInputOrder)
+	return scored, nil
+}
+
+// NormalizationStep rescales the scores of the batch with Mode, keeping the
+// document order
+type NormalizationStep struct {
+	Mode ScoreNormalization
+}
+
+// Process replaces each document's Score by its normalized score
+func (s NormalizationStep) Process(ctx context.Context, query string, docs []Document) ([]Document, error) {
+	scores := make([]float64, len(docs))
+	for i, doc := range docs {
+		scores[i] = doc.Score
+	}
+	normalized, err := applyNormalization(scores, s.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	rescored := make([]Document, len(docs))
+	copy(rescored, docs)
+	for i := range rescored {
+		rescored[i].Score = normalized[i]
+	}
+	return rescored, nil
+}
+
+// PipelineBuilder collects the steps of a RerankPipeline in order
+type PipelineBuilder struct {
+	steps []PipelineStep
+	errs  []error
+}
+
+// NewPipeline starts an empty pipeline
+func NewPipeline() *PipelineBuilder {
+	return &PipelineBuilder{}
+}
+
+// WithDeduplication adds a DeduplicationStep; threshold must be in [0, 1]
+func (b *PipelineBuilder) WithDeduplication(threshold float64) *PipelineBuilder {
+	if threshold < 0 || threshold > 1 {
+		b.errs = append(b.errs, fmt.Errorf("%w: dedup threshold must be between 0 and 1, got %v", ErrInvalidInput, threshold))
+	}
+	return b.WithStep(DeduplicationStep{Threshold: threshold})
+}
+
+// WithChunking adds a ChunkingStep of size tokens sharing overlap tokens
+func (b *PipelineBuilder) WithChunking(size, overlap int) *PipelineBuilder {
+	if size <= 0 || overlap < 0 || overlap >= size {
+		b.errs = append(b.errs, fmt.Errorf("%w: chunking needs a positive size and an overlap below it, got %d and %d", ErrInvalidInput, size, overlap))
+	}
+	return b.WithStep(ChunkingStep{Size: size, Overlap: overlap})
+}
+
+// WithReranker adds a RerankStep scoring with r
+func (b *PipelineBuilder) WithReranker(r Reranker) *PipelineBuilder {
+	if r == nil {
+		b.errs = append(b.errs, fmt.Errorf("%w: pipeline reranker must not be nil", ErrInvalidInput))
+	}
+	return b.WithStep(RerankStep{Reranker: r})
+}
+
+// WithNormalization adds a NormalizationStep
+func (b *PipelineBuilder) WithNormalization(mode ScoreNormalization) *PipelineBuilder {
+	if _, err := applyNormalization(nil, mode); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b.WithStep(NormalizationStep{Mode: mode})
+}
+
+// WithStep adds a custom step
+func (b *PipelineBuilder) WithStep(step PipelineStep) *PipelineBuilder {
+	b.steps = append(b.steps, step)
+	return b
+}
+
+// Build returns the pipeline, failing with ErrInvalidInput when a step was
+// misconfigured or no reranker was added
+func (b *PipelineBuilder) Build() (*RerankPipeline, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+
+	p := &RerankPipeline{steps: append([]PipelineStep(nil), b.steps...)}
+	for _, step := range p.steps {
+		if rerank, ok := step.(RerankStep); ok {
+			p.rerankers = append(p.rerankers, rerank.Reranker)
+		}
+	}
+	if len(p.rerankers) == 0 {
+		return nil, fmt.Errorf("%w: pipeline has no reranker step", ErrInvalidInput)
+	}
+	return p, nil
+}
+
+// RerankPipeline runs its steps in order and implements Reranker. Every
+// document records its input position in Meta["original_index"] before the
+// first step, so ranked chunks and survivors map back to the input. The
+// configured Threshold and MaxDocs apply to the final documents.
+type RerankPipeline struct {
+	config    Config
+	steps     []PipelineStep
+	rerankers []Reranker
+}
+
+// run copies documents, tags them with their input position and applies every step
+func (p *RerankPipeline) run(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	docs := make([]Document, len(documents))
+	for i, doc := range documents {
+		docs[i] = doc
+		docs[i].Meta = make(map[string]interface{}, len(doc.Meta)+1)
+		for key, value := range doc.Meta {
+			docs[i].Meta[key] = value
+		}
+		docs[i].Meta[MetaOriginalIndex] = i
+	}
+
+	for _, step := range p.steps {
+		var err error
+		docs, err = step.Process(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// filter applies the threshold and a result limit to the final documents
+func (p *RerankPipeline) filter(docs []Document, limit int) []Document {
+	scores := make([]float64, len(docs))
+	for i, doc := range docs {
+		scores[i] = doc.Score
+	}
+	threshold := p.config.scoreThreshold(scores)
+
+	var kept []Document
+	for _, doc := range docs {
+		if doc.Score >= threshold {
+			kept = append(kept, doc)
+		}
+	}
+	if limit > 0 && len(kept) > limit {
+		kept = kept[:limit]
+	}
+	return kept
+}
+
+// Rerank returns the documents produced by the pipeline
+func (p *RerankPipeline) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := p.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	docs, err := p.run(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return p.filter(docs, p.config.MaxDocs), nil
+}
+
+// ComputeScore returns, for each input document, the best final score of the
+// documents derived from it (itself or its chunks), in document order.
+// Documents dropped by a step score 0.
+func (p *RerankPipeline) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := p.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	docs, err := p.run(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(documents))
+	scored := make([]bool, len(documents))
+	for _, doc := range docs {
+		index := OptionInt(doc.Meta, MetaOriginalIndex, -1)
+		if index < 0 || index >= len(documents) {
+			continue
+		}
+		if !scored[index] || doc.Score > scores[index] {
+			scores[index] = doc.Score
+			scored[index] = true
+		}
+	}
+	return scores, nil
+}
+
+// Rank returns up to topN of the documents produced by the pipeline, with
+// Index pointing at the input document each one came from
+func (p *RerankPipeline) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := p.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	docs, err := p.run(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return DocumentsToResults(p.filter(docs, topN)), nil
+}
+
+// Steps returns the pipeline steps in order
+func (p *RerankPipeline) Steps() []PipelineStep {
+	return append([]PipelineStep(nil), p.steps...)
+}
+
+// GetModelName returns the model name of the first reranker step
+func (p *RerankPipeline) GetModelName() string {
+	return p.rerankers[0].GetModelName()
+}
+
+// Version returns the version of the first reranker step
+func (p *RerankPipeline) Version() string {
+	return p.rerankers[0].Version()
+}
+
+// HealthCheck checks every reranker step
+func (p *RerankPipeline) HealthCheck(ctx context.Context) error {
+	return healthCheckAll(ctx, p.rerankers)
+}
+
+// Configure updates the threshold and document limit applied to the final documents
+func (p *RerankPipeline) Configure(config Config) error {
+	p.config = config
+	return nil
+}
+
+// Close releases resources held by the reranker steps
+func (p *RerankPipeline) Close() error {
+	var errs []error
+	for _, r := range p.rerankers {
+		errs = append(errs, closeReranker(r))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func pipelineDocuments() []Document {
+	return []Document{
+		{ID: "ml", Content: "machine learning models learn patterns from data"},
+		{ID: "ml-copy", Content: "machine learning models learn patterns from data"},
+		{ID: "long", Content: "cooking pasta takes water salt and patience then machine learning appears near the end"},
+		{ID: "garden", Content: "tomatoes grow best with plenty of sun"},
+	}
+}
+
+func TestRerankPipeline_AppliesEveryStep(t *testing.T) {
+	pipeline, err := NewPipeline().
+		WithDeduplication(0.9).
+		WithChunking(8, 2).
+		WithReranker(NewSimpleReranker(Config{Model: "simple"})).
+		WithNormalization("minmax").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	pipeline.Configure(Config{Threshold: -1})
+
+	documents := pipelineDocuments()
+	results, err := pipeline.Rank(context.Background(), "machine learning", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	// Deduplication drops ml-copy; chunking splits the 15-token document into 0-8 and 6-14
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.Document.ID
+	}
+	if got := strings.Join(ids, ","); got != "ml,long#1,long#0,garden" {
+		t.Fatalf("Expected ml, the matching chunk, the other chunk and garden, got %s", got)
+	}
+
+	// Scores are min-max normalized after reranking
+	if results[0].Score != 1 || results[len(results)-1].Score != 0 {
+		t.Errorf("Expected normalized scores from 1 to 0, got %v and %v", results[0].Score, results[len(results)-1].Score)
+	}
+
+	chunk := results[1]
+	if chunk.Index != 2 || chunk.Rank != 2 || chunk.Document.Meta[MetaParentID] != "long" || chunk.Document.Meta[MetaChunkIndex] != 1 {
+		t.Errorf("Expected the chunk to map back to input document 2, got %+v", chunk)
+	}
+	if documents[0].Meta != nil || documents[0].Score != 0 {
+		t.Error("Expected the input documents to be left untouched")
+	}
+
+	scores, err := pipeline.ComputeScore(context.Background(), "machine learning", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] != 1 || scores[1] != 0 || scores[2] != 1 || scores[3] != 0 {
+		t.Errorf("Expected best chunk scores per input document, got %v", scores)
+	}
+}
+
+type uppercaseStep struct{}
+
+func (uppercaseStep) Process(ctx context.Context, query string, docs []Document) ([]Document, error) {
+	for i := range docs {
+		docs[i].Content = strings.ToUpper(docs[i].Content)
+	}
+	return docs, nil
+}
+
+func TestRerankPipeline_CustomStepAndLimits(t *testing.T) {
+	pipeline, err := NewPipeline().WithStep(uppercaseStep{}).WithReranker(NewSimpleReranker(Config{Model: "simple"})).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	pipeline.Configure(Config{MaxDocs: 1})
+
+	reranked, err := pipeline.Rerank(context.Background(), "machine learning", pipelineDocuments())
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if len(reranked) != 1 || reranked[0].Content != "MACHINE LEARNING MODELS LEARN PATTERNS FROM DATA" {
+		t.Errorf("Expected one document processed by the custom step, got %+v", reranked)
+	}
+	if pipeline.GetModelName() != "simple" || len(pipeline.Steps()) != 2 {
+		t.Errorf("Unexpected pipeline: model %q, %d steps", pipeline.GetModelName(), len(pipeline.Steps()))
+	}
+}
+
+func TestPipelineBuilder_Validation(t *testing.T) {
+	r := NewSimpleReranker(Config{})
+	builders := map[string]*PipelineBuilder{
+		"no reranker":           NewPipeline().WithDeduplication(0.9),
+		"nil reranker":          NewPipeline().WithReranker(nil),
+		"bad threshold":         NewPipeline().WithDeduplication(1.5).WithReranker(r),
+		"overlap above size":    NewPipeline().WithChunking(10, 10).WithReranker(r),
+		"unknown normalization": NewPipeline().WithReranker(r).WithNormalization("zscore"),
+	}
+	for name, builder := range builders {
+		if _, err := builder.Build(); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// PoolStats is a snapshot of a RerankerPool's load
+type PoolStats struct {
+	// ActiveWorkers is the number of members serving a call
+	ActiveWorkers int `json:"active_workers"`
+	// QueueDepth is the number of calls waiting for an idle member
+	QueueDepth int `json:"queue_depth"`
+	// TotalRequests counts the calls handed to a member since the pool was created
+	TotalRequests int64 `json:"total_requests"`
+}
+
+// RerankerPool load-balances calls across several instances of the same
+// reranker, each with its own subprocesses or connections. Idle members wait
+// in a channel that doubles as the pool's semaphore: a call takes the member
+// that has been idle longest, so members are used round-robin, and waits when
+// every member is busy.
+type RerankerPool struct {
+	config  Config
+	members []Reranker
+	idle    chan Reranker
+	done    chan struct{}
+
+	active  atomic.Int64
+	waiting atomic.Int64
+	total   atomic.Int64
+
+	mutex  sync.Mutex
+	closed bool
+
+	// configuring serializes Configure so concurrent calls cannot each hold
+	// part of the idle members and wait forever for the rest
+	configuring sync.Mutex
+}
+
+// NewRerankerPool creates size rerankers from config with NewReranker
+func NewRerankerPool(config Config, size int) (*RerankerPool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("%w: reranker pool size must be positive, got %d", ErrInvalidInput, size)
+	}
+
+	members := make([]Reranker, 0, size)
+	for i := 0; i < size; i++ {
+		member, err := NewReranker(config)
+		if err != nil {
+			for _, created := range members {
+				closeReranker(created)
+			}
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return newRerankerPool(config, members), nil
+}
+
+// newRerankerPool pools members, which must not be used elsewhere
+func newRerankerPool(config Config, members []Reranker) *RerankerPool {
+	p := &RerankerPool{
+		config:  config,
+		members: members,
+		idle:    make(chan Reranker, len(members)),
+		done:    make(chan struct{}),
+	}
+	for _, member := range members {
+		p.idle <- member
+	}
+	return p
+}
+
+// Size returns the number of members
+func (p *RerankerPool) Size() int {
+	return len(p.members)
+}
+
+// Stats returns the current load of the pool
+func (p *RerankerPool) Stats() PoolStats {
+	return PoolStats{
+		ActiveWorkers: int(p.active.Load()),
+		QueueDepth:    int(p.waiting.Load()),
+		TotalRequests: p.total.Load(),
+	}
+}
+
+// acquire waits for an idle member until ctx is done or the pool is closed;
+// the member must be handed back with release
+func (p *RerankerPool) acquire(ctx context.Context) (Reranker, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p.waiting.Add(1)
+	defer p.waiting.Add(-1)
+	select {
+	case <-p.done:
+		return nil, ErrPoolClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case member := <-p.idle:
+		if p.isClosed() {
+			p.idle <- member
+			return nil, ErrPoolClosed
+		}
+		p.active.Add(1)
+		return member, nil
+	}
+}
+
+// acquireCall is acquire for a Rerank, ComputeScore or Rank call, counting
+// the call in TotalRequests
+func (p *RerankerPool) acquireCall(ctx context.Context) (Reranker, error) {
+	member, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.total.Add(1)
+	return member, nil
+}
+
+// release hands a member obtained from acquire back to the pool
+func (p *RerankerPool) release(member Reranker) {
+	p.active.Add(-1)
+	p.idle <- member
+}
+
+// isClosed reports whether Close has been called
+func (p *RerankerPool) isClosed() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.closed
+}
+
+// Rerank reorders documents on the next idle member
+func (p *RerankerPool) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	member, err := p.acquireCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.release(member)
+	return member.Rerank(ctx, query, documents)
+}
+
+// ComputeScore scores documents on the next idle member
+func (p *RerankerPool) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	member, err := p.acquireCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.release(member)
+	return member.ComputeScore(ctx, query, documents)
+}
+
+// Rank returns top-N documents ranked by the next idle member
+func (p *RerankerPool) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	member, err := p.acquireCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer p.release(member)
+	return member.Rank(ctx, query, documents, topN)
+}
+
+// Configure reconfigures every member, waiting for each to be idle
+func (p *RerankerPool) Configure(config Config) error {
+	p.configuring.Lock()
+	defer p.configuring.Unlock()
+
+	members := make([]Reranker, 0, len(p.members))
+	defer func() {
+		for _, member := range members {
+			p.release(member)
+		}
+	}()
+	for range p.members {
+		member, err := p.acquire(context.Background())
+		if err != nil {
+			return err
+		}
+		members = append(members, member)
+	}
+
+	for _, member := range members {
+		if err := member.Configure(config); err != nil {
+			return err
+		}
+	}
+	p.mutex.Lock()
+	p.config = config
+	p.mutex.Unlock()
+	return nil
+}
+
+// GetModelName returns the members' model name
+func (p *RerankerPool) GetModelName() string {
+	return p.members[0].GetModelName()
+}
+
+// Version returns the members' version
+func (p *RerankerPool) Version() string {
+	return p.members[0].Version()
+}
+
+// HealthCheck checks every member, joining the errors of unhealthy ones
+func (p *RerankerPool) HealthCheck(ctx context.Context) error {
+	return healthCheckAll(ctx, p.members)
+}
+
+// Close waits for in-flight calls to finish and closes every member; later
+// calls fail with ErrPoolClosed
+func (p *RerankerPool) Close() error {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	p.mutex.Unlock()
+
+	// Drain: members in use are handed back when their call finishes
+	for range p.members {
+		<-p.idle
+	}
+
+	var errs []error
+	for _, member := range p.members {
+		errs = append(errs, closeReranker(member))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,215 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newMockPool pools size mocks scoring documents by content length
+func newMockPool(size int) *RerankerPool {
+	members := make([]Reranker, size)
+	for i := range members {
+		members[i] = NewMockReranker(func(query string, doc Document) float64 {
+			return float64(len(doc.Content))
+		})
+	}
+	return newRerankerPool(Config{Model: "mock"}, members)
+}
+
+func TestRerankerPool_ConcurrentRequests(t *testing.T) {
+	pool := newMockPool(3)
+	documents := []Document{{ID: "short", Content: "a"}, {ID: "long", Content: "a longer document"}}
+
+	const calls = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, calls)
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results, err := pool.Rank(context.Background(), fmt.Sprintf("query %d", i), documents, 2)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(results) != 2 || results[0].Document.ID != "long" {
+				errs <- fmt.Errorf("unexpected ranking %v", resultIDs(results))
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	stats := pool.Stats()
+	if stats.TotalRequests != calls || stats.ActiveWorkers != 0 || stats.QueueDepth != 0 {
+		t.Errorf("Expected %d requests and an idle pool, got %+v", calls, stats)
+	}
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+func TestRerankerPool_StatsAndRoundRobin(t *testing.T) {
+	release := make(chan struct{})
+	var mutex sync.Mutex
+	var used []string
+	members := make([]Reranker, 2)
+	for i := range members {
+		name := fmt.Sprintf("member-%d", i)
+		members[i] = NewMockReranker(func(query string, doc Document) float64 {
+			mutex.Lock()
+			used = append(used, name)
+			mutex.Unlock()
+			<-release
+			return 1
+		})
+	}
+	pool := newRerankerPool(Config{}, members)
+	documents := []Document{{ID: "a", Content: "a"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := pool.ComputeScore(context.Background(), "q", documents); err != nil {
+				t.Errorf("ComputeScore failed: %v", err)
+			}
+		}()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.Stats() != (PoolStats{ActiveWorkers: 2, QueueDepth: 1, TotalRequests: 2}) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected two busy members and one queued call, got %+v", pool.Stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A queued call gives up when its context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Rank(ctx, "q", documents, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to end the wait, got %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if len(used) != 3 || used[0] == used[1] {
+		t.Errorf("Expected the first calls to go to different members, got %v", used)
+	}
+}
+
+func TestRerankerPool_ConfigureIsNotCounted(t *testing.T) {
+	pool := newMockPool(3)
+	defer pool.Close()
+
+	if _, err := pool.Rank(context.Background(), "q", []Document{{ID: "a", Content: "a"}}, 1); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if err := pool.Configure(Config{Model: "reconfigured"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if total := pool.Stats().TotalRequests; total != 1 {
+		t.Errorf("Expected Configure to leave TotalRequests at 1, got %d", total)
+	}
+}
+
+func TestRerankerPool_Close(t *testing.T) {
+	pool := newMockPool(2)
+	if err := pool.Configure(Config{Model: "pooled", Threshold: 5}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	results, err := pool.Rank(context.Background(), "q", []Document{{ID: "a", Content: "abc"}, {ID: "b", Content: "abcdefgh"}}, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 1 || pool.GetModelName() != "pooled" {
+		t.Errorf("Expected every member reconfigured, got %v from %s", resultIDs(results), pool.GetModelName())
+	}
+	if err := pool.HealthCheck(context.Background()); err != nil {
+		t.Errorf("HealthCheck failed: %v", err)
+	}
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := pool.Close(); err != nil {
+		t.Errorf("Expected a second Close to be a no-op, got %v", err)
+	}
+	if _, err := pool.Rerank(context.Background(), "q", []Document{{Content: "a"}}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed after Close, got %v", err)
+	}
+}
+
+func TestNewRerankerPool_Errors(t *testing.T) {
+	if _, err := NewRerankerPool(Config{Model: "mxbai-v2"}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for an empty pool, got %v", err)
+	}
+	if _, err := NewRerankerPool(Config{Model: "no-such-model"}, 2); err == nil {
+		t.Error("Expected an error for an unknown model")
+	}
+}
+
+func TestRerankerPool_ConcurrentConfigure(t *testing.T) {
+	release := make(chan struct{})
+	members := make([]Reranker, 3)
+	for i := range members {
+		members[i] = NewMockReranker(func(query string, doc Document) float64 {
+			<-release
+			return 1
+		})
+	}
+	pool := newRerankerPool(Config{}, members)
+	documents := []Document{{ID: "a", Content: "a"}}
+
+	var wg sync.WaitGroup
+	rank := func() {
+		defer wg.Done()
+		if _, err := pool.Rank(context.Background(), "q", documents, 1); err != nil {
+			t.Errorf("Rank failed: %v", err)
+		}
+	}
+	// Busy members come back one by one, which used to split them between
+	// two waiting Configure calls
+	for i := 0; i < len(members); i++ {
+		wg.Add(1)
+		go rank()
+	}
+	for pool.Stats().ActiveWorkers != len(members) {
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < 2; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := pool.Configure(Config{Model: fmt.Sprintf("pooled-%d", i)}); err != nil {
+				t.Errorf("Configure failed: %v", err)
+			}
+		}(i)
+		go rank()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Concurrent Configure calls deadlocked")
+	}
+	if stats := pool.Stats(); stats.ActiveWorkers != 0 || stats.QueueDepth != 0 {
+		t.Errorf("Expected an idle pool, got %+v", stats)
+	}
+}
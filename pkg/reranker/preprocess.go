@@ -0,0 +1,511 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Defaults for document chunking
+const (
+	DefaultChunkOverlapTokens = 32
+	ChunkPoolMax              = "max"
+	ChunkPoolMean             = "mean"
+)
+
+// ChunkingPreprocessor splits long documents into overlapping windows, scores
+// every window with the wrapped reranker and pools window scores back into a
+// single score per document.
+//
+// Recognized options:
+//   - "max_chunk_tokens": window size in whitespace tokens
+//   - "chunk_overlap_tokens": tokens shared by consecutive windows (default 32)
+//   - "max_chunk_chars": window size in characters (used when max_chunk_tokens is unset)
+//   - "chunk_overlap_chars": characters shared by consecutive windows
+//   - "chunk_pool": "max" (default) or "mean"
+type ChunkingPreprocessor struct {
+	config    Config
+	inner     Reranker
+	chunkSize int
+	overlap   int
+	byChars   bool
+	pool      string
+}
+
+// chunkingEnabled reports whether options request document chunking
+func chunkingEnabled(opts map[string]interface{}) bool {
+	return OptionInt(opts, "max_chunk_tokens", 0) > 0 || OptionInt(opts, "max_chunk_chars", 0) > 0
+}
+
+// NewChunkingPreprocessor wraps inner with chunked scoring configured from config options
+func NewChunkingPreprocessor(inner Reranker, config Config) (*ChunkingPreprocessor, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: chunking requires an inner reranker", ErrInvalidInput)
+	}
+
+	p := &ChunkingPreprocessor{inner: inner}
+	if err := p.Configure(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Chunk splits text into overlapping windows according to the configured unit
+func (p *ChunkingPreprocessor) Chunk(text string) []string {
+	if p.byChars {
+		return chunkRunes([]rune(text), p.chunkSize, p.overlap)
+	}
+	return chunkTokens(strings.Fields(text), p.chunkSize, p.overlap)
+}
+
+// chunkTokens splits tokens into windows of size with the given overlap
+func chunkTokens(tokens []string, size, overlap int) []string {
+	if len(tokens) <= size {
+		return []string{strings.Join(tokens, " ")}
+	}
+
+	var chunks []string
+	for start := 0; start < len(tokens); start += size - overlap {
+		end := start + size
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		chunks = append(chunks, strings.Join(tokens[start:end], " "))
+		if end == len(tokens) {
+			break
+		}
+	}
+	return chunks
+}
+
+// chunkRunes splits characters into windows of size with the given overlap
+func chunkRunes(runes []rune, size, overlap int) []string {
+	if len(runes) <= size {
+		return []string{string(runes)}
+	}
+
+	var chunks []string
+	for start := 0; start < len(runes); start += size - overlap {
+		end := start + size
+		if end > len(runes) {
+			end = len(runes)
+		}
+		chunks = append(chunks, string(runes[start:end]))
+		if end == len(runes) {
+			break
+		}
+	}
+	return chunks
+}
+
+// Rerank reorders documents by pooled chunk score
+func (p *ChunkingPreprocessor) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := p.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := p.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankByScores(documents, scores, p.config.scoreThreshold(scores), p.config.MaxDocs, p.config.tieBreak()), nil
+}
+
+// ComputeScore scores every chunk in a single inner call and pools per document
+func (p *ChunkingPreprocessor) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := p.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	var chunks []Document
+	owners := make([]int, 0, len(documents))
+	for i, doc := range documents {
+		for j, text := range p.Chunk(doc.Content) {
+			// Character windows can fall entirely within a run of whitespace
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			chunk := doc
+			chunk.ID = fmt.Sprintf("%s#chunk%d", documentKey(doc, i), j)
+			chunk.Content = text
+			chunks = append(chunks, chunk)
+			owners = append(owners, i)
+		}
+	}
+
+	chunkScores, err := p.inner.ComputeScore(ctx, query, chunks)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunkScores) != len(chunks) {
+		return nil, fmt.Errorf("%w: expected %d chunk scores, got %d", ErrInference, len(chunks), len(chunkScores))
+	}
+
+	scores := make([]float64, len(documents))
+	counts := make([]int, len(documents))
+	for i, score := range chunkScores {
+		owner := owners[i]
+		switch {
+		case counts[owner] == 0:
+			scores[owner] = score
+		case p.pool == ChunkPoolMean:
+			scores[owner] += score
+		case score > scores[owner]:
+			scores[owner] = score
+		}
+		counts[owner]++
+	}
+	if p.pool == ChunkPoolMean {
+		for i := range scores {
+			scores[i] /= float64(counts[i])
+		}
+	}
+
+	return scores, nil
+}
+
+// Rank returns top-N documents by pooled chunk score
+func (p *ChunkingPreprocessor) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := p.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := p.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignRanks(rankByScores(documents, scores, p.config.scoreThreshold(scores), topN, p.config.tieBreak()), p.config.NormalizeScores), nil
+}
+
+// GetModelName returns the wrapped model name
+func (p *ChunkingPreprocessor) GetModelName() string {
+	return p.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (p *ChunkingPreprocessor) Version() string {
+	return p.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (p *ChunkingPreprocessor) HealthCheck(ctx context.Context) error {
+	return p.inner.HealthCheck(ctx)
+}
+
+// Configure updates chunking settings; the inner reranker is left unchanged
+func (p *ChunkingPreprocessor) Configure(config Config) error {
+	chunkSize := OptionInt(config.Options, "max_chunk_tokens", 0)
+	overlap := OptionInt(config.Options, "chunk_overlap_tokens", DefaultChunkOverlapTokens)
+	byChars := false
+	if chunkSize <= 0 {
+		chunkSize = OptionInt(config.Options, "max_chunk_chars", 0)
+		overlap = OptionInt(config.Options, "chunk_overlap_chars", 0)
+		byChars = true
+	}
+	if chunkSize <= 0 {
+		return fmt.Errorf("%w: max_chunk_tokens or max_chunk_chars must be positive", ErrInvalidInput)
+	}
+	if overlap < 0 {
+		overlap = 0
+	}
+	if overlap >= chunkSize {
+		// Keep windows advancing; clamp rather than fail on oversized defaults
+		overlap = chunkSize / 2
+	}
+
+	pool := OptionString(config.Options, "chunk_pool", ChunkPoolMax)
+	if pool != ChunkPoolMax && pool != ChunkPoolMean {
+		return fmt.Errorf("%w: chunk_pool must be %q or %q, got %q", ErrInvalidInput, ChunkPoolMax, ChunkPoolMean, pool)
+	}
+
+	p.config = config
+	if p.config.MaxDocs == 0 {
+		p.config.MaxDocs = 100
+	}
+	p.chunkSize = chunkSize
+	p.overlap = overlap
+	p.byChars = byChars
+	p.pool = pool
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (p *ChunkingPreprocessor) Close() error {
+	return closeReranker(p.inner)
+}
+
+// Unwrap returns the wrapped reranker
+func (p *ChunkingPreprocessor) Unwrap() Reranker {
+	return p.inner
+}
+
+// DefaultExpansionWeight scores documents against the expanded query only
+const DefaultExpansionWeight = 1.0
+
+// QueryExpander appends expansion terms to the query before delegating to the
+// wrapped reranker, boosting recall for short queries. The expanded query is
+// only used for scoring; ranked documents carry the original query in
+// Meta["original_query"] and the expanded one in Meta["expanded_query"].
+//
+// Recognized options:
+//   - "expansion_terms": static terms appended to every query
+//   - "expansion_weight": weight w in [0, 1] of the expanded query score; the
+//     final score is (1-w)*original + w*expanded, so w < 0.5 favors the original
+//     query (default 1, which needs a single inner call)
+type QueryExpander struct {
+	config Config
+	inner  Reranker
+	terms  []string
+	expand func(string) []string
+	weight float64
+}
+
+// expansionEnabled reports whether options request query expansion
+func expansionEnabled(opts map[string]interface{}) bool {
+	return len(OptionStrings(opts, "expansion_terms")) > 0
+}
+
+// NewQueryExpander wraps inner, appending the static terms from Options["expansion_terms"]
+func NewQueryExpander(inner Reranker, config Config) (*QueryExpander, error) {
+	return NewQueryExpanderFunc(inner, config, nil)
+}
+
+// NewQueryExpanderFunc wraps inner, appending the terms returned by expand for
+// each query in addition to any static expansion terms
+func NewQueryExpanderFunc(inner Reranker, config Config, expand func(string) []string) (*QueryExpander, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: query expansion requires an inner reranker", ErrInvalidInput)
+	}
+
+	e := &QueryExpander{inner: inner, expand: expand}
+	if err := e.Configure(config); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ExpandQuery returns query followed by the static and callback expansion terms
+func (e *QueryExpander) ExpandQuery(query string) string {
+	terms := append([]string(nil), e.terms...)
+	if e.expand != nil {
+		terms = append(terms, e.expand(query)...)
+	}
+	if len(terms) == 0 {
+		return query
+	}
+	return query + " " + strings.Join(terms, " ")
+}
+
+// Rerank reorders documents by their expanded query score
+func (e *QueryExpander) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := e.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := e.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := rerankByScores(documents, scores, e.config.scoreThreshold(scores), e.config.MaxDocs, e.config.tieBreak())
+	expanded := e.ExpandQuery(query)
+	for i := range reranked {
+		reranked[i].Meta = withQueryMeta(reranked[i].Meta, query, expanded)
+	}
+	return reranked, nil
+}
+
+// ComputeScore scores documents against the expanded query, blending in the
+// original query score when expansion_weight is below 1
+func (e *QueryExpander) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := e.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	expandedScores, err := e.inner.ComputeScore(ctx, e.ExpandQuery(query), documents)
+	if err != nil {
+		return nil, err
+	}
+	if e.weight >= 1 {
+		return expandedScores, nil
+	}
+
+	originalScores, err := e.inner.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	if len(originalScores) != len(expandedScores) {
+		return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(expandedScores), len(originalScores))
+	}
+
+	scores := make([]float64, len(documents))
+	for i := range scores {
+		scores[i] = (1-e.weight)*originalScores[i] + e.weight*expandedScores[i]
+	}
+	return scores, nil
+}
+
+// Rank returns top-N documents by their expanded query score
+func (e *QueryExpander) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := e.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := e.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	results := assignRanks(rankByScores(documents, scores, e.config.scoreThreshold(scores), topN, e.config.tieBreak()), e.config.NormalizeScores)
+	expanded := e.ExpandQuery(query)
+	for i := range results {
+		results[i].Document.Meta = withQueryMeta(results[i].Document.Meta, query, expanded)
+	}
+	return results, nil
+}
+
+// withQueryMeta copies meta and records the original and expanded queries
+func withQueryMeta(meta map[string]interface{}, original, expanded string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(meta)+2)
+	for key, value := range meta {
+		copied[key] = value
+	}
+	copied["original_query"] = original
+	copied["expanded_query"] = expanded
+	return copied
+}
+
+// GetModelName returns the wrapped model name
+func (e *QueryExpander) GetModelName() string {
+	return e.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (e *QueryExpander) Version() string {
+	return e.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (e *QueryExpander) HealthCheck(ctx context.Context) error {
+	return e.inner.HealthCheck(ctx)
+}
+
+// Configure updates expansion settings; the inner reranker is left unchanged
+func (e *QueryExpander) Configure(config Config) error {
+	weight := OptionFloat(config.Options, "expansion_weight", DefaultExpansionWeight)
+	if weight < 0 || weight > 1 {
+		return fmt.Errorf("%w: expansion_weight must be in [0, 1], got %f", ErrInvalidInput, weight)
+	}
+
+	e.config = config
+	if e.config.MaxDocs == 0 {
+		e.config.MaxDocs = 100
+	}
+	e.terms = OptionStrings(config.Options, "expansion_terms")
+	e.weight = weight
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (e *QueryExpander) Close() error {
+	return closeReranker(e.inner)
+}
+
+// Unwrap returns the wrapped reranker
+func (e *QueryExpander) Unwrap() Reranker {
+	return e.inner
+}
+
+// Defaults for token-budget truncation
+const (
+	DefaultMaxTokens = 512
+	TruncateEnd      = "end"
+	TruncateMiddle   = "middle"
+)
+
+// TruncateToTokenBudget returns doc with its content cut to at most maxTokens
+// whitespace tokens by dropping the tail. Documents already within budget, and
+// non-positive budgets, leave doc unchanged.
+func TruncateToTokenBudget(doc Document, maxTokens int) Document {
+	doc.Content = truncateTokens(doc.Content, maxTokens, TruncateEnd)
+	return doc
+}
+
+// truncateTokens cuts text to at most maxTokens whitespace tokens, dropping
+// either the tail ("end") or the middle ("middle") of the text
+func truncateTokens(text string, maxTokens int, strategy string) string {
+	if maxTokens <= 0 {
+		return text
+	}
+	tokens := strings.Fields(text)
+	if len(tokens) <= maxTokens {
+		return text
+	}
+
+	if strategy == TruncateMiddle {
+		head := (maxTokens + 1) / 2
+		tail := maxTokens - head
+		kept := append(append([]string(nil), tokens[:head]...), tokens[len(tokens)-tail:]...)
+		return strings.Join(kept, " ")
+	}
+	return strings.Join(tokens[:maxTokens], " ")
+}
+
+// truncatePair fits query and document into maxTokens combined whitespace
+// tokens. The document is shortened first; the query is only cut when it
+// alone would leave the document less than half of the budget.
+func truncatePair(query, document string, maxTokens int, strategy string) (string, string) {
+	if maxTokens <= 0 {
+		return query, document
+	}
+	queryTokens := len(strings.Fields(query))
+	documentTokens := len(strings.Fields(document))
+	if queryTokens+documentTokens <= maxTokens {
+		return query, document
+	}
+
+	documentBudget := maxTokens - queryTokens
+	if documentBudget < maxTokens/2 {
+		documentBudget = maxTokens / 2
+		if documentTokens < documentBudget {
+			documentBudget = documentTokens
+		}
+		query = truncateTokens(query, maxTokens-documentBudget, strategy)
+	}
+	return query, truncateTokens(document, documentBudget, strategy)
+}
+
+// truncationFromOptions reads "max_tokens" and "truncate_strategy"
+func truncationFromOptions(opts map[string]interface{}) (int, string, error) {
+	strategy := OptionString(opts, "truncate_strategy", TruncateEnd)
+	if strategy != TruncateEnd && strategy != TruncateMiddle {
+		return 0, "", fmt.Errorf("%w: truncate_strategy must be %q or %q, got %q", ErrInvalidInput, TruncateEnd, TruncateMiddle, strategy)
+	}
+	return OptionInt(opts, "max_tokens", DefaultMaxTokens), strategy, nil
+}
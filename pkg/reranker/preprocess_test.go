@@ -0,0 +1,288 @@
+package reranker
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChunkTokens(t *testing.T) {
+	tokens := strings.Fields("a b c d e f g")
+	got := chunkTokens(tokens, 3, 1)
+	want := []string{"a b c", "c d e", "e f g"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if got := chunkTokens(tokens[:2], 3, 1); len(got) != 1 {
+		t.Errorf("Expected short text to stay in one chunk, got %v", got)
+	}
+}
+
+func TestChunkRunes(t *testing.T) {
+	got := chunkRunes([]rune("abcdefgh"), 4, 2)
+	want := []string{"abcd", "cdef", "efgh"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestChunkingPreprocessor_TopPassage(t *testing.T) {
+	filler := strings.Repeat("unrelated filler text about gardening and weather ", 10)
+	documents := []Document{
+		{ID: "mismatch", Content: filler + "nothing relevant here at all"},
+		{ID: "match", Content: filler + "machine learning models learn from data"},
+	}
+
+	config := Config{
+		Model:     "simple",
+		Threshold: -1,
+		Options:   map[string]interface{}{"max_chunk_tokens": 8, "chunk_overlap_tokens": 2},
+	}
+	chunker, err := NewChunkingPreprocessor(NewSimpleReranker(config), config)
+	if err != nil {
+		t.Fatalf("NewChunkingPreprocessor failed: %v", err)
+	}
+
+	results, err := chunker.Rank(context.Background(), "machine learning", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if results[0].Document.ID != "match" {
+		t.Errorf("Expected matching document first, got %s", results[0].Document.ID)
+	}
+	if results[0].Score != 1.0 || results[1].Score != 0.0 {
+		t.Errorf("Expected max-pooled scores 1 and 0, got %f and %f", results[0].Score, results[1].Score)
+	}
+	if results[0].Document.Content != documents[1].Content {
+		t.Error("Expected ranked document to keep its full content")
+	}
+}
+
+func TestChunkingPreprocessor_MeanPool(t *testing.T) {
+	config := Config{
+		Options: map[string]interface{}{"max_chunk_tokens": 2, "chunk_overlap_tokens": 0, "chunk_pool": "mean"},
+	}
+	chunker, err := NewChunkingPreprocessor(NewSimpleReranker(config), config)
+	if err != nil {
+		t.Fatalf("NewChunkingPreprocessor failed: %v", err)
+	}
+
+	scores, err := chunker.ComputeScore(context.Background(), "machine", []Document{{Content: "machine learning cooking recipes"}})
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] != 0.5 {
+		t.Errorf("Expected mean of chunk scores 1 and 0, got %f", scores[0])
+	}
+}
+
+func TestChunkingPreprocessor_SkipsBlankCharacterChunks(t *testing.T) {
+	config := Config{
+		Threshold: -1,
+		Options:   map[string]interface{}{"max_chunk_chars": 10},
+	}
+	chunker, err := NewChunkingPreprocessor(NewSimpleReranker(config), config)
+	if err != nil {
+		t.Fatalf("NewChunkingPreprocessor failed: %v", err)
+	}
+
+	documents := []Document{{ID: "a", Content: "hello" + strings.Repeat(" ", 30) + "world"}}
+	results, err := chunker.Rank(context.Background(), "world", documents, 0)
+	if err != nil {
+		t.Fatalf("Expected whitespace-only chunks to be skipped, got %v", err)
+	}
+	if len(results) != 1 || results[0].Score != 1.0 {
+		t.Errorf("Expected the document to score 1 from its last chunk, got %+v", results)
+	}
+}
+
+func TestChunkingPreprocessor_Factory(t *testing.T) {
+	r, err := NewReranker(Config{
+		Model:   "http/test",
+		Options: map[string]interface{}{"endpoint": "http://localhost:1", "max_chunk_tokens": 128},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*ChunkingPreprocessor); !ok {
+		t.Errorf("Expected *ChunkingPreprocessor wrapper, got %T", r)
+	}
+
+	_, err = NewChunkingPreprocessor(NewSimpleReranker(Config{}), Config{
+		Options: map[string]interface{}{"max_chunk_tokens": 10, "chunk_pool": "median"},
+	})
+	if err == nil {
+		t.Error("Expected error for unknown pooling mode")
+	}
+}
+
+func TestQueryExpander_BoostsExpandedTermMatch(t *testing.T) {
+	inner := NewSimpleReranker(Config{})
+	documents := []Document{
+		{ID: "1", Content: "automobile repair guide"},
+		{ID: "2", Content: "baking bread at home"},
+	}
+
+	baseline, err := inner.ComputeScore(context.Background(), "car", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+
+	expander, err := NewQueryExpander(inner, Config{
+		Options: map[string]interface{}{"expansion_terms": []string{"automobile"}},
+	})
+	if err != nil {
+		t.Fatalf("NewQueryExpander failed: %v", err)
+	}
+	expanded, err := expander.ComputeScore(context.Background(), "car", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if expanded[0] <= baseline[0] {
+		t.Errorf("Expected expansion to raise score of document 1: %v <= %v", expanded[0], baseline[0])
+	}
+	if expanded[1] != baseline[1] {
+		t.Errorf("Expected unrelated document score unchanged, got %v vs %v", expanded[1], baseline[1])
+	}
+
+	results, err := expander.Rank(context.Background(), "car", documents, 1)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if results[0].Document.ID != "1" {
+		t.Errorf("Expected document 1 first, got %s", results[0].Document.ID)
+	}
+	if got := results[0].Document.Meta["original_query"]; got != "car" {
+		t.Errorf("Expected original query in metadata, got %v", got)
+	}
+	if documents[0].Meta != nil {
+		t.Error("Expected input document metadata to be left untouched")
+	}
+}
+
+func TestQueryExpander_CallbackAndWeight(t *testing.T) {
+	inner := NewSimpleReranker(Config{})
+	documents := []Document{{Content: "automobile"}}
+	callback := func(query string) []string {
+		return []string{"automobile"}
+	}
+
+	expander, err := NewQueryExpanderFunc(inner, Config{
+		Options: map[string]interface{}{"expansion_weight": 0.25},
+	}, callback)
+	if err != nil {
+		t.Fatalf("NewQueryExpanderFunc failed: %v", err)
+	}
+	if got := expander.ExpandQuery("car"); got != "car automobile" {
+		t.Errorf("Expected expanded query %q, got %q", "car automobile", got)
+	}
+
+	// Original scores 0, expanded scores 0.5; blended 0.75*0 + 0.25*0.5
+	scores, err := expander.ComputeScore(context.Background(), "car", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] != 0.125 {
+		t.Errorf("Expected blended score 0.125, got %v", scores[0])
+	}
+
+	_, err = NewQueryExpander(inner, Config{Options: map[string]interface{}{"expansion_weight": 2.0}})
+	if err == nil {
+		t.Error("Expected error for expansion_weight outside [0, 1]")
+	}
+}
+
+func TestQueryExpander_Factory(t *testing.T) {
+	r, err := NewRerankerWithOptions("http/test",
+		WithOptions(map[string]interface{}{"endpoint": "http://localhost:1"}),
+		WithQueryExpansion([]string{"automobile"}),
+	)
+	if err != nil {
+		t.Fatalf("NewRerankerWithOptions failed: %v", err)
+	}
+	if _, ok := r.(*QueryExpander); !ok {
+		t.Errorf("Expected *QueryExpander wrapper, got %T", r)
+	}
+}
+
+func TestTruncateToTokenBudget(t *testing.T) {
+	doc := Document{ID: "long", Content: "one two three four five six"}
+	truncated := TruncateToTokenBudget(doc, 4)
+	if truncated.Content != "one two three four" || truncated.ID != "long" {
+		t.Errorf("Expected tail to be dropped, got %+v", truncated)
+	}
+	if doc.Content != "one two three four five six" {
+		t.Error("Expected original document to be unchanged")
+	}
+	if got := TruncateToTokenBudget(doc, 10); got.Content != doc.Content {
+		t.Errorf("Expected document within budget to be unchanged, got %q", got.Content)
+	}
+	if got := truncateTokens(doc.Content, 3, TruncateMiddle); got != "one two six" {
+		t.Errorf("Expected middle to be dropped, got %q", got)
+	}
+}
+
+func TestTruncatePair_StaysWithinBudget(t *testing.T) {
+	longText := strings.Repeat("word ", 1000)
+	cases := []struct {
+		name     string
+		query    string
+		document string
+	}{
+		{"short query", "what is go", longText},
+		{"long query", longText, longText},
+		{"long query short document", longText, "tiny document"},
+	}
+
+	for _, strategy := range []string{TruncateEnd, TruncateMiddle} {
+		for _, tc := range cases {
+			query, document := truncatePair(tc.query, tc.document, 512, strategy)
+			total := len(strings.Fields(query)) + len(strings.Fields(document))
+			if total > 512 {
+				t.Errorf("%s/%s: expected at most 512 tokens, got %d", strategy, tc.name, total)
+			}
+		}
+	}
+
+	// The document gives way before the query does
+	query, document := truncatePair("what is go", longText, 512, TruncateEnd)
+	if query != "what is go" || len(strings.Fields(document)) != 509 {
+		t.Errorf("Expected only the document to be cut, got query %q and %d document tokens", query, len(strings.Fields(document)))
+	}
+	query, document = truncatePair(longText, "tiny document", 512, TruncateEnd)
+	if document != "tiny document" || len(strings.Fields(query)) != 510 {
+		t.Errorf("Expected short document to be kept whole, got %q", document)
+	}
+}
+
+func TestGGUFLocalReranker_TruncatesToMaxTokens(t *testing.T) {
+	reranker, _ := newCountingGGUFReranker(t)
+	if err := reranker.Configure(Config{Options: map[string]interface{}{"max_tokens": 4, "truncate_strategy": "middle"}}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	if _, err := reranker.computeRerankerScore(context.Background(), "query", "a b c d e f"); err != nil {
+		t.Fatalf("computeRerankerScore failed: %v", err)
+	}
+	if _, ok := reranker.scoreCache.Get("query|||a b f"); !ok {
+		t.Error("Expected the truncated pair to be scored")
+	}
+
+	if err := reranker.Configure(Config{Options: map[string]interface{}{"truncate_strategy": "start"}}); err == nil {
+		t.Error("Expected error for unknown truncate_strategy")
+	}
+
+	// The instruction counts against the budget: 5 tokens of the instructed
+	// query leave 1 for the document
+	if err := reranker.Configure(Config{Options: map[string]interface{}{"max_tokens": 6, "instruction": "Find docs"}}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if _, err := reranker.computeRerankerScore(context.Background(), "query", "a b c d e f"); err != nil {
+		t.Fatalf("computeRerankerScore failed: %v", err)
+	}
+	if _, ok := reranker.scoreCache.Get("Instruct: Find docs\nQuery: query|||a"); !ok {
+		t.Error("Expected the instructed pair to fit in max_tokens")
+	}
+}
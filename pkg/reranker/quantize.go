@@ -0,0 +1,77 @@
+package reranker
+
+import "math"
+
+// QuantizationInfo holds what DequantizeScores needs to rebuild a batch of
+// quantized scores: the batch's score range and the bits per score
+type QuantizationInfo struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Bits int     `json:"bits"`
+}
+
+// quantizationLevels returns the highest quantized value for bits, clamped
+// to 1..16 bits
+func quantizationLevels(bits int) float64 {
+	if bits < 1 {
+		bits = 1
+	}
+	if bits > 16 {
+		bits = 16
+	}
+	return float64(uint32(1)<<bits - 1)
+}
+
+// QuantizeScores maps scores linearly onto 0..2^bits-1 over the batch's own
+// min and max, rounding to the nearest level, so each score is rebuilt within
+// half a step of (max-min)/(2^bits-1). bits is clamped to 1..16. Keep the
+// range with QuantizeScoresInfo to dequantize.
+func QuantizeScores(scores []float64, bits int) []uint16 {
+	quantized, _ := QuantizeScoresInfo(scores, bits)
+	return quantized
+}
+
+// QuantizeScoresInfo is QuantizeScores also returning the range and bits
+// needed by DequantizeScores
+func QuantizeScoresInfo(scores []float64, bits int) ([]uint16, QuantizationInfo) {
+	if len(scores) == 0 {
+		return nil, QuantizationInfo{Bits: bits}
+	}
+
+	info := QuantizationInfo{Min: scores[0], Max: scores[0], Bits: bits}
+	for _, score := range scores[1:] {
+		info.Min = math.Min(info.Min, score)
+		info.Max = math.Max(info.Max, score)
+	}
+
+	levels := quantizationLevels(bits)
+	span := info.Max - info.Min
+	quantized := make([]uint16, len(scores))
+	if span == 0 {
+		return quantized, info
+	}
+	for i, score := range scores {
+		quantized[i] = uint16(math.Round((score - info.Min) / span * levels))
+	}
+	return quantized, info
+}
+
+// DequantizeScores rebuilds scores quantized over [min, max] with bits per
+// score; values above the highest level are treated as the highest level
+func DequantizeScores(quantized []uint16, min, max float64, bits int) []float64 {
+	if len(quantized) == 0 {
+		return nil
+	}
+
+	levels := quantizationLevels(bits)
+	scores := make([]float64, len(quantized))
+	for i, value := range quantized {
+		scores[i] = min + math.Min(float64(value), levels)/levels*(max-min)
+	}
+	return scores
+}
+
+// Dequantize rebuilds scores quantized with this info
+func (q QuantizationInfo) Dequantize(quantized []uint16) []float64 {
+	return DequantizeScores(quantized, q.Min, q.Max, q.Bits)
+}
@@ -0,0 +1,87 @@
+package reranker
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestQuantizeScores_ReconstructionError(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	scores := make([]float64, 1000)
+	for i := range scores {
+		scores[i] = rng.Float64()*20 - 10
+	}
+
+	for _, bits := range []int{1, 4, 8, 12, 16} {
+		quantized, info := QuantizeScoresInfo(scores, bits)
+		if info.Bits != bits {
+			t.Errorf("Expected %d bits in the info, got %d", bits, info.Bits)
+		}
+		restored := DequantizeScores(quantized, info.Min, info.Max, bits)
+
+		span := info.Max - info.Min
+		bound := 2 / (math.Pow(2, float64(bits)) - 1) * span
+		for i := range scores {
+			if diff := math.Abs(restored[i] - scores[i]); diff >= bound {
+				t.Fatalf("%d bits: score %d reconstructed with error %v, bound %v", bits, i, diff, bound)
+			}
+		}
+		if maxLevel := uint16(math.Pow(2, float64(bits)) - 1); quantized[argmax(scores)] != maxLevel {
+			t.Errorf("%d bits: expected the maximum to use level %d, got %d", bits, maxLevel, quantized[argmax(scores)])
+		}
+	}
+}
+
+// argmax returns the index of the largest value
+func argmax(values []float64) int {
+	best := 0
+	for i, value := range values {
+		if value > values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+func TestQuantizeScores_PreservesOrder(t *testing.T) {
+	scores := []float64{0.91, -2.5, 0.9, 3.75, 0.1, 0.1, 3.7}
+	for _, bits := range []int{4, 8, 16} {
+		quantized, info := QuantizeScoresInfo(scores, bits)
+		restored := info.Dequantize(quantized)
+		for i := range scores {
+			for j := range scores {
+				if scores[i] < scores[j] && restored[i] > restored[j] {
+					t.Errorf("%d bits: scores %v < %v restored out of order as %v > %v", bits, scores[i], scores[j], restored[i], restored[j])
+				}
+			}
+		}
+	}
+
+	// At 16 bits these scores are far enough apart to stay distinct
+	restored := DequantizeScores(QuantizeScores(scores, 16), -2.5, 3.75, 16)
+	if !(restored[0] > restored[2] && restored[3] > restored[6]) {
+		t.Errorf("Expected close scores to stay distinct at 16 bits, got %v", restored)
+	}
+}
+
+func TestQuantizeScores_EdgeCases(t *testing.T) {
+	if got := QuantizeScores(nil, 8); got != nil {
+		t.Errorf("Expected nil for no scores, got %v", got)
+	}
+
+	quantized, info := QuantizeScoresInfo([]float64{0.4, 0.4}, 8)
+	restored := info.Dequantize(quantized)
+	if restored[0] != 0.4 || restored[1] != 0.4 {
+		t.Errorf("Expected equal scores restored exactly, got %v", restored)
+	}
+
+	// Bits beyond 16 are clamped to 16
+	wide := QuantizeScores([]float64{0, 1}, 32)
+	if wide[1] != math.MaxUint16 {
+		t.Errorf("Expected the maximum at level 65535, got %d", wide[1])
+	}
+	if got := DequantizeScores([]uint16{300}, 0, 1, 8); got[0] != 1 {
+		t.Errorf("Expected out-of-range levels clamped to max, got %v", got)
+	}
+}
@@ -0,0 +1,204 @@
+package reranker
+
+import (
+	"sort"
+)
+
+// rankByScores builds sorted, threshold-filtered results from precomputed scores
+func rankByScores(documents []Document, scores []float64, threshold float64, topN int, ties tieBreak) []RerankResult {
+	// Create results with scores and original indices
+	results := make([]RerankResult, len(documents))
+	for i, doc := range documents {
+		results[i] = RerankResult{
+			Document: doc,
+			Score:    scores[i],
+			Index:    i,
+		}
+	}
+
+	// Sort by score (descending)
+	sortResults(results, ties)
+
+	// Apply threshold filter
+	var filtered []RerankResult
+	for _, result := range results {
+		if result.Score >= threshold {
+			filtered = append(filtered, result)
+		}
+	}
+
+	// Limit to topN
+	if topN > 0 && len(filtered) > topN {
+		filtered = filtered[:topN]
+	}
+
+	return filtered
+}
+
+// rankIDsByScores orders document IDs like rankByScores orders results,
+// sorting positions instead of copying documents
+func rankIDsByScores(documents []Document, scores []float64, threshold float64, topN int, ties tieBreak) ([]string, []float64) {
+	order := make([]int, 0, len(documents))
+	for i := range documents {
+		if scores[i] >= threshold {
+			order = append(order, i)
+		}
+	}
+
+	// Sort by score (descending), breaking ties like sortResults
+	less := func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	}
+	switch ties {
+	case tieInputOrder:
+		less = func(i, j int) bool {
+			if scores[order[i]] != scores[order[j]] {
+				return scores[order[i]] > scores[order[j]]
+			}
+			return order[i] < order[j]
+		}
+	case tieContentHash:
+		less = func(i, j int) bool {
+			if scores[order[i]] != scores[order[j]] {
+				return scores[order[i]] > scores[order[j]]
+			}
+			hashI, hashJ := HashDocument(documents[order[i]]), HashDocument(documents[order[j]])
+			if hashI != hashJ {
+				return hashI < hashJ
+			}
+			return order[i] < order[j]
+		}
+	}
+	sort.Slice(order, less)
+
+	// Limit to topN
+	if topN > 0 && len(order) > topN {
+		order = order[:topN]
+	}
+
+	ids := make([]string, len(order))
+	sorted := make([]float64, len(order))
+	for i, index := range order {
+		ids[i] = documents[index].ID
+		sorted[i] = scores[index]
+	}
+	return ids, sorted
+}
+
+// rerankByScores applies scores to documents, sorts them and applies threshold and max docs
+func rerankByScores(documents []Document, scores []float64, threshold float64, maxDocs int, ties tieBreak) []Document {
+	// Apply scores to documents
+	for i := range documents {
+		documents[i].Score = scores[i]
+	}
+
+	// Sort by score (descending)
+	sortDocuments(documents, ties)
+
+	// Apply threshold filter
+	var filtered []Document
+	for _, doc := range documents {
+		if doc.Score >= threshold {
+			filtered = append(filtered, doc)
+		}
+	}
+
+	// Limit to max documents
+	if maxDocs > 0 && len(filtered) > maxDocs {
+		filtered = filtered[:maxDocs]
+	}
+
+	return filtered
+}
+
+// sortResults orders results by descending score. With tieInputOrder, ties
+// are broken by ascending Index so equal scores keep their input order; with
+// tieContentHash, by ascending HashDocument and then Index; otherwise the
+// faster unstable sort is used.
+func sortResults(results []RerankResult, ties tieBreak) {
+	switch ties {
+	case tieInputOrder:
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].Score != results[j].Score {
+				return results[i].Score > results[j].Score
+			}
+			return results[i].Index < results[j].Index
+		})
+	case tieContentHash:
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].Score != results[j].Score {
+				return results[i].Score > results[j].Score
+			}
+			hashI, hashJ := HashDocument(results[i].Document), HashDocument(results[j].Document)
+			if hashI != hashJ {
+				return hashI < hashJ
+			}
+			return results[i].Index < results[j].Index
+		})
+	default:
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
+		})
+	}
+}
+
+// sortDocuments orders documents by descending Score. With tieInputOrder,
+// ties keep their input order; with tieContentHash, they are ordered by
+// ascending HashDocument and then input order; otherwise the faster unstable
+// sort is used.
+func sortDocuments(documents []Document, ties tieBreak) {
+	switch ties {
+	case tieInputOrder:
+		sort.SliceStable(documents, func(i, j int) bool {
+			return documents[i].Score > documents[j].Score
+		})
+	case tieContentHash:
+		sort.SliceStable(documents, func(i, j int) bool {
+			if documents[i].Score != documents[j].Score {
+				return documents[i].Score > documents[j].Score
+			}
+			return HashDocument(documents[i]) < HashDocument(documents[j])
+		})
+	default:
+		sort.Slice(documents, func(i, j int) bool {
+			return documents[i].Score > documents[j].Score
+		})
+	}
+}
+
+// assignRanks fills the 1-based Rank and NormalizedRank of sorted results and,
+// when a score normalization is configured, their RelativeScore
+func assignRanks(results []RerankResult, mode ScoreNormalization) []RerankResult {
+	var relative []float64
+	if mode != "" && mode != NormalizationNone {
+		scores := make([]float64, len(results))
+		for i, result := range results {
+			scores[i] = result.Score
+		}
+		relative = NormalizeMinMax(scores)
+	}
+
+	n := float64(len(results))
+	for i := range results {
+		results[i].Rank = i + 1
+		results[i].NormalizedRank = 1 - float64(i)/n
+		if relative != nil {
+			results[i].RelativeScore = relative[i]
+		}
+	}
+	return results
+}
+
+// ResultsByRank returns a copy of results ordered by Rank, restoring the
+// original order after a caller has re-sorted or shuffled the slice.
+// Results without a rank are placed last.
+func ResultsByRank(results []RerankResult) []RerankResult {
+	sorted := append([]RerankResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Rank == 0 || sorted[j].Rank == 0 {
+			return sorted[j].Rank == 0 && sorted[i].Rank != 0
+		}
+		return sorted[i].Rank < sorted[j].Rank
+	})
+	return sorted
+}
@@ -0,0 +1,111 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestAssignRanks(t *testing.T) {
+	results := []RerankResult{{Score: 4}, {Score: 3}, {Score: 2}, {Score: 0}}
+	assignRanks(results, NormalizationSigmoid)
+
+	wantNormalized := []float64{1, 0.75, 0.5, 0.25}
+	wantRelative := []float64{1, 0.75, 0.5, 0}
+	for i, result := range results {
+		if result.Rank != i+1 {
+			t.Errorf("Expected rank %d, got %d", i+1, result.Rank)
+		}
+		if result.NormalizedRank != wantNormalized[i] {
+			t.Errorf("Expected normalized rank %v, got %v", wantNormalized[i], result.NormalizedRank)
+		}
+		if result.RelativeScore != wantRelative[i] {
+			t.Errorf("Expected relative score %v, got %v", wantRelative[i], result.RelativeScore)
+		}
+	}
+}
+
+func TestResultsByRank(t *testing.T) {
+	results := assignRanks([]RerankResult{{Index: 2}, {Index: 0}, {Index: 1}}, NormalizationNone)
+	shuffled := []RerankResult{results[2], {Index: 9}, results[0], results[1]}
+
+	sorted := ResultsByRank(shuffled)
+	wantIndices := []int{2, 0, 1, 9}
+	for i, result := range sorted {
+		if result.Index != wantIndices[i] {
+			t.Errorf("Position %d: expected index %d, got %d", i, wantIndices[i], result.Index)
+		}
+	}
+	if shuffled[0].Index != 1 {
+		t.Error("Expected ResultsByRank to leave its input untouched")
+	}
+}
+
+func TestStableSort_TiesKeepInputOrder(t *testing.T) {
+	// Enough tied documents that the unstable sort would not fall back to insertion sort
+	documents := make([]Document, 50)
+	for i := range documents {
+		content := "unrelated text"
+		if i%2 == 0 {
+			content = "machine learning"
+		}
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: content}
+	}
+
+	rerankers := map[string]Reranker{
+		"simple":        NewSimpleReranker(Config{Model: "simple", StableSort: true}),
+		"cross-encoder": NewCrossEncoderReranker(Config{Model: "cross-encoder", Threshold: -100, StableSort: true}),
+	}
+	for name, r := range rerankers {
+		t.Run(name, func(t *testing.T) {
+			for run := 0; run < 5; run++ {
+				results, err := r.Rank(context.Background(), "machine learning", documents, 0)
+				if err != nil {
+					t.Fatalf("Rank failed: %v", err)
+				}
+				for i := 1; i < len(results); i++ {
+					if results[i].Score == results[i-1].Score && results[i].Index < results[i-1].Index {
+						t.Fatalf("Run %d: tied results out of input order at %d: %d after %d", run, i, results[i].Index, results[i-1].Index)
+					}
+				}
+
+				docs := append([]Document(nil), documents...)
+				reranked, err := r.Rerank(context.Background(), "machine learning", docs)
+				if err != nil {
+					t.Fatalf("Rerank failed: %v", err)
+				}
+				position := make(map[string]int, len(documents))
+				for i, doc := range documents {
+					position[doc.ID] = i
+				}
+				for i := 1; i < len(reranked); i++ {
+					if reranked[i].Score == reranked[i-1].Score && position[reranked[i].ID] < position[reranked[i-1].ID] {
+						t.Fatalf("Run %d: tied documents out of input order: %s after %s", run, reranked[i].ID, reranked[i-1].ID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	results := []RerankResult{
+		{Index: 3, Score: 0.5},
+		{Index: 0, Score: 0.9},
+		{Index: 2, Score: 0.5},
+		{Index: 1, Score: 0.5},
+	}
+
+	sortResults(results, tieInputOrder)
+	want := []int{0, 1, 2, 3}
+	for i, result := range results {
+		if result.Index != want[i] {
+			t.Errorf("Position %d: expected index %d, got %d", i, want[i], result.Index)
+		}
+	}
+
+	sortResults(results, tieUnordered)
+	if results[0].Index != 0 {
+		t.Errorf("Expected the highest score first with unstable sort, got index %d", results[0].Index)
+	}
+}
@@ -0,0 +1,291 @@
+package reranker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModelRegistryEnv names the environment variable pointing at a registry file
+// that is loaded on first use when LoadModelRegistry has not been called
+const ModelRegistryEnv = "RERANKERS_MODEL_REGISTRY"
+
+// UserRegistryFile is the registry managed by the "models" CLI sub-command,
+// relative to the user's home directory
+const UserRegistryFile = ".go-rerankers/models.json"
+
+// registryLockTimeout bounds how long registry writers wait for the lock file
+const registryLockTimeout = 5 * time.Second
+
+// ModelRegistryFile is the on-disk registry format, in YAML or JSON:
+//
+//	models:
+//	  - name: my-reranker
+//	    display_name: My Reranker
+//	    provider: ACME
+//	    model_id: http/acme-rerank-v1
+//	    type: http
+//	    strengths: [Self-hosted]
+//
+// Type selects the backend (a RerankerType such as "gguf-local" or "http") and
+// ModelID is the model name passed to that backend; it defaults to Name.
+// Entries whose name matches a built-in model override it.
+type ModelRegistryFile struct {
+	Models []ModelInfo `json:"models" yaml:"models"`
+}
+
+// registry holds the models loaded by LoadModelRegistry
+var registry struct {
+	mutex  sync.RWMutex
+	loaded bool
+	models []ModelInfo
+}
+
+var (
+	envRegistryOnce sync.Once
+	envRegistryErr  error
+)
+
+// LoadModelRegistry parses a YAML or JSON registry file and replaces any
+// previously loaded registry. Files ending in .json are parsed as JSON,
+// everything else as YAML. Both a ModelRegistryFile and a bare list of
+// models are accepted.
+func LoadModelRegistry(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read model registry: %v", ErrInitialization, err)
+	}
+
+	models, err := parseModelRegistry(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return err
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.loaded = true
+	registry.models = models
+	return nil
+}
+
+// parseModelRegistry decodes and validates registry entries
+func parseModelRegistry(data []byte, isJSON bool) ([]ModelInfo, error) {
+	unmarshal := yaml.Unmarshal
+	if isJSON {
+		unmarshal = json.Unmarshal
+	}
+
+	var file ModelRegistryFile
+	if err := unmarshal(data, &file); err != nil {
+		// Fall back to a bare list of models
+		if listErr := unmarshal(data, &file.Models); listErr != nil {
+			return nil, fmt.Errorf("%w: failed to parse model registry: %v", ErrInvalidInput, err)
+		}
+	}
+
+	for i := range file.Models {
+		model := &file.Models[i]
+		if model.Name == "" {
+			return nil, fmt.Errorf("%w: model registry entry %d has no name", ErrInvalidInput, i)
+		}
+		if model.Type == "" {
+			return nil, fmt.Errorf("%w: model registry entry %q has no type", ErrInvalidInput, model.Name)
+		}
+		if model.ModelID == "" {
+			model.ModelID = model.Name
+		}
+	}
+	return file.Models, nil
+}
+
+// loadEnvRegistry loads the registry named by RERANKERS_MODEL_REGISTRY once,
+// unless a registry was already loaded explicitly
+func loadEnvRegistry() error {
+	envRegistryOnce.Do(func() {
+		path := os.Getenv(ModelRegistryEnv)
+		if path == "" {
+			return
+		}
+
+		registry.mutex.RLock()
+		loaded := registry.loaded
+		registry.mutex.RUnlock()
+		if !loaded {
+			envRegistryErr = LoadModelRegistry(path)
+		}
+	})
+	return envRegistryErr
+}
+
+// registeredModels returns a copy of the loaded registry
+func registeredModels() []ModelInfo {
+	// Listing models falls back to the built-in list when the env registry is invalid;
+	// NewReranker surfaces the load error instead
+	_ = loadEnvRegistry()
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return append([]ModelInfo(nil), registry.models...)
+}
+
+// lookupRegisteredModel finds a model in the loaded registry by name
+func lookupRegisteredModel(name string) (ModelInfo, bool) {
+	for _, model := range registeredModels() {
+		if model.Name == name {
+			return model, true
+		}
+	}
+	return ModelInfo{}, false
+}
+
+// mergeRegisteredModels overrides built-in entries by name and appends new ones
+func mergeRegisteredModels(models []ModelInfo) []ModelInfo {
+	positions := make(map[string]int, len(models))
+	for i, model := range models {
+		positions[model.Name] = i
+	}
+
+	for _, model := range registeredModels() {
+		if i, exists := positions[model.Name]; exists {
+			models[i] = model
+			continue
+		}
+		positions[model.Name] = len(models)
+		models = append(models, model)
+	}
+	return models
+}
+
+// UserRegistryPath returns the path of the user registry, ~/.go-rerankers/models.json
+func UserRegistryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to locate home directory: %v", ErrInitialization, err)
+	}
+	return filepath.Join(home, UserRegistryFile), nil
+}
+
+// ReadRegistryFile returns the models of a registry file; a missing file
+// holds no models
+func ReadRegistryFile(path string) ([]ModelInfo, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read model registry: %v", ErrInitialization, err)
+	}
+	return parseModelRegistry(data, strings.EqualFold(filepath.Ext(path), ".json"))
+}
+
+// AddRegistryModel adds model to the JSON registry file at path, creating
+// the file and its directory as needed. Names must be unique within the file.
+func AddRegistryModel(path string, model ModelInfo) error {
+	if model.Name == "" {
+		return fmt.Errorf("%w: model name is required", ErrInvalidInput)
+	}
+	if model.Type == "" {
+		return fmt.Errorf("%w: model %q has no type", ErrInvalidInput, model.Name)
+	}
+	if model.ModelID == "" {
+		model.ModelID = model.Name
+	}
+
+	return updateRegistryFile(path, func(models []ModelInfo) ([]ModelInfo, error) {
+		for _, existing := range models {
+			if existing.Name == model.Name {
+				return nil, fmt.Errorf("%w: model %q is already registered", ErrInvalidInput, model.Name)
+			}
+		}
+		return append(models, model), nil
+	})
+}
+
+// RemoveRegistryModel deletes the model called name from the registry file
+// at path, failing with ErrModelNotFound when it is not registered there
+func RemoveRegistryModel(path, name string) error {
+	return updateRegistryFile(path, func(models []ModelInfo) ([]ModelInfo, error) {
+		for i, model := range models {
+			if model.Name == name {
+				return append(models[:i], models[i+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %q is not in the model registry", ErrModelNotFound, name)
+	})
+}
+
+// updateRegistryFile applies update to the models of the registry file while
+// holding its lock, then replaces the file atomically
+func updateRegistryFile(path string, update func([]ModelInfo) ([]ModelInfo, error)) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("%w: failed to create registry directory: %v", ErrInitialization, err)
+	}
+
+	unlock, err := lockRegistryFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	models, err := ReadRegistryFile(path)
+	if err != nil {
+		return err
+	}
+	models, err = update(models)
+	if err != nil {
+		return err
+	}
+	if models == nil {
+		models = []ModelInfo{}
+	}
+
+	data, err := json.MarshalIndent(ModelRegistryFile{Models: models}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: failed to encode model registry: %v", ErrInitialization, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("%w: failed to write model registry: %v", ErrInitialization, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: failed to write model registry: %v", ErrInitialization, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: failed to write model registry: %v", ErrInitialization, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%w: failed to write model registry: %v", ErrInitialization, err)
+	}
+	return nil
+}
+
+// lockRegistryFile creates "<path>.lock" with O_EXCL, waiting up to
+// registryLockTimeout for another writer to release it, and returns the
+// function removing it
+func lockRegistryFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(registryLockTimeout)
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			lock.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("%w: failed to lock model registry: %v", ErrInitialization, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: model registry is locked; remove %s if no other process is writing it", ErrInitialization, lockPath)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
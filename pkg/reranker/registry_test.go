@@ -0,0 +1,207 @@
+package reranker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// resetModelRegistry clears the loaded registry and lets the environment variable be read again
+func resetModelRegistry() {
+	registry.mutex.Lock()
+	registry.loaded = false
+	registry.models = nil
+	registry.mutex.Unlock()
+
+	envRegistryOnce = sync.Once{}
+	envRegistryErr = nil
+}
+
+func writeRegistryFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write registry: %v", err)
+	}
+	return path
+}
+
+func TestLoadModelRegistry_YAML(t *testing.T) {
+	t.Cleanup(resetModelRegistry)
+	path := writeRegistryFile(t, "models.yaml", `
+models:
+  - name: acme-rerank
+    display_name: ACME Rerank
+    provider: ACME
+    model_id: http/acme-rerank-v1
+    type: http
+    strengths: [Self-hosted]
+  - name: jina-v2
+    display_name: Jina Override
+    provider: Jina AI
+    model_id: models/custom-jina.gguf
+    type: gguf-local
+`)
+	if err := LoadModelRegistry(path); err != nil {
+		t.Fatalf("LoadModelRegistry failed: %v", err)
+	}
+
+	info, err := GetModelByName("acme-rerank")
+	if err != nil {
+		t.Fatalf("GetModelByName failed: %v", err)
+	}
+	if info.ModelID != "http/acme-rerank-v1" || info.Provider != "ACME" || len(info.Strengths) != 1 {
+		t.Errorf("Unexpected model info: %+v", info)
+	}
+
+	override, err := GetModelByName("jina-v2")
+	if err != nil {
+		t.Fatalf("GetModelByName failed: %v", err)
+	}
+	if override.DisplayName != "Jina Override" {
+		t.Errorf("Expected registry to override built-in model, got %+v", override)
+	}
+	if len(GetSupportedModels()) != len(builtinModels())+1 {
+		t.Errorf("Expected one model to be added, got %d models", len(GetSupportedModels()))
+	}
+
+	r, err := NewReranker(Config{
+		Model:   "acme-rerank",
+		Options: map[string]interface{}{"endpoint": "http://localhost:1"},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*HTTPReranker); !ok {
+		t.Errorf("Expected registry type to route to *HTTPReranker, got %T", r)
+	}
+	if r.GetModelName() != "http/acme-rerank-v1" {
+		t.Errorf("Expected backend to receive the registry model ID, got %s", r.GetModelName())
+	}
+}
+
+func TestLoadModelRegistry_JSONList(t *testing.T) {
+	t.Cleanup(resetModelRegistry)
+	path := writeRegistryFile(t, "models.json", `[{"name": "cohere-en", "model_id": "cohere/rerank-english-v3.0", "type": "cohere"}]`)
+	if err := LoadModelRegistry(path); err != nil {
+		t.Fatalf("LoadModelRegistry failed: %v", err)
+	}
+
+	r, err := NewReranker(Config{Model: "cohere-en", Options: map[string]interface{}{"api_key": "k"}})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*CohereReranker); !ok {
+		t.Errorf("Expected *CohereReranker, got %T", r)
+	}
+}
+
+func TestLoadModelRegistry_Invalid(t *testing.T) {
+	t.Cleanup(resetModelRegistry)
+	path := writeRegistryFile(t, "models.yaml", "models:\n  - name: missing-type\n")
+	if err := LoadModelRegistry(path); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for entry without type, got %v", err)
+	}
+	if err := LoadModelRegistry(filepath.Join(t.TempDir(), "absent.yaml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestModelRegistryEnv(t *testing.T) {
+	resetModelRegistry()
+	t.Cleanup(resetModelRegistry)
+	path := writeRegistryFile(t, "env.yml", "- name: env-model\n  type: grpc\n  model_id: grpc/env\n")
+	t.Setenv(ModelRegistryEnv, path)
+
+	if _, err := GetModelByName("env-model"); err != nil {
+		t.Errorf("Expected model from %s to be loaded: %v", ModelRegistryEnv, err)
+	}
+}
+
+func TestRegistryFile_AddListRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry", "models.json")
+
+	if models, err := ReadRegistryFile(path); err != nil || len(models) != 0 {
+		t.Fatalf("Expected an empty registry before the file exists, got %v, %v", models, err)
+	}
+
+	if err := AddRegistryModel(path, ModelInfo{Name: "mymodel", ModelID: "/models/my.gguf", Type: "gguf-local"}); err != nil {
+		t.Fatalf("AddRegistryModel failed: %v", err)
+	}
+	if err := AddRegistryModel(path, ModelInfo{Name: "other", Type: "http"}); err != nil {
+		t.Fatalf("AddRegistryModel failed: %v", err)
+	}
+	if err := AddRegistryModel(path, ModelInfo{Name: "mymodel", Type: "http"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for a duplicate name, got %v", err)
+	}
+
+	models, err := ReadRegistryFile(path)
+	if err != nil {
+		t.Fatalf("ReadRegistryFile failed: %v", err)
+	}
+	if len(models) != 2 || models[0].ModelID != "/models/my.gguf" || models[1].ModelID != "other" {
+		t.Fatalf("Unexpected registry contents: %+v", models)
+	}
+
+	// The file is a loadable registry whose models are listed with the built-ins
+	t.Cleanup(resetModelRegistry)
+	if err := LoadModelRegistry(path); err != nil {
+		t.Fatalf("LoadModelRegistry failed: %v", err)
+	}
+	supported := GetSupportedModels()
+	if len(supported) != len(builtinModels())+2 {
+		t.Errorf("Expected 2 user models merged with the built-ins, got %d models", len(supported))
+	}
+
+	if err := RemoveRegistryModel(path, "mymodel"); err != nil {
+		t.Fatalf("RemoveRegistryModel failed: %v", err)
+	}
+	if err := RemoveRegistryModel(path, "mymodel"); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound removing a missing model, got %v", err)
+	}
+	models, err = ReadRegistryFile(path)
+	if err != nil || len(models) != 1 || models[0].Name != "other" {
+		t.Errorf("Expected only other after removal, got %+v, %v", models, err)
+	}
+
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("Expected the lock file to be removed, got %v", err)
+	}
+}
+
+func TestRegistryFile_WaitsForLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.json")
+	if err := os.WriteFile(path+".lock", nil, 0o644); err != nil {
+		t.Fatalf("Failed to create lock file: %v", err)
+	}
+
+	released := make(chan struct{})
+	go func() {
+		defer close(released)
+		time.Sleep(50 * time.Millisecond)
+		os.Remove(path + ".lock")
+	}()
+
+	if err := AddRegistryModel(path, ModelInfo{Name: "mymodel", Type: "gguf-local"}); err != nil {
+		t.Fatalf("AddRegistryModel failed: %v", err)
+	}
+	<-released
+	if models, err := ReadRegistryFile(path); err != nil || len(models) != 1 {
+		t.Errorf("Expected one model once the lock was released, got %+v, %v", models, err)
+	}
+}
+
+func TestAddRegistryModel_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.json")
+	for _, model := range []ModelInfo{{Type: "gguf-local"}, {Name: "untyped"}} {
+		if err := AddRegistryModel(path, model); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %+v, got %v", model, err)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected no registry file for invalid models, got %v", err)
+	}
+}
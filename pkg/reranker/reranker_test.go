@@ -1,8 +1,17 @@
 package reranker
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestFactoryFunction(t *testing.T) {
@@ -30,6 +39,34 @@ func TestFactoryFunction(t *testing.T) {
 	}
 }
 
+func TestUnwrap_ReturnsBackend(t *testing.T) {
+	r, err := NewReranker(Config{
+		Model:       "bm25",
+		Deduplicate: true,
+		Options: map[string]interface{}{
+			"retry_max_attempts": 2,
+			"fallback_model":     "simple",
+			"max_chunk_tokens":   64,
+			"query_cache_size":   8,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	if _, ok := r.(*QueryCachingReranker); !ok {
+		t.Fatalf("Expected the query cache outermost, got %T", r)
+	}
+	backend := Unwrap(r)
+	if _, ok := backend.(*BM25Reranker); !ok {
+		t.Errorf("Expected Unwrap to return *BM25Reranker, got %T", backend)
+	}
+
+	simple := NewSimpleReranker(Config{})
+	if Unwrap(simple) != Reranker(simple) {
+		t.Error("Expected Unwrap to return an unwrapped reranker unchanged")
+	}
+}
+
 func TestGetSupportedModels(t *testing.T) {
 	models := GetSupportedModels()
 	if len(models) == 0 {
@@ -42,7 +79,7 @@ func TestGetSupportedModels(t *testing.T) {
 		"mxbai-v1", "mxbai-v2",
 		"qwen-0.6b", "qwen-4b", "qwen-8b",
 		"ms-marco-v2", "ms-marco-l4-v2",
-		"bge-base", "bge-large", "bge-v2-m3", "bge-v2-gemma", "colbert-v2",
+		"bge-base", "bge-large", "bge-v2-m3", "bge-v2-gemma",
 	}
 	for _, expected := range expectedModels {
 		found := false
@@ -73,6 +110,13 @@ func TestGetModelByName(t *testing.T) {
 		t.Errorf("Expected name mxbai-v2, got %s", model.Name)
 	}
 
+	// Listed types match the backend the factory creates
+	for name, want := range map[string]RerankerType{"colbert-v2": TypeColBERT, "bge-v2-minicpm-layerwise": TypeLayerwise} {
+		if model, err := GetModelByName(name); err != nil || model.Type != string(want) {
+			t.Errorf("Expected %s to be listed as %s, got %+v (%v)", name, want, model, err)
+		}
+	}
+
 	// Test non-existing model
 	_, err = GetModelByName("non-existent-model")
 	if err == nil {
@@ -117,3 +161,226 @@ func TestAllGGUFModelsInitialization(t *testing.T) {
 		})
 	}
 }
+
+func TestWarnUnsupportedLanguage(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	warnUnsupportedLanguage(Config{Model: "jina-v1-tiny", Language: "de"})
+	if !strings.Contains(buf.String(), "WARNING") {
+		t.Errorf("Expected a warning for German on an English-only model, got %q", buf.String())
+	}
+
+	buf.Reset()
+	warnUnsupportedLanguage(Config{Model: "jina-v1-tiny", Language: "en-US"})
+	warnUnsupportedLanguage(Config{Model: "jina-v2", Language: "de"})
+	warnUnsupportedLanguage(Config{Model: "jina-v1-tiny"})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no warnings, got %q", buf.String())
+	}
+}
+
+func TestResultToDocuments(t *testing.T) {
+	if docs := ResultToDocuments(nil); docs != nil {
+		t.Errorf("Expected nil for nil results, got %v", docs)
+	}
+	if docs := ResultToDocuments([]RerankResult{}); docs == nil || len(docs) != 0 {
+		t.Errorf("Expected an empty slice for empty results, got %#v", docs)
+	}
+
+	meta := map[string]interface{}{"source": "bm25", MetaRerankRank: 7}
+	results := []RerankResult{
+		{Document: Document{ID: "b", Content: "beta", Score: 0.1, Meta: meta}, Score: 2.5, Index: 1, Rank: 1},
+		{Document: Document{ID: "a", Content: "alpha"}, Score: 1.5, Index: 0},
+	}
+
+	docs := ResultToDocuments(results)
+	if len(docs) != 2 || docs[0].ID != "b" || docs[0].Score != 2.5 || docs[1].Score != 1.5 {
+		t.Fatalf("Expected documents in result order with result scores, got %+v", docs)
+	}
+	if docs[0].Meta["source"] != "bm25" || docs[0].Meta[MetaRerankRank] != 1 || docs[0].Meta[MetaOriginalIndex] != 1 {
+		t.Errorf("Expected existing metadata kept and rank overwritten, got %v", docs[0].Meta)
+	}
+	if docs[1].Meta[MetaRerankRank] != 2 || docs[1].Meta[MetaOriginalIndex] != 0 {
+		t.Errorf("Expected an unranked result to get its position as rank, got %v", docs[1].Meta)
+	}
+	if meta[MetaRerankRank] != 7 || len(meta) != 2 {
+		t.Errorf("Expected the input metadata to be left untouched, got %v", meta)
+	}
+}
+
+func TestDocumentsToResults(t *testing.T) {
+	if results := DocumentsToResults(nil); results != nil {
+		t.Errorf("Expected nil for nil documents, got %v", results)
+	}
+	if results := DocumentsToResults([]Document{}); results == nil || len(results) != 0 {
+		t.Errorf("Expected an empty slice for empty documents, got %#v", results)
+	}
+
+	results := DocumentsToResults([]Document{
+		{ID: "x", Score: 0.9, Meta: map[string]interface{}{MetaOriginalIndex: 4}},
+		{ID: "y", Score: 0.4},
+	})
+	if results[0].Index != 4 || results[0].Score != 0.9 || results[0].Rank != 1 {
+		t.Errorf("Expected index from metadata, got %+v", results[0])
+	}
+	if results[1].Index != 1 || results[1].Rank != 2 || results[1].NormalizedRank != 0.5 {
+		t.Errorf("Expected position as index without metadata, got %+v", results[1])
+	}
+}
+
+func TestResultDocumentRoundTrip(t *testing.T) {
+	results := []RerankResult{
+		{Document: Document{ID: "b", Content: "beta"}, Score: 2.5, Index: 1, Rank: 1, NormalizedRank: 1},
+		{Document: Document{ID: "a", Content: "alpha"}, Score: 1.5, Index: 0, Rank: 2, NormalizedRank: 0.5},
+	}
+
+	// Metadata survives JSON between stages, turning ints into float64
+	payload, err := json.Marshal(ResultToDocuments(results))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var docs []Document
+	if err := json.Unmarshal(payload, &docs); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	roundTrip := DocumentsToResults(docs)
+	for i := range roundTrip {
+		roundTrip[i].Document = results[i].Document
+	}
+	if !reflect.DeepEqual(roundTrip, results) {
+		t.Errorf("Expected %+v after a round trip, got %+v", results, roundTrip)
+	}
+}
+
+func TestInstructionTestDataForwarded(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "test_data", "test_instruction.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	var testData TestData
+	if err := json.Unmarshal(data, &testData); err != nil {
+		t.Fatalf("Failed to parse test file: %v", err)
+	}
+	if testData.Instruction == "" {
+		t.Fatal("Expected the test file to carry an instruction")
+	}
+
+	// The CLI passes the test case instruction as Options["instruction"]
+	r, prompts := newInstructionGGUFReranker(t)
+	r.modelPath = filepath.Join(filepath.Dir(r.modelPath), "Qwen3-Reranker-0.6B.Q4_K_M.gguf")
+	r.config.Options["instruction"] = testData.Instruction
+	documents := make([]Document, len(testData.Documents))
+	for i, content := range testData.Documents {
+		documents[i] = Document{Content: content}
+	}
+	if _, err := r.Rank(context.Background(), testData.Query, documents, 1); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	logged, err := os.ReadFile(prompts)
+	if err != nil {
+		t.Fatalf("Failed to read prompts: %v", err)
+	}
+	want := FormatQwen3Query(testData.Instruction, testData.Query)
+	if !strings.Contains(string(logged), want+"\n---") {
+		t.Fatalf("Expected the instructed query prompt to reach the model, got:\n%s", logged)
+	}
+	system := strings.Index(want, "<|im_start|>system")
+	instruct := strings.Index(want, "<Instruct>: "+testData.Instruction)
+	query := strings.Index(want, "<Query>: "+testData.Query)
+	if system != 0 || instruct <= system || query <= instruct {
+		t.Errorf("Expected the instruction between the system prompt and the query, got %q", want)
+	}
+}
+
+func TestGetModelCapabilities(t *testing.T) {
+	jina, err := GetModelCapabilities("jina-v2")
+	if err != nil {
+		t.Fatalf("GetModelCapabilities failed: %v", err)
+	}
+	if !jina.Multilingual {
+		t.Error("Expected jina-v2 to be multilingual")
+	}
+	tiny, err := GetModelCapabilities("jina-v1-tiny")
+	if err != nil {
+		t.Fatalf("GetModelCapabilities failed: %v", err)
+	}
+	if tiny.Multilingual {
+		t.Error("Expected jina-v1-tiny not to be multilingual")
+	}
+
+	qwen, err := GetModelCapabilities("gguf/qwen-4b")
+	if err != nil {
+		t.Fatalf("GetModelCapabilities failed for an alias: %v", err)
+	}
+	if !qwen.InstructionFollowing || qwen.QuantizationBits != 4 {
+		t.Errorf("Expected an instruction-following 4-bit model, got %+v", qwen)
+	}
+
+	if _, err := GetModelCapabilities("unknown"); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound, got %v", err)
+	}
+}
+
+func TestGetModelCapabilities_AllBuiltinModels(t *testing.T) {
+	for _, model := range builtinModels() {
+		capabilities, err := GetModelCapabilities(model.Name)
+		if err != nil {
+			t.Errorf("Expected capabilities for %s: %v", model.Name, err)
+			continue
+		}
+		if capabilities.MaxContextTokens <= 0 {
+			t.Errorf("Expected a positive context length for %s, got %d", model.Name, capabilities.MaxContextTokens)
+		}
+		if capabilities.Multilingual && len(model.Languages) > 0 {
+			t.Errorf("Expected %s with languages %v not to be multilingual", model.Name, model.Languages)
+		}
+	}
+}
+
+func TestRank_PreCancelledContext(t *testing.T) {
+	// The GGUF stub records every inference it is asked for
+	gguf := newFakeGGUFReranker(t, 2)
+	calls := filepath.Join(t.TempDir(), "calls")
+	script := "#!/bin/sh\necho call >> " + calls + "\necho '{\"data\":[{\"index\":0,\"embedding\":[1,0]}]}'\n"
+	writeStubBinary(t, gguf.inferenceBinary, script)
+
+	rerankers := map[string]Reranker{
+		"simple":        NewSimpleReranker(Config{}),
+		"cross-encoder": NewCrossEncoderReranker(Config{}),
+		"gguf":          gguf,
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	for name, r := range rerankers {
+		for _, tc := range []struct {
+			ctx  context.Context
+			want error
+		}{{cancelled, context.Canceled}, {expired, context.DeadlineExceeded}} {
+			documents := []Document{{ID: "a", Content: "cooking pasta"}, {ID: "b", Content: "machine learning"}}
+
+			if _, err := r.Rank(tc.ctx, "machine learning", documents, 2); !errors.Is(err, ErrInference) || !errors.Is(err, tc.want) {
+				t.Errorf("%s: expected Rank to fail with ErrInference wrapping %v, got %v", name, tc.want, err)
+			}
+			if _, err := r.ComputeScore(tc.ctx, "machine learning", documents); !errors.Is(err, ErrInference) || !errors.Is(err, tc.want) {
+				t.Errorf("%s: expected ComputeScore to fail with ErrInference wrapping %v, got %v", name, tc.want, err)
+			}
+			if _, err := r.Rerank(tc.ctx, "machine learning", documents); !errors.Is(err, tc.want) {
+				t.Errorf("%s: expected Rerank to fail with %v, got %v", name, tc.want, err)
+			}
+			// Rerank sorts in place, so untouched documents were never scored
+			if documents[0].ID != "a" || documents[0].Score != 0 || documents[1].Score != 0 {
+				t.Errorf("%s: expected the documents to be left unscored, got %+v", name, documents)
+			}
+		}
+	}
+	if _, err := os.Stat(calls); !os.IsNotExist(err) {
+		t.Error("Expected no GGUF inference with a cancelled context")
+	}
+}
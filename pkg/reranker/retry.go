@@ -0,0 +1,189 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// Default retry settings for RetryReranker
+const (
+	DefaultRetryMaxAttempts       = 3
+	DefaultRetryInitialBackoffMS  = 100
+	DefaultRetryBackoffMultiplier = 2.0
+)
+
+// retryJitter is the fraction of each backoff interval added or subtracted at random
+const retryJitter = 0.1
+
+// RetryReranker retries calls on the wrapped reranker that fail with
+// ErrInference, sleeping with exponential backoff between attempts. Other
+// errors, including ErrInvalidInput, are returned immediately.
+//
+// Recognized options:
+//   - "retry_max_attempts": total attempts per call, including the first (default 3)
+//   - "retry_initial_backoff_ms": delay before the first retry (default 100)
+//   - "retry_backoff_multiplier": growth factor of the delay per retry (default 2.0)
+type RetryReranker struct {
+	config         Config
+	inner          Reranker
+	maxAttempts    int
+	initialBackoff time.Duration
+	multiplier     float64
+}
+
+// retryEnabled reports whether the options request retries
+func retryEnabled(opts map[string]interface{}) bool {
+	_, ok := opts["retry_max_attempts"]
+	return ok
+}
+
+// NewRetryReranker wraps inner with retries configured from config options
+func NewRetryReranker(inner Reranker, config Config) (*RetryReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: retry requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &RetryReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// retry runs call until it succeeds, fails with a non-inference error or
+// runs out of attempts. Cancelling ctx while waiting returns the last error
+// joined with the context error.
+func (r *RetryReranker) retry(ctx context.Context, method string, call func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	backoff := float64(r.initialBackoff)
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = call(); err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrInvalidInput) || !errors.Is(err, ErrInference) || attempt >= r.maxAttempts {
+			return err
+		}
+
+		delay := time.Duration(backoff * (1 + retryJitter*(2*rand.Float64()-1)))
+		log.Printf("%s failed on %s (attempt %d/%d): %v; retrying in %v", method, r.inner.GetModelName(), attempt, r.maxAttempts, err, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= r.multiplier
+	}
+}
+
+// Rerank reranks with the wrapped reranker, retrying inference errors
+func (r *RetryReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	var reranked []Document
+	err := r.retry(ctx, "Rerank", func() error {
+		// Copy documents since rerankers may reorder the slice in place before failing
+		var err error
+		reranked, err = r.inner.Rerank(ctx, query, append([]Document(nil), documents...))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reranked, nil
+}
+
+// ComputeScore scores with the wrapped reranker, retrying inference errors
+func (r *RetryReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	var scores []float64
+	err := r.retry(ctx, "ComputeScore", func() error {
+		var err error
+		scores, err = r.inner.ComputeScore(ctx, query, documents)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// Rank ranks with the wrapped reranker, retrying inference errors
+func (r *RetryReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	var results []RerankResult
+	err := r.retry(ctx, "Rank", func() error {
+		var err error
+		results, err = r.inner.Rank(ctx, query, documents, topN)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *RetryReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *RetryReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *RetryReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the retry settings; the inner reranker is left unchanged
+func (r *RetryReranker) Configure(config Config) error {
+	maxAttempts := OptionInt(config.Options, "retry_max_attempts", DefaultRetryMaxAttempts)
+	if maxAttempts < 1 {
+		return fmt.Errorf("%w: retry_max_attempts must be at least 1, got %d", ErrInvalidInput, maxAttempts)
+	}
+	backoffMS := OptionFloat(config.Options, "retry_initial_backoff_ms", DefaultRetryInitialBackoffMS)
+	if backoffMS < 0 {
+		return fmt.Errorf("%w: retry_initial_backoff_ms must not be negative, got %f", ErrInvalidInput, backoffMS)
+	}
+	multiplier := OptionFloat(config.Options, "retry_backoff_multiplier", DefaultRetryBackoffMultiplier)
+	if multiplier < 1 {
+		return fmt.Errorf("%w: retry_backoff_multiplier must be at least 1, got %f", ErrInvalidInput, multiplier)
+	}
+
+	r.config = config
+	r.maxAttempts = maxAttempts
+	r.initialBackoff = time.Duration(backoffMS * float64(time.Millisecond))
+	r.multiplier = multiplier
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *RetryReranker) Close() error {
+	return closeReranker(r.inner)
+}
+
+// Unwrap returns the wrapped reranker
+func (r *RetryReranker) Unwrap() Reranker {
+	return r.inner
+}
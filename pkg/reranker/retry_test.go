@@ -0,0 +1,177 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// flakyReranker fails the first failures calls with err, then delegates to a SimpleReranker
+type flakyReranker struct {
+	SimpleReranker
+	err      error
+	failures int
+	calls    int
+}
+
+func newFlakyReranker(failures int, err error) *flakyReranker {
+	return &flakyReranker{SimpleReranker: *NewSimpleReranker(Config{}), err: err, failures: failures}
+}
+
+func (r *flakyReranker) fail() error {
+	r.calls++
+	if r.calls <= r.failures {
+		return r.err
+	}
+	return nil
+}
+
+func (r *flakyReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.fail(); err != nil {
+		return nil, err
+	}
+	return r.SimpleReranker.Rerank(ctx, query, documents)
+}
+
+func (r *flakyReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.fail(); err != nil {
+		return nil, err
+	}
+	return r.SimpleReranker.ComputeScore(ctx, query, documents)
+}
+
+func (r *flakyReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.fail(); err != nil {
+		return nil, err
+	}
+	return r.SimpleReranker.Rank(ctx, query, documents, topN)
+}
+
+func retryConfig(attempts int) Config {
+	return Config{Options: map[string]interface{}{
+		"retry_max_attempts":       attempts,
+		"retry_initial_backoff_ms": 1,
+	}}
+}
+
+var retryDocuments = []Document{
+	{ID: "1", Content: "machine learning"},
+	{ID: "2", Content: "cooking"},
+}
+
+func TestRetryReranker_SucceedsOnThirdAttempt(t *testing.T) {
+	inner := newFlakyReranker(2, fmt.Errorf("%w: subprocess timed out", ErrInference))
+	r, err := NewRetryReranker(inner, retryConfig(3))
+	if err != nil {
+		t.Fatalf("NewRetryReranker failed: %v", err)
+	}
+
+	results, err := r.Rank(context.Background(), "machine learning", retryDocuments, 0)
+	if err != nil {
+		t.Fatalf("Expected success on the third attempt, got %v", err)
+	}
+	if inner.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", inner.calls)
+	}
+	if len(results) != 2 || results[0].Document.ID != "1" {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+}
+
+func TestRetryReranker_NilContext(t *testing.T) {
+	inner := newFlakyReranker(1, fmt.Errorf("%w: subprocess timed out", ErrInference))
+	r, err := NewRetryReranker(inner, retryConfig(2))
+	if err != nil {
+		t.Fatalf("NewRetryReranker failed: %v", err)
+	}
+
+	// utils.BenchmarkReranker ranks with a nil context
+	if _, err := r.Rank(nil, "machine learning", retryDocuments, 0); err != nil {
+		t.Fatalf("Expected success on the second attempt, got %v", err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", inner.calls)
+	}
+}
+
+func TestRetryReranker_GivesUpAfterMaxAttempts(t *testing.T) {
+	inner := newFlakyReranker(2, fmt.Errorf("%w: server returned 503", ErrInference))
+	r, err := NewRetryReranker(inner, retryConfig(2))
+	if err != nil {
+		t.Fatalf("NewRetryReranker failed: %v", err)
+	}
+
+	if _, err := r.ComputeScore(context.Background(), "query", retryDocuments); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference, got %v", err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", inner.calls)
+	}
+}
+
+func TestRetryReranker_DoesNotRetryInvalidInput(t *testing.T) {
+	inner := newFlakyReranker(2, fmt.Errorf("%w: empty query", ErrInvalidInput))
+	r, err := NewRetryReranker(inner, retryConfig(3))
+	if err != nil {
+		t.Fatalf("NewRetryReranker failed: %v", err)
+	}
+
+	if _, err := r.Rerank(context.Background(), "query", retryDocuments); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", inner.calls)
+	}
+}
+
+func TestRetryReranker_CancelDuringBackoff(t *testing.T) {
+	inner := newFlakyReranker(2, fmt.Errorf("%w: subprocess timed out", ErrInference))
+	config := retryConfig(3)
+	config.Options["retry_initial_backoff_ms"] = 10000
+	r, err := NewRetryReranker(inner, config)
+	if err != nil {
+		t.Fatalf("NewRetryReranker failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = r.Rank(ctx, "query", retryDocuments, 0)
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrInference) {
+		t.Errorf("Expected inference and deadline errors, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected backoff to stop on cancellation, took %v", elapsed)
+	}
+	if inner.calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", inner.calls)
+	}
+}
+
+func TestNewRetryReranker_Validation(t *testing.T) {
+	if _, err := NewRetryReranker(nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for nil inner, got %v", err)
+	}
+
+	invalid := []map[string]interface{}{
+		{"retry_max_attempts": 0},
+		{"retry_initial_backoff_ms": -1},
+		{"retry_backoff_multiplier": 0.5},
+	}
+	for _, opts := range invalid {
+		if _, err := NewRetryReranker(NewSimpleReranker(Config{}), Config{Options: opts}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %v, got %v", opts, err)
+		}
+	}
+
+	r, err := NewRetryReranker(NewSimpleReranker(Config{}), Config{})
+	if err != nil {
+		t.Fatalf("NewRetryReranker failed: %v", err)
+	}
+	if r.maxAttempts != DefaultRetryMaxAttempts || r.initialBackoff != 100*time.Millisecond || r.multiplier != DefaultRetryBackoffMultiplier {
+		t.Errorf("Unexpected defaults: %d, %v, %f", r.maxAttempts, r.initialBackoff, r.multiplier)
+	}
+}
@@ -0,0 +1,117 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// RoundRobinStats is a snapshot of a RoundRobinReranker's routing
+type RoundRobinStats struct {
+	// RequestCount is the number of calls routed to each member, by model
+	// name; members sharing a name are summed
+	RequestCount map[string]int64 `json:"request_count"`
+}
+
+// RoundRobinReranker spreads calls over several rerankers, e.g. GGUF models
+// loaded on different GPUs, sending each call to the next member in turn.
+// Unlike RerankerPool it never waits for a member to be idle.
+type RoundRobinReranker struct {
+	config   Config
+	members  []Reranker
+	next     atomic.Uint64
+	requests []atomic.Int64
+}
+
+// NewRoundRobinReranker cycles through members, which must not be empty
+func NewRoundRobinReranker(config Config, members []Reranker) (*RoundRobinReranker, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%w: round-robin reranking requires at least one member", ErrInvalidInput)
+	}
+	for i, member := range members {
+		if member == nil {
+			return nil, fmt.Errorf("%w: round-robin member %d has no reranker", ErrInvalidInput, i)
+		}
+	}
+
+	r := &RoundRobinReranker{
+		members:  append([]Reranker(nil), members...),
+		requests: make([]atomic.Int64, len(members)),
+	}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// member returns the member serving the next call and counts the call
+func (r *RoundRobinReranker) member() Reranker {
+	index := int((r.next.Add(1) - 1) % uint64(len(r.members)))
+	r.requests[index].Add(1)
+	return r.members[index]
+}
+
+// current returns the member that served the latest call, or the first
+// member before any call
+func (r *RoundRobinReranker) current() Reranker {
+	next := r.next.Load()
+	if next == 0 {
+		return r.members[0]
+	}
+	return r.members[(next-1)%uint64(len(r.members))]
+}
+
+// Rerank reorders documents with the next member
+func (r *RoundRobinReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	return r.member().Rerank(ctx, query, documents)
+}
+
+// ComputeScore scores documents with the next member
+func (r *RoundRobinReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	return r.member().ComputeScore(ctx, query, documents)
+}
+
+// Rank returns top-N documents ranked by the next member
+func (r *RoundRobinReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	return r.member().Rank(ctx, query, documents, topN)
+}
+
+// Stats returns the number of calls routed to each member
+func (r *RoundRobinReranker) Stats() RoundRobinStats {
+	stats := RoundRobinStats{RequestCount: make(map[string]int64, len(r.members))}
+	for i, member := range r.members {
+		stats.RequestCount[member.GetModelName()] += r.requests[i].Load()
+	}
+	return stats
+}
+
+// GetModelName returns the model name of the member that served the latest call
+func (r *RoundRobinReranker) GetModelName() string {
+	return r.current().GetModelName()
+}
+
+// Version returns the version of the member that served the latest call
+func (r *RoundRobinReranker) Version() string {
+	return r.current().Version()
+}
+
+// HealthCheck checks every member
+func (r *RoundRobinReranker) HealthCheck(ctx context.Context) error {
+	return healthCheckAll(ctx, r.members)
+}
+
+// Configure updates the round-robin configuration; members are left unchanged
+func (r *RoundRobinReranker) Configure(config Config) error {
+	r.config = config
+	return nil
+}
+
+// Close releases resources held by the members
+func (r *RoundRobinReranker) Close() error {
+	var errs []error
+	for _, member := range r.members {
+		errs = append(errs, closeReranker(member))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// newRecordingMembers returns size mocks named model-0, model-1, ... that
+// count the calls they serve in calls
+func newRecordingMembers(size int, calls map[string]int, mutex *sync.Mutex) []Reranker {
+	members := make([]Reranker, size)
+	for i := range members {
+		name := fmt.Sprintf("model-%d", i)
+		mock := NewMockReranker(func(query string, doc Document) float64 {
+			mutex.Lock()
+			calls[name]++
+			mutex.Unlock()
+			return 1
+		})
+		mock.Configure(Config{Model: name, StableSort: true})
+		members[i] = mock
+	}
+	return members
+}
+
+func TestRoundRobinReranker_Distribution(t *testing.T) {
+	documents := []Document{{ID: "a", Content: "a"}}
+	for _, tc := range []struct{ calls, members int }{{10, 3}, {7, 7}, {100, 4}, {2, 5}} {
+		var mutex sync.Mutex
+		calls := make(map[string]int)
+		r, err := NewRoundRobinReranker(Config{}, newRecordingMembers(tc.members, calls, &mutex))
+		if err != nil {
+			t.Fatalf("NewRoundRobinReranker failed: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < tc.calls; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := r.Rank(context.Background(), "query", documents, 1); err != nil {
+					t.Errorf("Rank failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		stats := r.Stats()
+		low, high := tc.calls, 0
+		for i := 0; i < tc.members; i++ {
+			name := fmt.Sprintf("model-%d", i)
+			if int64(calls[name]) != stats.RequestCount[name] {
+				t.Errorf("Expected stats to count %d calls of %s, got %d", calls[name], name, stats.RequestCount[name])
+			}
+			low, high = min(low, calls[name]), max(high, calls[name])
+		}
+		if high-low > 1 {
+			t.Errorf("%d calls over %d members: expected at most 1 skew, got %v", tc.calls, tc.members, calls)
+		}
+	}
+}
+
+func TestRoundRobinReranker_CurrentModel(t *testing.T) {
+	var mutex sync.Mutex
+	r, err := NewRoundRobinReranker(Config{}, newRecordingMembers(2, make(map[string]int), &mutex))
+	if err != nil {
+		t.Fatalf("NewRoundRobinReranker failed: %v", err)
+	}
+	if name := r.GetModelName(); name != "model-0" {
+		t.Errorf("Expected the first member before any call, got %s", name)
+	}
+
+	documents := []Document{{ID: "a", Content: "a"}}
+	for i, want := range []string{"model-0", "model-1", "model-0"} {
+		if _, err := r.ComputeScore(context.Background(), "query", documents); err != nil {
+			t.Fatalf("ComputeScore failed: %v", err)
+		}
+		if name := r.GetModelName(); name != want {
+			t.Errorf("Call %d: expected current model %s, got %s", i, want, name)
+		}
+	}
+
+	if _, err := NewRoundRobinReranker(Config{}, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without members, got %v", err)
+	}
+}
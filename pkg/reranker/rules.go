@@ -0,0 +1,294 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Operators of ScoreRule
+const (
+	// RuleOpBoost adds Weight times the document's field value, min-max
+	// scaled to [0, 1] over the batch
+	RuleOpBoost = "boost"
+	// RuleOpMatch adds Weight when the field equals Value
+	RuleOpMatch = "match"
+)
+
+// Directions of a boost rule
+const (
+	// RuleDirectionDesc boosts larger values and later dates the most (the default)
+	RuleDirectionDesc = "desc"
+	// RuleDirectionAsc boosts smaller values and earlier dates the most
+	RuleDirectionAsc = "asc"
+)
+
+// ScoreModifier returns the adjusted score of doc given its model score
+type ScoreModifier func(doc Document, baseScore float64) float64
+
+// ScoreRule is a declarative score adjustment on a Meta field, e.g. a
+// recency boost {"field": "date", "op": "boost", "weight": 0.1, "direction": "desc"}
+// or a source authority bonus {"field": "source", "op": "match", "value": "docs", "weight": 0.2}.
+// Field may use dots to reach nested maps. A negative Weight demotes.
+type ScoreRule struct {
+	Field     string      `json:"field"`
+	Op        string      `json:"op"`
+	Weight    float64     `json:"weight"`
+	Direction string      `json:"direction,omitempty"`
+	Value     interface{} `json:"value,omitempty"`
+}
+
+// validate rejects rules with an empty field, unknown operator or direction
+func (rule ScoreRule) validate() error {
+	if rule.Field == "" {
+		return fmt.Errorf("%w: score rule has no field", ErrInvalidInput)
+	}
+	switch rule.Op {
+	case RuleOpBoost:
+		switch rule.Direction {
+		case "", RuleDirectionDesc, RuleDirectionAsc:
+		default:
+			return fmt.Errorf("%w: unknown direction %q in score rule on %s", ErrInvalidInput, rule.Direction, rule.Field)
+		}
+	case RuleOpMatch:
+	default:
+		return fmt.Errorf("%w: unknown operator %q in score rule on %s", ErrInvalidInput, rule.Op, rule.Field)
+	}
+	return nil
+}
+
+// ruleValue returns a field value as a number, reading dates as Unix seconds
+func ruleValue(doc Document, field string) (float64, bool) {
+	value, ok := lookupMeta(doc.Meta, field)
+	if !ok {
+		return 0, false
+	}
+	if f, ok := toFloat(value); ok {
+		return f, true
+	}
+	if t, ok := toTime(value); ok {
+		return float64(t.Unix()), true
+	}
+	return 0, false
+}
+
+// modifier builds the ScoreModifier of rule for a batch of documents. Boosts
+// are relative to the batch: the document with the best field value gets the
+// full Weight, the worst none; documents without the field are unchanged.
+func (rule ScoreRule) modifier(documents []Document) ScoreModifier {
+	if rule.Op == RuleOpMatch {
+		return func(doc Document, score float64) float64 {
+			if value, ok := lookupMeta(doc.Meta, rule.Field); ok && compareValues(value, rule.Value) == 0 {
+				return score + rule.Weight
+			}
+			return score
+		}
+	}
+
+	var values []float64
+	for _, doc := range documents {
+		if value, ok := ruleValue(doc, rule.Field); ok {
+			values = append(values, value)
+		}
+	}
+	low, high := 0.0, 0.0
+	for i, value := range values {
+		if i == 0 || value < low {
+			low = value
+		}
+		if i == 0 || value > high {
+			high = value
+		}
+	}
+
+	return func(doc Document, score float64) float64 {
+		value, ok := ruleValue(doc, rule.Field)
+		if !ok {
+			return score
+		}
+		position := 1.0
+		if high > low {
+			position = (value - low) / (high - low)
+		}
+		if rule.Direction == RuleDirectionAsc && high > low {
+			position = 1 - position
+		}
+		return score + rule.Weight*position
+	}
+}
+
+// scoreRulesOption reads Options["rules"], accepting []ScoreRule, a JSON
+// string or the generic JSON form ([]interface{} of objects)
+func scoreRulesOption(opts map[string]interface{}) ([]ScoreRule, error) {
+	if opts == nil || opts["rules"] == nil {
+		return nil, nil
+	}
+
+	var rules []ScoreRule
+	switch value := opts["rules"].(type) {
+	case []ScoreRule:
+		rules = value
+	case string:
+		if err := json.Unmarshal([]byte(value), &rules); err != nil {
+			return nil, fmt.Errorf("%w: invalid rules option: %v", ErrInvalidInput, err)
+		}
+	default:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid rules option: %v", ErrInvalidInput, err)
+		}
+		if err := json.Unmarshal(raw, &rules); err != nil {
+			return nil, fmt.Errorf("%w: invalid rules option: %v", ErrInvalidInput, err)
+		}
+	}
+
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// BusinessRuleReranker adjusts the wrapped reranker's scores with business
+// rules before sorting, e.g. to promote recent documents or trusted sources.
+// The rules of Options["rules"] are applied first, in order, then
+// ScoreModifier when set.
+//
+// Recognized options:
+//   - "rules": JSON array (or []ScoreRule) of rules such as
+//     {"field": "date", "op": "boost", "weight": 0.1, "direction": "desc"}
+type BusinessRuleReranker struct {
+	// ScoreModifier, when set, adjusts each score after the declarative rules
+	ScoreModifier ScoreModifier
+
+	config Config
+	inner  Reranker
+	rules  []ScoreRule
+}
+
+// NewBusinessRuleReranker wraps inner; rules are read from config options
+func NewBusinessRuleReranker(inner Reranker, config Config) (*BusinessRuleReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: business rule reranking requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &BusinessRuleReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rules returns the declarative rules in the order they are applied
+func (r *BusinessRuleReranker) Rules() []ScoreRule {
+	return append([]ScoreRule(nil), r.rules...)
+}
+
+// Rerank reorders documents by their adjusted score
+func (r *BusinessRuleReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns each document's model score adjusted by the rules, in
+// document order
+func (r *BusinessRuleReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *BusinessRuleReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.inner.ComputeScore(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) != len(documents) {
+		return nil, fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(documents), len(scores))
+	}
+
+	modifiers := make([]ScoreModifier, 0, len(r.rules)+1)
+	for _, rule := range r.rules {
+		modifiers = append(modifiers, rule.modifier(documents))
+	}
+	if r.ScoreModifier != nil {
+		modifiers = append(modifiers, r.ScoreModifier)
+	}
+
+	adjusted := make([]float64, len(scores))
+	for i, doc := range documents {
+		adjusted[i] = scores[i]
+		for _, modify := range modifiers {
+			adjusted[i] = modify(doc, adjusted[i])
+		}
+	}
+	return adjusted, nil
+}
+
+// Rank returns top-N documents by their adjusted score
+func (r *BusinessRuleReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *BusinessRuleReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *BusinessRuleReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *BusinessRuleReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure validates and updates the rules; the inner reranker and
+// ScoreModifier are left unchanged
+func (r *BusinessRuleReranker) Configure(config Config) error {
+	rules, err := scoreRulesOption(config.Options)
+	if err != nil {
+		return err
+	}
+
+	r.config = config
+	r.rules = rules
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *BusinessRuleReranker) Close() error {
+	return closeReranker(r.inner)
+}
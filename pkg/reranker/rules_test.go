@@ -0,0 +1,125 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// ruleDocuments returns an older document with a slightly higher model score
+// and a newer one
+func ruleDocuments() ([]Document, *windowRecorder) {
+	documents := []Document{
+		{ID: "old", Content: "older article", Meta: map[string]interface{}{"date": "2021-03-01", "source": "blog"}},
+		{ID: "new", Content: "newer article", Meta: map[string]interface{}{"date": "2024-06-15", "source": "docs"}},
+		{ID: "undated", Content: "undated article"},
+	}
+	inner := &windowRecorder{scores: map[string]float64{"old": 0.82, "new": 0.8, "undated": 0.5}}
+	return documents, inner
+}
+
+func TestBusinessRuleReranker_RecencyBoost(t *testing.T) {
+	documents, inner := ruleDocuments()
+
+	plain, err := inner.Rank(context.Background(), "query", documents, 0)
+	if err != nil || plain[0].Document.ID != "old" {
+		t.Fatalf("Expected the older document first without rules, got %+v, %v", plain, err)
+	}
+
+	r, err := NewBusinessRuleReranker(inner, Config{Options: map[string]interface{}{
+		"rules": `[{"field":"date","op":"boost","weight":0.1,"direction":"desc"}]`,
+	}})
+	if err != nil {
+		t.Fatalf("NewBusinessRuleReranker failed: %v", err)
+	}
+
+	results, err := r.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if results[0].Document.ID != "new" || results[0].Rank != 1 {
+		t.Errorf("Expected the newer document first, got %+v", results)
+	}
+	scores, _ := r.ComputeScore(context.Background(), "query", documents)
+	if want := []float64{0.82, 0.9, 0.5}; !approxEqualScores(scores, want) {
+		t.Errorf("Expected scores %v, got %v", want, scores)
+	}
+}
+
+func TestBusinessRuleReranker_AscendingAndMatch(t *testing.T) {
+	documents, inner := ruleDocuments()
+	r, err := NewBusinessRuleReranker(inner, Config{Options: map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"field": "date", "op": "boost", "weight": 0.1, "direction": "asc"},
+			map[string]interface{}{"field": "source", "op": "match", "value": "docs", "weight": 0.5},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("NewBusinessRuleReranker failed: %v", err)
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	// The older document gets the ascending boost, the newer the source bonus
+	if want := []float64{0.92, 1.3, 0.5}; !approxEqualScores(scores, want) {
+		t.Errorf("Expected scores %v, got %v", want, scores)
+	}
+}
+
+func TestBusinessRuleReranker_ScoreModifier(t *testing.T) {
+	documents, inner := ruleDocuments()
+	r, err := NewBusinessRuleReranker(inner, Config{Options: map[string]interface{}{
+		"rules": []ScoreRule{{Field: "source", Op: RuleOpMatch, Value: "blog", Weight: 1}},
+	}})
+	if err != nil {
+		t.Fatalf("NewBusinessRuleReranker failed: %v", err)
+	}
+	// The modifier runs after the rules and sees their adjustment
+	r.ScoreModifier = func(doc Document, baseScore float64) float64 {
+		if doc.ID == "undated" {
+			return baseScore * 10
+		}
+		return baseScore / 2
+	}
+
+	reranked, err := r.Rerank(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if reranked[0].ID != "undated" || reranked[1].ID != "old" || !approxEqual(reranked[1].Score, 0.91) {
+		t.Errorf("Expected undated then old (0.91), got %+v", reranked)
+	}
+}
+
+func TestBusinessRuleReranker_InvalidRules(t *testing.T) {
+	inner := &windowRecorder{}
+	if _, err := NewBusinessRuleReranker(nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without inner reranker, got %v", err)
+	}
+
+	for _, rules := range []interface{}{
+		`not json`,
+		`[{"op":"boost","weight":0.1}]`,
+		`[{"field":"date","op":"promote","weight":0.1}]`,
+		`[{"field":"date","op":"boost","weight":0.1,"direction":"up"}]`,
+	} {
+		if _, err := NewBusinessRuleReranker(inner, Config{Options: map[string]interface{}{"rules": rules}}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %v, got %v", rules, err)
+		}
+	}
+}
+
+// approxEqualScores compares score slices with approxEqual
+func approxEqualScores(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !approxEqual(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
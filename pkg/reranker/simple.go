@@ -3,7 +3,6 @@ package reranker
 import (
 	"context"
 	"log"
-	"sort"
 	"strings"
 )
 
@@ -28,6 +27,13 @@ func NewSimpleReranker(config Config) *SimpleReranker {
 
 // Rerank reorders documents based on relevance to a query
 func (r *SimpleReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
 	if len(documents) == 0 {
 		return documents, nil
 	}
@@ -35,19 +41,22 @@ func (r *SimpleReranker) Rerank(ctx context.Context, query string, documents []D
 	log.Printf("Reranking %d documents for query: %s", len(documents), query)
 
 	// Apply basic text similarity scoring
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
 	for i := range documents {
-		documents[i].Score = r.calculateSimilarity(query, documents[i].Content)
+		documents[i].Score = scores[i]
 	}
 
 	// Sort by score (descending)
-	sort.Slice(documents, func(i, j int) bool {
-		return documents[i].Score > documents[j].Score
-	})
+	sortDocuments(documents, r.config.tieBreak())
 
 	// Apply threshold filter
+	threshold := r.config.scoreThreshold(scores)
 	var filtered []Document
 	for _, doc := range documents {
-		if doc.Score >= r.config.Threshold {
+		if doc.Score >= threshold {
 			filtered = append(filtered, doc)
 		}
 	}
@@ -62,6 +71,9 @@ func (r *SimpleReranker) Rerank(ctx context.Context, query string, documents []D
 
 // Configure updates the reranker configuration
 func (r *SimpleReranker) Configure(config Config) error {
+	if err := validateThreshold(config); err != nil {
+		return err
+	}
 	r.config = config
 	if r.config.MaxDocs == 0 {
 		r.config.MaxDocs = 100
@@ -91,25 +103,47 @@ func (r *SimpleReranker) calculateSimilarity(query, content string) float64 {
 	return float64(matches) / float64(len(queryWords))
 }
 
-// ComputeScore computes scores for query-document pairs
+// ComputeScore computes scores for query-document pairs, stopping with
+// ctx's error wrapped in ErrInference once ctx is done
 func (r *SimpleReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *SimpleReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
 	scores := make([]float64, len(documents))
 	
 	for i, doc := range documents {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
 		scores[i] = r.calculateSimilarity(query, doc.Content)
 	}
 	
-	return scores, nil
+	return r.config.transformScores(scores)
 }
 
 // Rank returns top-N ranked documents
 func (r *SimpleReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
 	if len(documents) == 0 {
 		return nil, nil
 	}
 
 	// Calculate scores for all documents
-	scores, err := r.ComputeScore(ctx, query, documents)
+	scores, err := r.computeScores(ctx, query, documents)
 	if err != nil {
 		return nil, err
 	}
@@ -125,14 +159,13 @@ func (r *SimpleReranker) Rank(ctx context.Context, query string, documents []Doc
 	}
 
 	// Sort by score (descending)
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+	sortResults(results, r.config.tieBreak())
 
 	// Apply threshold filter
+	threshold := r.config.scoreThreshold(scores)
 	var filtered []RerankResult
 	for _, result := range results {
-		if result.Score >= r.config.Threshold {
+		if result.Score >= threshold {
 			filtered = append(filtered, result)
 		}
 	}
@@ -142,9 +175,35 @@ func (r *SimpleReranker) Rank(ctx context.Context, query string, documents []Doc
 		filtered = filtered[:topN]
 	}
 
+	// Record output positions
+	assignRanks(filtered, r.config.NormalizeScores)
+	r.explainResults(query, filtered)
+
 	return filtered, nil
 }
 
+// RankIDs returns the IDs and scores of the top-N documents in Rank's order,
+// without copying documents into results
+func (r *SimpleReranker) RankIDs(ctx context.Context, query string, documents []Document, topN int) ([]string, []float64, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids, sorted := rankIDsByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak())
+	return ids, sorted, nil
+}
+
 // GetModelName returns the model name
 func (r *SimpleReranker) GetModelName() string {
 	if r.config.Model != "" {
@@ -152,3 +211,13 @@ func (r *SimpleReranker) GetModelName() string {
 	}
 	return "simple-reranker"
 }
+
+// Version returns "{model}@go-rerankers-{semver}"
+func (r *SimpleReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck is a no-op; scoring needs no external resources
+func (r *SimpleReranker) HealthCheck(ctx context.Context) error {
+	return nil
+}
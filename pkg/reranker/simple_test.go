@@ -2,6 +2,8 @@ package reranker
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -67,4 +69,81 @@ func TestSimpleReranker(t *testing.T) {
 	if len(rankResults) != 2 {
 		t.Errorf("Expected 2 rank results, got %d", len(rankResults))
 	}
-}
\ No newline at end of file
+
+	// Check output positions
+	for i, result := range rankResults {
+		if result.Rank != i+1 {
+			t.Errorf("Expected rank %d, got %d", i+1, result.Rank)
+		}
+		if want := 1 - float64(i)/2; result.NormalizedRank != want {
+			t.Errorf("Expected normalized rank %v, got %v", want, result.NormalizedRank)
+		}
+		if result.RelativeScore != 0 {
+			t.Errorf("Expected no relative score without normalization, got %v", result.RelativeScore)
+		}
+	}
+}
+
+// rankIDsDocuments returns n documents sharing a varying number of words with
+// "machine learning models", so many scores tie
+func rankIDsDocuments(n int) []Document {
+	words := []string{"machine", "learning", "models", "cooking", "pasta"}
+	documents := make([]Document, n)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("%s %s document %d", words[i%5], words[(i/5)%5], i)}
+	}
+	return documents
+}
+
+func TestSimpleReranker_RankIDsMatchesRank(t *testing.T) {
+	documents := rankIDsDocuments(100)
+	for _, config := range []Config{
+		{Model: "simple"},
+		{Model: "simple", StableSort: true, Threshold: 0.3},
+		{Model: "simple", TiebreakerHash: true},
+	} {
+		reranker := NewSimpleReranker(config)
+		for _, topN := range []int{0, 10} {
+			results, err := reranker.Rank(context.Background(), "machine learning models", documents, topN)
+			if err != nil {
+				t.Fatalf("Rank failed: %v", err)
+			}
+			ids, scores, err := reranker.RankIDs(context.Background(), "machine learning models", documents, topN)
+			if err != nil {
+				t.Fatalf("RankIDs failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(ids, resultIDs(results)) {
+				t.Errorf("%+v, topN %d: expected IDs %v, got %v", config, topN, resultIDs(results), ids)
+			}
+			for i, result := range results {
+				if scores[i] != result.Score {
+					t.Errorf("%+v, topN %d: expected score %v at %d, got %v", config, topN, result.Score, i, scores[i])
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkRankVsRankIDs(b *testing.B) {
+	reranker := NewSimpleReranker(Config{Model: "simple"})
+	documents := rankIDsDocuments(100)
+	ctx := context.Background()
+
+	b.Run("Rank", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := reranker.Rank(ctx, "machine learning models", documents, 10); err != nil {
+				b.Fatalf("Rank failed: %v", err)
+			}
+		}
+	})
+	b.Run("RankIDs", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := reranker.RankIDs(ctx, "machine learning models", documents, 10); err != nil {
+				b.Fatalf("RankIDs failed: %v", err)
+			}
+		}
+	})
+}
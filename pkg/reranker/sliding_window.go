@@ -0,0 +1,228 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Default sliding window settings
+const (
+	DefaultWindowSize   = 20
+	DefaultWindowStride = 10
+	DefaultWindowPasses = 3
+)
+
+// SlidingWindowReranker ranks large candidate sets as a tournament so the
+// wrapped reranker never sees more than a window of documents at once. Each
+// pass scores overlapping windows of size W every S documents and keeps the
+// best document of each window; passes repeat until the candidates fit in one
+// window or the pass budget is spent. The survivors are then scored in
+// windows of W and ranked by score; eliminated documents are not returned.
+//
+// Recognized options:
+//   - "window_size": W, documents scored per call (default 20)
+//   - "window_stride": S, offset between consecutive windows, at most W (default 10)
+//   - "window_passes": maximum number of elimination passes (default 3)
+type SlidingWindowReranker struct {
+	config Config
+	inner  Reranker
+	size   int
+	stride int
+	passes int
+}
+
+// NewSlidingWindowReranker wraps inner; window settings are read from config options
+func NewSlidingWindowReranker(inner Reranker, config Config) (*SlidingWindowReranker, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("%w: sliding window reranking requires an inner reranker", ErrInvalidInput)
+	}
+
+	r := &SlidingWindowReranker{inner: inner}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// tournament returns the indices of the surviving documents and the score of
+// every document from the last window it was scored in
+func (r *SlidingWindowReranker) tournament(ctx context.Context, query string, documents []Document) ([]int, []float64, error) {
+	scores := make([]float64, len(documents))
+	scoreWindow := func(window []int) error {
+		windowDocs := make([]Document, len(window))
+		for i, index := range window {
+			windowDocs[i] = documents[index]
+		}
+		windowScores, err := r.inner.ComputeScore(ctx, query, windowDocs)
+		if err != nil {
+			return err
+		}
+		if len(windowScores) != len(window) {
+			return fmt.Errorf("%w: expected %d scores, got %d", ErrInference, len(window), len(windowScores))
+		}
+		for i, index := range window {
+			scores[index] = windowScores[i]
+		}
+		return nil
+	}
+
+	candidates := make([]int, len(documents))
+	for i := range candidates {
+		candidates[i] = i
+	}
+
+	for pass := 0; pass < r.passes && len(candidates) > r.size; pass++ {
+		var winners []int
+		won := make(map[int]bool)
+		for start := 0; start < len(candidates); start += r.stride {
+			end := start + r.size
+			if end > len(candidates) {
+				end = len(candidates)
+			}
+			window := candidates[start:end]
+			if err := scoreWindow(window); err != nil {
+				return nil, nil, err
+			}
+
+			best := window[0]
+			for _, index := range window[1:] {
+				if scores[index] > scores[best] {
+					best = index
+				}
+			}
+			if !won[best] {
+				won[best] = true
+				winners = append(winners, best)
+			}
+			if end == len(candidates) {
+				break
+			}
+		}
+		candidates = winners
+	}
+
+	// Final scores of the survivors, one window at a time
+	for start := 0; start < len(candidates); start += r.size {
+		end := start + r.size
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		if err := scoreWindow(candidates[start:end]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return candidates, scores, nil
+}
+
+// Rerank returns the surviving documents ordered by score
+func (r *SlidingWindowReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	results, err := r.Rank(ctx, query, documents, r.config.MaxDocs)
+	if err != nil {
+		return nil, err
+	}
+
+	reranked := make([]Document, len(results))
+	for i, result := range results {
+		reranked[i] = result.Document
+		reranked[i].Score = result.Score
+	}
+	return reranked, nil
+}
+
+// ComputeScore returns each document's score from the last window it was
+// scored in, in document order
+func (r *SlidingWindowReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	_, scores, err := r.tournament(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// Rank returns up to topN of the surviving documents ordered by score
+func (r *SlidingWindowReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	survivors, scores, err := r.tournament(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	survivorScores := make([]float64, len(survivors))
+	for i, index := range survivors {
+		survivorScores[i] = scores[index]
+	}
+	threshold := r.config.scoreThreshold(survivorScores)
+
+	var results []RerankResult
+	for _, index := range survivors {
+		if scores[index] >= threshold {
+			results = append(results, RerankResult{Document: documents[index], Score: scores[index], Index: index})
+		}
+	}
+	sortResults(results, r.config.tieBreak())
+
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+	return assignRanks(results, r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the wrapped model name
+func (r *SlidingWindowReranker) GetModelName() string {
+	return r.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (r *SlidingWindowReranker) Version() string {
+	return r.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (r *SlidingWindowReranker) HealthCheck(ctx context.Context) error {
+	return r.inner.HealthCheck(ctx)
+}
+
+// Configure updates the window settings; the inner reranker is left unchanged
+func (r *SlidingWindowReranker) Configure(config Config) error {
+	size := OptionInt(config.Options, "window_size", DefaultWindowSize)
+	stride := OptionInt(config.Options, "window_stride", DefaultWindowStride)
+	passes := OptionInt(config.Options, "window_passes", DefaultWindowPasses)
+	if size < 2 {
+		return fmt.Errorf("%w: window_size must be at least 2, got %d", ErrInvalidInput, size)
+	}
+	if stride < 1 || stride > size {
+		return fmt.Errorf("%w: window_stride must be between 1 and window_size (%d), got %d", ErrInvalidInput, size, stride)
+	}
+	if passes < 1 {
+		return fmt.Errorf("%w: window_passes must be at least 1, got %d", ErrInvalidInput, passes)
+	}
+
+	r.config = config
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.size = size
+	r.stride = stride
+	r.passes = passes
+	return nil
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *SlidingWindowReranker) Close() error {
+	return closeReranker(r.inner)
+}
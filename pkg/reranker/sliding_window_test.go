@@ -0,0 +1,149 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// windowRecorder scores documents by ID and records the largest window it was given
+type windowRecorder struct {
+	scores    map[string]float64
+	maxWindow int
+	calls     int
+}
+
+func (r *windowRecorder) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	r.calls++
+	if len(documents) > r.maxWindow {
+		r.maxWindow = len(documents)
+	}
+	scores := make([]float64, len(documents))
+	for i, doc := range documents {
+		scores[i] = r.scores[doc.ID]
+	}
+	return scores, nil
+}
+
+func (r *windowRecorder) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	scores, _ := r.ComputeScore(ctx, query, documents)
+	return rerankByScores(documents, scores, 0, 0, tieInputOrder), nil
+}
+
+func (r *windowRecorder) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	scores, _ := r.ComputeScore(ctx, query, documents)
+	return rankByScores(documents, scores, 0, topN, tieInputOrder), nil
+}
+
+func (r *windowRecorder) Configure(config Config) error { return nil }
+
+func (r *windowRecorder) GetModelName() string { return "recorder" }
+
+func (r *windowRecorder) Version() string { return packageVersion("recorder") }
+
+func (r *windowRecorder) HealthCheck(ctx context.Context) error { return nil }
+
+// slidingWindowDocuments returns n documents where the one at position
+// relevant scores 1000 and the rest score by position
+func slidingWindowDocuments(n, relevant int) ([]Document, *windowRecorder) {
+	inner := &windowRecorder{scores: make(map[string]float64)}
+	documents := make([]Document, n)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("document %d", i)}
+		inner.scores[documents[i].ID] = float64((i * 37) % n)
+	}
+	inner.scores[documents[relevant].ID] = 1000
+	return documents, inner
+}
+
+func TestSlidingWindowReranker_RelevantDocumentBubblesUp(t *testing.T) {
+	for _, position := range []int{0, 1, 49, 50, 98, 99} {
+		documents, inner := slidingWindowDocuments(100, position)
+		r, err := NewSlidingWindowReranker(inner, Config{})
+		if err != nil {
+			t.Fatalf("NewSlidingWindowReranker failed: %v", err)
+		}
+
+		results, err := r.Rank(context.Background(), "query", documents, 3)
+		if err != nil {
+			t.Fatalf("Rank failed: %v", err)
+		}
+		if len(results) == 0 || results[0].Document.ID != documents[position].ID {
+			t.Errorf("Expected %s first from position %d, got %+v", documents[position].ID, position, results)
+			continue
+		}
+		if results[0].Index != position || results[0].Rank != 1 {
+			t.Errorf("Expected index %d and rank 1, got index %d rank %d", position, results[0].Index, results[0].Rank)
+		}
+		if inner.maxWindow > DefaultWindowSize {
+			t.Errorf("Expected windows of at most %d documents, got %d", DefaultWindowSize, inner.maxWindow)
+		}
+	}
+}
+
+func TestSlidingWindowReranker_Options(t *testing.T) {
+	documents, inner := slidingWindowDocuments(30, 17)
+	r, err := NewSlidingWindowReranker(inner, Config{Options: map[string]interface{}{
+		"window_size":   4,
+		"window_stride": 2,
+		"window_passes": 1,
+	}})
+	if err != nil {
+		t.Fatalf("NewSlidingWindowReranker failed: %v", err)
+	}
+
+	reranked, err := r.Rerank(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if inner.maxWindow != 4 {
+		t.Errorf("Expected windows of 4 documents, got %d", inner.maxWindow)
+	}
+	// One pass over 30 documents: windows start every 2 documents, the last at 26
+	if len(reranked) > 14 {
+		t.Errorf("Expected at most 14 survivors after one pass, got %d", len(reranked))
+	}
+	if reranked[0].ID != "doc_17" || reranked[0].Score != 1000 {
+		t.Errorf("Expected doc_17 first, got %+v", reranked[0])
+	}
+	for i := 1; i < len(reranked); i++ {
+		if reranked[i].Score > reranked[i-1].Score {
+			t.Errorf("Expected descending scores, got %v before %v", reranked[i-1].Score, reranked[i].Score)
+		}
+	}
+}
+
+func TestSlidingWindowReranker_SmallInputSingleWindow(t *testing.T) {
+	documents, inner := slidingWindowDocuments(5, 3)
+	r, err := NewSlidingWindowReranker(inner, Config{})
+	if err != nil {
+		t.Fatalf("NewSlidingWindowReranker failed: %v", err)
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if inner.calls != 1 || len(scores) != 5 || scores[3] != 1000 {
+		t.Errorf("Expected one window scoring all 5 documents, got %d calls and scores %v", inner.calls, scores)
+	}
+}
+
+func TestSlidingWindowReranker_InvalidOptions(t *testing.T) {
+	inner := &windowRecorder{}
+	if _, err := NewSlidingWindowReranker(nil, Config{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without inner reranker, got %v", err)
+	}
+
+	for _, opts := range []map[string]interface{}{
+		{"window_size": 1},
+		{"window_size": 10, "window_stride": 11},
+		{"window_stride": 0},
+		{"window_passes": 0},
+	} {
+		if _, err := NewSlidingWindowReranker(inner, Config{Options: opts}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %v, got %v", opts, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package reranker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SortByScore returns a copy of results ordered by descending Score, breaking
+// ties by ascending original Index
+func SortByScore(results []RerankResult) []RerankResult {
+	sorted := copyResults(results)
+	sortResults(sorted, tieInputOrder)
+	return sorted
+}
+
+// SortByOriginalIndex returns a copy of results in input document order
+func SortByOriginalIndex(results []RerankResult) []RerankResult {
+	sorted := copyResults(results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Index < sorted[j].Index
+	})
+	return sorted
+}
+
+// SortByField returns a copy of results ordered by descending
+// Document.Meta[metaKey], parsed as a float64 from any numeric type or numeric
+// string. Ties keep their relative order. It fails with ErrInvalidInput when a
+// result lacks the key or its value is not numeric.
+func SortByField(results []RerankResult, metaKey string) ([]RerankResult, error) {
+	values := make([]float64, len(results))
+	for i, result := range results {
+		value, ok := metaFloat(result.Document.Meta, metaKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: result %d has no numeric meta field %q", ErrInvalidInput, i, metaKey)
+		}
+		values[i] = value
+	}
+
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	sorted := make([]RerankResult, len(results))
+	for i, index := range order {
+		sorted[i] = results[index]
+	}
+	return sorted, nil
+}
+
+// metaFloat reads a numeric meta value, reporting whether it was present and parseable
+func metaFloat(meta map[string]interface{}, key string) (float64, bool) {
+	if _, ok := meta[key]; !ok {
+		return 0, false
+	}
+	value := OptionFloat(meta, key, math.NaN())
+	return value, !math.IsNaN(value)
+}
+
+// Reverse returns a copy of results in reverse order
+func Reverse(results []RerankResult) []RerankResult {
+	reversed := make([]RerankResult, len(results))
+	for i, result := range results {
+		reversed[len(results)-1-i] = result
+	}
+	return reversed
+}
+
+// TopK returns a copy of the first k results, or all of them when k exceeds
+// the length. It panics if k is negative.
+func TopK(results []RerankResult, k int) []RerankResult {
+	if k < 0 {
+		panic(fmt.Sprintf("reranker: TopK called with negative k %d", k))
+	}
+	if k > len(results) {
+		k = len(results)
+	}
+	return copyResults(results[:k])
+}
+
+// copyResults returns a shallow copy of results
+func copyResults(results []RerankResult) []RerankResult {
+	return append([]RerankResult(nil), results...)
+}
@@ -0,0 +1,106 @@
+package reranker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// sortFixture has tied scores (a, c) and tied meta values (b, c)
+func sortFixture() []RerankResult {
+	return []RerankResult{
+		{Document: Document{ID: "c", Meta: map[string]interface{}{"year": 2020}}, Score: 0.5, Index: 2},
+		{Document: Document{ID: "a", Meta: map[string]interface{}{"year": "2023"}}, Score: 0.5, Index: 0},
+		{Document: Document{ID: "b", Meta: map[string]interface{}{"year": 2020.0}}, Score: 0.9, Index: 1},
+		{Document: Document{ID: "d", Meta: map[string]interface{}{"year": int64(1999)}}, Score: 0.1, Index: 3},
+	}
+}
+
+func resultIDs(results []RerankResult) []string {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.Document.ID
+	}
+	return ids
+}
+
+func TestSortByScore(t *testing.T) {
+	input := sortFixture()
+	got := resultIDs(SortByScore(input))
+	if want := []string{"b", "a", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if !reflect.DeepEqual(input, sortFixture()) {
+		t.Error("Expected input to be left unchanged")
+	}
+}
+
+func TestSortByOriginalIndex(t *testing.T) {
+	input := sortFixture()
+	input = append(input, RerankResult{Document: Document{ID: "a2"}, Index: 0})
+	got := resultIDs(SortByOriginalIndex(input))
+	if want := []string{"a", "a2", "b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if input[0].Document.ID != "c" {
+		t.Error("Expected input to be left unchanged")
+	}
+}
+
+func TestSortByField(t *testing.T) {
+	input := sortFixture()
+	sorted, err := SortByField(input, "year")
+	if err != nil {
+		t.Fatalf("SortByField failed: %v", err)
+	}
+	// c and b tie on 2020 and keep their input order
+	if got, want := resultIDs(sorted), []string{"a", "c", "b", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if !reflect.DeepEqual(input, sortFixture()) {
+		t.Error("Expected input to be left unchanged")
+	}
+
+	if _, err := SortByField(input, "missing"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for missing field, got %v", err)
+	}
+	input[0].Document.Meta["year"] = "recent"
+	if _, err := SortByField(input, "year"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for non-numeric field, got %v", err)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	input := sortFixture()
+	got := resultIDs(Reverse(input))
+	if want := []string{"d", "b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if input[0].Document.ID != "c" {
+		t.Error("Expected input to be left unchanged")
+	}
+}
+
+func TestTopK(t *testing.T) {
+	input := sortFixture()
+	top := TopK(SortByScore(input), 2)
+	if got, want := resultIDs(top), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if len(TopK(input, 10)) != 4 || len(TopK(input, 0)) != 0 {
+		t.Error("Expected k to be clamped to the result count")
+	}
+
+	top = TopK(input, 1)
+	top[0].Score = 42
+	if input[0].Score == 42 {
+		t.Error("Expected TopK to return a copy")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected TopK to panic on negative k")
+		}
+	}()
+	TopK(input, -1)
+}
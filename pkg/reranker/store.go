@@ -0,0 +1,212 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// DocumentStore holds documents by ID so they can be ranked without passing
+// their content on every call
+type DocumentStore interface {
+	Get(id string) (Document, error)
+	GetMany(ids []string) ([]Document, error)
+	Add(doc Document) error
+}
+
+var (
+	_ DocumentStore = (*InMemoryDocumentStore)(nil)
+	_ DocumentStore = (*JSONFileDocumentStore)(nil)
+)
+
+// InMemoryDocumentStore is a thread-safe DocumentStore backed by a map
+type InMemoryDocumentStore struct {
+	mutex     sync.RWMutex
+	documents map[string]Document
+}
+
+// NewInMemoryDocumentStore creates an empty in-memory store
+func NewInMemoryDocumentStore() *InMemoryDocumentStore {
+	return &InMemoryDocumentStore{documents: make(map[string]Document)}
+}
+
+// Get returns the document with id, or ErrDocumentNotFound
+func (s *InMemoryDocumentStore) Get(id string) (Document, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	doc, ok := s.documents[id]
+	if !ok {
+		return Document{}, fmt.Errorf("%w: %s", ErrDocumentNotFound, id)
+	}
+	return doc, nil
+}
+
+// GetMany returns the documents with ids in the same order, failing with
+// ErrDocumentNotFound if any is missing
+func (s *InMemoryDocumentStore) GetMany(ids []string) ([]Document, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	documents := make([]Document, len(ids))
+	for i, id := range ids {
+		doc, ok := s.documents[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrDocumentNotFound, id)
+		}
+		documents[i] = doc
+	}
+	return documents, nil
+}
+
+// Add stores doc, replacing any document with the same ID
+func (s *InMemoryDocumentStore) Add(doc Document) error {
+	if doc.ID == "" {
+		return fmt.Errorf("%w: document ID is required", ErrInvalidInput)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.documents[doc.ID] = doc
+	return nil
+}
+
+// Len returns the number of stored documents
+func (s *InMemoryDocumentStore) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.documents)
+}
+
+// all returns the stored documents sorted by ID
+func (s *InMemoryDocumentStore) all() []Document {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	documents := make([]Document, 0, len(s.documents))
+	for _, doc := range s.documents {
+		documents = append(documents, doc)
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].ID < documents[j].ID
+	})
+	return documents
+}
+
+// JSONFileDocumentStore is an in-memory store persisted to a JSON file holding
+// an array of documents. Every Add rewrites the file.
+type JSONFileDocumentStore struct {
+	*InMemoryDocumentStore
+	path      string
+	saveMutex sync.Mutex
+}
+
+// NewJSONFileDocumentStore loads the documents in path, starting empty when
+// the file does not exist yet
+func NewJSONFileDocumentStore(path string) (*JSONFileDocumentStore, error) {
+	s := &JSONFileDocumentStore{InMemoryDocumentStore: NewInMemoryDocumentStore(), path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read document store: %v", ErrInitialization, err)
+	}
+
+	var documents []Document
+	if err := json.Unmarshal(data, &documents); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse document store: %v", ErrInvalidInput, err)
+	}
+	for _, doc := range documents {
+		if err := s.InMemoryDocumentStore.Add(doc); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add stores doc and saves the store to its file
+func (s *JSONFileDocumentStore) Add(doc Document) error {
+	if err := s.InMemoryDocumentStore.Add(doc); err != nil {
+		return err
+	}
+	return s.Save()
+}
+
+// Save writes every document to the file, sorted by ID. The file is replaced
+// atomically so readers never see a partial write.
+func (s *JSONFileDocumentStore) Save() error {
+	s.saveMutex.Lock()
+	defer s.saveMutex.Unlock()
+
+	data, err := json.MarshalIndent(s.all(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: failed to encode document store: %v", ErrInvalidInput, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save document store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save document store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save document store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save document store: %w", err)
+	}
+	return nil
+}
+
+// StoreReranker ranks documents held in a DocumentStore by ID. It embeds the
+// wrapped Reranker, so slice-based calls are passed through unchanged.
+type StoreReranker struct {
+	Reranker
+	store DocumentStore
+}
+
+// NewStoreReranker wraps reranker with document lookups from store
+func NewStoreReranker(reranker Reranker, store DocumentStore) (*StoreReranker, error) {
+	if reranker == nil {
+		return nil, fmt.Errorf("%w: store reranker requires an inner reranker", ErrInvalidInput)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("%w: store reranker requires a document store", ErrInvalidInput)
+	}
+	return &StoreReranker{Reranker: reranker, store: store}, nil
+}
+
+// RankByIDs fetches the documents with docIDs and ranks them; result indices
+// refer to positions in docIDs
+func (r *StoreReranker) RankByIDs(ctx context.Context, query string, docIDs []string, topN int) ([]RerankResult, error) {
+	if len(docIDs) == 0 {
+		return nil, nil
+	}
+
+	documents, err := r.store.GetMany(docIDs)
+	if err != nil {
+		return nil, err
+	}
+	return r.Rank(ctx, query, documents, topN)
+}
+
+// Store returns the document store
+func (r *StoreReranker) Store() DocumentStore {
+	return r.store
+}
+
+// Close releases resources held by the wrapped reranker
+func (r *StoreReranker) Close() error {
+	return closeReranker(r.Reranker)
+}
@@ -0,0 +1,141 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var storeDocuments = []Document{
+	{ID: "ml", Content: "machine learning models learn from data", Meta: map[string]interface{}{"source": "wiki"}},
+	{ID: "cooking", Content: "cooking pasta at home"},
+	{ID: "dl", Content: "deep learning is a kind of machine learning"},
+}
+
+func testDocumentStore(t *testing.T, store DocumentStore) {
+	t.Helper()
+
+	for _, doc := range storeDocuments {
+		if err := store.Add(doc); err != nil {
+			t.Fatalf("Add(%s) failed: %v", doc.ID, err)
+		}
+	}
+
+	got, err := store.Get("ml")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, storeDocuments[0]) {
+		t.Errorf("Expected %+v, got %+v", storeDocuments[0], got)
+	}
+
+	many, err := store.GetMany([]string{"dl", "cooking"})
+	if err != nil {
+		t.Fatalf("GetMany failed: %v", err)
+	}
+	if len(many) != 2 || many[0].ID != "dl" || many[1].ID != "cooking" {
+		t.Errorf("Expected documents in requested order, got %+v", many)
+	}
+
+	if _, err := store.Get("missing"); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("Expected ErrDocumentNotFound, got %v", err)
+	}
+	if _, err := store.GetMany([]string{"ml", "missing"}); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("Expected ErrDocumentNotFound, got %v", err)
+	}
+	if err := store.Add(Document{Content: "no id"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for a document without ID, got %v", err)
+	}
+}
+
+func TestInMemoryDocumentStore(t *testing.T) {
+	store := NewInMemoryDocumentStore()
+	testDocumentStore(t, store)
+
+	if err := store.Add(Document{ID: "ml", Content: "replaced"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if doc, _ := store.Get("ml"); doc.Content != "replaced" || store.Len() != 3 {
+		t.Errorf("Expected Add to replace by ID, got %+v with %d documents", doc, store.Len())
+	}
+}
+
+func TestJSONFileDocumentStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "documents.json")
+	store, err := NewJSONFileDocumentStore(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileDocumentStore failed: %v", err)
+	}
+	testDocumentStore(t, store)
+
+	reloaded, err := NewJSONFileDocumentStore(path)
+	if err != nil {
+		t.Fatalf("Reloading store failed: %v", err)
+	}
+	for _, want := range storeDocuments {
+		got, err := reloaded.Get(want.ID)
+		if err != nil {
+			t.Fatalf("Get(%s) after reload failed: %v", want.ID, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %+v after reload, got %+v", want, got)
+		}
+	}
+}
+
+func TestJSONFileDocumentStore_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "documents.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatalf("Failed to write store file: %v", err)
+	}
+	if _, err := NewJSONFileDocumentStore(path); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestStoreReranker_RankByIDs(t *testing.T) {
+	store := NewInMemoryDocumentStore()
+	for _, doc := range storeDocuments {
+		if err := store.Add(doc); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	r, err := NewStoreReranker(NewSimpleReranker(Config{}), store)
+	if err != nil {
+		t.Fatalf("NewStoreReranker failed: %v", err)
+	}
+
+	ids := []string{"cooking", "ml", "dl"}
+	results, err := r.RankByIDs(context.Background(), "machine learning", ids, 2)
+	if err != nil {
+		t.Fatalf("RankByIDs failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Document.ID == "cooking" {
+			t.Errorf("Expected the unrelated document to be ranked out, got %+v", results)
+		}
+		if ids[result.Index] != result.Document.ID {
+			t.Errorf("Expected index %d to refer to %s", result.Index, result.Document.ID)
+		}
+	}
+
+	if _, err := r.RankByIDs(context.Background(), "query", []string{"missing"}, 1); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("Expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestNewStoreReranker_Validation(t *testing.T) {
+	if _, err := NewStoreReranker(nil, NewInMemoryDocumentStore()); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for nil reranker, got %v", err)
+	}
+	if _, err := NewStoreReranker(NewSimpleReranker(Config{}), nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for nil store, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package reranker
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+)
+
+var _ StreamingReranker = (*GGUFLocalReranker)(nil)
+
+// resultMinHeap keeps the lowest-scoring result at the root so the running
+// top-N can be maintained in O(log N) per scored document
+type resultMinHeap []RerankResult
+
+func (h resultMinHeap) Len() int            { return len(h) }
+func (h resultMinHeap) Less(i, j int) bool  { return h[i].Score < h[j].Score }
+func (h resultMinHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *resultMinHeap) Push(x interface{}) { *h = append(*h, x.(RerankResult)) }
+func (h *resultMinHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// offer adds a result to the running top-N and reports whether it made the cut.
+// A non-positive topN keeps every result.
+func (h *resultMinHeap) offer(result RerankResult, topN int) bool {
+	if topN <= 0 || h.Len() < topN {
+		heap.Push(h, result)
+		return true
+	}
+	if result.Score <= (*h)[0].Score {
+		return false
+	}
+	heap.Pop(h)
+	heap.Push(h, result)
+	return true
+}
+
+// RankStream scores documents concurrently and emits each result as soon as its
+// subprocess call completes, provided it enters the running top-N (tracked with a
+// min-heap of size topN) and passes the threshold. Results therefore arrive in
+// completion order, not sorted order; the final top-N is a subset of what was emitted.
+// Scores are transformed as in Rank before the threshold applies.
+// Batch-relative normalizations (minmax, softmax), score caps and percentile
+// thresholds cannot be streamed.
+func (r *GGUFLocalReranker) RankStream(ctx context.Context, query string, documents []Document, topN int) (<-chan RerankResult, <-chan error) {
+	results := make(chan RerankResult)
+	errs := make(chan error, 1)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	go func() {
+		defer close(errs)
+		defer close(results)
+
+		if _, err := r.config.transformScores(nil); err != nil {
+			errs <- err
+			return
+		}
+		switch r.config.NormalizeScores {
+		case NormalizationMinMax, NormalizationSoftmax:
+			errs <- fmt.Errorf("%w: %s normalization is not supported for streaming", ErrInvalidInput, r.config.NormalizeScores)
+			return
+		}
+		if OptionFloat(r.config.Options, "score_cap_percentile", 1) < 1 {
+			errs <- fmt.Errorf("%w: score_cap_percentile is not supported for streaming", ErrInvalidInput)
+			return
+		}
+		if r.config.ThresholdMode == ThresholdPercentile {
+			errs <- fmt.Errorf("%w: percentile thresholds are not supported for streaming", ErrInvalidInput)
+			return
+		}
+
+		scored := make(chan RerankResult)
+		slots := make(chan struct{}, r.workerCount())
+		done := make(chan struct{})
+		defer close(done)
+
+		// Producers: bounded fan-out of per-document inference
+		go func() {
+			var wg sync.WaitGroup
+			defer close(scored)
+			defer wg.Wait()
+			for i, doc := range documents {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				case <-done:
+					return
+				}
+				wg.Add(1)
+				go func(i int, doc Document) {
+					defer wg.Done()
+					defer func() { <-slots }()
+					score, err := r.computeRerankerScore(ctx, query, doc.Content)
+					if err != nil {
+						// If scoring fails, assign a low score
+						score = -5.0
+					}
+					// Only per-score steps remain (sigmoid, scale and offset), so
+					// one score transforms as it would within its batch
+					if transformed, err := r.config.transformScores([]float64{score}); err == nil {
+						score = transformed[0]
+					}
+					select {
+					case scored <- RerankResult{Document: doc, Score: score, Index: i}:
+					case <-done:
+					}
+				}(i, doc)
+			}
+		}()
+
+		// Consumer: maintain the running top-N and forward qualifying results
+		top := &resultMinHeap{}
+		for {
+			select {
+			case <-ctx.Done():
+				errs <- fmt.Errorf("%w: %v", ErrInference, ctx.Err())
+				return
+			case result, ok := <-scored:
+				if !ok {
+					if err := ctx.Err(); err != nil {
+						errs <- fmt.Errorf("%w: %v", ErrInference, err)
+					}
+					return
+				}
+				if result.Score < r.config.Threshold || !top.offer(result, topN) {
+					continue
+				}
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					errs <- fmt.Errorf("%w: %v", ErrInference, ctx.Err())
+					return
+				}
+			}
+		}
+	}()
+
+	return results, errs
+}
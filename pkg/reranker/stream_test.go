@@ -0,0 +1,130 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestResultMinHeap_Offer(t *testing.T) {
+	top := &resultMinHeap{}
+	accepted := 0
+	for i, score := range []float64{1, 5, 3, 0.5, 4, 2} {
+		if top.offer(RerankResult{Score: score, Index: i}, 3) {
+			accepted++
+		}
+	}
+
+	if top.Len() != 3 {
+		t.Fatalf("Expected heap of size 3, got %d", top.Len())
+	}
+	if (*top)[0].Score != 3 {
+		t.Errorf("Expected minimum of top-3 to be 3, got %f", (*top)[0].Score)
+	}
+	// 1, 5, 3 fill the heap; 4 displaces 1; 0.5 and 2 are rejected
+	if accepted != 4 {
+		t.Errorf("Expected 4 accepted results, got %d", accepted)
+	}
+}
+
+func TestGGUFLocalReranker_RankStream(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 1)
+	reranker.config.Threshold = -10
+	documents := make([]Document, 8)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("document %d", i)}
+	}
+
+	start := time.Now()
+	results, errs := reranker.RankStream(context.Background(), "query", documents, 0)
+
+	var firstArrival time.Duration
+	count := 0
+	for result := range results {
+		if count == 0 {
+			firstArrival = time.Since(start)
+		}
+		if result.Document.ID != documents[result.Index].ID {
+			t.Errorf("Result index %d does not match document %s", result.Index, result.Document.ID)
+		}
+		count++
+	}
+	total := time.Since(start)
+
+	if err := <-errs; err != nil {
+		t.Fatalf("Unexpected stream error: %v", err)
+	}
+	if count != len(documents) {
+		t.Errorf("Expected %d results, got %d", len(documents), count)
+	}
+	if firstArrival >= total/2 {
+		t.Errorf("Expected first result (%v) well before completion (%v)", firstArrival, total)
+	}
+}
+
+func TestGGUFLocalReranker_RankStreamCancel(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 1)
+	documents := make([]Document, 20)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("document %d", i)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, errs := reranker.RankStream(ctx, "query", documents, 3)
+	<-results
+	cancel()
+
+	for range results {
+	}
+	if err := <-errs; !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference after cancellation, got %v", err)
+	}
+	if _, open := <-errs; open {
+		t.Error("Expected error channel to be closed")
+	}
+}
+
+func TestGGUFLocalReranker_RankStreamRejectsScoreCap(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 1)
+	reranker.config.Options["score_cap_percentile"] = 0.9
+
+	results, errs := reranker.RankStream(context.Background(), "query", []Document{{ID: "1", Content: "document"}}, 0)
+	for range results {
+		t.Error("Expected no results with a score cap")
+	}
+	if err := <-errs; !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for score_cap_percentile, got %v", err)
+	}
+}
+
+func TestGGUFLocalReranker_RankStreamTransformsScores(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 2)
+	reranker.config.Options["score_scale"] = 2.0
+	reranker.config.Options["score_offset"] = 1.0
+	// The stub's raw score is 1; only the transformed 3 passes
+	reranker.config.Threshold = 2.5
+	documents := []Document{{ID: "a", Content: "document a"}, {ID: "b", Content: "document b"}}
+
+	want, err := reranker.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	results, errs := reranker.RankStream(context.Background(), "query", documents, 0)
+	count := 0
+	for result := range results {
+		if math.Abs(result.Score-3) > 1e-9 {
+			t.Errorf("Expected transformed score 3 for %s, got %v", result.Document.ID, result.Score)
+		}
+		count++
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Unexpected stream error: %v", err)
+	}
+	if count != len(want) || count != len(documents) {
+		t.Errorf("Expected %d streamed results like Rank's %d, got %d", len(documents), len(want), count)
+	}
+}
@@ -0,0 +1,26 @@
+package reranker
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+// writeStubBinary writes script as an executable stand-in for llama-embedding
+// at path, skipping the test where no POSIX shell can run it
+func writeStubBinary(tb testing.TB, path, script string) {
+	tb.Helper()
+	if runtime.GOOS == "windows" {
+		tb.Skip("stub inference binary requires a POSIX shell")
+	}
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		tb.Fatalf("Failed to write stub binary: %v", err)
+	}
+}
+
+// keywordStubScript embeds texts mentioning keyword to one axis and every
+// other text to the other
+func keywordStubScript(keyword string) string {
+	return "#!/bin/sh\ncase \"$*\" in\n*" + keyword + "*) echo '{\"data\":[{\"index\":0,\"embedding\":[1,0]}]}' ;;\n" +
+		"*) echo '{\"data\":[{\"index\":0,\"embedding\":[0,1]}]}' ;;\nesac\n"
+}
@@ -0,0 +1,278 @@
+package reranker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ErrPoolClosed is returned by a SubprocessPool or RerankerPool after Close
+var ErrPoolClosed = errors.New("pool closed")
+
+// PoolRequest is the line a pooled worker reads from stdin for each call
+type PoolRequest struct {
+	Text string `json:"text"`
+}
+
+// poolResponse is the line a pooled worker writes to stdout for each call:
+// an EmbeddingResponse, or an error message
+type poolResponse struct {
+	EmbeddingResponse
+	Error string `json:"error,omitempty"`
+}
+
+// poolWorker is one warm process and its pipes
+type poolWorker struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	exited chan struct{}
+}
+
+// alive reports whether the worker's process is still running
+func (w *poolWorker) alive() bool {
+	select {
+	case <-w.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// kill stops the worker's process and waits until it has exited, so the
+// next Get respawns it
+func (w *poolWorker) kill() {
+	w.cmd.Process.Kill()
+	<-w.exited
+}
+
+// SubprocessPool keeps a fixed number of warm inference processes and hands
+// them out one caller at a time, instead of starting a process per call.
+// Workers speak a line protocol: each PoolRequest written as one JSON line to
+// stdin is answered by one JSON line on stdout holding an EmbeddingResponse
+// (as llama-embedding prints with --embd-output-format json) or
+// {"error": "..."}. Workers that exit are respawned when next handed out.
+type SubprocessPool struct {
+	newCmd func() *exec.Cmd
+
+	idle  chan *poolWorker
+	done  chan struct{}
+	slots int
+
+	mutex   sync.Mutex
+	workers map[*exec.Cmd]*poolWorker
+	closed  bool
+}
+
+// NewSubprocessPool starts size workers created by newCmd; a non-positive
+// size uses runtime.NumCPU()
+func NewSubprocessPool(size int, newCmd func() *exec.Cmd) (*SubprocessPool, error) {
+	if newCmd == nil {
+		return nil, fmt.Errorf("%w: subprocess pool requires a command", ErrInvalidInput)
+	}
+	if size <= 0 {
+		size = runtime.NumCPU()
+	}
+
+	p := &SubprocessPool{
+		newCmd:  newCmd,
+		idle:    make(chan *poolWorker, size),
+		done:    make(chan struct{}),
+		workers: make(map[*exec.Cmd]*poolWorker, size),
+	}
+	for i := 0; i < size; i++ {
+		w, err := p.spawn()
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.idle <- w
+		p.slots++
+	}
+	return p, nil
+}
+
+// spawn starts a worker and registers it
+func (p *SubprocessPool) spawn() (*poolWorker, error) {
+	cmd := p.newCmd()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open worker stdin: %v", ErrInitialization, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open worker stdout: %v", ErrInitialization, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%w: failed to start worker: %v", ErrInitialization, err)
+	}
+
+	w := &poolWorker{cmd: cmd, stdin: stdin, stdout: bufio.NewReader(stdout), exited: make(chan struct{})}
+	go func() {
+		cmd.Wait()
+		close(w.exited)
+	}()
+
+	p.mutex.Lock()
+	p.workers[cmd] = w
+	p.mutex.Unlock()
+	return w, nil
+}
+
+// Size returns the number of workers
+func (p *SubprocessPool) Size() int {
+	return p.slots
+}
+
+// Get waits for an idle worker, respawning it first if its process has
+// exited. The worker must be handed back with Return.
+func (p *SubprocessPool) Get() (*exec.Cmd, error) {
+	select {
+	case <-p.done:
+		return nil, ErrPoolClosed
+	case w := <-p.idle:
+		if p.isClosed() {
+			p.idle <- w
+			return nil, ErrPoolClosed
+		}
+		if w.alive() {
+			return w.cmd, nil
+		}
+
+		p.mutex.Lock()
+		delete(p.workers, w.cmd)
+		p.mutex.Unlock()
+		respawned, err := p.spawn()
+		if err != nil {
+			// Keep the slot so a later Get retries the respawn
+			p.idle <- w
+			return nil, err
+		}
+		return respawned.cmd, nil
+	}
+}
+
+// Return hands a worker obtained from Get back to the pool
+func (p *SubprocessPool) Return(cmd *exec.Cmd) {
+	if w := p.worker(cmd); w != nil {
+		p.idle <- w
+	}
+}
+
+// worker returns the registered worker running cmd
+func (p *SubprocessPool) worker(cmd *exec.Cmd) *poolWorker {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.workers[cmd]
+}
+
+// isClosed reports whether Close has been called
+func (p *SubprocessPool) isClosed() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.closed
+}
+
+// Embed sends text to an idle worker and returns its response. When ctx
+// ends first the worker is killed, to be respawned by a later Get.
+func (p *SubprocessPool) Embed(ctx context.Context, text string) (*EmbeddingResponse, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer p.Return(cmd)
+	w := p.worker(cmd)
+
+	request, err := json.Marshal(PoolRequest{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode pool request: %v", ErrInvalidInput, err)
+	}
+
+	type reply struct {
+		line []byte
+		err  error
+	}
+	replies := make(chan reply, 1)
+	go func() {
+		if _, err := w.stdin.Write(append(request, '\n')); err != nil {
+			replies <- reply{err: err}
+			return
+		}
+		line, err := w.stdout.ReadBytes('\n')
+		replies <- reply{line: line, err: err}
+	}()
+
+	var answer reply
+	select {
+	case <-ctx.Done():
+		w.kill()
+		<-replies
+		return nil, fmt.Errorf("%w: pooled worker stopped: %w", ErrInference, ctx.Err())
+	case answer = <-replies:
+	}
+	if answer.err != nil {
+		// A worker that cannot be written to or read from is of no further use
+		w.kill()
+		return nil, fmt.Errorf("%w: pooled worker failed: %v", ErrInference, answer.err)
+	}
+
+	var response poolResponse
+	if err := json.Unmarshal(answer.line, &response); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse pooled worker response: %v", ErrInference, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%w: pooled worker: %s", ErrInference, response.Error)
+	}
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("%w: no embedding data returned", ErrInference)
+	}
+	return &response.EmbeddingResponse, nil
+}
+
+// Close waits for every worker to be returned, closes their stdin so they
+// can exit cleanly and kills those still running after inferenceKillGrace
+func (p *SubprocessPool) Close() error {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	p.mutex.Unlock()
+
+	// Drain: workers in use are handed back when their call finishes
+	for i := 0; i < p.slots; i++ {
+		<-p.idle
+	}
+
+	p.mutex.Lock()
+	workers := make([]*poolWorker, 0, len(p.workers))
+	for _, w := range p.workers {
+		workers = append(workers, w)
+	}
+	p.mutex.Unlock()
+
+	for _, w := range workers {
+		w.stdin.Close()
+	}
+	deadline := time.After(inferenceKillGrace)
+	for _, w := range workers {
+		select {
+		case <-w.exited:
+		case <-deadline:
+			w.kill()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package reranker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// poolWorkerEnv makes the test binary serve the SubprocessPool protocol on
+// stdin/stdout, for the pool tests
+const poolWorkerEnv = "GO_RERANKERS_POOL_WORKER"
+
+// TestSubprocessPoolWorker is the pooled worker when poolWorkerEnv is set.
+// It answers each request with the embedding [len(text), 1] and its PID as
+// the object, fails on "fail", exits on "crash" and stalls on "slow".
+func TestSubprocessPoolWorker(t *testing.T) {
+	if os.Getenv(poolWorkerEnv) == "" {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+	for scanner.Scan() {
+		var request PoolRequest
+		json.Unmarshal(scanner.Bytes(), &request)
+		switch request.Text {
+		case "crash":
+			os.Exit(3)
+		case "slow":
+			time.Sleep(time.Minute)
+		case "fail":
+			encoder.Encode(map[string]string{"error": "cannot embed"})
+			continue
+		}
+		encoder.Encode(map[string]interface{}{
+			"object": strconv.Itoa(os.Getpid()),
+			"data":   []map[string]interface{}{{"index": 0, "embedding": []float64{float64(len(request.Text)), 1}}},
+		})
+	}
+	os.Exit(0)
+}
+
+// newTestPool starts a pool of size test-binary workers
+func newTestPool(t *testing.T, size int) *SubprocessPool {
+	t.Helper()
+	pool, err := NewSubprocessPool(size, func() *exec.Cmd {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestSubprocessPoolWorker$")
+		cmd.Env = append(os.Environ(), poolWorkerEnv+"=1")
+		return cmd
+	})
+	if err != nil {
+		t.Fatalf("NewSubprocessPool failed: %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return pool
+}
+
+func TestSubprocessPool_ReusesWorkers(t *testing.T) {
+	pool := newTestPool(t, 1)
+
+	first, err := pool.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	second, err := pool.Embed(context.Background(), "hello world")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	if first.Data[0].Embedding[0] != 5 || second.Data[0].Embedding[0] != 11 {
+		t.Errorf("Expected embeddings of the text lengths, got %v and %v", first.Data[0].Embedding, second.Data[0].Embedding)
+	}
+	if first.Object != second.Object {
+		t.Errorf("Expected both calls served by one process, got PIDs %s and %s", first.Object, second.Object)
+	}
+}
+
+func TestSubprocessPool_RespawnsExitedWorker(t *testing.T) {
+	pool := newTestPool(t, 1)
+
+	before, err := pool.Embed(context.Background(), "before")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if _, err := pool.Embed(context.Background(), "crash"); !errors.Is(err, ErrInference) {
+		t.Fatalf("Expected ErrInference from a crashing worker, got %v", err)
+	}
+
+	after, err := pool.Embed(context.Background(), "after")
+	if err != nil {
+		t.Fatalf("Expected a respawned worker, got %v", err)
+	}
+	if after.Object == before.Object {
+		t.Errorf("Expected a new process after the crash, got PID %s again", after.Object)
+	}
+}
+
+func TestSubprocessPool_WorkerErrorAndTimeout(t *testing.T) {
+	pool := newTestPool(t, 1)
+
+	if _, err := pool.Embed(context.Background(), "fail"); !errors.Is(err, ErrInference) {
+		t.Errorf("Expected ErrInference for a worker error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := pool.Embed(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded for a stalled worker, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the stalled worker to be killed promptly, took %v", elapsed)
+	}
+
+	if _, err := pool.Embed(context.Background(), "recovered"); err != nil {
+		t.Errorf("Expected the pool to recover after a timeout, got %v", err)
+	}
+}
+
+func TestSubprocessPool_GetReturnAndClose(t *testing.T) {
+	pool := newTestPool(t, 2)
+	if pool.Size() != 2 {
+		t.Fatalf("Expected 2 workers, got %d", pool.Size())
+	}
+
+	a, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	b, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if a == b {
+		t.Error("Expected distinct workers for concurrent Get calls")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Expected Close to wait for workers in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	pool.Return(a)
+	pool.Return(b)
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not finish after the workers were returned")
+	}
+
+	for _, cmd := range []*exec.Cmd{a, b} {
+		if cmd.ProcessState == nil {
+			t.Errorf("Expected worker %d to have exited", cmd.Process.Pid)
+		}
+	}
+	if _, err := pool.Get(); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed after Close, got %v", err)
+	}
+}
+
+func TestGGUFLocalReranker_SubprocessPool(t *testing.T) {
+	r := newFakeGGUFReranker(t, 1)
+	// Any per-call subprocess would fail, so scores must come from the pool
+	r.inferenceBinary = filepath.Join(t.TempDir(), "missing")
+	r.pool = newTestPool(t, 2)
+
+	documents := make([]Document, 4)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("document %d", i)}
+	}
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if len(scores) != len(documents) {
+		t.Fatalf("Expected %d scores, got %d", len(documents), len(scores))
+	}
+	for i, score := range scores {
+		if score <= 0 || score > 1 {
+			t.Errorf("Expected a cosine similarity in (0, 1] for document %d, got %v", i, score)
+		}
+	}
+}
+
+func TestNewSubprocessPool_Invalid(t *testing.T) {
+	if _, err := NewSubprocessPool(1, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a command, got %v", err)
+	}
+	missing := filepath.Join(t.TempDir(), "missing")
+	if _, err := NewSubprocessPool(2, func() *exec.Cmd { return exec.Command(missing) }); !errors.Is(err, ErrInitialization) {
+		t.Errorf("Expected ErrInitialization for a missing command, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// TeeScorePrefix prefixes the metadata keys holding each model's score
+const TeeScorePrefix = "score_"
+
+// TeeReranker scores documents with several rerankers side by side for
+// evaluation. Documents are ranked by the first (primary) reranker's scores,
+// and every reranker's score is recorded in Meta["score_<model name>"].
+type TeeReranker struct {
+	config    Config
+	rerankers []Reranker
+}
+
+// NewTeeReranker creates a tee over the given rerankers; the first one is primary
+func NewTeeReranker(config Config, rerankers []Reranker) (*TeeReranker, error) {
+	if len(rerankers) == 0 {
+		return nil, fmt.Errorf("%w: tee requires at least one reranker", ErrInvalidInput)
+	}
+
+	r := &TeeReranker{rerankers: rerankers}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// scoreAll runs ComputeScore on every reranker concurrently, returning scores per reranker
+func (r *TeeReranker) scoreAll(ctx context.Context, query string, documents []Document) ([][]float64, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Each child gets its own copy since some implementations reorder in place
+	scores := make([][]float64, len(r.rerankers))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, child := range r.rerankers {
+		i, child := i, child
+		group.Go(func() error {
+			docs := make([]Document, len(documents))
+			copy(docs, documents)
+			childScores, err := child.ComputeScore(groupCtx, query, docs)
+			if err != nil {
+				return fmt.Errorf("reranker %s failed: %w", child.GetModelName(), err)
+			}
+			if len(childScores) != len(documents) {
+				return fmt.Errorf("%w: reranker %s returned %d scores for %d documents", ErrInference, child.GetModelName(), len(childScores), len(documents))
+			}
+			scores[i] = childScores
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// annotate returns copies of documents carrying every reranker's score in their metadata
+func (r *TeeReranker) annotate(documents []Document, scores [][]float64) []Document {
+	annotated := make([]Document, len(documents))
+	for i, doc := range documents {
+		meta := make(map[string]interface{}, len(doc.Meta)+len(r.rerankers))
+		for key, value := range doc.Meta {
+			meta[key] = value
+		}
+		for j, child := range r.rerankers {
+			meta[TeeScorePrefix+child.GetModelName()] = scores[j][i]
+		}
+		doc.Meta = meta
+		annotated[i] = doc
+	}
+	return annotated
+}
+
+// Rerank reorders documents by the primary reranker's scores, annotating every model's score
+func (r *TeeReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.scoreAll(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(r.annotate(documents, scores), scores[0], r.config.scoreThreshold(scores[0]), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns the primary reranker's scores
+func (r *TeeReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	return r.rerankers[0].ComputeScore(ctx, query, documents)
+}
+
+// Rank returns top-N documents by the primary reranker's scores, annotating every model's score
+func (r *TeeReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateWrappedInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.scoreAll(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(r.annotate(documents, scores), scores[0], r.config.scoreThreshold(scores[0]), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the comma-joined model names, primary first
+func (r *TeeReranker) GetModelName() string {
+	names := make([]string, len(r.rerankers))
+	for i, child := range r.rerankers {
+		names[i] = child.GetModelName()
+	}
+	return strings.Join(names, ",")
+}
+
+// Version returns the comma-joined versions, primary first
+func (r *TeeReranker) Version() string {
+	versions := make([]string, len(r.rerankers))
+	for i, child := range r.rerankers {
+		versions[i] = child.Version()
+	}
+	return strings.Join(versions, ",")
+}
+
+// HealthCheck checks every child reranker
+func (r *TeeReranker) HealthCheck(ctx context.Context) error {
+	return healthCheckAll(ctx, r.rerankers)
+}
+
+// Configure updates the tee configuration; child rerankers are left unchanged
+func (r *TeeReranker) Configure(config Config) error {
+	r.config = config
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	return nil
+}
+
+// Close releases resources held by every child reranker
+func (r *TeeReranker) Close() error {
+	var errs []error
+	for _, child := range r.rerankers {
+		errs = append(errs, closeReranker(child))
+	}
+	return errors.Join(errs...)
+}
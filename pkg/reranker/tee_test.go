@@ -0,0 +1,105 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTeeReranker_Rank(t *testing.T) {
+	primary := &orderedReranker{name: "primary", order: []string{"b", "c", "a"}}
+	secondary := &orderedReranker{name: "secondary", order: []string{"a", "b", "c"}}
+	r, err := NewTeeReranker(Config{}, []Reranker{primary, secondary})
+	if err != nil {
+		t.Fatalf("NewTeeReranker failed: %v", err)
+	}
+
+	documents := []Document{
+		{ID: "a", Content: "a", Meta: map[string]interface{}{"source": "wiki"}},
+		{ID: "b", Content: "b"},
+		{ID: "c", Content: "c"},
+	}
+	results, err := r.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	wantOrder := []string{"b", "c", "a"}
+	if len(results) != len(wantOrder) {
+		t.Fatalf("Expected %d results, got %d", len(wantOrder), len(results))
+	}
+	for i, id := range wantOrder {
+		if results[i].Document.ID != id {
+			t.Errorf("Position %d: expected %s (primary order), got %s", i, id, results[i].Document.ID)
+		}
+	}
+
+	primaryScores, _ := primary.ComputeScore(context.Background(), "query", documents)
+	secondaryScores, _ := secondary.ComputeScore(context.Background(), "query", documents)
+	for _, result := range results {
+		meta := result.Document.Meta
+		if got := meta["score_primary"]; got != primaryScores[result.Index] {
+			t.Errorf("%s: expected score_primary %v, got %v", result.Document.ID, primaryScores[result.Index], got)
+		}
+		if got := meta["score_secondary"]; got != secondaryScores[result.Index] {
+			t.Errorf("%s: expected score_secondary %v, got %v", result.Document.ID, secondaryScores[result.Index], got)
+		}
+		if result.Score != primaryScores[result.Index] {
+			t.Errorf("%s: expected primary score %v, got %v", result.Document.ID, primaryScores[result.Index], result.Score)
+		}
+	}
+
+	if results[2].Document.Meta["source"] != "wiki" {
+		t.Error("Expected existing metadata to be preserved")
+	}
+	if _, annotated := documents[0].Meta["score_primary"]; annotated {
+		t.Error("Expected the caller's metadata map to be left unchanged")
+	}
+}
+
+func TestTeeReranker_ComputeScoreAndName(t *testing.T) {
+	primary := &orderedReranker{name: "primary", order: []string{"b", "a"}}
+	secondary := &orderedReranker{name: "secondary", order: []string{"a", "b"}}
+	r, err := NewTeeReranker(Config{}, []Reranker{primary, secondary})
+	if err != nil {
+		t.Fatalf("NewTeeReranker failed: %v", err)
+	}
+
+	if got := r.GetModelName(); got != "primary,secondary" {
+		t.Errorf("Expected model name 'primary,secondary', got %q", got)
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "query", []Document{{ID: "a", Content: "a"}, {ID: "b", Content: "b"}})
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if len(scores) != 2 || scores[0] != 1 || scores[1] != 2 {
+		t.Errorf("Expected primary scores [1 2], got %v", scores)
+	}
+
+	reranked, err := r.Rerank(context.Background(), "query", []Document{{ID: "a", Content: "a"}, {ID: "b", Content: "b"}})
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if reranked[0].ID != "b" || reranked[0].Meta["score_secondary"] != 1.0 {
+		t.Errorf("Expected annotated primary order, got %+v", reranked)
+	}
+}
+
+func TestTeeReranker_Errors(t *testing.T) {
+	if _, err := NewTeeReranker(Config{}, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without rerankers, got %v", err)
+	}
+
+	failure := errors.New("model crashed")
+	r, err := NewTeeReranker(Config{}, []Reranker{
+		&orderedReranker{name: "primary"},
+		&erroringReranker{err: failure},
+	})
+	if err != nil {
+		t.Fatalf("NewTeeReranker failed: %v", err)
+	}
+	if _, err := r.Rank(context.Background(), "query", []Document{{ID: "a", Content: "a"}}, 0); !errors.Is(err, failure) {
+		t.Errorf("Expected child failure to propagate, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package reranker
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// tokenize lowercases text and splits it into letter/digit runs
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// termFrequencies counts tokens in text
+func termFrequencies(text string) map[string]float64 {
+	counts := make(map[string]float64)
+	for _, token := range tokenize(text) {
+		counts[token]++
+	}
+	return counts
+}
+
+// tfidfVectors builds sparse TF-IDF vectors for a corpus using smoothed IDF
+// idf(t) = ln((1 + N) / (1 + df(t))) + 1
+func tfidfVectors(texts []string) []map[string]float64 {
+	frequencies := make([]map[string]float64, len(texts))
+	documentFrequency := make(map[string]float64)
+	for i, text := range texts {
+		frequencies[i] = termFrequencies(text)
+		for term := range frequencies[i] {
+			documentFrequency[term]++
+		}
+	}
+
+	n := float64(len(texts))
+	vectors := make([]map[string]float64, len(texts))
+	for i, tf := range frequencies {
+		vector := make(map[string]float64, len(tf))
+		for term, count := range tf {
+			vector[term] = count * (math.Log((1+n)/(1+documentFrequency[term])) + 1)
+		}
+		vectors[i] = vector
+	}
+	return vectors
+}
+
+// sparseCosine computes cosine similarity between two sparse vectors
+func sparseCosine(a, b map[string]float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0.0
+	}
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	var dotProduct, normA, normB float64
+	for term, weight := range a {
+		dotProduct += weight * b[term]
+		normA += weight * weight
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+
+	if normA == 0.0 || normB == 0.0 {
+		return 0.0
+	}
+	return dotProduct / (math.Sqrt(normA) * math.Sqrt(normB))
+}
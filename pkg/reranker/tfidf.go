@@ -0,0 +1,171 @@
+package reranker
+
+import (
+	"context"
+	"math"
+)
+
+// InvertedIndex maps terms to the documents of a corpus containing them. It
+// supplies the IDF of a TFIDFReranker and can be built once and shared
+// across queries.
+type InvertedIndex struct {
+	// Postings maps each term to the ascending positions of the documents
+	// containing it
+	Postings map[string][]int
+	// Documents is the number of documents indexed
+	Documents int
+}
+
+// BuildInvertedIndex indexes the tokens of docs
+func BuildInvertedIndex(docs []Document) InvertedIndex {
+	index := InvertedIndex{Postings: make(map[string][]int), Documents: len(docs)}
+	for i, doc := range docs {
+		for term := range termFrequencies(doc.Content) {
+			index.Postings[term] = append(index.Postings[term], i)
+		}
+	}
+	return index
+}
+
+// IDF returns the smoothed inverse document frequency
+// ln((1 + N) / (1 + df)) + 1, which is at least 1
+func (index InvertedIndex) IDF(term string) float64 {
+	df := float64(len(index.Postings[term]))
+	return math.Log((1+float64(index.Documents))/(1+df)) + 1
+}
+
+// TFIDFReranker scores documents by the cosine similarity of their TF-IDF
+// vector with the query's. IDF comes from the index given to
+// NewTFIDFReranker, or from each call's documents when there is none.
+// Scores are in [0, 1]: 0 without shared terms, 1 for a document with the
+// query's exact term distribution.
+//
+// Recognized options:
+//   - "tfidf_sublinear": weight terms by 1 + ln(tf) instead of tf (default false)
+type TFIDFReranker struct {
+	config    Config
+	index     *InvertedIndex
+	sublinear bool
+}
+
+// NewTFIDFReranker creates a TF-IDF reranker using index for IDF; a nil
+// index indexes the documents of each call instead
+func NewTFIDFReranker(config Config, index *InvertedIndex) (*TFIDFReranker, error) {
+	r := &TFIDFReranker{index: index}
+	if err := r.Configure(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// vector returns the TF-IDF weights of text's terms
+func (r *TFIDFReranker) vector(text string, index InvertedIndex) map[string]float64 {
+	vector := termFrequencies(text)
+	for term, tf := range vector {
+		if r.sublinear {
+			tf = 1 + math.Log(tf)
+		}
+		vector[term] = tf * index.IDF(term)
+	}
+	return vector
+}
+
+// Rerank reorders documents by their TF-IDF similarity
+func (r *TFIDFReranker) Rerank(ctx context.Context, query string, documents []Document) ([]Document, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return documents, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return rerankByScores(documents, scores, r.config.scoreThreshold(scores), r.config.MaxDocs, r.config.tieBreak()), nil
+}
+
+// ComputeScore returns the cosine similarity of each document's TF-IDF
+// vector with the query's, in document order
+func (r *TFIDFReranker) ComputeScore(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+	return r.computeScores(ctx, query, documents)
+}
+
+// computeScores is ComputeScore without input validation
+func (r *TFIDFReranker) computeScores(ctx context.Context, query string, documents []Document) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	var index InvertedIndex
+	if r.index != nil {
+		index = *r.index
+	} else {
+		index = BuildInvertedIndex(documents)
+	}
+
+	queryVector := r.vector(query, index)
+	scores := make([]float64, len(documents))
+	for i, doc := range documents {
+		// Rounding can push an exact match just past 1
+		scores[i] = math.Min(sparseCosine(queryVector, r.vector(doc.Content, index)), 1)
+	}
+	return r.config.transformScores(scores)
+}
+
+// Rank returns top-N documents by TF-IDF similarity; Index is the input position
+func (r *TFIDFReranker) Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error) {
+	if err := r.config.validateInput(query, documents); err != nil {
+		return nil, err
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	scores, err := r.computeScores(ctx, query, documents)
+	if err != nil {
+		return nil, err
+	}
+	return assignRanks(rankByScores(documents, scores, r.config.scoreThreshold(scores), topN, r.config.tieBreak()), r.config.NormalizeScores), nil
+}
+
+// GetModelName returns the model name
+func (r *TFIDFReranker) GetModelName() string {
+	return r.config.Model
+}
+
+// Version returns "{model}@go-rerankers-{semver}"
+func (r *TFIDFReranker) Version() string {
+	return packageVersion(r.GetModelName())
+}
+
+// HealthCheck is a no-op; scoring needs no external resources
+func (r *TFIDFReranker) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// Configure updates the configuration; the index is left unchanged
+func (r *TFIDFReranker) Configure(config Config) error {
+	if err := validateThreshold(config); err != nil {
+		return err
+	}
+
+	r.config = config
+	if r.config.Model == "" {
+		r.config.Model = "tfidf"
+	}
+	if r.config.MaxDocs == 0 {
+		r.config.MaxDocs = 100
+	}
+	r.sublinear = OptionBool(config.Options, "tfidf_sublinear", false)
+	return nil
+}
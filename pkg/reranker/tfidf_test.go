@@ -0,0 +1,83 @@
+package reranker
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var tfidfCorpus = []Document{
+	{ID: "exact", Content: "machine learning models"},
+	{ID: "partial", Content: "Machine learning models learn patterns, and learning takes data"},
+	{ID: "one", Content: "Statistical models of the weather"},
+	{ID: "none", Content: "Cooking pasta at home"},
+}
+
+func TestTFIDFReranker_ScoresInUnitRange(t *testing.T) {
+	index := BuildInvertedIndex(tfidfCorpus)
+	for _, sublinear := range []bool{false, true} {
+		r, err := NewTFIDFReranker(Config{Options: map[string]interface{}{"tfidf_sublinear": sublinear}}, &index)
+		if err != nil {
+			t.Fatalf("NewTFIDFReranker failed: %v", err)
+		}
+
+		for _, query := range []string{"machine learning models", "models", "learning learning data", "pasta"} {
+			scores, err := r.ComputeScore(context.Background(), query, tfidfCorpus)
+			if err != nil {
+				t.Fatalf("ComputeScore failed: %v", err)
+			}
+			for i, score := range scores {
+				if score < 0 || score > 1 {
+					t.Errorf("sublinear=%v, %q: expected a score in [0, 1] for %s, got %v", sublinear, query, tfidfCorpus[i].ID, score)
+				}
+			}
+		}
+
+		results, err := r.Rank(context.Background(), "Machine learning models", tfidfCorpus, 0)
+		if err != nil {
+			t.Fatalf("Rank failed: %v", err)
+		}
+		if ids := resultIDs(results); !reflect.DeepEqual(ids, []string{"exact", "partial", "one", "none"}) {
+			t.Errorf("sublinear=%v: unexpected order %v", sublinear, ids)
+		}
+		if math.Abs(results[0].Score-1) > 1e-12 || results[3].Score != 0 {
+			t.Errorf("sublinear=%v: expected a perfect match to score 1 and no overlap 0, got %+v", sublinear, results)
+		}
+	}
+}
+
+func TestTFIDFReranker_SublinearAndIndex(t *testing.T) {
+	documents := []Document{{ID: "repeated", Content: "data data data data"}, {ID: "other", Content: "other words"}}
+	linear, _ := NewTFIDFReranker(Config{}, nil)
+	sublinear, _ := NewTFIDFReranker(Config{Options: map[string]interface{}{"tfidf_sublinear": true}}, nil)
+
+	// A single repeated term has the same direction whatever its weight
+	for _, r := range []*TFIDFReranker{linear, sublinear} {
+		scores, err := r.ComputeScore(context.Background(), "data", documents)
+		if err != nil || math.Abs(scores[0]-1) > 1e-12 {
+			t.Errorf("Expected a perfect match, got %v (%v)", scores, err)
+		}
+	}
+
+	// Damping the repeated term brings the document closer to the query
+	mixed := []Document{{ID: "mixed", Content: "data data data data other"}, {ID: "other", Content: "words"}}
+	linearScores, _ := linear.ComputeScore(context.Background(), "data other", mixed)
+	sublinearScores, _ := sublinear.ComputeScore(context.Background(), "data other", mixed)
+	if sublinearScores[0] <= linearScores[0] {
+		t.Errorf("Expected sublinear TF to score %v above linear TF %v", sublinearScores[0], linearScores[0])
+	}
+
+	index := BuildInvertedIndex(tfidfCorpus)
+	if index.Documents != 4 || !reflect.DeepEqual(index.Postings["models"], []int{0, 1, 2}) {
+		t.Errorf("Unexpected index %+v", index)
+	}
+	if got, want := index.IDF("models"), math.Log(5.0/4)+1; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Expected IDF %v, got %v", want, got)
+	}
+
+	r, err := NewReranker(Config{Model: "tfidf"})
+	if _, ok := r.(*TFIDFReranker); err != nil || !ok {
+		t.Errorf("Expected the factory to create a TFIDFReranker, got %T (%v)", r, err)
+	}
+}
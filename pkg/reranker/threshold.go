@@ -0,0 +1,61 @@
+package reranker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ThresholdMode selects how Config.Threshold is compared against scores
+type ThresholdMode string
+
+const (
+	// ThresholdAbsolute compares Threshold against the scores themselves (the default)
+	ThresholdAbsolute ThresholdMode = "absolute"
+	// ThresholdPercentile reads Threshold as a percentile in [0, 1] of the
+	// scores in each batch: 0.8 keeps the documents scoring at or above the
+	// 80th percentile, whatever scale the model scores on
+	ThresholdPercentile ThresholdMode = "percentile"
+)
+
+// Percentile returns the p-th percentile (p in [0, 1]) of scores,
+// interpolating linearly between the two nearest ranks. It is 0 for no scores.
+func Percentile(scores []float64, p float64) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), scores...)
+	sort.Float64s(sorted)
+
+	p = math.Max(0, math.Min(1, p))
+	position := p * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	fraction := position - float64(lower)
+	return sorted[lower] + fraction*(sorted[upper]-sorted[lower])
+}
+
+// scoreThreshold returns the minimum score a document of this batch needs to
+// be kept, resolving a percentile Threshold against scores
+func (c Config) scoreThreshold(scores []float64) float64 {
+	if c.ThresholdMode == ThresholdPercentile {
+		return Percentile(scores, c.Threshold)
+	}
+	return c.Threshold
+}
+
+// validateThreshold checks ThresholdMode and, in percentile mode, that
+// Threshold is a fraction
+func validateThreshold(config Config) error {
+	switch config.ThresholdMode {
+	case "", ThresholdAbsolute:
+		return nil
+	case ThresholdPercentile:
+		if config.Threshold < 0 || config.Threshold > 1 {
+			return fmt.Errorf("%w: percentile threshold must be between 0 and 1, got %v", ErrInvalidInput, config.Threshold)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown threshold mode %q", ErrInvalidInput, config.ThresholdMode)
+}
@@ -0,0 +1,158 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	scores := []float64{5, 1, 4, 2, 3}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{0.25, 2},
+		{0.5, 3},
+		{0.8, 4.2},
+		{1, 5},
+		{1.5, 5},
+	}
+	for _, tt := range tests {
+		if got := Percentile(scores, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if scores[0] != 5 {
+		t.Errorf("Percentile must not reorder its input, got %v", scores)
+	}
+	if got := Percentile(nil, 0.5); got != 0 {
+		t.Errorf("Expected 0 for no scores, got %v", got)
+	}
+}
+
+// percentileDocuments returns ten documents matching 1..10 of the ten query words
+func percentileDocuments() (string, []Document) {
+	words := []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet"}
+	documents := make([]Document, len(words))
+	for i := range documents {
+		content := ""
+		for _, word := range words[:i+1] {
+			content += word + " "
+		}
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: content}
+	}
+	query := ""
+	for _, word := range words {
+		query += word + " "
+	}
+	return query, documents
+}
+
+func TestThresholdPercentile_KeepsHalf(t *testing.T) {
+	query, documents := percentileDocuments()
+	config := NewConfig("", WithThresholdPercentile(0.5))
+
+	rerankers := map[string]Reranker{
+		"simple":        NewSimpleReranker(config),
+		"cross-encoder": NewCrossEncoderReranker(config),
+	}
+	for name, r := range rerankers {
+		docs := append([]Document(nil), documents...)
+		reranked, err := r.Rerank(context.Background(), query, docs)
+		if err != nil {
+			t.Fatalf("%s: Rerank failed: %v", name, err)
+		}
+		if len(reranked) < 4 || len(reranked) > 6 {
+			t.Errorf("%s: Expected about half of %d documents from Rerank, got %d", name, len(documents), len(reranked))
+		}
+
+		results, err := r.Rank(context.Background(), query, documents, 0)
+		if err != nil {
+			t.Fatalf("%s: Rank failed: %v", name, err)
+		}
+		if len(results) < 4 || len(results) > 6 {
+			t.Errorf("%s: Expected about half of %d documents from Rank, got %d", name, len(documents), len(results))
+		}
+		for _, result := range results {
+			if result.Index < 4 {
+				t.Errorf("%s: Expected only the better half, got %s", name, result.Document.ID)
+			}
+		}
+	}
+}
+
+func TestThresholdPercentile_GGUFLocal(t *testing.T) {
+	r := newFakeGGUFReranker(t, 2)
+	config := r.config
+	config.Threshold = 0.5
+	config.ThresholdMode = ThresholdPercentile
+	if err := r.Configure(config); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	// Seed the cache so each document has a distinct score
+	documents := make([]Document, 10)
+	for i := range documents {
+		documents[i] = Document{ID: fmt.Sprintf("doc_%d", i), Content: fmt.Sprintf("document %d", i)}
+		r.scoreCache.Set(fmt.Sprintf("query|||%s", documents[i].Content), float64(i))
+	}
+
+	results, err := r.Rank(context.Background(), "query", documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 5 {
+		t.Errorf("Expected 5 of 10 documents above the median, got %d", len(results))
+	}
+
+	reranked, err := r.Rerank(context.Background(), "query", append([]Document(nil), documents...))
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if len(reranked) != 5 || reranked[0].ID != "doc_9" {
+		t.Errorf("Expected doc_9 first of 5 documents, got %+v", reranked)
+	}
+}
+
+func TestThresholdAbsoluteIsDefault(t *testing.T) {
+	query, documents := percentileDocuments()
+	r := NewSimpleReranker(Config{Threshold: 0.5})
+
+	results, err := r.Rank(context.Background(), query, documents, 0)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	for _, result := range results {
+		if result.Score < 0.5 {
+			t.Errorf("Expected scores of at least 0.5, got %v", result.Score)
+		}
+	}
+	if len(results) != 6 {
+		t.Errorf("Expected the 6 documents matching half the query, got %d", len(results))
+	}
+}
+
+func TestValidateThreshold(t *testing.T) {
+	for _, config := range []Config{
+		{ThresholdMode: "median"},
+		{ThresholdMode: ThresholdPercentile, Threshold: 1.5},
+		{ThresholdMode: ThresholdPercentile, Threshold: -0.1},
+	} {
+		if err := NewSimpleReranker(Config{}).Configure(config); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput for %+v, got %v", config, err)
+		}
+	}
+	for _, config := range []Config{
+		{Threshold: 3},
+		{ThresholdMode: ThresholdAbsolute, Threshold: -2},
+		{ThresholdMode: ThresholdPercentile, Threshold: 0.8},
+	} {
+		if err := validateThreshold(config); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", config, err)
+		}
+	}
+}
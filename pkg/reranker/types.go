@@ -11,6 +11,13 @@ type Document struct {
 	Content string                 `json:"content"`
 	Score   float64                `json:"score"`
 	Meta    map[string]interface{} `json:"meta,omitempty"`
+
+	// Language is the ISO 639-1 code of Content (e.g. "en"), empty when unknown
+	Language string `json:"language,omitempty"`
+
+	// Vector is the document's dense embedding, e.g. from a vector store;
+	// HybridReranker blends its similarity to the query into the score
+	Vector []float32 `json:"vector,omitempty"`
 }
 
 // TestData represents test data structure
@@ -25,6 +32,83 @@ type RerankResult struct {
 	Document Document `json:"document"`
 	Score    float64  `json:"score"`
 	Index    int      `json:"index"`
+
+	// Rank is the 1-based output position and NormalizedRank is 1 - (Rank-1)/N
+	// for N returned results, so the top result has 1.0
+	Rank           int     `json:"rank"`
+	NormalizedRank float64 `json:"normalized_rank"`
+
+	// RelativeScore is the score min-max scaled across the returned results;
+	// it is only set when Config.NormalizeScores is not "none"
+	RelativeScore float64 `json:"relative_score,omitempty"`
+
+	// Explanation is a short human-readable justification of the score, set
+	// when Options["explain"] is true on rerankers that support it
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// Meta keys written by ResultToDocuments and read by DocumentsToResults
+const (
+	MetaRerankRank    = "rerank_rank"
+	MetaOriginalIndex = "original_index"
+)
+
+// ResultToDocuments converts ranked results back into documents for the next
+// reranking stage, in result order. Each document's Score is the result score,
+// and its Meta (copied, never modified in place) records the result's Rank under
+// "rerank_rank" and its Index under "original_index", overwriting earlier stages.
+func ResultToDocuments(results []RerankResult) []Document {
+	if results == nil {
+		return nil
+	}
+
+	docs := make([]Document, len(results))
+	for i, result := range results {
+		rank := result.Rank
+		if rank == 0 {
+			rank = i + 1
+		}
+
+		doc := result.Document
+		doc.Score = result.Score
+		doc.Meta = make(map[string]interface{}, len(result.Document.Meta)+2)
+		for key, value := range result.Document.Meta {
+			doc.Meta[key] = value
+		}
+		doc.Meta[MetaRerankRank] = rank
+		doc.Meta[MetaOriginalIndex] = result.Index
+		docs[i] = doc
+	}
+	return docs
+}
+
+// DocumentsToResults wraps documents as results in their current order, ranked
+// 1..N. Score is taken from Document.Score and Index from
+// Meta["original_index"] (an int, or a float64 after a JSON round trip),
+// falling back to the document's position.
+func DocumentsToResults(docs []Document) []RerankResult {
+	if docs == nil {
+		return nil
+	}
+
+	results := make([]RerankResult, len(docs))
+	for i, doc := range docs {
+		results[i] = RerankResult{
+			Document: doc,
+			Score:    doc.Score,
+			Index:    OptionInt(doc.Meta, MetaOriginalIndex, i),
+		}
+	}
+	return assignRanks(results, NormalizationNone)
+}
+
+// FilterSpec is a predicate on Document.Meta, e.g.
+// {Field: "language", Op: "eq", Value: "en"} or {Field: "date", Op: "gt", Value: "2023-01-01"}.
+// Field may use dots to reach nested maps ("author.name").
+type FilterSpec struct {
+	Field string      `json:"field"`
+	Op    string      `json:"op"` // "eq", "ne", "gt", "lt", "contains"
+	Value interface{} `json:"value"`
 }
 
 // Config holds configuration for rerankers
@@ -34,6 +118,34 @@ type Config struct {
 	Threshold float64                `json:"threshold"`
 	Device    string                 `json:"device,omitempty"`    // "cpu", "cuda", "auto"
 	Options   map[string]interface{} `json:"options,omitempty"`
+
+	// ThresholdMode selects how Threshold is read; empty means "absolute"
+	ThresholdMode ThresholdMode `json:"threshold_mode,omitempty"`
+
+	// NormalizeScores rescales scores returned by ComputeScore; empty means "none"
+	NormalizeScores ScoreNormalization `json:"normalize_scores,omitempty"`
+
+	// Deduplicate drops near-duplicate documents before Rank and Rerank,
+	// using Options["dedup_threshold"] as the Jaccard similarity cutoff
+	Deduplicate bool `json:"deduplicate,omitempty"`
+
+	// PreFilter drops documents before inference; PostFilter drops ranked results.
+	// All specs in a list must match for a document to be kept.
+	PreFilter  []FilterSpec `json:"pre_filter,omitempty"`
+	PostFilter []FilterSpec `json:"post_filter,omitempty"`
+
+	// StableSort keeps documents with equal scores in input order; when false
+	// the faster unstable sort is used and tie order is unspecified
+	StableSort bool `json:"stable_sort,omitempty"`
+
+	// TiebreakerHash orders documents with equal scores by the FNV-64a hash
+	// of their content (see HashDocument), so ties come out in the same order
+	// whatever the input order; it takes precedence over StableSort
+	TiebreakerHash bool `json:"tiebreaker_hash,omitempty"`
+
+	// Language is the ISO 639-1 code of the documents to rank; NewReranker
+	// warns when the model does not list it among its supported languages
+	Language string `json:"language,omitempty"`
 }
 
 // Reranker interface defines the contract for reranking implementations
@@ -43,6 +155,95 @@ type Reranker interface {
 	Rank(ctx context.Context, query string, documents []Document, topN int) ([]RerankResult, error)
 	Configure(config Config) error
 	GetModelName() string
+	// HealthCheck reports whether the reranker can currently score documents
+	HealthCheck(ctx context.Context) error
+	// Version identifies the exact model and package version serving calls,
+	// e.g. "model.gguf@1a2b3c4d" or "simple-reranker@go-rerankers-0.1.0"
+	Version() string
+}
+
+// StreamingReranker is implemented by rerankers that can emit results
+// while scoring is still in progress
+type StreamingReranker interface {
+	Reranker
+	// RankStream emits results as they are scored; the error channel receives
+	// at most one fatal error and is closed once the result channel is closed
+	RankStream(ctx context.Context, query string, documents []Document, topN int) (<-chan RerankResult, <-chan error)
+}
+
+// AsyncReranker is implemented by rerankers that can rank without blocking
+type AsyncReranker interface {
+	Reranker
+	// RankAsync starts ranking in the background; the future's Wait returns
+	// what Rank would have returned
+	RankAsync(ctx context.Context, query string, documents []Document, topN int) *RankFuture
+}
+
+// WarmableReranker is implemented by rerankers that can load their model
+// ahead of the first real request
+type WarmableReranker interface {
+	Reranker
+	Warmup(ctx context.Context) error
+}
+
+// InstructableReranker is implemented by instruction-following rerankers
+// (e.g. Qwen3-Reranker) that accept a task description alongside the query
+type InstructableReranker interface {
+	Reranker
+	// RankWithInstruction ranks like Rank with instruction prepended to the
+	// query; an empty instruction falls back to Options["instruction"]
+	RankWithInstruction(ctx context.Context, instruction, query string, documents []Document, topN int) ([]RerankResult, error)
+}
+
+// RankRequest is one independent ranking job of a bulk call
+type RankRequest struct {
+	Query     string     `json:"query"`
+	Documents []Document `json:"documents"`
+	TopN      int        `json:"top_n"`
+}
+
+// BulkReranker is implemented by rerankers that can rank many independent
+// queries in one call; results are returned in request order
+type BulkReranker interface {
+	Reranker
+	BulkRank(ctx context.Context, requests []RankRequest) ([][]RerankResult, error)
+}
+
+// PaginatedReranker is implemented by rerankers that can return rankings page by page
+type PaginatedReranker interface {
+	Reranker
+	// RankPage returns up to pageSize results following cursor (empty for the
+	// first page) and the cursor of the next page, which is empty after the last page
+	RankPage(ctx context.Context, query string, documents []Document, pageSize int, cursor string) ([]RerankResult, string, error)
+}
+
+// TermContribution is the share of a score attributed to a single token
+type TermContribution struct {
+	Token  string  `json:"token"`
+	Weight float64 `json:"weight"`
+}
+
+// ScoreExplanation breaks a raw (un-normalized) query-document score down into
+// per-token contributions; positive weights pushed the score up
+type ScoreExplanation struct {
+	Score      float64            `json:"score"`
+	QueryTerms []TermContribution `json:"query_terms"`
+	DocTerms   []TermContribution `json:"doc_terms"`
+}
+
+// ExplainableReranker is implemented by rerankers that can explain their scores
+type ExplainableReranker interface {
+	Reranker
+	Explain(ctx context.Context, query string, doc Document) (*ScoreExplanation, error)
+}
+
+// AttributableReranker is implemented by rerankers that can attribute a score
+// to the unique words of a document
+type AttributableReranker interface {
+	Reranker
+	// AttributeScores maps each document word to its contribution to the
+	// score; negative values mean the word hurts relevance
+	AttributeScores(ctx context.Context, query string, doc Document) (map[string]float64, error)
 }
 
 // Error types
@@ -52,20 +253,43 @@ var (
 	ErrInitialization    = fmt.Errorf("initialization error")
 	ErrInference         = fmt.Errorf("inference error")
 	ErrUnsupportedModel  = fmt.Errorf("unsupported model")
+	ErrDocumentNotFound  = fmt.Errorf("document not found")
 )
 
 // ModelInfo represents information about a supported model
 type ModelInfo struct {
-	Name        string   `json:"name"`
-	DisplayName string   `json:"display_name"`
-	Provider    string   `json:"provider"`
-	ModelID     string   `json:"model_id"`
-	Strengths   []string `json:"strengths"`
-	Type        string   `json:"type"` // "cross-encoder", "bi-encoder"
+	Name        string   `json:"name" yaml:"name"`
+	DisplayName string   `json:"display_name" yaml:"display_name"`
+	Provider    string   `json:"provider" yaml:"provider"`
+	ModelID     string   `json:"model_id" yaml:"model_id"`
+	Strengths   []string `json:"strengths" yaml:"strengths"`
+	Type        string   `json:"type" yaml:"type"` // "gguf-local", "gguf-layerwise", "jina-cloud", "voyage-cloud"
+	// Languages lists the ISO 639-1 codes the model handles well; empty means unrestricted
+	Languages   []string `json:"languages,omitempty" yaml:"languages,omitempty"`
+}
+
+// ModelCapabilities describes what a model supports, for choosing a model
+// programmatically (see GetModelCapabilities)
+type ModelCapabilities struct {
+	// MaxContextTokens is the longest query plus document the model accepts
+	MaxContextTokens int `json:"max_context_tokens"`
+	// Multilingual is true for models trained on many languages
+	Multilingual bool `json:"multilingual"`
+	// InstructionFollowing is true when Options["instruction"] steers the ranking
+	InstructionFollowing bool `json:"instruction_following"`
+	// CalibratedScores is true when the model's raw scores are relevance probabilities
+	CalibratedScores bool `json:"calibrated_scores"`
+	// QuantizationBits is the weight precision of the model file, 0 for hosted models
+	QuantizationBits int `json:"quantization_bits"`
 }
 
-// GetSupportedModels returns a list of all supported models
+// GetSupportedModels returns the built-in models merged with any loaded model registry
 func GetSupportedModels() []ModelInfo {
+	return mergeRegisteredModels(builtinModels())
+}
+
+// builtinModels returns the hard-coded list of supported models
+func builtinModels() []ModelInfo {
 	return []ModelInfo{
 		{
 			Name:        "jina-v2",
@@ -122,6 +346,7 @@ func GetSupportedModels() []ModelInfo {
 			ModelID:     "models/ms-marco-MiniLM-L12-v2.Q4_K_M.gguf",
 			Strengths:   []string{"Local inference", "Fast", "Well-established"},
 			Type:        "gguf-local",
+			Languages:   []string{"en"},
 		},
 		{
 			Name:        "bge-base",
@@ -130,6 +355,7 @@ func GetSupportedModels() []ModelInfo {
 			ModelID:     "models/bge-reranker-base-q4_k_m.gguf",
 			Strengths:   []string{"Local inference", "Fast", "Lightweight baseline"},
 			Type:        "gguf-local",
+			Languages:   []string{"en", "zh"},
 		},
 		{
 			Name:        "bge-large",
@@ -138,6 +364,7 @@ func GetSupportedModels() []ModelInfo {
 			ModelID:     "models/bge-reranker-large-q4_k_m.gguf",
 			Strengths:   []string{"Local inference", "Larger", "More accurate"},
 			Type:        "gguf-local",
+			Languages:   []string{"en", "zh"},
 		},
 		{
 			Name:        "bge-v2-m3",
@@ -155,13 +382,22 @@ func GetSupportedModels() []ModelInfo {
 			Strengths:   []string{"Local inference", "LLM-based reranker"},
 			Type:        "gguf-local",
 		},
+		{
+			Name:        "bge-v2-minicpm-layerwise",
+			DisplayName: "BGE Reranker V2-MiniCPM Layerwise",
+			Provider:    "BAAI",
+			ModelID:     "models/bge-reranker-v2-minicpm-layerwise-Q4_K_M.gguf",
+			Strengths:   []string{"Local inference", "Advanced layerwise model", "Speed/accuracy tradeoff by layer"},
+			Type:        string(TypeLayerwise),
+		},
 		{
 			Name:        "colbert-v2",
 			DisplayName: "ColBERT v2.0",
 			Provider:    "Stanford",
 			ModelID:     "models/colbertv2.0.Q4_K_M.gguf",
 			Strengths:   []string{"Local inference", "ColBERT architecture", "Efficient retrieval"},
-			Type:        "gguf-local",
+			Type:        string(TypeColBERT),
+			Languages:   []string{"en"},
 		},
 		{
 			Name:        "jina-m0",
@@ -178,6 +414,7 @@ func GetSupportedModels() []ModelInfo {
 			ModelID:     "models/jina-reranker-v1-tiny-en-Q4_K_M.gguf",
 			Strengths:   []string{"Local inference", "Tiny size", "English only", "Ultra fast"},
 			Type:        "gguf-local",
+			Languages:   []string{"en"},
 		},
 		{
 			Name:        "ms-marco-l4-v2",
@@ -186,6 +423,7 @@ func GetSupportedModels() []ModelInfo {
 			ModelID:     "models/ms-marco-MiniLM-L4-v2.Q4_K_M.gguf",
 			Strengths:   []string{"Local inference", "Ultra fast", "Lightweight", "4-layer model"},
 			Type:        "gguf-local",
+			Languages:   []string{"en"},
 		},
 		// GGUF Local Models
 		{
@@ -219,6 +457,7 @@ func GetSupportedModels() []ModelInfo {
 			ModelID:     "models/bge-reranker-base-q4_k_m.gguf",
 			Strengths:   []string{"Local inference", "Fast", "Lightweight baseline"},
 			Type:        "gguf-local",
+			Languages:   []string{"en", "zh"},
 		},
 		{
 			Name:        "gguf/bge-large",
@@ -227,6 +466,7 @@ func GetSupportedModels() []ModelInfo {
 			ModelID:     "models/bge-reranker-large-q4_k_m.gguf",
 			Strengths:   []string{"Local inference", "Larger", "More accurate"},
 			Type:        "gguf-local",
+			Languages:   []string{"en", "zh"},
 		},
 		{
 			Name:        "gguf/bge-v2-m3",
@@ -236,5 +476,31 @@ func GetSupportedModels() []ModelInfo {
 			Strengths:   []string{"Local inference", "Latest multilingual model"},
 			Type:        "gguf-local",
 		},
+		// Hosted Jina AI models (require Options["api_key"])
+		{
+			Name:        "jina-cloud/jina-reranker-v2-base-multilingual",
+			DisplayName: "Jina Reranker V2 (Jina AI Cloud)",
+			Provider:    "Jina AI",
+			ModelID:     "jina-cloud/jina-reranker-v2-base-multilingual",
+			Strengths:   []string{"Hosted API", "No local model files", "Multilingual support"},
+			Type:        string(TypeJinaCloud),
+		},
+		// Hosted Voyage AI models (require Options["api_key"])
+		{
+			Name:        "voyage/rerank-2",
+			DisplayName: "Voyage Rerank 2",
+			Provider:    "Voyage AI",
+			ModelID:     "voyage/rerank-2",
+			Strengths:   []string{"Hosted API", "No local model files", "High accuracy"},
+			Type:        string(TypeVoyage),
+		},
+		{
+			Name:        "voyage/rerank-lite-1",
+			DisplayName: "Voyage Rerank Lite 1",
+			Provider:    "Voyage AI",
+			ModelID:     "voyage/rerank-lite-1",
+			Strengths:   []string{"Hosted API", "No local model files", "Low latency"},
+			Type:        string(TypeVoyage),
+		},
 	}
 }
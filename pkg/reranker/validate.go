@@ -0,0 +1,62 @@
+package reranker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultMaxContentBytes is the largest document content accepted unless
+// Options["max_content_bytes"] is set
+const DefaultMaxContentBytes = 1 << 20
+
+// ValidateInput rejects input no reranker can score meaningfully: an empty
+// or whitespace-only query, a nil documents slice, a document whose content
+// is empty or whitespace-only or longer than DefaultMaxContentBytes, and two
+// documents sharing a non-empty ID. Errors wrap ErrInvalidInput.
+func ValidateInput(query string, documents []Document) error {
+	return validateInput(query, documents, DefaultMaxContentBytes)
+}
+
+// validateInput is ValidateInput with a content limit of maxContentBytes; a
+// non-positive limit disables the size check
+func validateInput(query string, documents []Document, maxContentBytes int) error {
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("%w: query must not be empty", ErrInvalidInput)
+	}
+	if documents == nil {
+		return fmt.Errorf("%w: documents must not be nil", ErrInvalidInput)
+	}
+
+	seen := make(map[string]int, len(documents))
+	for i, doc := range documents {
+		if strings.TrimSpace(doc.Content) == "" {
+			return fmt.Errorf("%w: document %d (%q) has empty content", ErrInvalidInput, i, doc.ID)
+		}
+		if maxContentBytes > 0 && len(doc.Content) > maxContentBytes {
+			return fmt.Errorf("%w: document %d (%q) content is %d bytes, over the %d byte limit", ErrInvalidInput, i, doc.ID, len(doc.Content), maxContentBytes)
+		}
+		if doc.ID == "" {
+			continue
+		}
+		if first, ok := seen[doc.ID]; ok {
+			return fmt.Errorf("%w: documents %d and %d share the ID %q", ErrInvalidInput, first, i, doc.ID)
+		}
+		seen[doc.ID] = i
+	}
+	return nil
+}
+
+// validateInput applies ValidateInput with the content limit from
+// Options["max_content_bytes"] (default DefaultMaxContentBytes; a
+// non-positive value disables the limit)
+func (c Config) validateInput(query string, documents []Document) error {
+	return validateInput(query, documents, OptionInt(c.Options, "max_content_bytes", DefaultMaxContentBytes))
+}
+
+// validateWrappedInput is validateInput for rerankers that delegate to
+// another reranker: the content limit applies only when the wrapper's own
+// Options["max_content_bytes"] is set, leaving it to the wrapped reranker
+// otherwise
+func (c Config) validateWrappedInput(query string, documents []Document) error {
+	return validateInput(query, documents, OptionInt(c.Options, "max_content_bytes", 0))
+}
@@ -0,0 +1,98 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateInput(t *testing.T) {
+	valid := []Document{{ID: "a", Content: "first"}, {ID: "b", Content: "second"}, {Content: "no ID"}, {Content: "also no ID"}}
+	if err := ValidateInput("query", valid); err != nil {
+		t.Fatalf("Expected valid input to pass, got %v", err)
+	}
+	if err := ValidateInput("query", []Document{}); err != nil {
+		t.Errorf("Expected an empty, non-nil slice to pass, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		documents []Document
+		want      string
+	}{
+		{"empty query", "", valid, "query must not be empty"},
+		{"whitespace query", " \t\n", valid, "query must not be empty"},
+		{"nil documents", "query", nil, "documents must not be nil"},
+		{"empty content", "query", []Document{{ID: "a", Content: "first"}, {ID: "b"}}, `document 1 ("b") has empty content`},
+		{"whitespace content", "query", []Document{{ID: "a", Content: "  \n\t"}}, `document 0 ("a") has empty content`},
+		{"duplicate IDs", "query", []Document{{ID: "a", Content: "x"}, {ID: "b", Content: "y"}, {ID: "a", Content: "z"}}, `documents 0 and 2 share the ID "a"`},
+		{"oversized content", "query", []Document{{ID: "big", Content: strings.Repeat("x", DefaultMaxContentBytes+1)}}, "over the 1048576 byte limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInput(tt.query, tt.documents)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("Expected ErrInvalidInput, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %q", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateInputMaxContentBytes(t *testing.T) {
+	documents := []Document{{Content: "twelve bytes"}}
+	if err := (Config{Options: map[string]interface{}{"max_content_bytes": 12}}).validateInput("query", documents); err != nil {
+		t.Errorf("Expected content at the limit to pass, got %v", err)
+	}
+	if err := (Config{Options: map[string]interface{}{"max_content_bytes": 11}}).validateInput("query", documents); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput over the limit, got %v", err)
+	}
+
+	large := []Document{{Content: strings.Repeat("x", DefaultMaxContentBytes+1)}}
+	if err := (Config{Options: map[string]interface{}{"max_content_bytes": 0}}).validateInput("query", large); err != nil {
+		t.Errorf("Expected max_content_bytes 0 to disable the limit, got %v", err)
+	}
+	if err := (Config{}).validateWrappedInput("query", large); err != nil {
+		t.Errorf("Expected wrappers to leave the default limit to the wrapped reranker, got %v", err)
+	}
+}
+
+func TestRerankers_RejectInvalidInput(t *testing.T) {
+	inner := NewSimpleReranker(Config{})
+	hybrid, err := NewHybridReranker(inner, Config{Options: map[string]interface{}{
+		"embedding_func": func(string) ([]float32, error) { return nil, nil },
+	}})
+	if err != nil {
+		t.Fatalf("NewHybridReranker failed: %v", err)
+	}
+	multiQuery, err := NewMultiQueryReranker(inner, []string{"variant"}, Config{})
+	if err != nil {
+		t.Fatalf("NewMultiQueryReranker failed: %v", err)
+	}
+	documents := []Document{{ID: "a", Content: "alpha"}, {ID: "a", Content: "beta"}}
+
+	for name, r := range map[string]Reranker{"simple": inner, "mock": NewFixedScoreMock(nil), "hybrid": hybrid, "multi-query": multiQuery} {
+		ctx := context.Background()
+		if _, err := r.Rank(ctx, "query", documents, 0); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected Rank to reject duplicate IDs, got %v", name, err)
+		}
+		if _, err := r.Rerank(ctx, "query", nil); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected Rerank to reject nil documents, got %v", name, err)
+		}
+		if _, err := r.ComputeScore(ctx, "query", []Document{{Content: " "}}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ComputeScore to reject blank content, got %v", name, err)
+		}
+	}
+
+	// Configured variants stand in for an empty call query
+	if _, err := multiQuery.ComputeScore(context.Background(), "", []Document{{Content: "alpha"}}); err != nil {
+		t.Errorf("Expected multi-query variants to allow an empty call query, got %v", err)
+	}
+	if _, err := inner.Rank(context.Background(), " ", []Document{{Content: "alpha"}}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected a blank query to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package reranker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"go-rerankers/pkg/version"
+)
+
+// packageVersion returns the Version of rerankers without a model file:
+// "{model}@go-rerankers-{semver}"
+func packageVersion(model string) string {
+	return model + "@go-rerankers-" + version.Version
+}
+
+// modelFileVersion returns the Version of a model file:
+// "{filename}@{first 8 hex characters of its SHA-256}"
+func modelFileVersion(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return filepath.Base(path) + "@" + hex.EncodeToString(hash.Sum(nil))[:8], nil
+}
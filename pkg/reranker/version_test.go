@@ -0,0 +1,72 @@
+package reranker
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"go-rerankers/pkg/version"
+)
+
+func TestVersion_PackageFormat(t *testing.T) {
+	want := "@go-rerankers-" + version.Version
+	if got := NewSimpleReranker(Config{}).Version(); got != "simple-reranker"+want {
+		t.Errorf("Expected the default simple model name, got %q", got)
+	}
+	if got := NewCrossEncoderReranker(Config{Model: "ms-marco-v2"}).Version(); got != "ms-marco-v2"+want {
+		t.Errorf("Unexpected cross-encoder version %q", got)
+	}
+	if !regexp.MustCompile(`^\d+\.\d+\.\d+`).MatchString(version.Version) {
+		t.Errorf("Expected a semantic version, got %q", version.Version)
+	}
+
+	// Wrappers report the version of what they wrap
+	retry, err := NewRetryReranker(NewSimpleReranker(Config{Model: "inner"}), Config{})
+	if err != nil {
+		t.Fatalf("NewRetryReranker failed: %v", err)
+	}
+	if got := retry.Version(); got != "inner"+want {
+		t.Errorf("Expected the wrapped version, got %q", got)
+	}
+}
+
+func TestVersion_GGUFModelFile(t *testing.T) {
+	// Lay out root/models/stub.gguf next to a stub llama-embedding binary
+	root := t.TempDir()
+	binDir := filepath.Join(root, "llama.cpp", "build", "bin")
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeStubBinary(t, filepath.Join(binDir, "llama-embedding"), "#!/bin/sh\n")
+	modelPath := filepath.Join(root, "models", "stub.gguf")
+	if err := os.MkdirAll(filepath.Dir(modelPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(modelPath, []byte("model weights"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewGGUFLocalReranker(Config{Model: modelPath})
+	if err != nil {
+		t.Fatalf("NewGGUFLocalReranker failed: %v", err)
+	}
+
+	// The SHA-256 of "model weights" starts with a2d42c4a
+	got := r.Version()
+	if got != "stub.gguf@a2d42c4a" {
+		t.Fatalf("Expected {model_filename}@{sha256_first8_chars}, got %q", got)
+	}
+
+	// The hash is computed by the constructor, not on each call
+	if err := os.WriteFile(modelPath, []byte("other weights"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if again := r.Version(); again != got {
+		t.Errorf("Expected the version computed at construction %q, got %q", got, again)
+	}
+
+	if _, err := modelFileVersion(filepath.Join(t.TempDir(), "missing.gguf")); err == nil {
+		t.Error("Expected an error for a missing model file")
+	}
+}
@@ -0,0 +1,97 @@
+package reranker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// VoyageModelPrefix marks model names served by the hosted Voyage AI Rerank API
+const VoyageModelPrefix = "voyage/"
+
+// DefaultVoyageEndpoint is the Voyage AI Rerank API endpoint
+const DefaultVoyageEndpoint = "https://api.voyageai.com/v1/rerank"
+
+// VoyageReranker implements reranking using the hosted Voyage AI Rerank API
+// (e.g. "voyage/rerank-2" or "voyage/rerank-lite-1"). Rate-limited (429)
+// responses fail with ErrInference, so they are retried like other
+// transient failures.
+//
+// Recognized options:
+//   - "api_key": Voyage AI API key (required)
+//   - "endpoint": override the API endpoint (default DefaultVoyageEndpoint)
+//   - "truncation": let the API truncate inputs exceeding the context length (default true)
+//   - "return_documents": ask the API to echo document text back (default false)
+//   - "timeout_seconds", "max_retries": same as HTTPReranker
+type VoyageReranker struct {
+	hostedReranker
+}
+
+// VoyageRerankRequest represents the request body for the Voyage AI Rerank API
+type VoyageRerankRequest struct {
+	Model           string   `json:"model"`
+	Query           string   `json:"query"`
+	Documents       []string `json:"documents"`
+	TopK            int      `json:"top_k,omitempty"`
+	Truncation      bool     `json:"truncation"`
+	ReturnDocuments bool     `json:"return_documents"`
+}
+
+// VoyageRerankResponse represents the response body from the Voyage AI Rerank API
+type VoyageRerankResponse struct {
+	Model string `json:"model"`
+	Data  []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+		Document       string  `json:"document,omitempty"`
+	} `json:"data"`
+}
+
+// NewVoyageReranker creates a new reranker backed by the Voyage AI Rerank API
+func NewVoyageReranker(config Config) (*VoyageReranker, error) {
+	hosted, err := newHostedReranker(config, "Voyage", VoyageModelPrefix, DefaultVoyageEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	model := strings.TrimPrefix(config.Model, VoyageModelPrefix)
+	truncation := OptionBool(config.Options, "truncation", true)
+	returnDocuments := OptionBool(config.Options, "return_documents", false)
+	hosted.buildRequest = func(query string, documents []string, topK int) interface{} {
+		return VoyageRerankRequest{
+			Model:           model,
+			Query:           query,
+			Documents:       documents,
+			TopK:            topK,
+			Truncation:      truncation,
+			ReturnDocuments: returnDocuments,
+		}
+	}
+	hosted.decodeResponse = decodeVoyageResponse
+
+	return &VoyageReranker{hostedReranker: hosted}, nil
+}
+
+// decodeVoyageResponse parses a Voyage AI Rerank API response body
+func decodeVoyageResponse(payload []byte) ([]hostedResult, error) {
+	var response VoyageRerankResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse Voyage response: %v", ErrInference, err)
+	}
+
+	results := make([]hostedResult, len(response.Data))
+	for i, result := range response.Data {
+		results[i] = hostedResult{Index: result.Index, Score: result.RelevanceScore, Text: result.Document}
+	}
+	return results, nil
+}
+
+// Configure updates the reranker configuration
+func (r *VoyageReranker) Configure(config Config) error {
+	updated, err := NewVoyageReranker(config)
+	if err != nil {
+		return err
+	}
+	*r = *updated
+	return nil
+}
@@ -0,0 +1,147 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// voyageHandler scores documents in reverse order so the last one ranks first
+func voyageHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if got := req.Header.Get("Authorization"); got != "Bearer voyage-key" {
+			t.Errorf("Expected bearer API key, got %q", got)
+		}
+
+		var body VoyageRerankRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if body.Model != "rerank-2" {
+			t.Errorf("Expected model prefix to be stripped, got %q", body.Model)
+		}
+		if body.Truncation {
+			t.Error("Expected truncation option to be forwarded")
+		}
+
+		type result struct {
+			Index          int     `json:"index"`
+			RelevanceScore float64 `json:"relevance_score"`
+			Document       string  `json:"document,omitempty"`
+		}
+		var data []result
+		for i := len(body.Documents) - 1; i >= 0; i-- {
+			res := result{Index: i, RelevanceScore: float64(i+1) / float64(len(body.Documents))}
+			if body.ReturnDocuments {
+				res.Document = body.Documents[i]
+			}
+			data = append(data, res)
+		}
+		if body.TopK > 0 && len(data) > body.TopK {
+			data = data[:body.TopK]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"object": "list", "model": body.Model, "data": data})
+	}
+}
+
+func newTestVoyageReranker(t *testing.T, endpoint string, options map[string]interface{}) Reranker {
+	opts := map[string]interface{}{
+		"api_key":    "voyage-key",
+		"endpoint":   endpoint,
+		"truncation": false,
+	}
+	for key, value := range options {
+		opts[key] = value
+	}
+	r, err := NewReranker(Config{Model: "voyage/rerank-2", Options: opts})
+	if err != nil {
+		t.Fatalf("NewReranker failed: %v", err)
+	}
+	return r
+}
+
+func TestVoyageReranker_Rank(t *testing.T) {
+	server := httptest.NewServer(voyageHandler(t))
+	defer server.Close()
+
+	r := newTestVoyageReranker(t, server.URL, map[string]interface{}{"return_documents": true})
+	if _, ok := r.(*VoyageReranker); !ok {
+		t.Fatalf("Expected *VoyageReranker, got %T", r)
+	}
+
+	documents := []Document{{ID: "1", Content: "first"}, {ID: "2", Content: "second"}, {ID: "3", Content: "third"}}
+	results, err := r.Rank(context.Background(), "query", documents, 2)
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Document.ID != "3" || results[0].Index != 2 || results[0].Score != 1 || results[0].Rank != 1 {
+		t.Errorf("Expected document 3 first with relevance_score 1, got %+v", results[0])
+	}
+
+	scores, err := r.ComputeScore(context.Background(), "query", documents)
+	if err != nil {
+		t.Fatalf("ComputeScore failed: %v", err)
+	}
+	if scores[0] >= scores[2] {
+		t.Errorf("Expected scores in document order, got %v", scores)
+	}
+}
+
+func TestVoyageReranker_RequiresAPIKey(t *testing.T) {
+	_, err := NewVoyageReranker(Config{Model: "voyage/rerank-2"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestVoyageReranker_RateLimited(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		http.Error(w, `{"detail":"rate limit exceeded"}`, http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	r := newTestVoyageReranker(t, server.URL, map[string]interface{}{"max_retries": 1})
+	_, err := r.ComputeScore(context.Background(), "query", []Document{{Content: "a"}})
+	if !errors.Is(err, ErrInference) {
+		t.Errorf("Expected retriable ErrInference, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected the 429 to be retried once, got %d calls", got)
+	}
+}
+
+func TestVoyageReranker_RateLimitRecovers(t *testing.T) {
+	var calls atomic.Int32
+	handler := voyageHandler(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		handler(w, req)
+	}))
+	defer server.Close()
+
+	// The backend gives up at once; RetryReranker retries the ErrInference
+	r := newTestVoyageReranker(t, server.URL, map[string]interface{}{
+		"max_retries":              0,
+		"retry_max_attempts":       2,
+		"retry_initial_backoff_ms": 1,
+	})
+	scores, err := r.ComputeScore(context.Background(), "query", []Document{{Content: "a"}, {Content: "b"}})
+	if err != nil {
+		t.Fatalf("Expected the rate-limited call to be retried, got %v", err)
+	}
+	if len(scores) != 2 || calls.Load() != 2 {
+		t.Errorf("Expected 2 scores after 2 calls, got %v after %d calls", scores, calls.Load())
+	}
+}
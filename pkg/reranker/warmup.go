@@ -0,0 +1,41 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ WarmableReranker = (*GGUFLocalReranker)(nil)
+
+// Synthetic pair scored by Warmup
+const (
+	warmupQuery    = "warmup query"
+	warmupDocument = "warmup document"
+)
+
+// Warmup scores a short synthetic query-document pair so model weights are
+// loaded before the first real request; the score is cached and discarded
+func (r *GGUFLocalReranker) Warmup(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if _, err := r.computeRerankerScore(ctx, warmupQuery, warmupDocument); err != nil {
+		return fmt.Errorf("%w: warmup failed: %v", ErrInitialization, err)
+	}
+	return nil
+}
+
+// warmupIfRequested runs Warmup when Options["warmup"] is set and r supports it
+func warmupIfRequested(r Reranker, config Config) error {
+	if !OptionBool(config.Options, "warmup", false) {
+		return nil
+	}
+	if warmable, ok := r.(WarmableReranker); ok {
+		return warmable.Warmup(context.Background())
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package reranker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newCountingGGUFReranker returns a stub reranker whose binary records each invocation
+func newCountingGGUFReranker(t *testing.T) (*GGUFLocalReranker, string) {
+	t.Helper()
+
+	reranker := newFakeGGUFReranker(t, 1)
+	counter := filepath.Join(t.TempDir(), "calls")
+	script := fmt.Sprintf("#!/bin/sh\necho call >> %q\necho '{\"object\":\"list\",\"data\":[{\"object\":\"embedding\",\"index\":0,\"embedding\":[0.6,0.8]}]}'\n", counter)
+	writeStubBinary(t, reranker.inferenceBinary, script)
+	return reranker, counter
+}
+
+func countCalls(t *testing.T, counter string) int {
+	t.Helper()
+
+	data, err := os.ReadFile(counter)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatalf("Failed to read counter: %v", err)
+	}
+	return strings.Count(string(data), "call")
+}
+
+func TestGGUFLocalReranker_Warmup(t *testing.T) {
+	reranker, counter := newCountingGGUFReranker(t)
+	if err := reranker.Warmup(context.Background()); err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+	if reranker.CacheLen() != 1 {
+		t.Errorf("Expected warmup to populate the cache, got %d entries", reranker.CacheLen())
+	}
+	calls := countCalls(t, counter)
+	if calls == 0 {
+		t.Fatal("Expected warmup to invoke the inference binary")
+	}
+
+	if err := reranker.Warmup(context.Background()); err != nil {
+		t.Fatalf("Second warmup failed: %v", err)
+	}
+	if got := countCalls(t, counter); got != calls {
+		t.Errorf("Expected second warmup to hit the cache, binary calls went from %d to %d", calls, got)
+	}
+}
+
+func TestGGUFLocalReranker_WarmupCanceled(t *testing.T) {
+	reranker := newFakeGGUFReranker(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := reranker.Warmup(ctx); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if reranker.CacheLen() != 0 {
+		t.Errorf("Expected empty cache, got %d entries", reranker.CacheLen())
+	}
+}
+
+func TestWarmupIfRequested(t *testing.T) {
+	reranker, _ := newCountingGGUFReranker(t)
+	if err := warmupIfRequested(reranker, Config{}); err != nil {
+		t.Fatalf("warmupIfRequested failed: %v", err)
+	}
+	if reranker.CacheLen() != 0 {
+		t.Errorf("Expected no warmup without the option, got %d cache entries", reranker.CacheLen())
+	}
+
+	config := Config{Options: map[string]interface{}{"warmup": true}}
+	if err := warmupIfRequested(reranker, config); err != nil {
+		t.Fatalf("warmupIfRequested failed: %v", err)
+	}
+	if reranker.CacheLen() != 1 {
+		t.Errorf("Expected warmup to populate the cache, got %d entries", reranker.CacheLen())
+	}
+
+	// Rerankers without Warmup are left alone
+	if err := warmupIfRequested(NewSimpleReranker(config), config); err != nil {
+		t.Errorf("Expected no error for non-warmable reranker, got %v", err)
+	}
+}
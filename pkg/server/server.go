@@ -0,0 +1,369 @@
+// Package server exposes rerankers over a JSON HTTP API.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+	"go-rerankers/pkg/version"
+)
+
+// DefaultPort is the port used by the CLI when --port is not given
+const DefaultPort = 8080
+
+// maxRequestBytes bounds the size of a /rerank request body
+const maxRequestBytes = 10 << 20
+
+// shutdownTimeout bounds how long in-flight requests may take after shutdown starts
+const shutdownTimeout = 10 * time.Second
+
+// Factory creates a reranker for a model requested by a client
+type Factory func(config reranker.Config) (reranker.Reranker, error)
+
+// RerankRequest is the body of POST /rerank. Documents may be given as plain
+// strings or as Document objects.
+type RerankRequest struct {
+	Query     string           `json:"query"`
+	Documents RequestDocuments `json:"documents"`
+	Model     string           `json:"model"`
+	TopK      int              `json:"top_k"`
+}
+
+// RequestDocuments decodes a JSON array of strings and/or Document objects
+type RequestDocuments []reranker.Document
+
+// UnmarshalJSON accepts strings as documents with generated IDs
+func (d *RequestDocuments) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	documents := make(RequestDocuments, len(raw))
+	for i, item := range raw {
+		var content string
+		if err := json.Unmarshal(item, &content); err == nil {
+			documents[i] = reranker.Document{ID: fmt.Sprintf("doc_%d", i), Content: content}
+			continue
+		}
+		if err := json.Unmarshal(item, &documents[i]); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+	}
+	*d = documents
+	return nil
+}
+
+// RerankResponse is the body returned by POST /rerank
+type RerankResponse struct {
+	Model   string                  `json:"model"`
+	Results []reranker.RerankResult `json:"results"`
+}
+
+// HealthResponse is the body returned by GET /health. Status is "ok" when
+// every loaded model passes its health check and "unhealthy" otherwise, with
+// the failures in Errors keyed by model.
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Models []string          `json:"models"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// VersionResponse is the body returned by GET /version: the go-rerankers
+// version and the Version of every loaded model, keyed by model
+type VersionResponse struct {
+	Version string            `json:"version"`
+	Models  map[string]string `json:"models"`
+}
+
+// ErrorResponse is the body returned for failed requests
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithDefaultModel sets the model used when a request does not name one
+func WithDefaultModel(model string) Option {
+	return func(s *Server) {
+		s.defaultModel = model
+	}
+}
+
+// WithFactory replaces reranker.NewReranker as the way models are created
+func WithFactory(factory Factory) Option {
+	return func(s *Server) {
+		s.factory = factory
+	}
+}
+
+// Server serves reranking requests, creating each requested model once and
+// reusing it for later requests
+type Server struct {
+	defaultModel string
+	factory      Factory
+
+	mutex     sync.Mutex
+	rerankers map[string]reranker.Reranker
+	loading   map[string]*modelLoad
+}
+
+// modelLoad is a model being created by the factory; concurrent requests for
+// the same model wait on once and share the result
+type modelLoad struct {
+	once     sync.Once
+	reranker reranker.Reranker
+	err      error
+}
+
+// New creates a server
+func New(opts ...Option) *Server {
+	s := &Server{
+		factory:   reranker.NewReranker,
+		rerankers: make(map[string]reranker.Reranker),
+		loading:   make(map[string]*modelLoad),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Handler returns the HTTP routes: POST /rerank, GET /health, GET /models and
+// GET /version
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rerank", s.handleRerank)
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/models", s.handleModels)
+	mux.HandleFunc("/version", s.handleVersion)
+	return mux
+}
+
+// ListenAndServe serves on addr until ctx is cancelled, then shuts down
+// gracefully, letting in-flight requests finish, and closes the cached rerankers
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		s.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := httpServer.Shutdown(shutdownCtx)
+	s.Close()
+	return err
+}
+
+// Close releases every cached reranker
+func (s *Server) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var errs []error
+	for model, r := range s.rerankers {
+		switch closer := r.(type) {
+		case interface{ Close() error }:
+			errs = append(errs, closer.Close())
+		case interface{ Close() }:
+			closer.Close()
+		}
+		delete(s.rerankers, model)
+	}
+	return errors.Join(errs...)
+}
+
+// reranker returns the cached reranker for model, creating it on first use.
+// The factory runs without holding the mutex, so loading one model does not
+// block requests for other models, /health or /version; a failed load is
+// retried by the next request.
+func (s *Server) reranker(model string) (reranker.Reranker, error) {
+	s.mutex.Lock()
+	if r, exists := s.rerankers[model]; exists {
+		s.mutex.Unlock()
+		return r, nil
+	}
+	load, exists := s.loading[model]
+	if !exists {
+		load = &modelLoad{}
+		s.loading[model] = load
+	}
+	s.mutex.Unlock()
+
+	load.once.Do(func() {
+		load.reranker, load.err = s.factory(reranker.Config{Model: model})
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		delete(s.loading, model)
+		if load.err == nil {
+			s.rerankers[model] = load.reranker
+		}
+	})
+	return load.reranker, load.err
+}
+
+// handleRerank validates the request and ranks its documents
+func (s *Server) handleRerank(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var body RerankRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestBytes))
+	if err := decoder.Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+		return
+	}
+	if body.Model == "" {
+		body.Model = s.defaultModel
+	}
+	switch {
+	case body.Query == "":
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	case len(body.Documents) == 0:
+		writeError(w, http.StatusBadRequest, "documents must not be empty")
+		return
+	case body.Model == "":
+		writeError(w, http.StatusBadRequest, "model is required")
+		return
+	case body.TopK < 0:
+		writeError(w, http.StatusBadRequest, "top_k must not be negative")
+		return
+	}
+
+	r, err := s.reranker(body.Model)
+	if err != nil {
+		writeError(w, statusForError(err), err.Error())
+		return
+	}
+
+	results, err := r.Rank(req.Context(), body.Query, body.Documents, body.TopK)
+	if err != nil {
+		writeError(w, statusForError(err), err.Error())
+		return
+	}
+	if results == nil {
+		results = []reranker.RerankResult{}
+	}
+	writeJSON(w, http.StatusOK, RerankResponse{Model: r.GetModelName(), Results: results})
+}
+
+// handleHealth runs the health check of every loaded reranker, answering
+// 503 Service Unavailable if any fails
+func (s *Server) handleHealth(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	// Check a snapshot so slow checks do not block model creation
+	s.mutex.Lock()
+	loaded := make(map[string]reranker.Reranker, len(s.rerankers))
+	for model, r := range s.rerankers {
+		loaded[model] = r
+	}
+	s.mutex.Unlock()
+
+	response := HealthResponse{Status: "ok", Models: make([]string, 0, len(loaded))}
+	for model, r := range loaded {
+		response.Models = append(response.Models, model)
+		if err := r.HealthCheck(req.Context()); err != nil {
+			if response.Errors == nil {
+				response.Errors = make(map[string]string)
+			}
+			response.Errors[model] = err.Error()
+		}
+	}
+	sort.Strings(response.Models)
+
+	if len(response.Errors) > 0 {
+		response.Status = "unhealthy"
+		writeJSON(w, http.StatusServiceUnavailable, response)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleModels lists the supported models
+func (s *Server) handleModels(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, reranker.GetSupportedModels())
+}
+
+// handleVersion reports the package version and the version of every loaded model
+func (s *Server) handleVersion(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	s.mutex.Lock()
+	loaded := make(map[string]reranker.Reranker, len(s.rerankers))
+	for model, r := range s.rerankers {
+		loaded[model] = r
+	}
+	s.mutex.Unlock()
+
+	// Versions are read outside the lock since GGUF models hash their file once
+	response := VersionResponse{Version: version.Version, Models: make(map[string]string, len(loaded))}
+	for model, r := range loaded {
+		response.Models[model] = r.Version()
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// statusForError maps reranker errors to HTTP status codes
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, reranker.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, reranker.ErrModelNotFound), errors.Is(err, reranker.ErrUnsupportedModel):
+		return http.StatusNotFound
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON encodes value as the response body
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError sends an ErrorResponse
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{Error: message})
+}
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+	"go-rerankers/pkg/version"
+)
+
+// simpleFactory serves every model with a SimpleReranker
+func simpleFactory(config reranker.Config) (reranker.Reranker, error) {
+	if config.Model == "missing" {
+		return nil, fmt.Errorf("%w: %s", reranker.ErrModelNotFound, config.Model)
+	}
+	return reranker.NewSimpleReranker(config), nil
+}
+
+func newTestServer(t *testing.T, opts ...Option) *httptest.Server {
+	t.Helper()
+	s := New(append([]Option{WithFactory(simpleFactory)}, opts...)...)
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(func() {
+		ts.Close()
+		s.Close()
+	})
+	return ts
+}
+
+func postRerank(t *testing.T, url string, body interface{}) *http.Response {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Failed to encode request: %v", err)
+	}
+	resp, err := http.Post(url+"/rerank", "application/json", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("POST /rerank failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestServer_Rerank(t *testing.T) {
+	ts := newTestServer(t)
+
+	resp := postRerank(t, ts.URL, map[string]interface{}{
+		"query": "machine learning",
+		"documents": []interface{}{
+			"Cooking pasta at home",
+			map[string]interface{}{"id": "ml", "content": "Machine learning models learn from data"},
+			"Learning to paint",
+		},
+		"model": "simple",
+		"top_k": 2,
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	var body RerankResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Model != "simple" {
+		t.Errorf("Expected model 'simple', got %q", body.Model)
+	}
+	if len(body.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(body.Results))
+	}
+	if body.Results[0].Document.ID != "ml" || body.Results[0].Index != 1 || body.Results[0].Rank != 1 {
+		t.Errorf("Expected the ML document first, got %+v", body.Results[0])
+	}
+	if body.Results[1].Document.ID != "doc_2" {
+		t.Errorf("Expected generated ID doc_2 for string document, got %q", body.Results[1].Document.ID)
+	}
+}
+
+func TestServer_RerankValidation(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		body   interface{}
+		status int
+	}{
+		{"missing query", map[string]interface{}{"documents": []string{"a"}, "model": "simple"}, http.StatusBadRequest},
+		{"no documents", map[string]interface{}{"query": "q", "documents": []string{}, "model": "simple"}, http.StatusBadRequest},
+		{"missing model", map[string]interface{}{"query": "q", "documents": []string{"a"}}, http.StatusBadRequest},
+		{"negative top_k", map[string]interface{}{"query": "q", "documents": []string{"a"}, "model": "simple", "top_k": -1}, http.StatusBadRequest},
+		{"bad documents", map[string]interface{}{"query": "q", "documents": []int{1}, "model": "simple"}, http.StatusBadRequest},
+		{"unknown model", map[string]interface{}{"query": "q", "documents": []string{"a"}, "model": "missing"}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := postRerank(t, ts.URL, tt.body)
+			if resp.StatusCode != tt.status {
+				t.Errorf("Expected %d, got %d", tt.status, resp.StatusCode)
+			}
+			var body ErrorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error == "" {
+				t.Errorf("Expected an error message, got %+v (%v)", body, err)
+			}
+		})
+	}
+
+	resp, err := http.Get(ts.URL + "/rerank")
+	if err != nil {
+		t.Fatalf("GET /rerank failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET /rerank, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_DefaultModel(t *testing.T) {
+	ts := newTestServer(t, WithDefaultModel("simple"))
+
+	resp := postRerank(t, ts.URL, map[string]interface{}{"query": "q", "documents": []string{"q"}})
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the default model to be used, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_HealthAndModels(t *testing.T) {
+	ts := newTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET /health failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var health HealthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil || health.Status != "ok" {
+		t.Errorf("Expected status ok, got %v (%v)", health, err)
+	}
+
+	resp, err = http.Get(ts.URL + "/models")
+	if err != nil {
+		t.Fatalf("GET /models failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var models []reranker.ModelInfo
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		t.Fatalf("Failed to decode models: %v", err)
+	}
+	if len(models) != len(reranker.GetSupportedModels()) {
+		t.Errorf("Expected %d models, got %d", len(reranker.GetSupportedModels()), len(models))
+	}
+}
+
+// unhealthyReranker is a SimpleReranker whose health check always fails
+type unhealthyReranker struct {
+	*reranker.SimpleReranker
+}
+
+func (r unhealthyReranker) HealthCheck(ctx context.Context) error {
+	return errors.New("model file not found")
+}
+
+func TestServer_HealthChecksLoadedModels(t *testing.T) {
+	ts := newTestServer(t, WithFactory(func(config reranker.Config) (reranker.Reranker, error) {
+		if config.Model == "broken" {
+			return unhealthyReranker{reranker.NewSimpleReranker(config)}, nil
+		}
+		return reranker.NewSimpleReranker(config), nil
+	}))
+
+	getHealth := func() (int, HealthResponse) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + "/health")
+		if err != nil {
+			t.Fatalf("GET /health failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var health HealthResponse
+		if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+			t.Fatalf("Failed to decode health: %v", err)
+		}
+		return resp.StatusCode, health
+	}
+
+	postRerank(t, ts.URL, map[string]interface{}{"query": "q", "documents": []string{"q"}, "model": "simple"})
+	if status, health := getHealth(); status != http.StatusOK || health.Status != "ok" || len(health.Models) != 1 || health.Models[0] != "simple" {
+		t.Errorf("Expected 200 with the simple model, got %d %+v", status, health)
+	}
+
+	postRerank(t, ts.URL, map[string]interface{}{"query": "q", "documents": []string{"q"}, "model": "broken"})
+	status, health := getHealth()
+	if status != http.StatusServiceUnavailable || health.Status != "unhealthy" {
+		t.Fatalf("Expected 503 unhealthy, got %d %+v", status, health)
+	}
+	if len(health.Models) != 2 || health.Errors["broken"] != "model file not found" || health.Errors["simple"] != "" {
+		t.Errorf("Expected only the broken model to fail, got %+v", health)
+	}
+}
+
+func TestServer_LoadsModelsWithoutBlocking(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var slowLoads int32
+	ts := newTestServer(t, WithFactory(func(config reranker.Config) (reranker.Reranker, error) {
+		if config.Model == "slow" {
+			if atomic.AddInt32(&slowLoads, 1) == 1 {
+				close(started)
+			}
+			<-release
+		}
+		return reranker.NewSimpleReranker(config), nil
+	}))
+
+	payload := []byte(`{"query": "q", "documents": ["q"], "model": "slow"}`)
+	statuses := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			resp, err := http.Post(ts.URL+"/rerank", "application/json", bytes.NewReader(payload))
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	<-started
+
+	// Other models and /health are served while the slow model loads
+	if resp := postRerank(t, ts.URL, map[string]interface{}{"query": "q", "documents": []string{"q"}, "model": "simple"}); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 for a model that is not loading, got %d", resp.StatusCode)
+	}
+	resp, err := http.Get(ts.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET /health failed: %v", err)
+	}
+	resp.Body.Close()
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if status := <-statuses; status != http.StatusOK {
+			t.Errorf("Expected 200 once the slow model loaded, got %d", status)
+		}
+	}
+	if loads := atomic.LoadInt32(&slowLoads); loads != 1 {
+		t.Errorf("Expected the slow model to be created once, got %d", loads)
+	}
+}
+
+func TestServer_Version(t *testing.T) {
+	ts := newTestServer(t)
+	postRerank(t, ts.URL, map[string]interface{}{"query": "q", "documents": []string{"q"}, "model": "simple"})
+
+	resp, err := http.Get(ts.URL + "/version")
+	if err != nil {
+		t.Fatalf("GET /version failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var response VersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode version: %v", err)
+	}
+	if response.Version != version.Version || response.Models["simple"] != "simple@go-rerankers-"+version.Version {
+		t.Errorf("Unexpected version response %+v", response)
+	}
+
+	resp, err = http.Post(ts.URL+"/version", "application/json", nil)
+	if err != nil {
+		t.Fatalf("POST /version failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_GracefulShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve a port: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- New(WithFactory(simpleFactory)).ListenAndServe(ctx, addr)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := http.Get("http://" + addr + "/health")
+		if err == nil {
+			resp.Body.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Server did not start: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not shut down")
+	}
+}
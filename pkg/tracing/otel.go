@@ -0,0 +1,154 @@
+// Package tracing instruments rerankers with OpenTelemetry spans.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies the tracer used by TracedReranker
+const InstrumentationName = "go-rerankers/pkg/tracing"
+
+// Span names
+const (
+	SpanRank         = "reranker.Rank"
+	SpanRerank       = "reranker.Rerank"
+	SpanComputeScore = "reranker.ComputeScore"
+)
+
+// Span attribute keys
+const (
+	AttrModel      = attribute.Key("reranker.model")
+	AttrDocCount   = attribute.Key("reranker.doc_count")
+	AttrTopN       = attribute.Key("reranker.top_n")
+	AttrDurationMS = attribute.Key("reranker.duration_ms")
+)
+
+// Option configures a TracedReranker
+type Option func(*tracerOptions)
+
+// tracerOptions holds the settings applied by Option
+type tracerOptions struct {
+	provider trace.TracerProvider
+}
+
+// WithTracing creates spans with tp instead of the global tracer provider
+func WithTracing(tp trace.TracerProvider) Option {
+	return func(o *tracerOptions) {
+		o.provider = tp
+	}
+}
+
+// TracedReranker decorates a Reranker, wrapping each Rank, Rerank and
+// ComputeScore call in a child span of the span carried by the context.
+// The span's context is passed on to the wrapped reranker. It implements
+// reranker.Reranker itself.
+type TracedReranker struct {
+	inner  reranker.Reranker
+	tracer trace.Tracer
+}
+
+// NewTracedReranker wraps inner with OpenTelemetry tracing
+func NewTracedReranker(inner reranker.Reranker, opts ...Option) (*TracedReranker, error) {
+	if inner == nil {
+		return nil, errors.New("tracing: inner reranker is required")
+	}
+
+	options := tracerOptions{provider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &TracedReranker{
+		inner:  inner,
+		tracer: options.provider.Tracer(InstrumentationName),
+	}, nil
+}
+
+// start opens a span carrying the common attributes
+func (t *TracedReranker) start(ctx context.Context, name string, numDocs int, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	attrs = append(attrs,
+		AttrModel.String(t.inner.GetModelName()),
+		AttrDocCount.Int(numDocs),
+	)
+	return t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// end records the duration and outcome of a call and closes its span
+func end(span trace.Span, start time.Time, err error) {
+	span.SetAttributes(AttrDurationMS.Float64(float64(time.Since(start).Microseconds()) / 1000))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Rerank delegates to the wrapped reranker inside a span
+func (t *TracedReranker) Rerank(ctx context.Context, query string, documents []reranker.Document) ([]reranker.Document, error) {
+	ctx, span := t.start(ctx, SpanRerank, len(documents))
+	start := time.Now()
+	reranked, err := t.inner.Rerank(ctx, query, documents)
+	end(span, start, err)
+	return reranked, err
+}
+
+// ComputeScore delegates to the wrapped reranker inside a span
+func (t *TracedReranker) ComputeScore(ctx context.Context, query string, documents []reranker.Document) ([]float64, error) {
+	ctx, span := t.start(ctx, SpanComputeScore, len(documents))
+	start := time.Now()
+	scores, err := t.inner.ComputeScore(ctx, query, documents)
+	end(span, start, err)
+	return scores, err
+}
+
+// Rank delegates to the wrapped reranker inside a span
+func (t *TracedReranker) Rank(ctx context.Context, query string, documents []reranker.Document, topN int) ([]reranker.RerankResult, error) {
+	ctx, span := t.start(ctx, SpanRank, len(documents), AttrTopN.Int(topN))
+	start := time.Now()
+	results, err := t.inner.Rank(ctx, query, documents, topN)
+	end(span, start, err)
+	return results, err
+}
+
+// Configure delegates to the wrapped reranker
+func (t *TracedReranker) Configure(config reranker.Config) error {
+	return t.inner.Configure(config)
+}
+
+// GetModelName returns the wrapped model name
+func (t *TracedReranker) GetModelName() string {
+	return t.inner.GetModelName()
+}
+
+// Version returns the wrapped reranker's version
+func (t *TracedReranker) Version() string {
+	return t.inner.Version()
+}
+
+// HealthCheck checks the wrapped reranker
+func (t *TracedReranker) HealthCheck(ctx context.Context) error {
+	return t.inner.HealthCheck(ctx)
+}
+
+// Close releases resources held by the wrapped reranker
+func (t *TracedReranker) Close() error {
+	switch closer := t.inner.(type) {
+	case interface{ Close() error }:
+		return closer.Close()
+	case interface{ Close() }:
+		closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newRecorder returns a tracer provider backed by an in-memory exporter
+func newRecorder() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+// spanAttributes indexes the attributes of a recorded span by key
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracedReranker_Rank(t *testing.T) {
+	tp, exporter := newRecorder()
+	inner := reranker.NewSimpleReranker(reranker.Config{Model: "simple"})
+	traced, err := NewTracedReranker(inner, WithTracing(tp))
+	if err != nil {
+		t.Fatalf("NewTracedReranker failed: %v", err)
+	}
+
+	documents := []reranker.Document{{ID: "1", Content: "machine learning"}, {ID: "2", Content: "cooking"}}
+	if _, err := traced.Rank(context.Background(), "machine learning", documents, 1); err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != SpanRank {
+		t.Errorf("Expected span %q, got %q", SpanRank, span.Name)
+	}
+
+	attrs := spanAttributes(span)
+	if got := attrs[AttrModel].AsString(); got != "simple" {
+		t.Errorf("Expected model attribute 'simple', got %q", got)
+	}
+	if got := attrs[AttrDocCount].AsInt64(); got != 2 {
+		t.Errorf("Expected doc_count 2, got %d", got)
+	}
+	if got := attrs[AttrTopN].AsInt64(); got != 1 {
+		t.Errorf("Expected top_n 1, got %d", got)
+	}
+	if _, ok := attrs[AttrDurationMS]; !ok {
+		t.Error("Expected duration_ms attribute")
+	}
+	if span.Status.Code == codes.Error {
+		t.Errorf("Expected non-error status, got %v", span.Status)
+	}
+}
+
+// contextCapturingReranker records the span context it was called with
+type contextCapturingReranker struct {
+	reranker.Reranker
+	seen trace.SpanContext
+}
+
+func (r *contextCapturingReranker) Rerank(ctx context.Context, query string, documents []reranker.Document) ([]reranker.Document, error) {
+	r.seen = trace.SpanContextFromContext(ctx)
+	return documents, nil
+}
+
+func (r *contextCapturingReranker) GetModelName() string { return "capturing" }
+
+func (r *contextCapturingReranker) Version() string { return "capturing@test" }
+
+func (r *contextCapturingReranker) HealthCheck(ctx context.Context) error { return nil }
+
+func TestTracedReranker_PropagatesContext(t *testing.T) {
+	tp, exporter := newRecorder()
+	inner := &contextCapturingReranker{}
+	traced, err := NewTracedReranker(inner, WithTracing(tp))
+	if err != nil {
+		t.Fatalf("NewTracedReranker failed: %v", err)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	if _, err := traced.Rerank(ctx, "query", []reranker.Document{{ID: "1", Content: "doc"}}); err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	child := spans[0]
+	if child.Name != SpanRerank {
+		t.Fatalf("Expected first ended span %q, got %q", SpanRerank, child.Name)
+	}
+	if child.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Expected reranker span to be a child of the caller's span")
+	}
+	if inner.seen.SpanID() != child.SpanContext.SpanID() {
+		t.Error("Expected the inner reranker to receive the reranker span's context")
+	}
+}
+
+// failingReranker always fails with the configured error
+type failingReranker struct {
+	reranker.Reranker
+	err error
+}
+
+func (r *failingReranker) ComputeScore(ctx context.Context, query string, documents []reranker.Document) ([]float64, error) {
+	return nil, r.err
+}
+
+func (r *failingReranker) GetModelName() string { return "failing" }
+
+func (r *failingReranker) Version() string { return "failing@test" }
+
+func (r *failingReranker) HealthCheck(ctx context.Context) error { return nil }
+
+func TestTracedReranker_Error(t *testing.T) {
+	tp, exporter := newRecorder()
+	inner := &failingReranker{err: fmt.Errorf("%w: backend down", reranker.ErrInference)}
+	traced, err := NewTracedReranker(inner, WithTracing(tp))
+	if err != nil {
+		t.Fatalf("NewTracedReranker failed: %v", err)
+	}
+
+	if _, err := traced.ComputeScore(context.Background(), "query", []reranker.Document{{ID: "1"}}); err == nil {
+		t.Fatal("Expected ComputeScore to fail")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != SpanComputeScore {
+		t.Errorf("Expected span %q, got %q", SpanComputeScore, spans[0].Name)
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("Expected error status, got %v", spans[0].Status)
+	}
+	if _, ok := spanAttributes(spans[0])[AttrTopN]; ok {
+		t.Error("Expected no top_n attribute on ComputeScore spans")
+	}
+}
+
+func TestNewTracedReranker_RequiresInner(t *testing.T) {
+	if _, err := NewTracedReranker(nil); err == nil {
+		t.Error("Expected error for nil inner reranker")
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// ElasticsearchContentField is the _source field ToElasticsearchBulk stores
+// document content in
+const ElasticsearchContentField = "content"
+
+// FromElasticsearchHits converts the hits.hits array of an Elasticsearch or
+// OpenSearch search response into documents. _id becomes Document.ID, _score
+// the pre-existing Document.Score, the contentField of _source the content,
+// and every other _source field is copied into Document.Meta.
+func FromElasticsearchHits(hits []map[string]interface{}, contentField string) []reranker.Document {
+	docs := make([]reranker.Document, 0, len(hits))
+	for _, hit := range hits {
+		doc := reranker.Document{}
+		if id, ok := hit["_id"].(string); ok {
+			doc.ID = id
+		}
+		if score, ok := hit["_score"].(float64); ok {
+			doc.Score = score
+		}
+
+		source, _ := hit["_source"].(map[string]interface{})
+		for field, value := range source {
+			if field == contentField {
+				if content, ok := value.(string); ok {
+					doc.Content = content
+				} else if value != nil {
+					doc.Content = fmt.Sprint(value)
+				}
+				continue
+			}
+			if doc.Meta == nil {
+				doc.Meta = make(map[string]interface{}, len(source))
+			}
+			doc.Meta[field] = value
+		}
+
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// ToElasticsearchBulk encodes docs as an NDJSON body for the _bulk API: an
+// index action carrying the document ID (omitted when empty, letting the
+// cluster assign one) followed by a _source of the Meta fields plus the
+// content under ElasticsearchContentField.
+func ToElasticsearchBulk(docs []reranker.Document) []byte {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, doc := range docs {
+		action := map[string]interface{}{}
+		if doc.ID != "" {
+			action["_id"] = doc.ID
+		}
+
+		source := make(map[string]interface{}, len(doc.Meta)+1)
+		for field, value := range doc.Meta {
+			source[field] = value
+		}
+		source[ElasticsearchContentField] = doc.Content
+
+		encoder.Encode(map[string]interface{}{"index": action})
+		if err := encoder.Encode(source); err != nil {
+			// Meta held a value JSON cannot represent; keep the action and
+			// source lines paired by indexing the content alone
+			encoder.Encode(map[string]interface{}{ElasticsearchContentField: doc.Content})
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,151 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// Defaults for ChromaDBConfig
+const (
+	DefaultChromaDBNResults = 50
+	defaultChromaDBTimeout  = 30 * time.Second
+)
+
+// ChromaDBConfig describes the ChromaDB collection candidates are retrieved from
+type ChromaDBConfig struct {
+	// URL is the ChromaDB base URL, e.g. "http://localhost:8000"
+	URL string `json:"url"`
+	// CollectionName is the collection queried
+	CollectionName string `json:"collection_name"`
+	// NResults is the number of candidates retrieved when a call passes 0 (default 50)
+	NResults int `json:"n_results,omitempty"`
+}
+
+// ChromaDBAdapter retrieves candidates from a ChromaDB collection over the v1
+// REST API and reranks them. The collection name is resolved to its ID, which
+// the query endpoint expects. Results become documents with ids as
+// Document.ID, documents as the content, 1 - distance as the initial
+// Document.Score, and metadatas plus Meta["chroma_distance"] in Meta.
+type ChromaDBAdapter struct {
+	config   ChromaDBConfig
+	reranker reranker.Reranker
+	client   *http.Client
+}
+
+// chromaCollection is a collection as listed or fetched by name
+type chromaCollection struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// chromaQueryResponse is the body of a query response; every field holds one
+// list per query embedding
+type chromaQueryResponse struct {
+	IDs       [][]string                 `json:"ids"`
+	Documents [][]*string                `json:"documents"`
+	Distances [][]float64                `json:"distances"`
+	Metadatas [][]map[string]interface{} `json:"metadatas"`
+}
+
+// NewChromaDBAdapter creates an adapter ranking ChromaDB candidates with r
+func NewChromaDBAdapter(config ChromaDBConfig, r reranker.Reranker) (*ChromaDBAdapter, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("%w: ChromaDB URL is required", reranker.ErrInvalidInput)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%w: ChromaDB adapter requires a reranker", reranker.ErrInvalidInput)
+	}
+	if config.NResults <= 0 {
+		config.NResults = DefaultChromaDBNResults
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	return &ChromaDBAdapter{
+		config:   config,
+		reranker: r,
+		client:   &http.Client{Timeout: defaultChromaDBTimeout},
+	}, nil
+}
+
+// ListCollections returns the names of all collections
+func (a *ChromaDBAdapter) ListCollections(ctx context.Context) ([]string, error) {
+	var collections []chromaCollection
+	if err := getJSON(ctx, a.client, "ChromaDB", a.config.URL+"/api/v1/collections", nil, &collections); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(collections))
+	for i, collection := range collections {
+		names[i] = collection.Name
+	}
+	return names, nil
+}
+
+// Query retrieves the nResults documents closest to queryEmbedding from the
+// configured collection; nResults of 0 uses the configured NResults
+func (a *ChromaDBAdapter) Query(ctx context.Context, queryEmbedding []float32, nResults int) ([]reranker.Document, error) {
+	if a.config.CollectionName == "" {
+		return nil, fmt.Errorf("%w: ChromaDB collection name is required", reranker.ErrInvalidInput)
+	}
+	if len(queryEmbedding) == 0 {
+		return nil, fmt.Errorf("%w: ChromaDB query embedding is required", reranker.ErrInvalidInput)
+	}
+	if nResults <= 0 {
+		nResults = a.config.NResults
+	}
+
+	var collection chromaCollection
+	if err := getJSON(ctx, a.client, "ChromaDB", a.config.URL+"/api/v1/collections/"+url.PathEscape(a.config.CollectionName), nil, &collection); err != nil {
+		return nil, err
+	}
+
+	request := map[string]interface{}{
+		"query_embeddings": [][]float32{queryEmbedding},
+		"n_results":        nResults,
+		"include":          []string{"documents", "metadatas", "distances"},
+	}
+	var response chromaQueryResponse
+	if err := postJSON(ctx, a.client, "ChromaDB", a.config.URL+"/api/v1/collections/"+url.PathEscape(collection.ID)+"/query", nil, request, &response); err != nil {
+		return nil, err
+	}
+	if len(response.IDs) == 0 {
+		return []reranker.Document{}, nil
+	}
+
+	ids := response.IDs[0]
+	documents := make([]reranker.Document, 0, len(ids))
+	for i, id := range ids {
+		doc := reranker.Document{ID: id, Meta: make(map[string]interface{})}
+		if len(response.Documents) > 0 && i < len(response.Documents[0]) && response.Documents[0][i] != nil {
+			doc.Content = *response.Documents[0][i]
+		}
+		if len(response.Distances) > 0 && i < len(response.Distances[0]) {
+			distance := response.Distances[0][i]
+			doc.Score = 1 - distance
+			doc.Meta["chroma_distance"] = distance
+		}
+		if len(response.Metadatas) > 0 && i < len(response.Metadatas[0]) {
+			for key, value := range response.Metadatas[0][i] {
+				doc.Meta[key] = value
+			}
+		}
+		documents = append(documents, doc)
+	}
+	return documents, nil
+}
+
+// RankQuery retrieves candidates closest to queryEmbedding and returns the
+// top-N reranked against query
+func (a *ChromaDBAdapter) RankQuery(ctx context.Context, query string, queryEmbedding []float32, topN int) ([]reranker.RerankResult, error) {
+	documents, err := a.Query(ctx, queryEmbedding, 0)
+	if err != nil {
+		return nil, err
+	}
+	return a.reranker.Rank(ctx, query, documents, topN)
+}
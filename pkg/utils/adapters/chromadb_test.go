@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+const chromaQueryResponseBody = `{
+  "ids": [["doc-1", "doc-2"]],
+  "documents": [["Cooking pasta at home", "Machine learning models learn from data"]],
+  "distances": [[0.25, 0.4]],
+  "metadatas": [[{"lang": "en"}, null]],
+  "embeddings": null
+}`
+
+// newChromaTestServer serves the articles collection (ID "c-123"), handing
+// each decoded query body to check
+func newChromaTestServer(t *testing.T, check func(body map[string]interface{})) *httptest.Server {
+	if check == nil {
+		check = func(map[string]interface{}) {}
+	}
+	return newJSONTestServer(t, "", "", map[string]jsonRoute{
+		"GET /api/v1/collections":              {response: `[{"id": "c-123", "name": "articles"}, {"id": "c-456", "name": "notes"}]`},
+		"GET /api/v1/collections/articles":     {response: `{"id": "c-123", "name": "articles", "metadata": null}`},
+		"POST /api/v1/collections/c-123/query": {response: chromaQueryResponseBody, check: check},
+	})
+}
+
+func newTestChromaDBAdapter(t *testing.T, url, collection string) *ChromaDBAdapter {
+	t.Helper()
+	adapter, err := NewChromaDBAdapter(ChromaDBConfig{
+		URL:            url,
+		CollectionName: collection,
+		NResults:       10,
+	}, reranker.NewSimpleReranker(reranker.Config{MaxDocs: 10}))
+	if err != nil {
+		t.Fatalf("NewChromaDBAdapter failed: %v", err)
+	}
+	return adapter
+}
+
+func TestChromaDBAdapter_Query(t *testing.T) {
+	server := newChromaTestServer(t, func(body map[string]interface{}) {
+		embeddings, _ := body["query_embeddings"].([]interface{})
+		if len(embeddings) != 1 || body["n_results"] != 5.0 {
+			t.Errorf("Expected one query embedding and 5 results, got %v", body)
+		}
+	})
+	defer server.Close()
+
+	documents, err := newTestChromaDBAdapter(t, server.URL, "articles").Query(context.Background(), []float32{0.5, 0.5}, 5)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(documents) != 2 {
+		t.Fatalf("Expected 2 documents, got %d", len(documents))
+	}
+
+	first := documents[0]
+	if first.ID != "doc-1" || first.Content != "Cooking pasta at home" || first.Score != 0.75 || first.Meta["lang"] != "en" || first.Meta["chroma_distance"] != 0.25 {
+		t.Errorf("Unexpected first document: %+v", first)
+	}
+	if documents[1].Score != 0.6 {
+		t.Errorf("Expected a score of 1 - distance, got %v", documents[1].Score)
+	}
+}
+
+func TestChromaDBAdapter_RankQuery(t *testing.T) {
+	server := newChromaTestServer(t, func(body map[string]interface{}) {
+		if body["n_results"] != 10.0 {
+			t.Errorf("Expected the configured n_results, got %v", body["n_results"])
+		}
+	})
+	defer server.Close()
+
+	results, err := newTestChromaDBAdapter(t, server.URL, "articles").RankQuery(context.Background(), "machine learning", []float32{0.5, 0.5}, 1)
+	if err != nil {
+		t.Fatalf("RankQuery failed: %v", err)
+	}
+	if len(results) != 1 || !strings.HasPrefix(results[0].Document.Content, "Machine learning") {
+		t.Errorf("Expected the machine learning document to be reranked first, got %+v", results)
+	}
+}
+
+func TestChromaDBAdapter_ListCollections(t *testing.T) {
+	server := newChromaTestServer(t, nil)
+	defer server.Close()
+
+	names, err := newTestChromaDBAdapter(t, server.URL, "").ListCollections(context.Background())
+	if err != nil {
+		t.Fatalf("ListCollections failed: %v", err)
+	}
+	if len(names) != 2 || names[0] != "articles" || names[1] != "notes" {
+		t.Errorf("Expected articles and notes, got %v", names)
+	}
+}
+
+func TestChromaDBAdapter_Errors(t *testing.T) {
+	server := newChromaTestServer(t, nil)
+	defer server.Close()
+
+	if _, err := newTestChromaDBAdapter(t, server.URL, "missing").Query(context.Background(), []float32{1}, 0); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected the HTTP status to be surfaced, got %v", err)
+	}
+	if _, err := newTestChromaDBAdapter(t, server.URL, "").Query(context.Background(), []float32{1}, 0); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a collection, got %v", err)
+	}
+	if _, err := newTestChromaDBAdapter(t, server.URL, "articles").Query(context.Background(), nil, 0); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a query embedding, got %v", err)
+	}
+	if _, err := NewChromaDBAdapter(ChromaDBConfig{}, reranker.NewSimpleReranker(reranker.Config{})); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without URL, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// postJSON sends body as JSON to endpoint and decodes the JSON response into
+// out. store names the service in errors; headers with an empty value are
+// not sent.
+func postJSON(ctx context.Context, client *http.Client, store, endpoint string, headers map[string]string, body, out interface{}) error {
+	return doJSON(ctx, client, store, http.MethodPost, endpoint, headers, body, out)
+}
+
+// getJSON fetches endpoint and decodes the JSON response into out
+func getJSON(ctx context.Context, client *http.Client, store, endpoint string, headers map[string]string, out interface{}) error {
+	return doJSON(ctx, client, store, http.MethodGet, endpoint, headers, nil, out)
+}
+
+// doJSON sends a request with an optional JSON body and decodes the JSON
+// response into out. Non-2xx responses are returned as errors carrying the
+// status and response body.
+func doJSON(ctx context.Context, client *http.Client, store, method, endpoint string, headers map[string]string, body, out interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("%w: failed to encode %s request: %v", reranker.ErrInvalidInput, store, err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("%w: failed to build request: %v", reranker.ErrInvalidInput, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		if value != "" {
+			req.Header.Set(key, value)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", store, err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", store, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %d: %s", store, resp.StatusCode, strings.TrimSpace(string(payload)))
+	}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", store, err)
+	}
+	return nil
+}
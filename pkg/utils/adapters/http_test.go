@@ -0,0 +1,78 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// jsonRoute is the canned response of one test server route
+type jsonRoute struct {
+	response string
+	// check, if set, receives the decoded JSON request body
+	check func(body map[string]interface{})
+}
+
+// newJSONTestServer answers "METHOD /path" routes with their response. When
+// header is set every request must carry it with value; requests matching no
+// route get a 404.
+func newJSONTestServer(t *testing.T, header, value string, routes map[string]jsonRoute) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if header != "" {
+			if got := req.Header.Get(header); got != value {
+				t.Errorf("Expected %s header %q, got %q", header, value, got)
+			}
+		}
+
+		route, ok := routes[req.Method+" "+req.URL.Path]
+		if !ok {
+			http.Error(w, `{"error": "not found"}`, http.StatusNotFound)
+			return
+		}
+		if route.check != nil {
+			var body map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+			route.check(body)
+		}
+		w.Write([]byte(route.response))
+	}))
+}
+
+func TestPostJSON(t *testing.T) {
+	server := newJSONTestServer(t, "X-Key", "secret", map[string]jsonRoute{
+		"POST /echo": {response: `{"ok": true}`, check: func(body map[string]interface{}) {
+			if body["name"] != "value" {
+				t.Errorf("Expected the encoded body, got %v", body)
+			}
+		}},
+		"GET /broken": {response: `not json`},
+	})
+	defer server.Close()
+
+	client := server.Client()
+	headers := map[string]string{"X-Key": "secret", "X-Unset": ""}
+	var out struct {
+		OK bool `json:"ok"`
+	}
+	if err := postJSON(context.Background(), client, "Test", server.URL+"/echo", headers, map[string]string{"name": "value"}, &out); err != nil || !out.OK {
+		t.Fatalf("postJSON failed: %v (%+v)", err, out)
+	}
+
+	if err := getJSON(context.Background(), client, "Test", server.URL+"/missing", headers, &out); err == nil || !strings.Contains(err.Error(), "Test returned 404") {
+		t.Errorf("Expected the HTTP status to be surfaced, got %v", err)
+	}
+	if err := getJSON(context.Background(), client, "Test", server.URL+"/broken", headers, &out); err == nil || !strings.Contains(err.Error(), "failed to parse Test response") {
+		t.Errorf("Expected a parse error, got %v", err)
+	}
+	if err := postJSON(context.Background(), client, "Test", server.URL+"/echo", headers, func() {}, &out); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for an unencodable body, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// Defaults for PineconeConfig
+const (
+	DefaultPineconeContentField = "content"
+	DefaultPineconeTopK         = 50
+	defaultPineconeTimeout      = 30 * time.Second
+)
+
+// PineconeConfig describes the Pinecone index candidates are retrieved from
+type PineconeConfig struct {
+	// APIKey is sent in the Api-Key header
+	APIKey string `json:"api_key"`
+	// Environment is the index environment, e.g. "us-east1-gcp"; together with
+	// IndexName it forms the host https://{IndexName}.svc.{Environment}.pinecone.io
+	Environment string `json:"environment,omitempty"`
+	// IndexName is the index host prefix, the index name followed by the
+	// project ID, e.g. "articles-abc1234"
+	IndexName string `json:"index_name,omitempty"`
+	// Host is the index URL, e.g. "https://articles-abc1234.svc.us-east1-gcp.pinecone.io";
+	// it takes precedence over IndexName and Environment
+	Host string `json:"host,omitempty"`
+	// Namespace is queried when set, otherwise the default namespace
+	Namespace string `json:"namespace,omitempty"`
+	// ContentField is the metadata key holding document text (default "content")
+	ContentField string `json:"content_field,omitempty"`
+	// TopK is the number of candidates retrieved when a call passes 0 (default 50)
+	TopK int `json:"top_k,omitempty"`
+}
+
+// PineconeAdapter retrieves candidates from Pinecone's Query API and reranks
+// them. Matches become documents with their text taken from the content
+// metadata field, the Pinecone similarity as the initial Document.Score and
+// in Meta["pinecone_score"], the vector in Document.Vector and the remaining
+// metadata in Meta.
+type PineconeAdapter struct {
+	config   PineconeConfig
+	reranker reranker.Reranker
+	client   *http.Client
+}
+
+// pineconeQueryResponse is the body of a query response
+type pineconeQueryResponse struct {
+	Matches []struct {
+		ID       string                 `json:"id"`
+		Score    float64                `json:"score"`
+		Values   []float32              `json:"values"`
+		Metadata map[string]interface{} `json:"metadata"`
+	} `json:"matches"`
+}
+
+// NewPineconeAdapter creates an adapter ranking Pinecone candidates with r
+func NewPineconeAdapter(config PineconeConfig, r reranker.Reranker) (*PineconeAdapter, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("%w: Pinecone API key is required", reranker.ErrInvalidInput)
+	}
+	if config.Host == "" {
+		if config.IndexName == "" || config.Environment == "" {
+			return nil, fmt.Errorf("%w: Pinecone host or index name and environment are required", reranker.ErrInvalidInput)
+		}
+		config.Host = fmt.Sprintf("https://%s.svc.%s.pinecone.io", config.IndexName, config.Environment)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%w: Pinecone adapter requires a reranker", reranker.ErrInvalidInput)
+	}
+	if config.ContentField == "" {
+		config.ContentField = DefaultPineconeContentField
+	}
+	if config.TopK <= 0 {
+		config.TopK = DefaultPineconeTopK
+	}
+	config.Host = strings.TrimRight(config.Host, "/")
+
+	return &PineconeAdapter{
+		config:   config,
+		reranker: r,
+		client:   &http.Client{Timeout: defaultPineconeTimeout},
+	}, nil
+}
+
+// Query retrieves the topK matches closest to queryVector from the configured
+// namespace; a topK of 0 uses the configured TopK
+func (a *PineconeAdapter) Query(ctx context.Context, queryVector []float32, topK int) ([]reranker.Document, error) {
+	if len(queryVector) == 0 {
+		return nil, fmt.Errorf("%w: Pinecone query vector is required", reranker.ErrInvalidInput)
+	}
+	if topK <= 0 {
+		topK = a.config.TopK
+	}
+
+	request := map[string]interface{}{
+		"vector":          queryVector,
+		"topK":            topK,
+		"includeMetadata": true,
+		"includeValues":   true,
+	}
+	if a.config.Namespace != "" {
+		request["namespace"] = a.config.Namespace
+	}
+	var response pineconeQueryResponse
+	if err := postJSON(ctx, a.client, "Pinecone", a.config.Host+"/query", map[string]string{"Api-Key": a.config.APIKey}, request, &response); err != nil {
+		return nil, err
+	}
+
+	documents := make([]reranker.Document, 0, len(response.Matches))
+	for _, match := range response.Matches {
+		doc := reranker.Document{
+			ID:     match.ID,
+			Score:  match.Score,
+			Vector: match.Values,
+			Meta:   map[string]interface{}{"pinecone_score": match.Score},
+		}
+		for key, value := range match.Metadata {
+			if key != a.config.ContentField {
+				doc.Meta[key] = value
+			}
+		}
+		if content, ok := match.Metadata[a.config.ContentField].(string); ok {
+			doc.Content = content
+		} else if value := match.Metadata[a.config.ContentField]; value != nil {
+			doc.Content = fmt.Sprint(value)
+		}
+		documents = append(documents, doc)
+	}
+	return documents, nil
+}
+
+// RankQuery retrieves candidates closest to queryVector and returns the top-N
+// reranked against query
+func (a *PineconeAdapter) RankQuery(ctx context.Context, query string, queryVector []float32, topN int) ([]reranker.RerankResult, error) {
+	documents, err := a.Query(ctx, queryVector, 0)
+	if err != nil {
+		return nil, err
+	}
+	return a.reranker.Rank(ctx, query, documents, topN)
+}
@@ -0,0 +1,126 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+const pineconeSampleResponse = `{
+  "matches": [
+    {"id": "doc-1", "score": 0.91, "values": [0.1, 0.2], "metadata": {"content": "Cooking pasta at home", "lang": "en"}},
+    {"id": "doc-2", "score": 0.87, "values": [0.3, 0.4], "metadata": {"content": "Machine learning models learn from data"}}
+  ],
+  "namespace": "articles",
+  "usage": {"readUnits": 5}
+}`
+
+// newPineconeTestServer answers queries with response, handing each decoded
+// request body to check
+func newPineconeTestServer(t *testing.T, response string, check func(body map[string]interface{})) *httptest.Server {
+	return newJSONTestServer(t, "Api-Key", "pinecone-key", map[string]jsonRoute{
+		"POST /query": {response: response, check: func(body map[string]interface{}) {
+			if body["includeMetadata"] != true || body["includeValues"] != true {
+				t.Errorf("Expected metadata and values to be requested, got %v", body)
+			}
+			if check != nil {
+				check(body)
+			}
+		}},
+	})
+}
+
+func newTestPineconeAdapter(t *testing.T, url string) *PineconeAdapter {
+	t.Helper()
+	adapter, err := NewPineconeAdapter(PineconeConfig{
+		APIKey:    "pinecone-key",
+		Host:      url,
+		Namespace: "articles",
+		TopK:      10,
+	}, reranker.NewSimpleReranker(reranker.Config{MaxDocs: 10}))
+	if err != nil {
+		t.Fatalf("NewPineconeAdapter failed: %v", err)
+	}
+	return adapter
+}
+
+func TestPineconeAdapter_Query(t *testing.T) {
+	server := newPineconeTestServer(t, pineconeSampleResponse, func(body map[string]interface{}) {
+		if body["topK"] != 5.0 || body["namespace"] != "articles" {
+			t.Errorf("Expected topK 5 in the articles namespace, got %v", body)
+		}
+		if vector, ok := body["vector"].([]interface{}); !ok || len(vector) != 2 {
+			t.Errorf("Expected a query vector, got %v", body["vector"])
+		}
+	})
+	defer server.Close()
+
+	documents, err := newTestPineconeAdapter(t, server.URL).Query(context.Background(), []float32{0.5, 0.5}, 5)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(documents) != 2 {
+		t.Fatalf("Expected 2 documents, got %d", len(documents))
+	}
+
+	first := documents[0]
+	if first.ID != "doc-1" || first.Content != "Cooking pasta at home" || first.Score != 0.91 || first.Meta["pinecone_score"] != 0.91 || first.Meta["lang"] != "en" {
+		t.Errorf("Unexpected first document: %+v", first)
+	}
+	if _, ok := first.Meta["content"]; ok {
+		t.Error("Expected the content field to be left out of Meta")
+	}
+	if len(first.Vector) != 2 || first.Vector[1] != 0.2 {
+		t.Errorf("Expected the match values to be kept, got %v", first.Vector)
+	}
+}
+
+func TestPineconeAdapter_RankQuery(t *testing.T) {
+	server := newPineconeTestServer(t, pineconeSampleResponse, func(body map[string]interface{}) {
+		if body["topK"] != 10.0 {
+			t.Errorf("Expected the configured topK, got %v", body["topK"])
+		}
+	})
+	defer server.Close()
+
+	results, err := newTestPineconeAdapter(t, server.URL).RankQuery(context.Background(), "machine learning", []float32{0.5, 0.5}, 1)
+	if err != nil {
+		t.Fatalf("RankQuery failed: %v", err)
+	}
+	if len(results) != 1 || !strings.HasPrefix(results[0].Document.Content, "Machine learning") {
+		t.Errorf("Expected the machine learning document to be reranked first, got %+v", results)
+	}
+}
+
+func TestPineconeAdapter_Errors(t *testing.T) {
+	server := newJSONTestServer(t, "", "", nil)
+	defer server.Close()
+
+	adapter := newTestPineconeAdapter(t, server.URL)
+	if _, err := adapter.Query(context.Background(), []float32{1}, 0); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected the HTTP status to be surfaced, got %v", err)
+	}
+	if _, err := adapter.Query(context.Background(), nil, 0); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a query vector, got %v", err)
+	}
+
+	simple := reranker.NewSimpleReranker(reranker.Config{})
+	if _, err := NewPineconeAdapter(PineconeConfig{Host: server.URL}, simple); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without an API key, got %v", err)
+	}
+	if _, err := NewPineconeAdapter(PineconeConfig{APIKey: "key", IndexName: "articles"}, simple); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a host or environment, got %v", err)
+	}
+
+	derived, err := NewPineconeAdapter(PineconeConfig{APIKey: "key", IndexName: "articles-abc1234", Environment: "us-east1-gcp"}, simple)
+	if err != nil {
+		t.Fatalf("NewPineconeAdapter failed: %v", err)
+	}
+	if derived.config.Host != "https://articles-abc1234.svc.us-east1-gcp.pinecone.io" {
+		t.Errorf("Unexpected host %q", derived.config.Host)
+	}
+}
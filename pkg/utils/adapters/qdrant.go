@@ -0,0 +1,182 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// Defaults for QdrantAdapterConfig
+const (
+	DefaultQdrantPayloadField = "content"
+	DefaultQdrantLimit        = 50
+	defaultQdrantTimeout      = 30 * time.Second
+)
+
+// QdrantAdapterConfig describes the Qdrant collection candidates are retrieved from
+type QdrantAdapterConfig struct {
+	// URL is the Qdrant base URL, e.g. "http://localhost:6333"
+	URL string `json:"url"`
+	// APIKey is sent in the api-key header when set
+	APIKey string `json:"api_key,omitempty"`
+	// CollectionName is searched when a call does not name a collection
+	CollectionName string `json:"collection_name,omitempty"`
+	// PayloadField is the payload key holding document text (default "content")
+	PayloadField string `json:"payload_field,omitempty"`
+	// Limit is the number of candidates retrieved when a call passes 0 (default 50)
+	Limit int `json:"limit,omitempty"`
+}
+
+// QdrantSparseSearch is the query of a sparse vector search: the named sparse
+// vector of the collection and the non-zero dimensions of the query
+type QdrantSparseSearch struct {
+	VectorName string    `json:"name"`
+	Indices    []uint32  `json:"indices"`
+	Values     []float32 `json:"values"`
+}
+
+// QdrantAdapter retrieves candidates from Qdrant's REST search endpoint and
+// reranks them. Points become documents with their text taken from the
+// payload field, the Qdrant similarity as the initial Document.Score and in
+// Meta["qdrant_score"], the vector in Document.Vector and the remaining
+// payload in Meta.
+type QdrantAdapter struct {
+	config   QdrantAdapterConfig
+	reranker reranker.Reranker
+	client   *http.Client
+}
+
+// qdrantSearchResponse is the body of a points search response
+type qdrantSearchResponse struct {
+	Result []struct {
+		ID      json.RawMessage        `json:"id"`
+		Score   float64                `json:"score"`
+		Payload map[string]interface{} `json:"payload"`
+		Vector  json.RawMessage        `json:"vector"`
+	} `json:"result"`
+}
+
+// NewQdrantAdapter creates an adapter ranking Qdrant candidates with r
+func NewQdrantAdapter(config QdrantAdapterConfig, r reranker.Reranker) (*QdrantAdapter, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("%w: Qdrant URL is required", reranker.ErrInvalidInput)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%w: Qdrant adapter requires a reranker", reranker.ErrInvalidInput)
+	}
+	if config.PayloadField == "" {
+		config.PayloadField = DefaultQdrantPayloadField
+	}
+	if config.Limit <= 0 {
+		config.Limit = DefaultQdrantLimit
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	return &QdrantAdapter{
+		config:   config,
+		reranker: r,
+		client:   &http.Client{Timeout: defaultQdrantTimeout},
+	}, nil
+}
+
+// Search retrieves the limit points of collectionName closest to queryVector.
+// An empty collectionName uses the configured collection and a limit of 0 the
+// configured limit.
+func (a *QdrantAdapter) Search(ctx context.Context, collectionName string, queryVector []float32, limit int) ([]reranker.Document, error) {
+	return a.search(ctx, collectionName, queryVector, limit)
+}
+
+// SparseSearch retrieves the limit points of collectionName closest to the
+// sparse query vector
+func (a *QdrantAdapter) SparseSearch(ctx context.Context, collectionName string, query QdrantSparseSearch, limit int) ([]reranker.Document, error) {
+	if query.VectorName == "" || len(query.Indices) != len(query.Values) {
+		return nil, fmt.Errorf("%w: sparse search needs a vector name and one value per index", reranker.ErrInvalidInput)
+	}
+	vector := map[string]interface{}{
+		"name":   query.VectorName,
+		"vector": map[string]interface{}{"indices": query.Indices, "values": query.Values},
+	}
+	return a.search(ctx, collectionName, vector, limit)
+}
+
+// RankSearch retrieves candidates closest to queryVector from the configured
+// collection and returns the top-N reranked against query
+func (a *QdrantAdapter) RankSearch(ctx context.Context, query string, queryVector []float32, topN int) ([]reranker.RerankResult, error) {
+	documents, err := a.Search(ctx, "", queryVector, 0)
+	if err != nil {
+		return nil, err
+	}
+	return a.reranker.Rank(ctx, query, documents, topN)
+}
+
+// RankSparseSearch retrieves candidates closest to the sparse query vector
+// from the configured collection and returns the top-N reranked against query
+func (a *QdrantAdapter) RankSparseSearch(ctx context.Context, query string, sparse QdrantSparseSearch, topN int) ([]reranker.RerankResult, error) {
+	documents, err := a.SparseSearch(ctx, "", sparse, 0)
+	if err != nil {
+		return nil, err
+	}
+	return a.reranker.Rank(ctx, query, documents, topN)
+}
+
+// search posts a points search with the given vector and converts the hits
+func (a *QdrantAdapter) search(ctx context.Context, collectionName string, vector interface{}, limit int) ([]reranker.Document, error) {
+	if collectionName == "" {
+		collectionName = a.config.CollectionName
+	}
+	if collectionName == "" {
+		return nil, fmt.Errorf("%w: Qdrant collection name is required", reranker.ErrInvalidInput)
+	}
+	if limit <= 0 {
+		limit = a.config.Limit
+	}
+
+	request := map[string]interface{}{
+		"vector":       vector,
+		"limit":        limit,
+		"with_payload": true,
+		"with_vector":  true,
+	}
+	endpoint := fmt.Sprintf("%s/collections/%s/points/search", a.config.URL, url.PathEscape(collectionName))
+	var response qdrantSearchResponse
+	if err := postJSON(ctx, a.client, "Qdrant", endpoint, map[string]string{"api-key": a.config.APIKey}, request, &response); err != nil {
+		return nil, err
+	}
+
+	documents := make([]reranker.Document, 0, len(response.Result))
+	for _, point := range response.Result {
+		// Point IDs are unsigned integers or UUID strings
+		doc := reranker.Document{
+			ID:    string(point.ID),
+			Score: point.Score,
+			Meta:  map[string]interface{}{"qdrant_score": point.Score},
+		}
+		var uuid string
+		if err := json.Unmarshal(point.ID, &uuid); err == nil {
+			doc.ID = uuid
+		}
+		for key, value := range point.Payload {
+			if key != a.config.PayloadField {
+				doc.Meta[key] = value
+			}
+		}
+		if content, ok := point.Payload[a.config.PayloadField].(string); ok {
+			doc.Content = content
+		} else if value := point.Payload[a.config.PayloadField]; value != nil {
+			doc.Content = fmt.Sprint(value)
+		}
+		// Named or sparse vectors are objects; only a plain dense vector is kept
+		var dense []float32
+		if err := json.Unmarshal(point.Vector, &dense); err == nil {
+			doc.Vector = dense
+		}
+		documents = append(documents, doc)
+	}
+	return documents, nil
+}
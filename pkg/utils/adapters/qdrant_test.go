@@ -0,0 +1,124 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+const qdrantSampleResponse = `{
+  "result": [
+    {"id": 7, "score": 0.91, "payload": {"content": "Cooking pasta at home", "lang": "en"}, "vector": [0.1, 0.2]},
+    {"id": "5c56c793-69f3-4fbf-87e6-c4bf54c28c26", "score": 0.87, "payload": {"content": "Machine learning models learn from data"}, "vector": [0.3, 0.4]}
+  ],
+  "status": "ok",
+  "time": 0.001
+}`
+
+// newQdrantTestServer answers searches on the articles collection with
+// response, handing each decoded request body to check
+func newQdrantTestServer(t *testing.T, response string, check func(body map[string]interface{})) *httptest.Server {
+	return newJSONTestServer(t, "api-key", "qdrant-key", map[string]jsonRoute{
+		"POST /collections/articles/points/search": {response: response, check: func(body map[string]interface{}) {
+			if body["with_payload"] != true || body["with_vector"] != true {
+				t.Errorf("Expected payload and vector to be requested, got %v", body)
+			}
+			if check != nil {
+				check(body)
+			}
+		}},
+	})
+}
+
+func newTestQdrantAdapter(t *testing.T, url string) *QdrantAdapter {
+	t.Helper()
+	adapter, err := NewQdrantAdapter(QdrantAdapterConfig{
+		URL:            url,
+		APIKey:         "qdrant-key",
+		CollectionName: "articles",
+		Limit:          10,
+	}, reranker.NewSimpleReranker(reranker.Config{MaxDocs: 10}))
+	if err != nil {
+		t.Fatalf("NewQdrantAdapter failed: %v", err)
+	}
+	return adapter
+}
+
+func TestQdrantAdapter_Search(t *testing.T) {
+	server := newQdrantTestServer(t, qdrantSampleResponse, func(body map[string]interface{}) {
+		if body["limit"] != 5.0 {
+			t.Errorf("Expected limit 5, got %v", body["limit"])
+		}
+		if vector, ok := body["vector"].([]interface{}); !ok || len(vector) != 2 {
+			t.Errorf("Expected a dense query vector, got %v", body["vector"])
+		}
+	})
+	defer server.Close()
+
+	documents, err := newTestQdrantAdapter(t, server.URL).Search(context.Background(), "articles", []float32{0.5, 0.5}, 5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(documents) != 2 {
+		t.Fatalf("Expected 2 documents, got %d", len(documents))
+	}
+
+	first := documents[0]
+	if first.ID != "7" || first.Content != "Cooking pasta at home" || first.Meta["lang"] != "en" || first.Score != 0.91 || first.Meta["qdrant_score"] != 0.91 {
+		t.Errorf("Unexpected first document: %+v", first)
+	}
+	if len(first.Vector) != 2 || first.Vector[1] != 0.2 {
+		t.Errorf("Expected the point vector to be kept, got %v", first.Vector)
+	}
+	if documents[1].ID != "5c56c793-69f3-4fbf-87e6-c4bf54c28c26" {
+		t.Errorf("Expected a UUID point ID, got %q", documents[1].ID)
+	}
+}
+
+func TestQdrantAdapter_RankSparseSearch(t *testing.T) {
+	server := newQdrantTestServer(t, qdrantSampleResponse, func(body map[string]interface{}) {
+		vector, _ := body["vector"].(map[string]interface{})
+		sparse, _ := vector["vector"].(map[string]interface{})
+		if vector["name"] != "text" || len(sparse["indices"].([]interface{})) != 2 || body["limit"] != 10.0 {
+			t.Errorf("Expected a named sparse query with the configured limit, got %v", body)
+		}
+	})
+	defer server.Close()
+
+	results, err := newTestQdrantAdapter(t, server.URL).RankSparseSearch(context.Background(), "machine learning",
+		QdrantSparseSearch{VectorName: "text", Indices: []uint32{3, 17}, Values: []float32{0.5, 1.2}}, 1)
+	if err != nil {
+		t.Fatalf("RankSparseSearch failed: %v", err)
+	}
+	if len(results) != 1 || !strings.HasPrefix(results[0].Document.Content, "Machine learning") {
+		t.Errorf("Expected the machine learning document to be reranked first, got %+v", results)
+	}
+}
+
+func TestQdrantAdapter_Errors(t *testing.T) {
+	server := newJSONTestServer(t, "", "", nil)
+	defer server.Close()
+
+	adapter := newTestQdrantAdapter(t, server.URL)
+	if _, err := adapter.Search(context.Background(), "", []float32{1}, 0); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected the HTTP status to be surfaced, got %v", err)
+	}
+	if _, err := adapter.SparseSearch(context.Background(), "", QdrantSparseSearch{VectorName: "text", Indices: []uint32{1}}, 0); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for mismatched sparse values, got %v", err)
+	}
+
+	noCollection, err := NewQdrantAdapter(QdrantAdapterConfig{URL: server.URL}, reranker.NewSimpleReranker(reranker.Config{}))
+	if err != nil {
+		t.Fatalf("NewQdrantAdapter failed: %v", err)
+	}
+	if _, err := noCollection.Search(context.Background(), "", []float32{1}, 0); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without a collection, got %v", err)
+	}
+	if _, err := NewQdrantAdapter(QdrantAdapterConfig{}, reranker.NewSimpleReranker(reranker.Config{})); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without URL, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package adapters retrieves candidate documents from external search systems
+// and reranks them.
+//
+// Every adapter sets Document.Score to the store's similarity, higher meaning
+// closer, so candidates compare across stores as inputs to fusion or hybrid
+// scoring. Stores reporting a distance use 1 - distance. The raw value is
+// kept in Meta under "<store>_score" or "<store>_distance", e.g.
+// Meta["qdrant_score"] or Meta["chroma_distance"].
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// Defaults for WeaviateConfig
+const (
+	DefaultWeaviateContentField = "content"
+	DefaultWeaviateLimit        = 50
+	defaultWeaviateTimeout      = 30 * time.Second
+)
+
+// WeaviateConfig describes the Weaviate class candidates are retrieved from
+type WeaviateConfig struct {
+	// URL is the Weaviate base URL, e.g. "http://localhost:8080"
+	URL string `json:"url"`
+	// APIKey is sent as a bearer token when set
+	APIKey string `json:"api_key,omitempty"`
+	// ClassName is the class queried, e.g. "Article"
+	ClassName string `json:"class_name"`
+	// ContentField is the property holding document text (default "content")
+	ContentField string `json:"content_field,omitempty"`
+	// Limit is the number of candidates retrieved (default 50)
+	Limit int `json:"limit,omitempty"`
+}
+
+// WeaviateAdapter retrieves candidates with a nearText or nearVector GraphQL
+// query and reranks them. It talks to the GraphQL endpoint over plain HTTP.
+// Hits become documents with _additional.id as Document.ID, 1 - distance as
+// the initial Document.Score and the distance in Meta["weaviate_distance"].
+type WeaviateAdapter struct {
+	config   WeaviateConfig
+	reranker reranker.Reranker
+	client   *http.Client
+}
+
+// weaviateResponse is the body of a GraphQL Get response
+type weaviateResponse struct {
+	Data struct {
+		Get map[string][]map[string]interface{} `json:"Get"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// NewWeaviateAdapter creates an adapter ranking Weaviate candidates with r
+func NewWeaviateAdapter(config WeaviateConfig, r reranker.Reranker) (*WeaviateAdapter, error) {
+	if config.URL == "" || config.ClassName == "" {
+		return nil, fmt.Errorf("%w: Weaviate URL and class name are required", reranker.ErrInvalidInput)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%w: Weaviate adapter requires a reranker", reranker.ErrInvalidInput)
+	}
+	if config.ContentField == "" {
+		config.ContentField = DefaultWeaviateContentField
+	}
+	if config.Limit <= 0 {
+		config.Limit = DefaultWeaviateLimit
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	return &WeaviateAdapter{
+		config:   config,
+		reranker: r,
+		client:   &http.Client{Timeout: defaultWeaviateTimeout},
+	}, nil
+}
+
+// NearText retrieves the candidates closest to concepts
+func (a *WeaviateAdapter) NearText(ctx context.Context, concepts ...string) ([]reranker.Document, error) {
+	encoded, err := json.Marshal(concepts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode concepts: %v", reranker.ErrInvalidInput, err)
+	}
+	return a.get(ctx, fmt.Sprintf("nearText: {concepts: %s}", encoded))
+}
+
+// NearVector retrieves the candidates closest to vector
+func (a *WeaviateAdapter) NearVector(ctx context.Context, vector []float32) ([]reranker.Document, error) {
+	values := make([]string, len(vector))
+	for i, value := range vector {
+		values[i] = strconv.FormatFloat(float64(value), 'g', -1, 32)
+	}
+	return a.get(ctx, fmt.Sprintf("nearVector: {vector: [%s]}", strings.Join(values, ", ")))
+}
+
+// RankNearText retrieves candidates for query with nearText and returns the
+// top-N reranked against query
+func (a *WeaviateAdapter) RankNearText(ctx context.Context, query string, topN int) ([]reranker.RerankResult, error) {
+	documents, err := a.NearText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return a.reranker.Rank(ctx, query, documents, topN)
+}
+
+// RankNearVector retrieves candidates closest to the query's vector and
+// returns the top-N reranked against query
+func (a *WeaviateAdapter) RankNearVector(ctx context.Context, query string, vector []float32, topN int) ([]reranker.RerankResult, error) {
+	documents, err := a.NearVector(ctx, vector)
+	if err != nil {
+		return nil, err
+	}
+	return a.reranker.Rank(ctx, query, documents, topN)
+}
+
+// get runs a Get query on the configured class with the given search
+// operator and converts the hits to documents. _additional.id becomes
+// Document.ID and _additional.distance is kept in Meta["weaviate_distance"].
+func (a *WeaviateAdapter) get(ctx context.Context, operator string) ([]reranker.Document, error) {
+	query := fmt.Sprintf("{ Get { %s(%s, limit: %d) { %s _additional { id distance } } } }",
+		a.config.ClassName, operator, a.config.Limit, a.config.ContentField)
+	headers := map[string]string{}
+	if a.config.APIKey != "" {
+		headers["Authorization"] = "Bearer " + a.config.APIKey
+	}
+
+	var response weaviateResponse
+	if err := postJSON(ctx, a.client, "Weaviate", a.config.URL+"/v1/graphql", headers, map[string]string{"query": query}, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("Weaviate query failed: %s", response.Errors[0].Message)
+	}
+
+	hits := response.Data.Get[a.config.ClassName]
+	documents := make([]reranker.Document, 0, len(hits))
+	for _, hit := range hits {
+		doc := reranker.Document{}
+		if content, ok := hit[a.config.ContentField].(string); ok {
+			doc.Content = content
+		} else if value := hit[a.config.ContentField]; value != nil {
+			doc.Content = fmt.Sprint(value)
+		}
+		if additional, ok := hit["_additional"].(map[string]interface{}); ok {
+			if id, ok := additional["id"].(string); ok {
+				doc.ID = id
+			}
+			if distance, ok := additional["distance"].(float64); ok {
+				doc.Score = 1 - distance
+				doc.Meta = map[string]interface{}{"weaviate_distance": distance}
+			}
+		}
+		documents = append(documents, doc)
+	}
+	return documents, nil
+}
@@ -0,0 +1,136 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-rerankers/pkg/reranker"
+)
+
+const weaviateSampleResponse = `{
+  "data": {
+    "Get": {
+      "Article": [
+        {"body": "Cooking pasta at home", "_additional": {"id": "id-1", "distance": 0.41}},
+        {"body": "Machine learning models learn from data", "_additional": {"id": "id-2", "distance": 0.12}}
+      ]
+    }
+  }
+}`
+
+// newWeaviateTestServer answers GraphQL queries with response, handing each
+// decoded query to check
+func newWeaviateTestServer(t *testing.T, response string, check func(query string)) *httptest.Server {
+	return newJSONTestServer(t, "Authorization", "Bearer weaviate-key", map[string]jsonRoute{
+		"POST /v1/graphql": {response: response, check: func(body map[string]interface{}) {
+			if check != nil {
+				query, _ := body["query"].(string)
+				check(query)
+			}
+		}},
+	})
+}
+
+func newTestWeaviateAdapter(t *testing.T, url string) *WeaviateAdapter {
+	t.Helper()
+	adapter, err := NewWeaviateAdapter(WeaviateConfig{
+		URL:          url,
+		APIKey:       "weaviate-key",
+		ClassName:    "Article",
+		ContentField: "body",
+		Limit:        10,
+	}, reranker.NewSimpleReranker(reranker.Config{MaxDocs: 10}))
+	if err != nil {
+		t.Fatalf("NewWeaviateAdapter failed: %v", err)
+	}
+	return adapter
+}
+
+func TestWeaviateAdapter_RankNearText(t *testing.T) {
+	server := newWeaviateTestServer(t, weaviateSampleResponse, func(query string) {
+		for _, want := range []string{`Article(nearText: {concepts: ["machine \"learning\""]}, limit: 10)`, "body", "_additional { id distance }"} {
+			if !strings.Contains(query, want) {
+				t.Errorf("Expected query to contain %q, got %s", want, query)
+			}
+		}
+	})
+	defer server.Close()
+
+	adapter := newTestWeaviateAdapter(t, server.URL)
+	results, err := adapter.RankNearText(context.Background(), `machine "learning"`, 1)
+	if err != nil {
+		t.Fatalf("RankNearText failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	doc := results[0].Document
+	if doc.ID != "id-2" || doc.Content != "Machine learning models learn from data" || doc.Meta["weaviate_distance"] != 0.12 || doc.Score != 1-0.12 {
+		t.Errorf("Unexpected top document: %+v", doc)
+	}
+}
+
+func TestWeaviateAdapter_NearVector(t *testing.T) {
+	server := newWeaviateTestServer(t, weaviateSampleResponse, func(query string) {
+		if !strings.Contains(query, "nearVector: {vector: [0.5, -1, 0.25]}") {
+			t.Errorf("Expected nearVector operator, got %s", query)
+		}
+	})
+	defer server.Close()
+
+	documents, err := newTestWeaviateAdapter(t, server.URL).NearVector(context.Background(), []float32{0.5, -1, 0.25})
+	if err != nil {
+		t.Fatalf("NearVector failed: %v", err)
+	}
+	if len(documents) != 2 || documents[0].ID != "id-1" || documents[0].Content != "Cooking pasta at home" {
+		t.Errorf("Unexpected documents: %+v", documents)
+	}
+}
+
+func TestWeaviateAdapter_GraphQLError(t *testing.T) {
+	server := newWeaviateTestServer(t, `{"errors": [{"message": "class Article not found"}]}`, nil)
+	defer server.Close()
+
+	_, err := newTestWeaviateAdapter(t, server.URL).NearText(context.Background(), "query")
+	if err == nil || !strings.Contains(err.Error(), "class Article not found") {
+		t.Errorf("Expected the GraphQL error to be surfaced, got %v", err)
+	}
+}
+
+func TestWeaviateAdapter_ContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := newTestWeaviateAdapter(t, server.URL).NearText(ctx, "query")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestNewWeaviateAdapter_Validation(t *testing.T) {
+	r := reranker.NewSimpleReranker(reranker.Config{})
+	if _, err := NewWeaviateAdapter(WeaviateConfig{URL: "http://localhost:8080"}, r); !errors.Is(err, reranker.ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput without class name, got %v", err)
+	}
+
+	adapter, err := NewWeaviateAdapter(WeaviateConfig{URL: "http://localhost:8080/", ClassName: "Article"}, r)
+	if err != nil {
+		t.Fatalf("NewWeaviateAdapter failed: %v", err)
+	}
+	if adapter.config.ContentField != DefaultWeaviateContentField || adapter.config.Limit != DefaultWeaviateLimit {
+		t.Errorf("Expected defaults to be applied, got %+v", adapter.config)
+	}
+}
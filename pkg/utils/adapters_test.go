@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+const sampleElasticsearchResponse = `{
+  "took": 3,
+  "timed_out": false,
+  "hits": {
+    "total": {"value": 2, "relation": "eq"},
+    "max_score": 7.25,
+    "hits": [
+      {
+        "_index": "articles",
+        "_id": "a1",
+        "_score": 7.25,
+        "_source": {"body": "Go is a statically typed language", "title": "Go", "year": 2009}
+      },
+      {
+        "_index": "articles",
+        "_id": "a2",
+        "_score": 3.5,
+        "_source": {"body": "Rust focuses on memory safety", "tags": ["systems", "safety"]}
+      }
+    ]
+  }
+}`
+
+func parseSampleHits(t *testing.T) []map[string]interface{} {
+	t.Helper()
+
+	var response struct {
+		Hits struct {
+			Hits []map[string]interface{} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal([]byte(sampleElasticsearchResponse), &response); err != nil {
+		t.Fatalf("Failed to parse sample response: %v", err)
+	}
+	return response.Hits.Hits
+}
+
+func TestFromElasticsearchHits(t *testing.T) {
+	docs := FromElasticsearchHits(parseSampleHits(t), "body")
+	if len(docs) != 2 {
+		t.Fatalf("Expected 2 documents, got %d", len(docs))
+	}
+
+	first := docs[0]
+	if first.ID != "a1" || first.Score != 7.25 || first.Content != "Go is a statically typed language" {
+		t.Errorf("Unexpected first document %+v", first)
+	}
+	if first.Meta["title"] != "Go" || first.Meta["year"] != float64(2009) {
+		t.Errorf("Expected remaining _source fields in Meta, got %v", first.Meta)
+	}
+	if _, ok := first.Meta["body"]; ok {
+		t.Error("Expected content field to be excluded from Meta")
+	}
+
+	tags, ok := docs[1].Meta["tags"].([]interface{})
+	if !ok || len(tags) != 2 || tags[0] != "systems" {
+		t.Errorf("Expected tags to be copied, got %v", docs[1].Meta["tags"])
+	}
+}
+
+func TestFromElasticsearchHits_MissingFields(t *testing.T) {
+	hits := []map[string]interface{}{{"_id": "x"}, {"_source": map[string]interface{}{"text": 42.0}}}
+	docs := FromElasticsearchHits(hits, "text")
+	if docs[0].ID != "x" || docs[0].Content != "" || docs[0].Meta != nil {
+		t.Errorf("Unexpected document for hit without _source: %+v", docs[0])
+	}
+	if docs[1].Content != "42" {
+		t.Errorf("Expected non-string content to be formatted, got %q", docs[1].Content)
+	}
+}
+
+func TestToElasticsearchBulk(t *testing.T) {
+	docs := []reranker.Document{
+		{ID: "a1", Content: "Go is a statically typed language", Meta: map[string]interface{}{"title": "Go"}},
+		{Content: "no id"},
+	}
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(ToElasticsearchBulk(docs)))
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("Expected an action and source line per document, got %d lines", len(lines))
+	}
+
+	action := lines[0]["index"].(map[string]interface{})
+	if action["_id"] != "a1" {
+		t.Errorf("Expected _id a1, got %v", action)
+	}
+	if lines[1][ElasticsearchContentField] != docs[0].Content || lines[1]["title"] != "Go" {
+		t.Errorf("Unexpected source %v", lines[1])
+	}
+	if _, ok := lines[2]["index"].(map[string]interface{})["_id"]; ok {
+		t.Error("Expected _id to be omitted for documents without an ID")
+	}
+}
+
+func TestElasticsearchRoundTrip(t *testing.T) {
+	original := FromElasticsearchHits(parseSampleHits(t), "body")
+	bulk := ToElasticsearchBulk(original)
+
+	var hits []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(bulk))
+	for scanner.Scan() {
+		var action map[string]map[string]interface{}
+		json.Unmarshal(scanner.Bytes(), &action)
+		scanner.Scan()
+		var source map[string]interface{}
+		json.Unmarshal(scanner.Bytes(), &source)
+		hits = append(hits, map[string]interface{}{"_id": action["index"]["_id"], "_source": source})
+	}
+
+	restored := FromElasticsearchHits(hits, ElasticsearchContentField)
+	if len(restored) != len(original) {
+		t.Fatalf("Expected %d documents, got %d", len(original), len(restored))
+	}
+	for i := range original {
+		if restored[i].ID != original[i].ID || restored[i].Content != original[i].Content || len(restored[i].Meta) != len(original[i].Meta) {
+			t.Errorf("Document %d: expected %+v, got %+v", i, original[i], restored[i])
+		}
+	}
+}
@@ -1,11 +1,19 @@
 package utils
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"go-rerankers/pkg/reranker"
 )
@@ -17,79 +25,276 @@ type TestData struct {
 	Instruction string   `json:"instruction,omitempty"`
 }
 
-// LoadTestData loads test data from a JSON file
+// LoadTestData loads test data from a JSON file, rejecting files that do not
+// match the test file schema (see ValidateTestData)
 func LoadTestData(filePath string) (*TestData, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read test file: %w", err)
 	}
 
+	testData, err := parseTestData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test file: %w", err)
+	}
+	return testData, nil
+}
+
+// parseTestData checks the shape of a JSON test case before decoding it, so
+// schema problems are reported by field name rather than as JSON type errors
+func parseTestData(data []byte) (*TestData, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("test case must be a JSON object: %w", err)
+	}
+
+	var query string
+	if err := json.Unmarshal(fields["query"], &query); err != nil {
+		return nil, errors.New(`field "query" must be a non-empty string`)
+	}
+
+	var documents []json.RawMessage
+	if err := json.Unmarshal(fields["documents"], &documents); err != nil || len(documents) == 0 {
+		return nil, errors.New(`field "documents" must be a non-empty array`)
+	}
+	for i, document := range documents {
+		var content string
+		if err := json.Unmarshal(document, &content); err != nil || string(document) == "null" {
+			return nil, fmt.Errorf(`field "documents[%d]" must be a string`, i)
+		}
+	}
+
+	if instruction, present := fields["instruction"]; present {
+		var value string
+		if err := json.Unmarshal(instruction, &value); err != nil || string(instruction) == "null" {
+			return nil, errors.New(`field "instruction" must be a string`)
+		}
+	}
+
 	var testData TestData
 	if err := json.Unmarshal(data, &testData); err != nil {
-		return nil, fmt.Errorf("failed to parse test file: %w", err)
+		return nil, fmt.Errorf("failed to parse test case: %w", err)
+	}
+	if err := ValidateTestData(&testData); err != nil {
+		return nil, err
 	}
-
 	return &testData, nil
 }
 
-// StringsToDocuments converts string slice to Document slice
-func StringsToDocuments(docs []string) []reranker.Document {
+// ValidateTestData checks that a test case has a non-empty query and a
+// non-empty list of documents
+func ValidateTestData(data *TestData) error {
+	if data == nil {
+		return errors.New("test case is missing")
+	}
+	if strings.TrimSpace(data.Query) == "" {
+		return errors.New(`field "query" must be a non-empty string`)
+	}
+	if len(data.Documents) == 0 {
+		return errors.New(`field "documents" must be a non-empty array`)
+	}
+	return nil
+}
+
+// maxJSONLLineBytes bounds the length of a single JSONL test case
+const maxJSONLLineBytes = 64 << 20
+
+// LoadTestDataJSONL loads test cases from a JSON Lines file, one TestData
+// object per line, validated like LoadTestData. Blank lines are skipped.
+func LoadTestDataJSONL(filePath string) ([]*TestData, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test file: %w", err)
+	}
+	defer file.Close()
+
+	var cases []*TestData
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineBytes)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		testData, err := parseTestData(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid test file line %d: %w", lineNumber, err)
+		}
+		cases = append(cases, testData)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read test file after line %d: %w", lineNumber, err)
+	}
+
+	return cases, nil
+}
+
+// StringsToDocuments converts string slice to Document slice with UUID v4 IDs.
+// When an optional config sets Options["detect_language"], each document's
+// Language is detected from its content.
+func StringsToDocuments(docs []string, config ...reranker.Config) []reranker.Document {
+	var converter DocumentConverter
+	if len(config) > 0 {
+		converter = NewDocumentConverter(config[0])
+	}
+	return converter.Convert(docs)
+}
+
+// DocumentConverter builds documents and fills in missing IDs
+type DocumentConverter struct {
+	// IDGenerator returns the ID for a document without one (default: NewUUID)
+	IDGenerator func() string
+	// DetectLanguage sets Language on documents without one using DetectLanguage
+	DetectLanguage bool
+}
+
+// NewDocumentConverter creates a converter with UUID IDs that detects
+// languages when config sets Options["detect_language"]
+func NewDocumentConverter(config reranker.Config) DocumentConverter {
+	return DocumentConverter{DetectLanguage: reranker.OptionBool(config.Options, "detect_language", false)}
+}
+
+// Convert converts string slice to Document slice with generated IDs
+func (c DocumentConverter) Convert(docs []string) []reranker.Document {
 	documents := make([]reranker.Document, len(docs))
 	for i, doc := range docs {
-		documents[i] = reranker.Document{
-			ID:      fmt.Sprintf("doc_%d", i+1),
-			Content: doc,
+		documents[i] = reranker.Document{Content: doc}
+	}
+	return c.AssignIDs(documents)
+}
+
+// AssignIDs generates an ID for every document whose ID is empty, and detects
+// missing languages when enabled, in place
+func (c DocumentConverter) AssignIDs(documents []reranker.Document) []reranker.Document {
+	generate := c.IDGenerator
+	if generate == nil {
+		generate = NewUUID
+	}
+	for i := range documents {
+		if documents[i].ID == "" {
+			documents[i].ID = generate()
+		}
+		if c.DetectLanguage && documents[i].Language == "" {
+			documents[i].Language = DetectLanguage(documents[i].Content)
 		}
 	}
 	return documents
 }
-// GetDevice detects the best available device for inference
-func GetDevice() string {
-	// TODO: Add actual device detection logic
-	// For now, return "cpu" as default
-	switch runtime.GOOS {
-	case "darwin":
-		// On macOS, check for Metal Performance Shaders availability
-		return "cpu" // Default to CPU for now
-	case "linux", "windows":
-		// Check for CUDA availability
-		return "cpu" // Default to CPU for now
-	default:
-		return "cpu"
+
+// SequentialIDGenerator returns a generator producing prefix_1, prefix_2, ...
+func SequentialIDGenerator(prefix string) func() string {
+	next := 0
+	return func() string {
+		next++
+		return fmt.Sprintf("%s_%d", prefix, next)
 	}
 }
 
+// NewUUID returns a random (version 4, RFC 4122 variant) UUID string
+func NewUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes for UUID: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// GetDevice detects the best available device for inference: "cuda", "metal" or "cpu"
+func GetDevice() string {
+	return reranker.DetectDevice()
+}
+
 // BenchmarkResult represents the result of a benchmark run
 type BenchmarkResult struct {
-	ModelName   string        `json:"model_name"`
-	Duration    time.Duration `json:"duration"`
-	DocsPerSec  float64       `json:"docs_per_sec"`
-	AvgScore    float64       `json:"avg_score"`
-	NumDocs     int           `json:"num_docs"`
-	Error       string        `json:"error,omitempty"`
+	ModelName  string        `json:"model_name"`
+	Duration   time.Duration `json:"duration"`
+	DocsPerSec float64       `json:"docs_per_sec"`
+	AvgScore   float64       `json:"avg_score"`
+	NumDocs    int           `json:"num_docs"`
+	Error      string        `json:"error,omitempty"`
+
+	// Iterations is the number of measured iterations; the statistics below
+	// are over per-iteration durations, with a t-distribution 95% CI of the mean
+	Iterations       int           `json:"iterations,omitempty"`
+	DurationStdDev   time.Duration `json:"duration_stddev,omitempty"`
+	DocsPerSecStdDev float64       `json:"docs_per_sec_stddev,omitempty"`
+	DurationCI95Low  time.Duration `json:"duration_ci95_low,omitempty"`
+	DurationCI95High time.Duration `json:"duration_ci95_high,omitempty"`
+
+	MemoryUsage MemoryUsage `json:"memory_usage"`
+
+	// Timestamp is when WriteBenchmarkReport recorded the result
+	Timestamp time.Time `json:"timestamp,omitempty"`
+}
+
+// MemoryUsage is the Go heap growth across measured Rank calls, from
+// runtime.MemStats.HeapInuse sampled before and after each call. It covers
+// only this process: rerankers that run inference in a subprocess (such as
+// llama-embedding) allocate their model memory there, so for them it reflects
+// the Go-side overhead of a call, not the model's footprint. A garbage
+// collection during a call can make a delta negative.
+type MemoryUsage struct {
+	// PeakHeapDelta is the largest per-call increase, in bytes
+	PeakHeapDelta int64 `json:"peak_heap_delta"`
+	// AvgHeapDelta is the mean per-call change, in bytes
+	AvgHeapDelta int64 `json:"avg_heap_delta"`
 }
 
-// BenchmarkReranker runs a performance benchmark on a reranker
-func BenchmarkReranker(r reranker.Reranker, query string, documents []reranker.Document, iterations int) *BenchmarkResult {
+// BenchmarkReranker runs a performance benchmark on a reranker. Duration and
+// DocsPerSec cover all measured iterations. When an optional config sets
+// Options["benchmark_warmup_iters"], that many extra iterations run first and
+// are excluded from every statistic. Options["benchmark_gc_before"] runs a
+// garbage collection before each iteration for cleaner memory measurements.
+func BenchmarkReranker(r reranker.Reranker, query string, documents []reranker.Document, iterations int, config ...reranker.Config) *BenchmarkResult {
 	if iterations <= 0 {
 		iterations = 1
 	}
 
+	warmupIters := 0
+	gcBefore := false
+	if len(config) > 0 {
+		warmupIters = benchmarkWarmupIters(config[0].Options)
+		gcBefore = benchmarkGCBefore(config[0].Options)
+	}
+
 	result := &BenchmarkResult{
 		ModelName: r.GetModelName(),
 		NumDocs:   len(documents),
 	}
 
-	start := time.Now()
 	var totalScore float64
 	var successfulRuns int
+	var durations []time.Duration
+	var heapDeltas []int64
+	var memStats runtime.MemStats
+
+	for i := 0; i < warmupIters+iterations; i++ {
+		if gcBefore {
+			runtime.GC()
+		}
+		runtime.ReadMemStats(&memStats)
+		heapBefore := memStats.HeapInuse
 
-	for i := 0; i < iterations; i++ {
+		start := time.Now()
 		ranked, err := r.Rank(nil, query, documents, len(documents))
+		elapsed := time.Since(start)
+
+		runtime.ReadMemStats(&memStats)
+		heapDelta := int64(memStats.HeapInuse) - int64(heapBefore)
 		if err != nil {
 			result.Error = err.Error()
 			break
 		}
+		if i < warmupIters {
+			continue
+		}
+		durations = append(durations, elapsed)
+		heapDeltas = append(heapDeltas, heapDelta)
 
 		// Calculate average score for this run
 		var runScore float64
@@ -103,33 +308,374 @@ func BenchmarkReranker(r reranker.Reranker, query string, documents []reranker.D
 		}
 	}
 
-	duration := time.Since(start)
-	result.Duration = duration
+	for _, elapsed := range durations {
+		result.Duration += elapsed
+	}
+	result.Iterations = len(durations)
+	if len(durations) > 0 {
+		_, result.DurationStdDev, result.DurationCI95Low, result.DurationCI95High = durationStats(durations)
+
+		rates := make([]float64, len(durations))
+		for i, elapsed := range durations {
+			rates[i] = float64(result.NumDocs) / elapsed.Seconds()
+		}
+		_, result.DocsPerSecStdDev = meanStdDev(rates)
+	}
+	result.MemoryUsage = heapUsage(heapDeltas)
 
 	if successfulRuns > 0 {
 		result.AvgScore = totalScore / float64(successfulRuns)
 		docsProcessed := float64(result.NumDocs * successfulRuns)
-		result.DocsPerSec = docsProcessed / duration.Seconds()
+		result.DocsPerSec = docsProcessed / result.Duration.Seconds()
 	}
 
 	return result
 }
 
+// WriteBenchmarkReport appends results to a JSON Lines file, one result per
+// line, creating the file when missing. Results without a Timestamp are
+// recorded with the current time; the results themselves are not modified.
+func WriteBenchmarkReport(results []*BenchmarkResult, path string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open benchmark report: %w", err)
+	}
+
+	now := time.Now().UTC()
+	encoder := json.NewEncoder(file)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		record := *result
+		if record.Timestamp.IsZero() {
+			record.Timestamp = now
+		}
+		if err := encoder.Encode(record); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write benchmark report: %w", err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write benchmark report: %w", err)
+	}
+	return nil
+}
+
+// ReadBenchmarkHistory reads the results appended by WriteBenchmarkReport, in
+// file order; blank lines are skipped
+func ReadBenchmarkHistory(path string) ([]*BenchmarkResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read benchmark history: %w", err)
+	}
+	defer file.Close()
+
+	var history []*BenchmarkResult
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineBytes)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var result BenchmarkResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			return nil, fmt.Errorf("invalid benchmark history line %d: %w", lineNumber, err)
+		}
+		history = append(history, &result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read benchmark history after line %d: %w", lineNumber, err)
+	}
+	return history, nil
+}
+
+// DetectRegression reports whether current took more than thresholdPct
+// percent longer than baseline. Missing or failed runs are not regressions.
+func DetectRegression(current, baseline *BenchmarkResult, thresholdPct float64) bool {
+	if current == nil || baseline == nil || current.Error != "" || baseline.Error != "" {
+		return false
+	}
+	return float64(current.Duration) > float64(baseline.Duration)*(1+thresholdPct/100)
+}
+
+// heapUsage summarizes per-call heap deltas
+func heapUsage(deltas []int64) MemoryUsage {
+	if len(deltas) == 0 {
+		return MemoryUsage{}
+	}
+
+	usage := MemoryUsage{PeakHeapDelta: deltas[0]}
+	var total int64
+	for _, delta := range deltas {
+		if delta > usage.PeakHeapDelta {
+			usage.PeakHeapDelta = delta
+		}
+		total += delta
+	}
+	usage.AvgHeapDelta = total / int64(len(deltas))
+	return usage
+}
+
+// benchmarkGCBefore reads the "benchmark_gc_before" option, accepting bool
+// and strings such as "true"
+func benchmarkGCBefore(opts map[string]interface{}) bool {
+	return reranker.OptionBool(opts, "benchmark_gc_before", false)
+}
+
+// formatBytes renders a signed byte count with a binary unit
+func formatBytes(n int64) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	if n < 1024 {
+		return fmt.Sprintf("%s%d B", sign, n)
+	}
+	value, unit := float64(n)/1024, "KiB"
+	for _, next := range []string{"MiB", "GiB"} {
+		if value < 1024 {
+			break
+		}
+		value, unit = value/1024, next
+	}
+	return fmt.Sprintf("%s%.1f %s", sign, value, unit)
+}
+
+// benchmarkWarmupIters reads the non-negative "benchmark_warmup_iters" option,
+// accepting int, float64 (JSON) and numeric strings
+func benchmarkWarmupIters(opts map[string]interface{}) int {
+	value := reranker.OptionInt(opts, "benchmark_warmup_iters", 0)
+	if value < 0 {
+		return 0
+	}
+	return value
+}
+
+// meanStdDev returns the mean and sample standard deviation of values
+// (0 for fewer than two values)
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+// durationStats returns the mean, sample standard deviation and the bounds of
+// the two-sided 95% confidence interval of the mean of durations
+func durationStats(durations []time.Duration) (mean, stddev, low, high time.Duration) {
+	values := make([]float64, len(durations))
+	for i, duration := range durations {
+		values[i] = float64(duration)
+	}
+	meanValue, stddevValue := meanStdDev(values)
+
+	var halfWidth float64
+	if len(values) > 1 {
+		halfWidth = tCritical95(len(values)-1) * stddevValue / math.Sqrt(float64(len(values)))
+	}
+	return time.Duration(meanValue), time.Duration(stddevValue),
+		time.Duration(meanValue - halfWidth), time.Duration(meanValue + halfWidth)
+}
+
+// tTable95 holds two-sided 95% critical values of Student's t-distribution
+// for 1 to 30 degrees of freedom
+var tTable95 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tCritical95 returns the two-sided 95% critical t value for df degrees of
+// freedom, using the table up to 30 and tabulated values beyond
+func tCritical95(df int) float64 {
+	switch {
+	case df < 1:
+		return math.Inf(1)
+	case df <= len(tTable95):
+		return tTable95[df-1]
+	case df <= 40:
+		return 2.021
+	case df <= 60:
+		return 2.000
+	case df <= 120:
+		return 1.980
+	default:
+		return 1.960
+	}
+}
+
 // PrintResults prints reranking results in a formatted way
 func PrintResults(modelName string, results []reranker.RerankResult, topK int) {
 	fmt.Printf("\n=== %s Results ===\n", modelName)
-	
-	limit := len(results)
-	if topK > 0 && topK < limit {
-		limit = topK
+
+	WriteResults(os.Stdout, PlainTextWriter{}, results, topK)
+}
+
+// TopContributions returns up to n words with the largest positive and the
+// largest negative contributions, strongest first; ties are ordered by word
+func TopContributions(contributions map[string]float64, n int) (positive, negative []reranker.TermContribution) {
+	for word, weight := range contributions {
+		switch {
+		case weight > 0:
+			positive = append(positive, reranker.TermContribution{Token: word, Weight: weight})
+		case weight < 0:
+			negative = append(negative, reranker.TermContribution{Token: word, Weight: weight})
+		}
 	}
+	byStrength := func(terms []reranker.TermContribution) []reranker.TermContribution {
+		sort.Slice(terms, func(i, j int) bool {
+			if math.Abs(terms[i].Weight) != math.Abs(terms[j].Weight) {
+				return math.Abs(terms[i].Weight) > math.Abs(terms[j].Weight)
+			}
+			return terms[i].Token < terms[j].Token
+		})
+		if len(terms) > n {
+			terms = terms[:n]
+		}
+		return terms
+	}
+	return byStrength(positive), byStrength(negative)
+}
 
-	for i := 0; i < limit; i++ {
-		result := results[i]
-		fmt.Printf("%d. [%.4f] %s\n", i+1, result.Score, result.Document.Content)
+// PrintAttributions prints the top n positive and negative word contributions of a result
+func PrintAttributions(result reranker.RerankResult, contributions map[string]float64, n int) {
+	positive, negative := TopContributions(contributions, n)
+	fmt.Printf("  #%d %s\n", result.Rank, result.Document.ID)
+	for _, group := range []struct {
+		label string
+		terms []reranker.TermContribution
+	}{{"+", positive}, {"-", negative}} {
+		parts := make([]string, len(group.terms))
+		for i, term := range group.terms {
+			parts[i] = fmt.Sprintf("%s (%+.4f)", term.Token, term.Weight)
+		}
+		fmt.Printf("    %s %s\n", group.label, strings.Join(parts, ", "))
 	}
 }
 
+// DefaultHistogramBins is the number of bins the CLI uses for --histogram
+const DefaultHistogramBins = 10
+
+// histogramBarWidth is the length of the longest bar printed by PrintHistogram
+const histogramBarWidth = 40
+
+// HistogramBin counts the scores in [Low, High); the last bin includes High
+type HistogramBin struct {
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	Count int     `json:"count"`
+}
+
+// Histogram is the distribution of a batch of result scores
+type Histogram struct {
+	Bins   []HistogramBin `json:"bins"`
+	Mean   float64        `json:"mean"`
+	Median float64        `json:"median"`
+	P90    float64        `json:"p90"`
+	P99    float64        `json:"p99"`
+}
+
+// ScoreHistogram splits the range of the result scores into bins of equal
+// width (DefaultHistogramBins when bins is not positive) and counts the
+// results in each. When every score is equal there is a single bin. The
+// percentiles interpolate linearly, as reranker.Percentile does.
+func ScoreHistogram(results []reranker.RerankResult, bins int) Histogram {
+	if len(results) == 0 {
+		return Histogram{}
+	}
+	if bins <= 0 {
+		bins = DefaultHistogramBins
+	}
+
+	scores := make([]float64, len(results))
+	low, high := results[0].Score, results[0].Score
+	for i, result := range results {
+		scores[i] = result.Score
+		low = math.Min(low, result.Score)
+		high = math.Max(high, result.Score)
+	}
+	if low == high {
+		bins = 1
+	}
+
+	histogram := Histogram{
+		Bins:   make([]HistogramBin, bins),
+		Median: reranker.Percentile(scores, 0.5),
+		P90:    reranker.Percentile(scores, 0.9),
+		P99:    reranker.Percentile(scores, 0.99),
+	}
+	histogram.Mean, _ = meanStdDev(scores)
+
+	width := (high - low) / float64(bins)
+	for i := range histogram.Bins {
+		histogram.Bins[i].Low = low + float64(i)*width
+		histogram.Bins[i].High = low + float64(i+1)*width
+	}
+	histogram.Bins[bins-1].High = high
+
+	for _, score := range scores {
+		bin := bins - 1
+		if width > 0 {
+			bin = int((score - low) / width)
+		}
+		// Correct for rounding so each score lands in the bin whose bounds hold it
+		for bin >= bins || (bin > 0 && score < histogram.Bins[bin].Low) {
+			bin--
+		}
+		for bin < bins-1 && score >= histogram.Bins[bin+1].Low {
+			bin++
+		}
+		histogram.Bins[bin].Count++
+	}
+	return histogram
+}
+
+// PrintHistogram prints an ASCII bar chart of the histogram and its statistics
+func PrintHistogram(histogram Histogram) {
+	fmt.Println("\n=== Score Distribution ===")
+	if len(histogram.Bins) == 0 {
+		fmt.Println("No scores")
+		return
+	}
+
+	maxCount := 0
+	for _, bin := range histogram.Bins {
+		if bin.Count > maxCount {
+			maxCount = bin.Count
+		}
+	}
+	for i, bin := range histogram.Bins {
+		closing := ")"
+		if i == len(histogram.Bins)-1 {
+			closing = "]"
+		}
+		bar := 0
+		if maxCount > 0 {
+			bar = int(math.Round(float64(bin.Count) / float64(maxCount) * histogramBarWidth))
+		}
+		fmt.Printf("[%8.4f, %8.4f%s %-*s %d\n", bin.Low, bin.High, closing, histogramBarWidth, strings.Repeat("#", bar), bin.Count)
+	}
+	fmt.Printf("Mean: %.4f  Median: %.4f  P90: %.4f  P99: %.4f\n", histogram.Mean, histogram.Median, histogram.P90, histogram.P99)
+}
+
 // PrintBenchmark prints benchmark results in a formatted way
 func PrintBenchmark(result *BenchmarkResult) {
 	fmt.Printf("\n=== Benchmark: %s ===\n", result.ModelName)
@@ -137,9 +683,132 @@ func PrintBenchmark(result *BenchmarkResult) {
 		fmt.Printf("Error: %s\n", result.Error)
 		return
 	}
-	
+
 	fmt.Printf("Duration: %v\n", result.Duration)
+	if result.Iterations > 1 {
+		mean := (result.DurationCI95Low + result.DurationCI95High) / 2
+		fmt.Printf("Per iteration: %v ± %v (95%% CI %v to %v, n=%d)\n",
+			mean, result.DurationStdDev, result.DurationCI95Low, result.DurationCI95High, result.Iterations)
+	}
 	fmt.Printf("Documents processed: %d\n", result.NumDocs)
 	fmt.Printf("Docs/second: %.2f\n", result.DocsPerSec)
+	if result.Iterations > 1 {
+		fmt.Printf("Docs/second std dev: %.2f\n", result.DocsPerSecStdDev)
+	}
 	fmt.Printf("Average score: %.4f\n", result.AvgScore)
+	fmt.Printf("Go heap per call: peak %s, average %s (excludes subprocess memory)\n",
+		formatBytes(result.MemoryUsage.PeakHeapDelta), formatBytes(result.MemoryUsage.AvgHeapDelta))
+}
+
+// DeduplicateDocuments removes near-duplicate documents whose word-shingle
+// Jaccard similarity to an earlier document exceeds threshold, keeping the
+// document with the lower original index
+func DeduplicateDocuments(docs []reranker.Document, threshold float64) []reranker.Document {
+	return reranker.DeduplicateDocuments(docs, threshold)
+}
+
+// vectorTerms lowercases text and splits it into letter/digit runs
+func vectorTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// BuildVocabulary picks the dim terms of docs with the highest total TF-IDF
+// weight (ties broken alphabetically) and returns each term's vector index
+// together with the smoothed IDF per index, idf = ln((1 + N) / (1 + df)) + 1.
+// idf always has dim entries; those past the corpus vocabulary are 0. Pass
+// both to VectorizeQuery to embed queries against the same corpus.
+func BuildVocabulary(docs []reranker.Document, dim int) (map[string]int, []float64) {
+	if dim < 0 {
+		dim = 0
+	}
+
+	documentFrequency := make(map[string]int)
+	termCounts := make(map[string]int)
+	for _, doc := range docs {
+		seen := make(map[string]bool)
+		for _, term := range vectorTerms(doc.Content) {
+			termCounts[term]++
+			if !seen[term] {
+				seen[term] = true
+				documentFrequency[term]++
+			}
+		}
+	}
+
+	n := float64(len(docs))
+	termIDF := func(term string) float64 {
+		return math.Log((1+n)/(1+float64(documentFrequency[term]))) + 1
+	}
+
+	terms := make([]string, 0, len(termCounts))
+	for term := range termCounts {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		wi := float64(termCounts[terms[i]]) * termIDF(terms[i])
+		wj := float64(termCounts[terms[j]]) * termIDF(terms[j])
+		if wi != wj {
+			return wi > wj
+		}
+		return terms[i] < terms[j]
+	})
+	if dim < len(terms) {
+		terms = terms[:dim]
+	}
+
+	vocab := make(map[string]int, len(terms))
+	idf := make([]float64, dim)
+	for i, term := range terms {
+		vocab[term] = i
+		idf[i] = termIDF(term)
+	}
+	return vocab, idf
+}
+
+// VectorizeQuery returns the unit-length TF-IDF vector of query over vocab,
+// with one dimension per IDF entry. Terms outside vocab are ignored, so a
+// query sharing no terms with the vocabulary gets the zero vector.
+func VectorizeQuery(query string, vocab map[string]int, idf []float64) []float32 {
+	weights := make([]float64, len(idf))
+	for _, term := range vectorTerms(query) {
+		if index, ok := vocab[term]; ok && index < len(weights) {
+			weights[index] += idf[index]
+		}
+	}
+
+	var norm float64
+	for _, weight := range weights {
+		norm += weight * weight
+	}
+	norm = math.Sqrt(norm)
+
+	vector := make([]float32, len(weights))
+	if norm == 0 {
+		return vector
+	}
+	for i, weight := range weights {
+		vector[i] = float32(weight / norm)
+	}
+	return vector
+}
+
+// VectorizeDocuments returns copies of docs with Vector set to deterministic
+// dim-dimensional TF-IDF embeddings over the corpus (see BuildVocabulary),
+// normalized to unit length. Vectors are padded with zeros when the corpus
+// has fewer than dim terms. They are meant for hybrid search demos and
+// tests, not as a substitute for a real embedding model.
+func VectorizeDocuments(docs []reranker.Document, dim int) []reranker.Document {
+	vectorized := make([]reranker.Document, len(docs))
+	copy(vectorized, docs)
+	if dim <= 0 {
+		return vectorized
+	}
+
+	vocab, idf := BuildVocabulary(docs, dim)
+	for i := range vectorized {
+		vectorized[i].Vector = VectorizeQuery(vectorized[i].Content, vocab, idf)
+	}
+	return vectorized
 }
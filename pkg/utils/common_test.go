@@ -1,7 +1,14 @@
 package utils
 
 import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
 	"go-rerankers/pkg/reranker"
 )
 
@@ -25,6 +32,49 @@ func TestStringsToDocuments(t *testing.T) {
 	}
 }
 
+func TestNewUUID_Unique(t *testing.T) {
+	const calls = 10000
+	seen := make(map[string]bool, calls)
+	for i := 0; i < calls; i++ {
+		id := NewUUID()
+		if seen[id] {
+			t.Fatalf("Duplicate UUID after %d calls: %s", i, id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestNewUUID_Format(t *testing.T) {
+	id := NewUUID()
+	parts := strings.Split(id, "-")
+	if len(id) != 36 || len(parts) != 5 {
+		t.Fatalf("Expected canonical UUID format, got %q", id)
+	}
+	if id[14] != '4' {
+		t.Errorf("Expected version 4, got %q", id)
+	}
+	if !strings.ContainsRune("89ab", rune(id[19])) {
+		t.Errorf("Expected RFC 4122 variant, got %q", id)
+	}
+}
+
+func TestDocumentConverter(t *testing.T) {
+	converter := DocumentConverter{IDGenerator: SequentialIDGenerator("doc")}
+	result := converter.Convert([]string{"First", "Second"})
+	if result[0].ID != "doc_1" || result[1].ID != "doc_2" {
+		t.Errorf("Expected sequential IDs, got %q and %q", result[0].ID, result[1].ID)
+	}
+
+	documents := []reranker.Document{{ID: "keep", Content: "a"}, {Content: "b"}}
+	DocumentConverter{}.AssignIDs(documents)
+	if documents[0].ID != "keep" {
+		t.Errorf("Expected existing ID to be kept, got %q", documents[0].ID)
+	}
+	if len(documents[1].ID) != 36 {
+		t.Errorf("Expected UUID for empty ID, got %q", documents[1].ID)
+	}
+}
+
 func TestBenchmarkReranker(t *testing.T) {
 	config := reranker.Config{
 		Model:   "simple",
@@ -72,8 +122,504 @@ func TestGetDevice(t *testing.T) {
 		t.Error("Expected non-empty device string")
 	}
 	
-	// Should return "cpu" for now as per implementation
-	if device != "cpu" {
-		t.Errorf("Expected 'cpu', got %s", device)
+	switch device {
+	case "cpu", "cuda", "metal":
+	default:
+		t.Errorf("Expected 'cpu', 'cuda' or 'metal', got %s", device)
+	}
+}
+
+func TestDeduplicateDocuments(t *testing.T) {
+	docs := []reranker.Document{
+		{ID: "1", Content: "The quick brown fox jumps over the lazy dog"},
+		{ID: "2", Content: "The quick brown fox jumps over the lazy dog"},
+		{ID: "3", Content: "The quick brown fox leaps over a sleepy cat"},
+	}
+
+	result := DeduplicateDocuments(docs, 0.8)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 documents after deduplication, got %d", len(result))
+	}
+	if result[0].ID != "1" {
+		t.Errorf("Expected the earlier duplicate to be kept, got %s", result[0].ID)
+	}
+	if result[1].ID != "3" {
+		t.Errorf("Expected similar document below threshold to be kept, got %s", result[1].ID)
+	}
+}
+
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cases.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	return path
+}
+
+func TestLoadTestDataJSONL_Empty(t *testing.T) {
+	cases, err := LoadTestDataJSONL(writeTestFile(t, ""))
+	if err != nil {
+		t.Fatalf("LoadTestDataJSONL failed: %v", err)
+	}
+	if len(cases) != 0 {
+		t.Errorf("Expected no test cases, got %d", len(cases))
+	}
+}
+
+func TestLoadTestDataJSONL_SingleLine(t *testing.T) {
+	path := writeTestFile(t, `{"query": "What is AI?", "documents": ["AI is...", "Cooking..."]}`+"\n\n")
+	cases, err := LoadTestDataJSONL(path)
+	if err != nil {
+		t.Fatalf("LoadTestDataJSONL failed: %v", err)
+	}
+	if len(cases) != 1 {
+		t.Fatalf("Expected 1 test case, got %d", len(cases))
+	}
+	if cases[0].Query != "What is AI?" || len(cases[0].Documents) != 2 {
+		t.Errorf("Unexpected test case: %+v", cases[0])
+	}
+}
+
+func TestLoadTestDataJSONL_MalformedLine(t *testing.T) {
+	content := `{"query": "first", "documents": ["a"]}` + "\n" +
+		"\n" +
+		`{"query": "third", "documents": [` + "\n"
+	_, err := LoadTestDataJSONL(writeTestFile(t, content))
+	if err == nil {
+		t.Fatal("Expected an error for a malformed line")
+	}
+	if !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected the error to name line 3, got %v", err)
+	}
+}
+
+func TestLoadTestDataJSONL_MissingFile(t *testing.T) {
+	if _, err := LoadTestDataJSONL(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestDurationStats(t *testing.T) {
+	durations := []time.Duration{
+		10 * time.Millisecond,
+		12 * time.Millisecond,
+		14 * time.Millisecond,
+		16 * time.Millisecond,
+		18 * time.Millisecond,
+	}
+
+	mean, stddev, low, high := durationStats(durations)
+	// Sample std dev is sqrt(10) ms; the CI half-width is t(4) * sd / sqrt(5) = 2.776 * sqrt(2) ms
+	wantStdDev := math.Sqrt(10) * float64(time.Millisecond)
+	wantHalf := 2.776 * math.Sqrt(2) * float64(time.Millisecond)
+	if mean != 14*time.Millisecond {
+		t.Errorf("Expected mean 14ms, got %v", mean)
+	}
+	if math.Abs(float64(stddev)-wantStdDev) > 1 {
+		t.Errorf("Expected std dev %v, got %v", time.Duration(wantStdDev), stddev)
+	}
+	if math.Abs(float64(low)-(float64(14*time.Millisecond)-wantHalf)) > 1 || math.Abs(float64(high)-(float64(14*time.Millisecond)+wantHalf)) > 1 {
+		t.Errorf("Expected CI 14ms ± %v, got %v to %v", time.Duration(wantHalf), low, high)
+	}
+
+	mean, stddev, low, high = durationStats([]time.Duration{time.Second})
+	if mean != time.Second || stddev != 0 || low != time.Second || high != time.Second {
+		t.Errorf("Expected a degenerate interval for one sample, got %v ± %v [%v, %v]", mean, stddev, low, high)
+	}
+}
+
+func TestTCritical95(t *testing.T) {
+	tests := map[int]float64{1: 12.706, 4: 2.776, 30: 2.042, 35: 2.021, 1000: 1.960}
+	for df, want := range tests {
+		if got := tCritical95(df); got != want {
+			t.Errorf("tCritical95(%d) = %f, want %f", df, got, want)
+		}
+	}
+}
+
+func TestBenchmarkReranker_WarmupIterations(t *testing.T) {
+	r := &countingReranker{Reranker: reranker.NewSimpleReranker(reranker.Config{Model: "simple"})}
+	documents := []reranker.Document{{ID: "1", Content: "machine learning"}}
+	config := reranker.Config{Options: map[string]interface{}{"benchmark_warmup_iters": 2}}
+
+	result := BenchmarkReranker(r, "machine learning", documents, 5, config)
+	if r.calls != 7 {
+		t.Errorf("Expected 2 warm-up and 5 measured iterations, got %d calls", r.calls)
+	}
+	if result.Iterations != 5 {
+		t.Errorf("Expected 5 measured iterations, got %d", result.Iterations)
+	}
+	if result.DurationCI95Low > result.DurationCI95High || result.DurationCI95High <= 0 {
+		t.Errorf("Expected a valid CI, got %v to %v", result.DurationCI95Low, result.DurationCI95High)
+	}
+}
+
+// countingReranker counts Rank calls made to the embedded reranker
+type countingReranker struct {
+	reranker.Reranker
+	calls int
+}
+
+func (r *countingReranker) Rank(ctx context.Context, query string, documents []reranker.Document, topN int) ([]reranker.RerankResult, error) {
+	r.calls++
+	return r.Reranker.Rank(ctx, query, documents, topN)
+}
+
+func TestTopContributions(t *testing.T) {
+	contributions := map[string]float64{
+		"learning": 2.5, "machine": 1.0, "data": 1.0, "the": 0,
+		"cooking": -3.0, "pasta": -0.5,
+	}
+
+	positive, negative := TopContributions(contributions, 2)
+	if len(positive) != 2 || positive[0].Token != "learning" || positive[1].Token != "data" {
+		t.Errorf("Expected learning then data (ties by word), got %+v", positive)
+	}
+	if len(negative) != 2 || negative[0].Token != "cooking" || negative[1].Token != "pasta" {
+		t.Errorf("Expected cooking then pasta, got %+v", negative)
+	}
+}
+
+func TestLoadTestData_SchemaValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"not an object", `["query", "documents"]`, "must be a JSON object"},
+		{"missing query", `{"documents": ["a"]}`, `field "query" must be a non-empty string`},
+		{"empty query", `{"query": "  ", "documents": ["a"]}`, `field "query" must be a non-empty string`},
+		{"non-string query", `{"query": 42, "documents": ["a"]}`, `field "query" must be a non-empty string`},
+		{"missing documents", `{"query": "q"}`, `field "documents" must be a non-empty array`},
+		{"empty documents", `{"query": "q", "documents": []}`, `field "documents" must be a non-empty array`},
+		{"documents not an array", `{"query": "q", "documents": "a,b"}`, `field "documents" must be a non-empty array`},
+		{"non-string document", `{"query": "q", "documents": ["a", 7]}`, `field "documents[1]" must be a string`},
+		{"null document", `{"query": "q", "documents": [null]}`, `field "documents[0]" must be a string`},
+		{"non-string instruction", `{"query": "q", "documents": ["a"], "instruction": ["x"]}`, `field "instruction" must be a string`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTestData(writeTestFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	testData, err := LoadTestData(writeTestFile(t, `{"query": "q", "documents": ["a"], "instruction": "Find it", "model": "ignored"}`))
+	if err != nil {
+		t.Fatalf("Expected a valid test file to load, got %v", err)
+	}
+	if testData.Instruction != "Find it" || len(testData.Documents) != 1 {
+		t.Errorf("Unexpected test data: %+v", testData)
+	}
+}
+
+func TestLoadTestDataJSONL_SchemaValidation(t *testing.T) {
+	content := `{"query": "first", "documents": ["a"]}` + "\n" + `{"query": "second", "documents": []}` + "\n"
+	_, err := LoadTestDataJSONL(writeTestFile(t, content))
+	if err == nil || !strings.Contains(err.Error(), "line 2") || !strings.Contains(err.Error(), `field "documents"`) {
+		t.Errorf("Expected a schema error naming line 2, got %v", err)
+	}
+}
+
+func TestValidateTestData(t *testing.T) {
+	if err := ValidateTestData(&TestData{Query: "q", Documents: []string{"a"}}); err != nil {
+		t.Errorf("Expected valid test data, got %v", err)
+	}
+	for _, data := range []*TestData{nil, {Documents: []string{"a"}}, {Query: "q"}} {
+		if err := ValidateTestData(data); err == nil {
+			t.Errorf("Expected an error for %+v", data)
+		}
+	}
+}
+
+// cosine32 is the cosine similarity of two equal-length vectors
+func cosine32(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func TestVectorizeDocuments(t *testing.T) {
+	docs := StringsToDocuments([]string{
+		"Cats are small domestic animals that purr and chase mice.",
+		"Domestic cats purr, sleep a lot and chase mice at night.",
+		"The central bank raised interest rates to fight inflation.",
+		"Stock markets fell after the interest rate decision.",
+	})
+
+	vectorized := VectorizeDocuments(docs, 16)
+	if len(vectorized) != len(docs) {
+		t.Fatalf("Expected %d documents, got %d", len(docs), len(vectorized))
+	}
+	for i, doc := range vectorized {
+		if len(doc.Vector) != 16 {
+			t.Fatalf("Expected 16 dimensions for document %d, got %d", i, len(doc.Vector))
+		}
+		if norm := cosine32(doc.Vector, doc.Vector); math.Abs(norm-1) > 1e-6 {
+			t.Errorf("Expected unit-length vector for document %d", i)
+		}
+		if docs[i].Vector != nil {
+			t.Errorf("Expected input document %d to be left unchanged", i)
+		}
+	}
+
+	related := cosine32(vectorized[0].Vector, vectorized[1].Vector)
+	unrelated := cosine32(vectorized[0].Vector, vectorized[2].Vector)
+	if related <= unrelated {
+		t.Errorf("Expected related documents to be closer: related %.3f, unrelated %.3f", related, unrelated)
+	}
+
+	again := VectorizeDocuments(docs, 16)
+	for i := range again {
+		for j := range again[i].Vector {
+			if again[i].Vector[j] != vectorized[i].Vector[j] {
+				t.Fatalf("Expected identical vectors across runs for document %d", i)
+			}
+		}
+	}
+}
+
+func TestVectorizeDocuments_PadsSmallCorpus(t *testing.T) {
+	docs := StringsToDocuments([]string{"tiny corpus"})
+	if vocab, idf := BuildVocabulary(docs, 8); len(vocab) != 2 || len(idf) != 8 {
+		t.Fatalf("Expected 2 terms and 8 IDF entries, got %v and %v", vocab, idf)
+	}
+
+	vectorized := VectorizeDocuments(docs, 8)
+	if len(vectorized[0].Vector) != 8 {
+		t.Fatalf("Expected 8 dimensions, got %d", len(vectorized[0].Vector))
+	}
+	for _, value := range vectorized[0].Vector[2:] {
+		if value != 0 {
+			t.Errorf("Expected zero padding, got %v", vectorized[0].Vector)
+		}
+	}
+}
+
+func TestVectorizeQuery(t *testing.T) {
+	docs := StringsToDocuments([]string{
+		"Cats purr and chase mice.",
+		"Interest rates rose again.",
+	})
+	vocab, idf := BuildVocabulary(docs, 32)
+	vectorized := VectorizeDocuments(docs, len(idf))
+
+	query := VectorizeQuery("Why do cats chase mice?", vocab, idf)
+	if len(query) != len(idf) {
+		t.Fatalf("Expected %d dimensions, got %d", len(idf), len(query))
+	}
+	if cosine32(query, vectorized[0].Vector) <= cosine32(query, vectorized[1].Vector) {
+		t.Error("Expected the query closer to the document about cats")
+	}
+
+	empty := VectorizeQuery("unrelated words only", vocab, idf)
+	for _, value := range empty {
+		if value != 0 {
+			t.Fatalf("Expected the zero vector for out-of-vocabulary queries, got %v", empty)
+		}
+	}
+}
+
+// allocatingReranker keeps a new buffer alive on every Rank call
+type allocatingReranker struct {
+	reranker.Reranker
+	retained [][]byte
+}
+
+func (r *allocatingReranker) Rank(ctx context.Context, query string, documents []reranker.Document, topN int) ([]reranker.RerankResult, error) {
+	buffer := make([]byte, 8<<20)
+	for i := range buffer {
+		buffer[i] = 1
+	}
+	r.retained = append(r.retained, buffer)
+	return r.Reranker.Rank(ctx, query, documents, topN)
+}
+
+func TestBenchmarkReranker_MemoryUsage(t *testing.T) {
+	r := &allocatingReranker{Reranker: reranker.NewSimpleReranker(reranker.Config{Model: "simple"})}
+	documents := []reranker.Document{{ID: "1", Content: "machine learning"}}
+	config := reranker.Config{Options: map[string]interface{}{"benchmark_gc_before": true}}
+
+	result := BenchmarkReranker(r, "machine learning", documents, 3, config)
+	if result.MemoryUsage.PeakHeapDelta < 8<<20 {
+		t.Errorf("Expected a peak heap delta of at least 8 MiB, got %d", result.MemoryUsage.PeakHeapDelta)
+	}
+	if result.MemoryUsage.AvgHeapDelta <= 0 || result.MemoryUsage.AvgHeapDelta > result.MemoryUsage.PeakHeapDelta {
+		t.Errorf("Expected a positive average at most the peak, got %+v", result.MemoryUsage)
+	}
+}
+
+func TestHeapUsage(t *testing.T) {
+	if got := heapUsage(nil); got != (MemoryUsage{}) {
+		t.Errorf("Expected zero usage without samples, got %+v", got)
+	}
+	got := heapUsage([]int64{-100, 400, 300})
+	if got.PeakHeapDelta != 400 || got.AvgHeapDelta != 200 {
+		t.Errorf("Expected peak 400 and average 200, got %+v", got)
+	}
+}
+
+func TestBenchmarkGCBefore(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  bool
+	}{{nil, false}, {true, true}, {"true", true}, {"no", false}, {1, false}}
+	for _, tt := range tests {
+		if got := benchmarkGCBefore(map[string]interface{}{"benchmark_gc_before": tt.value}); got != tt.want {
+			t.Errorf("benchmarkGCBefore(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{0: "0 B", 512: "512 B", 1536: "1.5 KiB", 3 << 20: "3.0 MiB", -2048: "-2.0 KiB"}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+// scoredResults returns results carrying the given scores
+func scoredResults(scores ...float64) []reranker.RerankResult {
+	results := make([]reranker.RerankResult, len(scores))
+	for i, score := range scores {
+		results[i] = reranker.RerankResult{Score: score, Index: i}
+	}
+	return results
+}
+
+func TestScoreHistogram_Bins(t *testing.T) {
+	histogram := ScoreHistogram(scoredResults(0, 0.1, 0.25, 0.5, 0.5, 0.75, 0.99, 1), 4)
+
+	want := []HistogramBin{
+		{Low: 0, High: 0.25, Count: 2},
+		{Low: 0.25, High: 0.5, Count: 1},
+		{Low: 0.5, High: 0.75, Count: 2},
+		{Low: 0.75, High: 1, Count: 3},
+	}
+	if len(histogram.Bins) != len(want) {
+		t.Fatalf("Expected %d bins, got %d", len(want), len(histogram.Bins))
+	}
+	total := 0
+	for i, bin := range histogram.Bins {
+		if math.Abs(bin.Low-want[i].Low) > 1e-12 || math.Abs(bin.High-want[i].High) > 1e-12 || bin.Count != want[i].Count {
+			t.Errorf("Bin %d: expected %+v, got %+v", i, want[i], bin)
+		}
+		if i > 0 && bin.Low != histogram.Bins[i-1].High {
+			t.Errorf("Bin %d starts at %v, previous ends at %v", i, bin.Low, histogram.Bins[i-1].High)
+		}
+		total += bin.Count
+	}
+	if total != 8 {
+		t.Errorf("Expected all 8 results counted, got %d", total)
+	}
+
+	if math.Abs(histogram.Mean-0.51125) > 1e-9 || histogram.Median != 0.5 {
+		t.Errorf("Expected mean 0.51125 and median 0.5, got %v and %v", histogram.Mean, histogram.Median)
+	}
+	if histogram.P90 < histogram.Median || histogram.P99 < histogram.P90 || histogram.P99 > 1 {
+		t.Errorf("Expected ordered percentiles up to the maximum, got P90 %v and P99 %v", histogram.P90, histogram.P99)
+	}
+}
+
+func TestScoreHistogram_RoundingAndEdgeCases(t *testing.T) {
+	// Scores on bin boundaries that do not divide evenly in floating point
+	scores := []float64{-1, -0.7, -0.4, -0.1, 0.2, 0.5, 0.8, 1.1, 1.4, 1.7, 2}
+	histogram := ScoreHistogram(scoredResults(scores...), 10)
+	for i, bin := range histogram.Bins {
+		want := 1
+		if i == len(histogram.Bins)-1 {
+			// The last bin also holds the maximum
+			want = 2
+		}
+		if bin.Count != want {
+			t.Errorf("Bin %d [%v, %v): expected %d scores, got %d", i, bin.Low, bin.High, want, bin.Count)
+		}
+	}
+
+	equal := ScoreHistogram(scoredResults(0.3, 0.3, 0.3), 5)
+	if len(equal.Bins) != 1 || equal.Bins[0].Count != 3 || equal.Bins[0].Low != 0.3 || equal.Bins[0].High != 0.3 {
+		t.Errorf("Expected a single bin for equal scores, got %+v", equal.Bins)
+	}
+	if empty := ScoreHistogram(nil, 5); len(empty.Bins) != 0 {
+		t.Errorf("Expected no bins without results, got %+v", empty.Bins)
+	}
+	if defaults := ScoreHistogram(scoredResults(0, 1), 0); len(defaults.Bins) != DefaultHistogramBins {
+		t.Errorf("Expected %d bins by default, got %d", DefaultHistogramBins, len(defaults.Bins))
+	}
+}
+
+func TestDetectRegression(t *testing.T) {
+	baseline := &BenchmarkResult{ModelName: "m", Duration: 100 * time.Millisecond}
+	tests := []struct {
+		name      string
+		current   *BenchmarkResult
+		threshold float64
+		want      bool
+	}{
+		{"faster", &BenchmarkResult{Duration: 80 * time.Millisecond}, 10, false},
+		{"within threshold", &BenchmarkResult{Duration: 109 * time.Millisecond}, 10, false},
+		{"exactly at threshold", &BenchmarkResult{Duration: 110 * time.Millisecond}, 10, false},
+		{"over threshold", &BenchmarkResult{Duration: 111 * time.Millisecond}, 10, true},
+		{"zero threshold", &BenchmarkResult{Duration: 101 * time.Millisecond}, 0, true},
+		{"failed run", &BenchmarkResult{Duration: time.Second, Error: "boom"}, 10, false},
+		{"missing run", nil, 10, false},
+	}
+	for _, tt := range tests {
+		if got := DetectRegression(tt.current, baseline, tt.threshold); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+	if DetectRegression(baseline, nil, 10) {
+		t.Error("Expected no regression without a baseline")
+	}
+}
+
+func TestBenchmarkReport_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.jsonl")
+	recorded := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	first := []*BenchmarkResult{{ModelName: "a", Duration: time.Second, Timestamp: recorded}}
+	second := []*BenchmarkResult{nil, {ModelName: "b", Duration: 2 * time.Second}}
+	if err := WriteBenchmarkReport(first, path); err != nil {
+		t.Fatalf("WriteBenchmarkReport failed: %v", err)
+	}
+	if err := WriteBenchmarkReport(second, path); err != nil {
+		t.Fatalf("WriteBenchmarkReport failed: %v", err)
+	}
+	if !second[1].Timestamp.IsZero() {
+		t.Error("Expected the written results to be left unchanged")
+	}
+
+	history, err := ReadBenchmarkHistory(path)
+	if err != nil {
+		t.Fatalf("ReadBenchmarkHistory failed: %v", err)
+	}
+	if len(history) != 2 || history[0].ModelName != "a" || history[1].ModelName != "b" {
+		t.Fatalf("Expected results a and b appended in order, got %+v", history)
+	}
+	if !history[0].Timestamp.Equal(recorded) {
+		t.Errorf("Expected the existing timestamp kept, got %v", history[0].Timestamp)
+	}
+	if history[1].Timestamp.IsZero() || history[1].Duration != 2*time.Second {
+		t.Errorf("Expected a timestamp and the duration recorded, got %+v", history[1])
+	}
+
+	if err := os.WriteFile(path, []byte("{\"model_name\":\"a\"}\n\nnot json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadBenchmarkHistory(path); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected an error naming line 3, got %v", err)
 	}
 }
@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// DefaultCompareThreshold is the rank difference at which two rankings are
+// considered to disagree about a document
+const DefaultCompareThreshold = 2
+
+// ANSI escapes used to highlight agreement in comparison output
+const (
+	ansiGreen = "\033[32m"
+	ansiRed   = "\033[31m"
+	ansiReset = "\033[0m"
+)
+
+// RankComparison is a document's rank and score under two rankings; a rank of
+// 0 means the document is missing from that ranking
+type RankComparison struct {
+	ID       string  `json:"id"`
+	Content  string  `json:"content"`
+	RankA    int     `json:"rank_a"`
+	RankB    int     `json:"rank_b"`
+	ScoreA   float64 `json:"score_a"`
+	ScoreB   float64 `json:"score_b"`
+	Disagree bool    `json:"disagree"`
+}
+
+// ComparisonReport compares two rankings of the same documents
+type ComparisonReport struct {
+	// Documents are in ranking A order, followed by documents only ranked by B
+	Documents []RankComparison `json:"documents"`
+	// Spearman is the rank correlation over documents present in both rankings
+	Spearman float64 `json:"spearman"`
+	// DisagreementPercent is the share of Documents that disagree, 0-100
+	DisagreementPercent float64 `json:"disagreement_percent"`
+}
+
+// CompareRankings compares two rankings of the same documents, matched by
+// Document.ID, using DefaultCompareThreshold
+func CompareRankings(a, b []reranker.RerankResult) ComparisonReport {
+	return CompareRankingsWithThreshold(a, b, DefaultCompareThreshold)
+}
+
+// CompareRankingsWithThreshold is CompareRankings where a document disagrees
+// when its ranks differ by at least threshold or it is missing from one ranking
+func CompareRankingsWithThreshold(a, b []reranker.RerankResult, threshold int) ComparisonReport {
+	positions := make(map[string]int, len(a))
+	var report ComparisonReport
+	for i, result := range a {
+		positions[result.Document.ID] = len(report.Documents)
+		report.Documents = append(report.Documents, RankComparison{
+			ID:      result.Document.ID,
+			Content: result.Document.Content,
+			RankA:   resultRank(result, i),
+			ScoreA:  result.Score,
+		})
+	}
+	for i, result := range b {
+		pos, ok := positions[result.Document.ID]
+		if !ok {
+			pos = len(report.Documents)
+			report.Documents = append(report.Documents, RankComparison{ID: result.Document.ID, Content: result.Document.Content})
+		}
+		report.Documents[pos].RankB = resultRank(result, i)
+		report.Documents[pos].ScoreB = result.Score
+	}
+
+	var shared []RankComparison
+	disagreements := 0
+	for i := range report.Documents {
+		doc := &report.Documents[i]
+		if doc.RankA > 0 && doc.RankB > 0 {
+			shared = append(shared, *doc)
+			doc.Disagree = abs(doc.RankA-doc.RankB) >= threshold
+		} else {
+			doc.Disagree = true
+		}
+		if doc.Disagree {
+			disagreements++
+		}
+	}
+	if len(report.Documents) > 0 {
+		report.DisagreementPercent = 100 * float64(disagreements) / float64(len(report.Documents))
+	}
+	report.Spearman = spearman(shared)
+	return report
+}
+
+// spearman returns the Spearman rank correlation of documents ranked by both
+// rankings, re-ranking them 1..n within the shared set. Fewer than two
+// documents are trivially in agreement.
+func spearman(shared []RankComparison) float64 {
+	n := len(shared)
+	if n < 2 {
+		return 1.0
+	}
+
+	// Ranks within the shared set are the number of shared documents ranked higher, plus one
+	var sumSquares float64
+	for _, doc := range shared {
+		rankA, rankB := 1, 1
+		for _, other := range shared {
+			if other.RankA < doc.RankA {
+				rankA++
+			}
+			if other.RankB < doc.RankB {
+				rankB++
+			}
+		}
+		d := float64(rankA - rankB)
+		sumSquares += d * d
+	}
+	return 1 - 6*sumSquares/float64(n*(n*n-1))
+}
+
+// abs returns the absolute value of x
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// WriteComparison writes report as a table of both models' ranks and scores,
+// followed by summary statistics. With color set, agreeing rows are green and
+// disagreeing rows red.
+func WriteComparison(w io.Writer, report ComparisonReport, modelA, modelB string, color bool) error {
+	if _, err := fmt.Fprintf(w, "%-40s %6s %10s %6s %10s\n", "Document", "Rank A", "Score A", "Rank B", "Score B"); err != nil {
+		return err
+	}
+	for _, doc := range report.Documents {
+		line := fmt.Sprintf("%-40s %6s %10s %6s %10s",
+			truncateContent(doc.Content, 40),
+			formatRank(doc.RankA), formatScore(doc.RankA, doc.ScoreA),
+			formatRank(doc.RankB), formatScore(doc.RankB, doc.ScoreB))
+		if doc.Disagree {
+			line += " *"
+		}
+		if color {
+			code := ansiGreen
+			if doc.Disagree {
+				code = ansiRed
+			}
+			line = code + line + ansiReset
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "\nA: %s\nB: %s\nSpearman correlation: %.4f\nDisagreement: %.1f%%\n",
+		modelA, modelB, report.Spearman, report.DisagreementPercent)
+	return err
+}
+
+// formatRank renders a rank, or "-" for a document missing from a ranking
+func formatRank(rank int) string {
+	if rank == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", rank)
+}
+
+// formatScore renders the score of a ranked document, or "-" when unranked
+func formatScore(rank int, score float64) string {
+	if rank == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.4f", score)
+}
+
+// truncateContent shortens single-line content to at most width runes
+func truncateContent(content string, width int) string {
+	content = strings.Join(strings.Fields(content), " ")
+	runes := []rune(content)
+	if len(runes) <= width {
+		return content
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
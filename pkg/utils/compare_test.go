@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// ranking builds results for the given document IDs in rank order
+func ranking(ids ...string) []reranker.RerankResult {
+	results := make([]reranker.RerankResult, len(ids))
+	for i, id := range ids {
+		results[i] = reranker.RerankResult{
+			Document: reranker.Document{ID: id, Content: "content " + id},
+			Score:    float64(len(ids) - i),
+			Rank:     i + 1,
+		}
+	}
+	return results
+}
+
+func TestCompareRankings_Identical(t *testing.T) {
+	report := CompareRankings(ranking("a", "b", "c"), ranking("a", "b", "c"))
+	if report.Spearman != 1 {
+		t.Errorf("Expected Spearman 1 for identical rankings, got %v", report.Spearman)
+	}
+	if report.DisagreementPercent != 0 {
+		t.Errorf("Expected no disagreement, got %v%%", report.DisagreementPercent)
+	}
+}
+
+func TestCompareRankings_Reversed(t *testing.T) {
+	report := CompareRankings(ranking("a", "b", "c", "d"), ranking("d", "c", "b", "a"))
+	if math.Abs(report.Spearman+1) > 1e-9 {
+		t.Errorf("Expected Spearman -1 for reversed rankings, got %v", report.Spearman)
+	}
+	// a and d move 3 places, b and c only 1
+	if report.DisagreementPercent != 50 {
+		t.Errorf("Expected 50%% disagreement, got %v%%", report.DisagreementPercent)
+	}
+	if got := report.Documents[0]; got.ID != "a" || got.RankA != 1 || got.RankB != 4 || !got.Disagree {
+		t.Errorf("Unexpected comparison for a: %+v", got)
+	}
+	if got := report.Documents[1]; got.ID != "b" || got.Disagree {
+		t.Errorf("Expected b to agree within the threshold: %+v", got)
+	}
+}
+
+func TestCompareRankings_Threshold(t *testing.T) {
+	report := CompareRankingsWithThreshold(ranking("a", "b", "c", "d"), ranking("d", "c", "b", "a"), 1)
+	if report.DisagreementPercent != 100 {
+		t.Errorf("Expected every moved document to disagree at threshold 1, got %v%%", report.DisagreementPercent)
+	}
+}
+
+func TestCompareRankings_MissingDocuments(t *testing.T) {
+	report := CompareRankings(ranking("a", "b", "c"), ranking("b", "a", "d"))
+	if len(report.Documents) != 4 {
+		t.Fatalf("Expected 4 compared documents, got %d", len(report.Documents))
+	}
+	// Shared documents a and b are swapped: d^2 sum is 2, n is 2
+	if math.Abs(report.Spearman+1) > 1e-9 {
+		t.Errorf("Expected Spearman -1 over the shared documents, got %v", report.Spearman)
+	}
+	if got := report.Documents[3]; got.ID != "d" || got.RankA != 0 || got.RankB != 3 || !got.Disagree {
+		t.Errorf("Expected d to be ranked by B only and disagree: %+v", got)
+	}
+	if report.DisagreementPercent != 50 {
+		t.Errorf("Expected 50%% disagreement, got %v%%", report.DisagreementPercent)
+	}
+}
+
+func TestWriteComparison(t *testing.T) {
+	report := CompareRankings(ranking("a", "b", "c", "d"), ranking("d", "b", "c", "a"))
+
+	var plain bytes.Buffer
+	if err := WriteComparison(&plain, report, "model-a", "model-b", false); err != nil {
+		t.Fatalf("WriteComparison failed: %v", err)
+	}
+	if strings.Contains(plain.String(), "\033[") {
+		t.Error("Expected no ANSI escapes without color")
+	}
+	for _, want := range []string{"content a", "Spearman correlation: -0.8000", "Disagreement: 50.0%", "A: model-a"} {
+		if !strings.Contains(plain.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, plain.String())
+		}
+	}
+
+	var colored bytes.Buffer
+	if err := WriteComparison(&colored, report, "model-a", "model-b", true); err != nil {
+		t.Fatalf("WriteComparison failed: %v", err)
+	}
+	if !strings.Contains(colored.String(), ansiRed) || !strings.Contains(colored.String(), ansiGreen) {
+		t.Errorf("Expected red and green rows, got:\n%s", colored.String())
+	}
+}
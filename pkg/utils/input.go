@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Document input formats
+const (
+	// InputFormatText reads one document per line
+	InputFormatText = "text"
+	// InputFormatJSONL reads one JSON document per line: a string, or an
+	// object whose "content" field holds the document text
+	InputFormatJSONL = "jsonl"
+)
+
+// StdinPath is the documents file path that reads from standard input
+const StdinPath = "-"
+
+// LoadDocuments reads documents from path in the given format; StdinPath
+// reads them from standard input, which must not be an interactive terminal
+func LoadDocuments(path, format string) ([]string, error) {
+	if path == StdinPath {
+		if IsTerminal(os.Stdin) {
+			return nil, errors.New("no documents piped to standard input")
+		}
+		return ReadDocuments(os.Stdin, format)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read documents file: %w", err)
+	}
+	defer file.Close()
+	return ReadDocuments(file, format)
+}
+
+// ReadDocuments reads one document per line of r in the given format. Blank
+// lines are skipped and surrounding whitespace is trimmed.
+func ReadDocuments(r io.Reader, format string) ([]string, error) {
+	if format == "" {
+		format = InputFormatText
+	}
+	if format != InputFormatText && format != InputFormatJSONL {
+		return nil, fmt.Errorf("unsupported input format %q (expected %s or %s)", format, InputFormatText, InputFormatJSONL)
+	}
+
+	var documents []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineBytes)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if format == InputFormatText {
+			documents = append(documents, string(line))
+			continue
+		}
+		document, err := parseDocumentLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document line %d: %w", lineNumber, err)
+		}
+		documents = append(documents, document)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read documents after line %d: %w", lineNumber, err)
+	}
+
+	return documents, nil
+}
+
+// parseDocumentLine decodes a JSONL document: a JSON string or an object with
+// a "content" field
+func parseDocumentLine(line []byte) (string, error) {
+	if line[0] == '"' {
+		var document string
+		err := json.Unmarshal(line, &document)
+		return document, err
+	}
+
+	var document struct {
+		Content *string `json:"content"`
+	}
+	if err := json.Unmarshal(line, &document); err != nil {
+		return "", err
+	}
+	if document.Content == nil {
+		return "", errors.New(`missing "content" field`)
+	}
+	return *document.Content, nil
+}
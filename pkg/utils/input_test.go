@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadDocuments_TextFile(t *testing.T) {
+	path := writeTestFile(t, "Machine learning is a subset of AI\n\n  Cooking pasta at home  \r\nThe weather is sunny\n")
+	documents, err := LoadDocuments(path, InputFormatText)
+	if err != nil {
+		t.Fatalf("LoadDocuments failed: %v", err)
+	}
+
+	want := []string{"Machine learning is a subset of AI", "Cooking pasta at home", "The weather is sunny"}
+	if !reflect.DeepEqual(documents, want) {
+		t.Errorf("Expected %q, got %q", want, documents)
+	}
+}
+
+func TestLoadDocuments_JSONLFile(t *testing.T) {
+	path := writeTestFile(t, `"Machine learning, with a comma"`+"\n"+`{"content": "Cooking pasta", "id": "x"}`+"\n")
+	documents, err := LoadDocuments(path, InputFormatJSONL)
+	if err != nil {
+		t.Fatalf("LoadDocuments failed: %v", err)
+	}
+
+	want := []string{"Machine learning, with a comma", "Cooking pasta"}
+	if !reflect.DeepEqual(documents, want) {
+		t.Errorf("Expected %q, got %q", want, documents)
+	}
+}
+
+func TestLoadDocuments_Stdin(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer reader.Close()
+	if _, err := writer.WriteString("first document\nsecond document\n"); err != nil {
+		t.Fatalf("Failed to write to pipe: %v", err)
+	}
+	writer.Close()
+
+	stdin := os.Stdin
+	os.Stdin = reader
+	defer func() { os.Stdin = stdin }()
+
+	documents, err := LoadDocuments(StdinPath, InputFormatText)
+	if err != nil {
+		t.Fatalf("LoadDocuments failed: %v", err)
+	}
+	if !reflect.DeepEqual(documents, []string{"first document", "second document"}) {
+		t.Errorf("Unexpected documents from stdin: %q", documents)
+	}
+}
+
+func TestReadDocuments_Errors(t *testing.T) {
+	if _, err := ReadDocuments(strings.NewReader("doc"), "csv"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+
+	_, err := ReadDocuments(strings.NewReader("\"ok\"\n{\"text\": \"no content\"}\n"), InputFormatJSONL)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error naming line 2, got %v", err)
+	}
+
+	if _, err := LoadDocuments(filepath.Join(t.TempDir(), "missing.txt"), InputFormatText); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// DocumentReader decodes one document per line of newline-delimited JSON,
+// without holding the whole input in memory. Each line is a Document object
+// or a JSON string used as the content; blank lines are skipped.
+type DocumentReader struct {
+	scanner    *bufio.Scanner
+	lineNumber int
+}
+
+// NewDocumentReader creates a reader decoding documents from r
+func NewDocumentReader(r io.Reader) *DocumentReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineBytes)
+	return &DocumentReader{scanner: scanner}
+}
+
+// Next returns the next document, or io.EOF once the input is exhausted.
+// Decoding errors name the offending line.
+func (r *DocumentReader) Next() (reranker.Document, error) {
+	for r.scanner.Scan() {
+		r.lineNumber++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		document, err := decodeDocumentLine(line)
+		if err != nil {
+			return reranker.Document{}, fmt.Errorf("failed to parse document line %d: %w", r.lineNumber, err)
+		}
+		return document, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return reranker.Document{}, fmt.Errorf("failed to read documents after line %d: %w", r.lineNumber, err)
+	}
+	return reranker.Document{}, io.EOF
+}
+
+// decodeDocumentLine decodes a JSON string as a document's content, or a
+// Document object with non-empty content
+func decodeDocumentLine(line []byte) (reranker.Document, error) {
+	if line[0] == '"' {
+		var content string
+		err := json.Unmarshal(line, &content)
+		return reranker.Document{Content: content}, err
+	}
+
+	var document reranker.Document
+	if err := json.Unmarshal(line, &document); err != nil {
+		return reranker.Document{}, err
+	}
+	if document.Content == "" {
+		return reranker.Document{}, errors.New(`missing "content" field`)
+	}
+	return document, nil
+}
+
+// DocumentWriter encodes documents as newline-delimited JSON readable by
+// DocumentReader. Output is buffered; call Flush when done.
+type DocumentWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewDocumentWriter creates a writer encoding documents to w
+func NewDocumentWriter(w io.Writer) *DocumentWriter {
+	writer := bufio.NewWriter(w)
+	return &DocumentWriter{writer: writer, encoder: json.NewEncoder(writer)}
+}
+
+// Write encodes doc as one JSON line
+func (w *DocumentWriter) Write(doc reranker.Document) error {
+	return w.encoder.Encode(doc)
+}
+
+// Flush writes any buffered documents to the underlying writer
+func (w *DocumentWriter) Flush() error {
+	return w.writer.Flush()
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+func TestDocumentReader_MatchesBulkLoading(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewDocumentWriter(&buf)
+	want := make([]reranker.Document, 1000)
+	for i := range want {
+		want[i] = reranker.Document{
+			ID:      fmt.Sprintf("doc_%d", i),
+			Content: fmt.Sprintf("Document %d about \"topic\" %d\nwith a newline", i, i%7),
+			Meta:    map[string]interface{}{"shard": float64(i % 3)},
+		}
+		if err := writer.Write(want[i]); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	bulk, err := ReadDocuments(bytes.NewReader(buf.Bytes()), InputFormatJSONL)
+	if err != nil {
+		t.Fatalf("ReadDocuments failed: %v", err)
+	}
+
+	reader := NewDocumentReader(bytes.NewReader(buf.Bytes()))
+	var streamed []reranker.Document
+	for {
+		doc, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		streamed = append(streamed, doc)
+	}
+
+	if !reflect.DeepEqual(streamed, want) {
+		t.Fatal("Expected the streamed documents to match the written ones")
+	}
+	if len(bulk) != len(streamed) {
+		t.Fatalf("Expected %d documents from bulk loading, got %d", len(streamed), len(bulk))
+	}
+	for i := range bulk {
+		if bulk[i] != streamed[i].Content {
+			t.Fatalf("Document %d: bulk loading read %q, streaming %q", i, bulk[i], streamed[i].Content)
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF to repeat, got %v", err)
+	}
+}
+
+func TestDocumentReader_MalformedLine(t *testing.T) {
+	input := `{"id": "a", "content": "first"}` + "\n" +
+		`"second as a string"` + "\n" +
+		`{"id": "c", "content": ` + "\n" +
+		`{"id": "d", "content": "never reached"}` + "\n"
+	reader := NewDocumentReader(strings.NewReader(input))
+
+	first, err := reader.Next()
+	if err != nil || first.ID != "a" || first.Content != "first" {
+		t.Fatalf("Unexpected first document %+v (%v)", first, err)
+	}
+	second, err := reader.Next()
+	if err != nil || second.Content != "second as a string" {
+		t.Fatalf("Unexpected second document %+v (%v)", second, err)
+	}
+	if _, err := reader.Next(); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected an error naming line 3, got %v", err)
+	}
+
+	blank := NewDocumentReader(strings.NewReader("\n\n" + `{"id": "x"}` + "\n"))
+	if _, err := blank.Next(); err == nil || !strings.Contains(err.Error(), "line 3") || !strings.Contains(err.Error(), "content") {
+		t.Errorf("Expected a missing content error on line 3 after blank lines, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package utils
+
+import "go-rerankers/pkg/reranker"
+
+// Languages recognized by DetectLanguage (ISO 639-1 codes)
+const (
+	LanguageEnglish = reranker.LanguageEnglish
+	LanguageFrench  = reranker.LanguageFrench
+	LanguageSpanish = reranker.LanguageSpanish
+	LanguageGerman  = reranker.LanguageGerman
+)
+
+// DetectLanguage guesses the language of text from trigram frequencies,
+// returning "en", "fr", "es" or "de", or "" when the text is too short or
+// matches no language better than the others. It is reranker.DetectLanguage,
+// which CrossLingualReranker uses as well.
+func DetectLanguage(text string) string {
+	return reranker.DetectLanguage(text)
+}
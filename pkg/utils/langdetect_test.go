@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"The quick brown fox jumps over the lazy dog and then it is gone with the wind", LanguageEnglish},
+		{"Machine learning is a field of artificial intelligence that uses statistical techniques", LanguageEnglish},
+		{"Le chat est sur la table et les enfants jouent dans le jardin pour toute la journée", LanguageFrench},
+		{"L'apprentissage automatique est une branche de l'intelligence artificielle", LanguageFrench},
+		{"El perro corre por el parque y los niños juegan con la pelota en la tarde", LanguageSpanish},
+		{"El aprendizaje automático es una rama de la inteligencia artificial que estudia los datos", LanguageSpanish},
+		{"Der Hund läuft durch den Park und die Kinder spielen mit dem Ball auf der Wiese", LanguageGerman},
+		{"Maschinelles Lernen ist ein Teilgebiet der künstlichen Intelligenz und sehr wichtig für die Forschung", LanguageGerman},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.text); got != tt.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDetectLanguage_Unknown(t *testing.T) {
+	for _, text := range []string{"", "ok", "12345 67890", "xyz"} {
+		if got := DetectLanguage(text); got != "" {
+			t.Errorf("DetectLanguage(%q) = %q, want empty", text, got)
+		}
+	}
+}
+
+func TestStringsToDocuments_DetectLanguage(t *testing.T) {
+	docs := []string{
+		"The weather is nice and the children are playing in the garden",
+		"Le temps est beau et les enfants jouent dans le jardin",
+	}
+
+	plain := StringsToDocuments(docs)
+	if plain[0].Language != "" {
+		t.Errorf("Expected no detection by default, got %q", plain[0].Language)
+	}
+
+	config := reranker.Config{Options: map[string]interface{}{"detect_language": true}}
+	detected := StringsToDocuments(docs, config)
+	if detected[0].Language != LanguageEnglish || detected[1].Language != LanguageFrench {
+		t.Errorf("Expected en and fr, got %q and %q", detected[0].Language, detected[1].Language)
+	}
+}
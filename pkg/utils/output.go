@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go-rerankers/pkg/reranker"
+)
+
+// Output formats accepted by NewResultWriter
+const (
+	OutputPlain = "plain"
+	OutputJSON  = "json"
+	OutputCSV   = "csv"
+	OutputXML   = "xml"
+)
+
+// ResultWriter serializes ranked results
+type ResultWriter interface {
+	Write(w io.Writer, results []reranker.RerankResult) error
+}
+
+// NewResultWriter returns the writer for format: plain, json, csv or xml
+func NewResultWriter(format string) (ResultWriter, error) {
+	switch format {
+	case OutputPlain:
+		return PlainTextWriter{}, nil
+	case OutputJSON:
+		return JSONWriter{}, nil
+	case OutputCSV:
+		return CSVWriter{}, nil
+	case OutputXML:
+		return XMLWriter{}, nil
+	}
+	return nil, fmt.Errorf("unsupported output format %q (expected plain, json, csv or xml)", format)
+}
+
+// WriteResults writes the first topK results (all when topK <= 0) with writer
+func WriteResults(w io.Writer, writer ResultWriter, results []reranker.RerankResult, topK int) error {
+	if topK > 0 && topK < len(results) {
+		results = results[:topK]
+	}
+	return writer.Write(w, results)
+}
+
+// resultRank returns the result's Rank, falling back to its 1-based position
+func resultRank(result reranker.RerankResult, position int) int {
+	if result.Rank > 0 {
+		return result.Rank
+	}
+	return position + 1
+}
+
+// PlainTextWriter writes one "rank. [score] content" line per result, followed
+// by an indented line with the explanation when the result has one
+type PlainTextWriter struct{}
+
+// Write writes the results as human-readable lines
+func (PlainTextWriter) Write(w io.Writer, results []reranker.RerankResult) error {
+	for i, result := range results {
+		if _, err := fmt.Fprintf(w, "%d. [%.4f] %s\n", resultRank(result, i), result.Score, result.Document.Content); err != nil {
+			return err
+		}
+		if result.Explanation != "" {
+			if _, err := fmt.Fprintf(w, "   %s\n", result.Explanation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// JSONWriter writes the results as an indented JSON array of RerankResult
+type JSONWriter struct{}
+
+// Write writes the results as a JSON array; no results produce []
+func (JSONWriter) Write(w io.Writer, results []reranker.RerankResult) error {
+	if results == nil {
+		results = []reranker.RerankResult{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
+
+// CSVWriter writes a rank,score,id,content header followed by one row per result
+type CSVWriter struct{}
+
+// CSVHeader is the header row written by CSVWriter
+var CSVHeader = []string{"rank", "score", "id", "content"}
+
+// Write writes the results as CSV; scores keep full precision
+func (CSVWriter) Write(w io.Writer, results []reranker.RerankResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVHeader); err != nil {
+		return err
+	}
+	for i, result := range results {
+		record := []string{
+			strconv.Itoa(resultRank(result, i)),
+			strconv.FormatFloat(result.Score, 'g', -1, 64),
+			result.Document.ID,
+			result.Document.Content,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// XMLResults is the document written by XMLWriter:
+//
+//	<results><result rank="1" score="0.93" id="doc_1">content</result></results>
+type XMLResults struct {
+	XMLName xml.Name    `xml:"results"`
+	Results []XMLResult `xml:"result"`
+}
+
+// XMLResult is one ranked document in XMLResults
+type XMLResult struct {
+	Rank    int     `xml:"rank,attr"`
+	Score   float64 `xml:"score,attr"`
+	ID      string  `xml:"id,attr"`
+	Content string  `xml:",chardata"`
+}
+
+// XMLWriter writes the results as an XMLResults document
+type XMLWriter struct{}
+
+// Write writes the results as indented XML; scores keep full precision
+func (XMLWriter) Write(w io.Writer, results []reranker.RerankResult) error {
+	document := XMLResults{Results: make([]XMLResult, len(results))}
+	for i, result := range results {
+		document.Results[i] = XMLResult{
+			Rank:    resultRank(result, i),
+			Score:   result.Score,
+			ID:      result.Document.ID,
+			Content: result.Document.Content,
+		}
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
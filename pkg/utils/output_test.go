@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-rerankers/pkg/reranker"
+)
+
+func sampleResults() []reranker.RerankResult {
+	return []reranker.RerankResult{
+		{Document: reranker.Document{ID: "doc_2", Content: "Machine learning, \"explained\""}, Score: 0.9312345678, Index: 1, Rank: 1, NormalizedRank: 1},
+		{Document: reranker.Document{ID: "doc_1", Content: "Cooking <pasta> & sauce\nat home"}, Score: -2.5, Index: 0, Rank: 2, NormalizedRank: 0.5},
+	}
+}
+
+func writeSample(t *testing.T, writer ResultWriter) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := writer.Write(&buf, sampleResults()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestPlainTextWriter_RoundTrip(t *testing.T) {
+	results := []reranker.RerankResult{
+		{Document: reranker.Document{Content: "Machine learning"}, Score: 0.9312, Rank: 1},
+		{Document: reranker.Document{Content: "Cooking pasta"}, Score: -2.5, Rank: 2},
+	}
+	var buf bytes.Buffer
+	if err := (PlainTextWriter{}).Write(&buf, results); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(results) {
+		t.Fatalf("Expected %d lines, got %d", len(results), len(lines))
+	}
+	for i, line := range lines {
+		var rank int
+		var score float64
+		prefix := fmt.Sprintf("%d. [", results[i].Rank)
+		if _, err := fmt.Sscanf(line, "%d. [%f]", &rank, &score); err != nil {
+			t.Fatalf("Failed to parse %q: %v", line, err)
+		}
+		content := line[strings.Index(line, "] ")+2:]
+		if !strings.HasPrefix(line, prefix) || rank != results[i].Rank || score != results[i].Score || content != results[i].Document.Content {
+			t.Errorf("Line %d: parsed (%d, %f, %q) from %q", i, rank, score, content, line)
+		}
+	}
+}
+
+func TestJSONWriter_RoundTrip(t *testing.T) {
+	var parsed []reranker.RerankResult
+	if err := json.Unmarshal([]byte(writeSample(t, JSONWriter{})), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, sampleResults()) {
+		t.Errorf("Expected %+v, got %+v", sampleResults(), parsed)
+	}
+
+	var buf bytes.Buffer
+	if err := (JSONWriter{}).Write(&buf, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected [] for no results, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestCSVWriter_RoundTrip(t *testing.T) {
+	records, err := csv.NewReader(strings.NewReader(writeSample(t, CSVWriter{}))).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV output: %v", err)
+	}
+	if !reflect.DeepEqual(records[0], CSVHeader) {
+		t.Errorf("Expected header %v, got %v", CSVHeader, records[0])
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+	for i, want := range sampleResults() {
+		record := records[i+1]
+		rank, _ := strconv.Atoi(record[0])
+		score, _ := strconv.ParseFloat(record[1], 64)
+		if rank != want.Rank || score != want.Score || record[2] != want.Document.ID || record[3] != want.Document.Content {
+			t.Errorf("Row %d: expected %+v, got %v", i, want, record)
+		}
+	}
+}
+
+func TestXMLWriter_RoundTrip(t *testing.T) {
+	output := writeSample(t, XMLWriter{})
+	if !strings.HasPrefix(output, "<results>") || !strings.Contains(output, `<result rank="1"`) {
+		t.Errorf("Unexpected XML layout: %s", output)
+	}
+
+	var parsed XMLResults
+	if err := xml.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Failed to parse XML output: %v", err)
+	}
+	if len(parsed.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(parsed.Results))
+	}
+	for i, want := range sampleResults() {
+		got := parsed.Results[i]
+		if got.Rank != want.Rank || got.Score != want.Score || got.ID != want.Document.ID || got.Content != want.Document.Content {
+			t.Errorf("Result %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestNewResultWriter(t *testing.T) {
+	for _, format := range []string{OutputPlain, OutputJSON, OutputCSV, OutputXML} {
+		if _, err := NewResultWriter(format); err != nil {
+			t.Errorf("NewResultWriter(%q) failed: %v", format, err)
+		}
+	}
+	if _, err := NewResultWriter("yaml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestWriteResults_TopK(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, PlainTextWriter{}, sampleResults(), 1); err != nil {
+		t.Fatalf("WriteResults failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+		t.Errorf("Expected 1 line, got %d", lines)
+	}
+}
+
+func TestPlainTextWriter_Explanation(t *testing.T) {
+	results := []reranker.RerankResult{
+		{Document: reranker.Document{Content: "Machine learning"}, Score: 0.93, Rank: 1, Explanation: "Score 0.93: query term 'machine' found 1x in document; document is relevant."},
+		{Document: reranker.Document{Content: "Cooking pasta"}, Score: -2.5, Rank: 2},
+	}
+	var buf bytes.Buffer
+	if err := (PlainTextWriter{}).Write(&buf, results); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	want := "1. [0.9300] Machine learning\n   " + results[0].Explanation + "\n2. [-2.5000] Cooking pasta\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// Length bounds, in words, of generated documents
+const (
+	minGeneratedWords = 12
+	maxGeneratedWords = 24
+)
+
+// Bounds of the share of query words in a generated relevant document
+const (
+	minQueryDensity = 0.1
+	maxQueryDensity = 0.6
+)
+
+// distractorVocabulary holds the topic-neutral words generated documents are
+// filled with; words that also occur in the query are never used
+var distractorVocabulary = []string{
+	"river", "mountain", "garden", "kitchen", "violin", "harbor", "lantern", "pebble",
+	"orchard", "bicycle", "canyon", "meadow", "teapot", "glacier", "festival", "ribbon",
+	"compass", "volcano", "pottery", "sailboat", "blanket", "cathedral", "marble", "tulip",
+	"desert", "carousel", "notebook", "thunder", "village", "quilt", "bakery", "lighthouse",
+	"walnut", "fountain", "saddle", "prairie", "chimney", "raincoat", "puzzle", "island",
+}
+
+// GenerateTestData returns synthetic test data for query with numRelevant
+// relevant documents followed by numDistractor distractors, so with a
+// relevance file doc_1 to doc_<numRelevant> are the relevant ones. Relevant
+// documents mix query words into filler at a random density and contain at
+// least one query word unless the query has none; distractors contain filler
+// only. The same seed always yields the same documents.
+func GenerateTestData(query string, numRelevant, numDistractor int, seed int64) *TestData {
+	rng := rand.New(rand.NewSource(seed))
+	queryWords := queryTerms(query)
+	filler := fillerWords(queryWords)
+
+	var documents []string
+	for i := 0; i < numRelevant; i++ {
+		density := minQueryDensity + rng.Float64()*(maxQueryDensity-minQueryDensity)
+		documents = append(documents, generateDocument(rng, queryWords, filler, density))
+	}
+	for i := 0; i < numDistractor; i++ {
+		documents = append(documents, generateDocument(rng, nil, filler, 0))
+	}
+
+	return &TestData{Query: query, Documents: documents}
+}
+
+// generateDocument builds one sentence drawing each word from queryWords with
+// probability density and from filler otherwise. When queryWords is not empty
+// at least one of them is included.
+func generateDocument(rng *rand.Rand, queryWords, filler []string, density float64) string {
+	words := make([]string, minGeneratedWords+rng.Intn(maxGeneratedWords-minGeneratedWords+1))
+	hasQueryWord := false
+	for i := range words {
+		if len(queryWords) > 0 && rng.Float64() < density {
+			words[i] = queryWords[rng.Intn(len(queryWords))]
+			hasQueryWord = true
+		} else {
+			words[i] = filler[rng.Intn(len(filler))]
+		}
+	}
+	if len(queryWords) > 0 && !hasQueryWord {
+		words[rng.Intn(len(words))] = queryWords[rng.Intn(len(queryWords))]
+	}
+
+	sentence := []rune(strings.Join(words, " "))
+	sentence[0] = unicode.ToUpper(sentence[0])
+	return string(sentence) + "."
+}
+
+// queryTerms returns the distinct lower-cased words of query, without punctuation
+func queryTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// fillerWords returns the distractor vocabulary minus any query word
+func fillerWords(queryWords []string) []string {
+	excluded := make(map[string]bool, len(queryWords))
+	for _, word := range queryWords {
+		excluded[word] = true
+	}
+	filler := make([]string, 0, len(distractorVocabulary))
+	for _, word := range distractorVocabulary {
+		if !excluded[word] {
+			filler = append(filler, word)
+		}
+	}
+	return filler
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTestData_RelevantContainQueryWords(t *testing.T) {
+	query := "How does machine learning work?"
+	data := GenerateTestData(query, 5, 10, 42)
+
+	if data.Query != query {
+		t.Errorf("Expected query %q, got %q", query, data.Query)
+	}
+	if len(data.Documents) != 15 {
+		t.Fatalf("Expected 15 documents, got %d", len(data.Documents))
+	}
+
+	terms := queryTerms(query)
+	for i, doc := range data.Documents {
+		words := queryTerms(doc)
+		matches := 0
+		for _, word := range words {
+			for _, term := range terms {
+				if word == term {
+					matches++
+				}
+			}
+		}
+		if i < 5 && matches == 0 {
+			t.Errorf("Expected relevant document %d to contain a query word: %q", i, doc)
+		}
+		if i >= 5 && matches > 0 {
+			t.Errorf("Expected distractor %d to contain no query word: %q", i, doc)
+		}
+	}
+}
+
+func TestGenerateTestData_Seeded(t *testing.T) {
+	first := GenerateTestData("neural networks", 3, 3, 7)
+	second := GenerateTestData("neural networks", 3, 3, 7)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same seed to generate the same documents")
+	}
+
+	other := GenerateTestData("neural networks", 3, 3, 8)
+	if reflect.DeepEqual(first, other) {
+		t.Error("Expected a different seed to generate different documents")
+	}
+}
+
+func TestGenerateTestData_DocumentShape(t *testing.T) {
+	for _, doc := range GenerateTestData("river", 2, 2, 1).Documents {
+		words := strings.Fields(doc)
+		if len(words) < minGeneratedWords || len(words) > maxGeneratedWords {
+			t.Errorf("Expected %d-%d words, got %d: %q", minGeneratedWords, maxGeneratedWords, len(words), doc)
+		}
+		if !strings.HasSuffix(doc, ".") {
+			t.Errorf("Expected a sentence ending in a period: %q", doc)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Package version holds the go-rerankers release version.
+package version
+
+// Version is the go-rerankers release version. Release builds override it with
+//
+//	go build -ldflags "-X go-rerankers/pkg/version.Version=1.2.3"
+var Version = "0.1.0"